@@ -0,0 +1,177 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-service/optsutils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/kroma-network/kroma/kroma-validator/rpc"
+)
+
+var (
+	ErrOutputSubmitterDisabled = errors.New("output submitter is not enabled")
+	ErrUnknownRole             = errors.New("unknown validator role")
+)
+
+var (
+	_ rpc.ValidatorBackend = (*Validator)(nil)
+	_ rpc.AdminBackend     = (*Validator)(nil)
+)
+
+// RoundInfo returns the submission round of the next output.
+func (v *Validator) RoundInfo(ctx context.Context) (*rpc.RoundInfo, error) {
+	if v.l2os == nil {
+		return nil, ErrOutputSubmitterDisabled
+	}
+	return v.l2os.FetchRoundInfo(ctx)
+}
+
+// NextValidator returns the validator selected to submit the next output, or the public round address.
+func (v *Validator) NextValidator(ctx context.Context) (common.Address, error) {
+	roundInfo, err := v.RoundInfo(ctx)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return roundInfo.NextValidator, nil
+}
+
+// ValidatorStatus returns the status and the jail state of the validator in the ValidatorManager contract.
+func (v *Validator) ValidatorStatus(ctx context.Context) (*rpc.ValidatorStatus, error) {
+	from := v.cfg.TxManager.From()
+
+	cCtx, cCancel := context.WithTimeout(ctx, v.cfg.NetworkTimeout)
+	defer cCancel()
+	status, err := v.valMgrContract.GetStatus(optsutils.NewSimpleCallOpts(cCtx), from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the validator status: %w", err)
+	}
+
+	cCtx, cCancel = context.WithTimeout(ctx, v.cfg.NetworkTimeout)
+	defer cCancel()
+	inJail, err := v.valMgrContract.InJail(optsutils.NewSimpleCallOpts(cCtx), from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the jail status: %w", err)
+	}
+
+	cCtx, cCancel = context.WithTimeout(ctx, v.cfg.NetworkTimeout)
+	defer cCancel()
+	jailExpiresAt, err := v.valMgrContract.JailExpiresAt(optsutils.NewSimpleCallOpts(cCtx), from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the jail expiration: %w", err)
+	}
+
+	return &rpc.ValidatorStatus{
+		Address:       from,
+		Status:        status,
+		InJail:        inJail,
+		JailExpiresAt: hexutil.Uint64(jailExpiresAt.Uint64()),
+	}, nil
+}
+
+// Checkpoints returns the output indexes that the challenger and the guardian last checked.
+func (v *Validator) Checkpoints() *rpc.Checkpoints {
+	var checkpoints rpc.Checkpoints
+	if v.challenger != nil && v.cfg.ChallengerEnabled {
+		if checkpoint := v.challenger.Checkpoint(); checkpoint != nil {
+			checkpoints.Challenger = (*hexutil.Big)(checkpoint)
+		}
+	}
+	if v.guardian != nil {
+		if checkpoint := v.guardian.Checkpoint(); checkpoint != nil {
+			checkpoints.Guardian = (*hexutil.Big)(checkpoint)
+		}
+	}
+	return &checkpoints
+}
+
+// Challenges returns the challenges that the validator is currently handling.
+func (v *Validator) Challenges() []*rpc.ChallengeInfo {
+	if v.challenger == nil {
+		return []*rpc.ChallengeInfo{}
+	}
+	return v.challenger.InflightChallenges()
+}
+
+// Roles returns the runtime state of each validator role.
+func (v *Validator) Roles() map[string]*rpc.RoleInfo {
+	guardianSigner := v.cfg.TxManager.From()
+	if v.guardian != nil {
+		guardianSigner = v.guardian.Signer()
+	}
+	return map[string]*rpc.RoleInfo{
+		rpc.RoleOutputSubmitter: {
+			Enabled: v.l2os != nil,
+			Paused:  v.l2os != nil && v.l2os.Paused(),
+			Signer:  v.cfg.TxManager.From(),
+		},
+		rpc.RoleChallenger: {
+			Enabled: v.challenger != nil && v.cfg.ChallengerEnabled,
+			Paused:  v.challenger != nil && v.challenger.Paused(),
+			Signer:  v.cfg.TxManager.From(),
+		},
+		rpc.RoleGuardian: {
+			Enabled: v.guardian != nil,
+			Paused:  v.guardian != nil && v.guardian.Paused(),
+			Signer:  guardianSigner,
+		},
+		rpc.RoleRewardManager: {
+			Enabled: v.rewardManager != nil,
+			Paused:  v.rewardManager != nil && v.rewardManager.Paused(),
+			Signer:  v.cfg.TxManager.From(),
+		},
+	}
+}
+
+// SetRolePaused pauses or resumes the given validator role.
+// Pausing the challenger does not pause the defence of the outputs submitted by the output submitter,
+// which would lose them by timeout.
+func (v *Validator) SetRolePaused(role string, paused bool) error {
+	type pausable interface {
+		Pause()
+		Resume()
+	}
+
+	var target pausable
+	switch role {
+	case rpc.RoleOutputSubmitter:
+		if v.l2os != nil {
+			target = v.l2os
+		}
+	case rpc.RoleChallenger:
+		if v.challenger != nil && v.cfg.ChallengerEnabled {
+			target = v.challenger
+		}
+	case rpc.RoleGuardian:
+		if v.guardian != nil {
+			target = v.guardian
+		}
+	case rpc.RoleRewardManager:
+		if v.rewardManager != nil {
+			target = v.rewardManager
+		}
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownRole, role)
+	}
+	if target == nil {
+		return fmt.Errorf("%s is not enabled", role)
+	}
+
+	if paused {
+		target.Pause()
+	} else {
+		target.Resume()
+	}
+	return nil
+}
+
+// RotateGuardianSigner switches the guardian to sign the transactions with the given remote signer.
+func (v *Validator) RotateGuardianSigner(ctx context.Context, endpoint string, address common.Address) error {
+	if v.guardian == nil {
+		return fmt.Errorf("%s is not enabled", rpc.RoleGuardian)
+	}
+	return v.guardian.RotateSigner(ctx, endpoint, address)
+}
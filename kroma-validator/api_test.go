@@ -0,0 +1,49 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/kroma-validator/rpc"
+)
+
+func TestSetRolePaused(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	// only the output submitter is enabled, so the challenger only defends its outputs and cannot be paused
+	v := &Validator{
+		cfg:        Config{OutputSubmitterEnabled: true, TxManager: newTestTxManager(common.Address{0x01})},
+		l2os:       &L2OutputSubmitter{log: logger},
+		challenger: &Challenger{log: logger},
+	}
+
+	require.ErrorContains(t, v.SetRolePaused(rpc.RoleChallenger, true), "not enabled")
+	require.False(t, v.challenger.Paused())
+	roles := v.Roles()
+	require.False(t, roles[rpc.RoleChallenger].Enabled)
+	require.False(t, roles[rpc.RoleChallenger].Paused)
+
+	require.NoError(t, v.SetRolePaused(rpc.RoleOutputSubmitter, true))
+	require.True(t, v.l2os.Paused())
+	require.True(t, v.Roles()[rpc.RoleOutputSubmitter].Paused)
+	require.False(t, v.Roles()[rpc.RoleChallenger].Paused)
+
+	// pausing the challenger does not pause the output submitter
+	v.cfg.ChallengerEnabled = true
+	require.NoError(t, v.SetRolePaused(rpc.RoleOutputSubmitter, false))
+	require.NoError(t, v.SetRolePaused(rpc.RoleChallenger, true))
+	require.True(t, v.challenger.Paused())
+	roles = v.Roles()
+	require.True(t, roles[rpc.RoleChallenger].Enabled)
+	require.True(t, roles[rpc.RoleChallenger].Paused)
+	require.False(t, roles[rpc.RoleOutputSubmitter].Paused)
+	require.NoError(t, v.SetRolePaused(rpc.RoleChallenger, false))
+	require.False(t, v.challenger.Paused())
+
+	require.ErrorContains(t, v.SetRolePaused(rpc.RoleGuardian, true), "not enabled")
+	require.False(t, v.Roles()[rpc.RoleGuardian].Enabled)
+	require.ErrorIs(t, v.SetRolePaused("unknown", true), ErrUnknownRole)
+}
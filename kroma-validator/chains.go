@@ -0,0 +1,165 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ChainsConfig lists the chain deployments that a single validator process runs against.
+type ChainsConfig struct {
+	Chains []ChainConfig `json:"chains"`
+}
+
+// ChainConfig is a chain deployment in the chains config. The settings not listed here are shared by the chains and
+// given by the flags.
+type ChainConfig struct {
+	// Name identifies the chain in the logs and the data directory.
+	Name string `json:"name"`
+	// L2ChainID is the chain ID of the L2, which labels the metrics of the chain.
+	// It must match the chain ID in the rollup config given by the rollup RPC.
+	L2ChainID uint64 `json:"l2ChainId"`
+
+	L2EthRpc               string `json:"l2EthRpc"`
+	RollupRpc              string `json:"rollupRpc"`
+	L2OOAddress            string `json:"l2ooAddress"`
+	ColosseumAddress       string `json:"colosseumAddress"`
+	ValPoolAddress         string `json:"valPoolAddress"`
+	ValMgrAddress          string `json:"valMgrAddress"`
+	AssetManagerAddress    string `json:"assetManagerAddress"`
+	SecurityCouncilAddress string `json:"securityCouncilAddress,omitempty"`
+
+	OutputSubmitterEnabled bool `json:"outputSubmitterEnabled"`
+	ChallengerEnabled      bool `json:"challengerEnabled"`
+	GuardianEnabled        bool `json:"guardianEnabled"`
+
+	// The prover endpoints of the chain. If empty, the endpoints given by the flags are used.
+	ZkEVMProverRPC      []string `json:"zkEVMProverRpc,omitempty"`
+	ZkVMProverRPC       []string `json:"zkVMProverRpc,omitempty"`
+	WitnessGeneratorRPC []string `json:"witnessGeneratorRpc,omitempty"`
+
+	// DataDir is the directory to persist the validator state of the chain.
+	// If empty, the subdirectory named after the chain in the data directory given by the flag is used.
+	DataDir string `json:"dataDir,omitempty"`
+
+	// RPCPort is the port to serve the validator RPC of the chain on. If zero, the RPC of the chain is not served.
+	RPCPort int `json:"rpcPort,omitempty"`
+}
+
+// LoadChainsConfig reads the chains config from the given JSON file.
+func LoadChainsConfig(path string) (*ChainsConfig, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open chains config: %w", err)
+	}
+	defer file.Close()
+
+	var cfg ChainsConfig
+	dec := json.NewDecoder(file)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode chains config: %w", err)
+	}
+	if err := cfg.Check(); err != nil {
+		return nil, fmt.Errorf("invalid chains config: %w", err)
+	}
+	return &cfg, nil
+}
+
+// Check ensures that the chains are distinguishable from each other.
+func (c *ChainsConfig) Check() error {
+	if len(c.Chains) == 0 {
+		return errors.New("no chain is given")
+	}
+	names := make(map[string]bool)
+	l2ChainIDs := make(map[uint64]bool)
+	rpcPorts := make(map[int]bool)
+	for i, chain := range c.Chains {
+		if chain.Name == "" {
+			return fmt.Errorf("name of chain %d is empty", i)
+		}
+		if names[chain.Name] {
+			return fmt.Errorf("duplicated chain name: %s", chain.Name)
+		}
+		names[chain.Name] = true
+		if chain.L2ChainID == 0 {
+			return fmt.Errorf("l2 chain id of chain %s is not given", chain.Name)
+		}
+		if l2ChainIDs[chain.L2ChainID] {
+			return fmt.Errorf("duplicated l2 chain id: %d", chain.L2ChainID)
+		}
+		l2ChainIDs[chain.L2ChainID] = true
+		if chain.RPCPort != 0 {
+			if rpcPorts[chain.RPCPort] {
+				return fmt.Errorf("duplicated rpc port: %d", chain.RPCPort)
+			}
+			rpcPorts[chain.RPCPort] = true
+		}
+	}
+	return nil
+}
+
+// Contracts returns the addresses of the L1 contracts of all chains, whose logs the validators subscribe to.
+func (c *ChainsConfig) Contracts() ([]common.Address, error) {
+	var contracts []common.Address
+	for _, chain := range c.Chains {
+		for _, addr := range []string{
+			chain.L2OOAddress,
+			chain.ColosseumAddress,
+			chain.ValPoolAddress,
+			chain.ValMgrAddress,
+			chain.AssetManagerAddress,
+			chain.SecurityCouncilAddress,
+		} {
+			if addr == "" {
+				continue
+			}
+			parsed, err := opservice.ParseAddress(addr)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse contract address of chain %s: %w", chain.Name, err)
+			}
+			contracts = append(contracts, parsed)
+		}
+	}
+	return contracts, nil
+}
+
+// ForChain returns the CLIConfig of the given chain, overriding the chain specific settings.
+func (c CLIConfig) ForChain(chain ChainConfig) CLIConfig {
+	cfg := c
+	cfg.L2EthRpc = chain.L2EthRpc
+	cfg.RollupRpc = chain.RollupRpc
+	cfg.L2OOAddress = chain.L2OOAddress
+	cfg.ColosseumAddress = chain.ColosseumAddress
+	cfg.ValPoolAddress = chain.ValPoolAddress
+	cfg.ValMgrAddress = chain.ValMgrAddress
+	cfg.AssetManagerAddress = chain.AssetManagerAddress
+	cfg.SecurityCouncilAddress = chain.SecurityCouncilAddress
+	cfg.OutputSubmitterEnabled = chain.OutputSubmitterEnabled
+	cfg.ChallengerEnabled = chain.ChallengerEnabled
+	cfg.GuardianEnabled = chain.GuardianEnabled
+
+	if len(chain.ZkEVMProverRPC) > 0 {
+		cfg.ZkEVMProverRPC = chain.ZkEVMProverRPC
+	}
+	if len(chain.ZkVMProverRPC) > 0 {
+		cfg.ZkVMProverRPC = chain.ZkVMProverRPC
+	}
+	if len(chain.WitnessGeneratorRPC) > 0 {
+		cfg.WitnessGeneratorRPC = chain.WitnessGeneratorRPC
+	}
+
+	if chain.DataDir != "" {
+		cfg.DataDir = chain.DataDir
+	} else if c.DataDir != "" {
+		cfg.DataDir = filepath.Join(c.DataDir, chain.Name)
+	}
+
+	cfg.RPCConfig.ListenPort = chain.RPCPort
+	return cfg
+}
@@ -0,0 +1,86 @@
+package validator
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainsConfigCheck(t *testing.T) {
+	tests := []struct {
+		name   string
+		chains []ChainConfig
+		ok     bool
+	}{
+		{
+			name: "no chain",
+		},
+		{
+			name:   "empty name",
+			chains: []ChainConfig{{Name: ""}},
+		},
+		{
+			name:   "duplicated name",
+			chains: []ChainConfig{{Name: "a", L2ChainID: 1}, {Name: "a", L2ChainID: 2}},
+		},
+		{
+			name:   "no l2 chain id",
+			chains: []ChainConfig{{Name: "a"}},
+		},
+		{
+			name:   "duplicated l2 chain id",
+			chains: []ChainConfig{{Name: "a", L2ChainID: 1}, {Name: "b", L2ChainID: 1}},
+		},
+		{
+			name:   "duplicated rpc port",
+			chains: []ChainConfig{{Name: "a", L2ChainID: 1, RPCPort: 8545}, {Name: "b", L2ChainID: 2, RPCPort: 8545}},
+		},
+		{
+			name:   "rpc ports not served",
+			chains: []ChainConfig{{Name: "a", L2ChainID: 1}, {Name: "b", L2ChainID: 2}},
+			ok:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &ChainsConfig{Chains: tt.chains}
+			if tt.ok {
+				require.NoError(t, cfg.Check())
+			} else {
+				require.Error(t, cfg.Check())
+			}
+		})
+	}
+}
+
+func TestCLIConfigForChain(t *testing.T) {
+	cfg := CLIConfig{
+		L2EthRpc:      "http://l2",
+		DataDir:       "/data",
+		ZkVMProverRPC: []string{"http://prover"},
+	}
+	cfg.RPCConfig.ListenPort = 6545
+
+	chainCfg := cfg.ForChain(ChainConfig{
+		Name:              "a",
+		L2EthRpc:          "http://a",
+		ChallengerEnabled: true,
+	})
+	require.Equal(t, "http://a", chainCfg.L2EthRpc)
+	require.True(t, chainCfg.ChallengerEnabled)
+	require.Equal(t, []string{"http://prover"}, chainCfg.ZkVMProverRPC)
+	require.Equal(t, filepath.Join("/data", "a"), chainCfg.DataDir)
+	require.Zero(t, chainCfg.RPCConfig.ListenPort)
+
+	chainCfg = cfg.ForChain(ChainConfig{
+		Name:          "b",
+		ZkVMProverRPC: []string{"http://prover-b"},
+		DataDir:       "/data-b",
+		RPCPort:       7545,
+	})
+	require.Equal(t, []string{"http://prover-b"}, chainCfg.ZkVMProverRPC)
+	require.Equal(t, "/data-b", chainCfg.DataDir)
+	require.Equal(t, 7545, chainCfg.RPCConfig.ListenPort)
+	require.Equal(t, "http://l2", cfg.L2EthRpc)
+}
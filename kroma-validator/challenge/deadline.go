@@ -0,0 +1,69 @@
+package challenge
+
+import (
+	"sync"
+	"time"
+)
+
+// proofDurationHistorySize is the number of recent proof durations to project the next proof duration.
+const proofDurationHistorySize = 16
+
+// TurnDeadline returns the unix timestamp until when the current turn of the challenge should be taken.
+// For the challenge in AsserterTimeout status, the challenger should prove the fault within the proving timeout after
+// the asserter timed out. It returns false if the challenge is not in progress or has already timed out.
+func TurnDeadline(status uint8, timeoutAt uint64, provingTimeout uint64) (uint64, bool) {
+	switch status {
+	case StatusChallengerTurn, StatusAsserterTurn, StatusReadyToProve:
+		return timeoutAt, true
+	case StatusAsserterTimeout:
+		return timeoutAt + provingTimeout, true
+	default:
+		return 0, false
+	}
+}
+
+// ProofDurationTracker keeps the durations of the recent proof generations to project how long the next proof
+// generation will take.
+type ProofDurationTracker struct {
+	initial time.Duration
+
+	mu        sync.Mutex
+	durations []time.Duration
+	next      int
+}
+
+// NewProofDurationTracker creates a ProofDurationTracker that projects the initial duration until any proof
+// generation is recorded.
+func NewProofDurationTracker(initial time.Duration) *ProofDurationTracker {
+	return &ProofDurationTracker{
+		initial:   initial,
+		durations: make([]time.Duration, 0, proofDurationHistorySize),
+	}
+}
+
+// Add records the duration of a completed proof generation.
+func (t *ProofDurationTracker) Add(d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.durations) < proofDurationHistorySize {
+		t.durations = append(t.durations, d)
+		return
+	}
+	t.durations[t.next] = d
+	t.next = (t.next + 1) % proofDurationHistorySize
+}
+
+// Projected returns the projected duration of a proof generation. It is the longest of the recent durations to
+// alert conservatively.
+func (t *ProofDurationTracker) Projected() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.durations) == 0 {
+		return t.initial
+	}
+	var projected time.Duration
+	for _, d := range t.durations {
+		projected = max(projected, d)
+	}
+	return projected
+}
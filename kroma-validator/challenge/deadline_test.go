@@ -0,0 +1,44 @@
+package challenge
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTurnDeadline(t *testing.T) {
+	tests := []struct {
+		status   uint8
+		deadline uint64
+		ok       bool
+	}{
+		{StatusNone, 0, false},
+		{StatusChallengerTurn, 100, true},
+		{StatusAsserterTurn, 100, true},
+		{StatusReadyToProve, 100, true},
+		{StatusAsserterTimeout, 150, true},
+		{StatusChallengerTimeout, 0, false},
+	}
+	for _, tt := range tests {
+		deadline, ok := TurnDeadline(tt.status, 100, 50)
+		require.Equal(t, tt.ok, ok, "status %d", tt.status)
+		require.Equal(t, tt.deadline, deadline, "status %d", tt.status)
+	}
+}
+
+func TestProofDurationTracker(t *testing.T) {
+	tracker := NewProofDurationTracker(time.Hour)
+	require.Equal(t, time.Hour, tracker.Projected())
+
+	tracker.Add(10 * time.Minute)
+	tracker.Add(30 * time.Minute)
+	tracker.Add(20 * time.Minute)
+	require.Equal(t, 30*time.Minute, tracker.Projected())
+
+	// the old durations are evicted
+	for i := 0; i < proofDurationHistorySize; i++ {
+		tracker.Add(5 * time.Minute)
+	}
+	require.Equal(t, 5*time.Minute, tracker.Projected())
+}
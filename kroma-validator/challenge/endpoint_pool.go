@@ -0,0 +1,321 @@
+package challenge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+const (
+	ServiceZkEVMProver      = "zkevm_prover"
+	ServiceZkVMProver       = "zkvm_prover"
+	ServiceWitnessGenerator = "witness_generator"
+
+	// affinityCacheSize is the maximum number of jobs whose endpoint is remembered.
+	affinityCacheSize = 1024
+)
+
+var ErrNoEndpoint = errors.New("no endpoint available")
+
+// EndpointMetricer records the requests to the prover endpoints.
+type EndpointMetricer interface {
+	RecordProverRequest(service string, endpoint string, method string, success bool, duration time.Duration)
+	RecordProverEndpointHealth(service string, endpoint string, healthy bool)
+}
+
+// AffinityFunc returns the affinity key of the call, which identifies the job on an endpoint such as a proof
+// generation. If start is true, the call starts the job, so the following calls with the same key are routed to the
+// endpoint that succeeded this call. An empty key means that the call can be routed to any endpoint.
+type AffinityFunc func(method string, args []any) (key string, start bool)
+
+// ZkVMAffinity routes the status queries of witness and proof generations to the endpoint that accepted the request.
+func ZkVMAffinity(method string, args []any) (string, bool) {
+	if len(args) < 2 {
+		return "", false
+	}
+	switch method {
+	case "requestWitness", "requestProve":
+		return zkVMJobKey(args), true
+	case "getWitness", "getProof":
+		return zkVMJobKey(args), false
+	default:
+		return "", false
+	}
+}
+
+// zkVMJobKey identifies the job by the block hash and the L1 head, separated explicitly to keep the key unambiguous.
+func zkVMJobKey(args []any) string {
+	return fmt.Sprintf("%v/%v", args[0], args[1])
+}
+
+type EndpointPoolConfig struct {
+	// Service is the name of the service of the endpoints, used for logs and metrics.
+	Service string
+	// CallTimeout is the max duration of a call before considering the endpoint stalled. Zero means no timeout.
+	CallTimeout time.Duration
+	// HealthCheckMethod is the RPC method without arguments to check the health of the endpoints periodically.
+	// If empty, the health of the endpoints is determined only by the results of the calls.
+	HealthCheckMethod string
+	// HealthCheckInterval is the interval of the health checks.
+	HealthCheckInterval time.Duration
+	// Admit checks the result of the health check method. If set, an endpoint is kept out of the pool until its result
+	// passes the check, e.g. to route the calls only to the endpoints serving the expected version.
+	Admit func(result json.RawMessage) error
+	// Affinity is the routing rule of the jobs. If nil, every call can be routed to any endpoint.
+	Affinity AffinityFunc
+}
+
+type endpoint struct {
+	name     string
+	rpc      client.RPC
+	healthy  atomic.Bool
+	admitted atomic.Bool
+}
+
+// EndpointPool is a client.RPC that distributes the calls across redundant endpoints of the same service.
+// Calls are balanced over the healthy endpoints in round-robin, and fail over to the next endpoint when a call errors
+// or stalls. Unhealthy endpoints are tried last, so that a call is never rejected only because of health checks.
+// Endpoints that are not admitted by the health checks are never called, until a later health check admits them.
+type EndpointPool struct {
+	cfg  EndpointPoolConfig
+	log  log.Logger
+	metr EndpointMetricer
+
+	endpoints []*endpoint
+	next      atomic.Uint64
+	affinity  *lru.Cache[string, *endpoint]
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+var _ client.RPC = (*EndpointPool)(nil)
+
+// NewEndpointPool creates a pool of the given RPC clients, keyed by their URLs.
+// It starts the health checks if the health check method is configured.
+func NewEndpointPool(cfg EndpointPoolConfig, l log.Logger, m EndpointMetricer, urls []string, rpcs []client.RPC) (*EndpointPool, error) {
+	if len(rpcs) == 0 {
+		return nil, ErrNoEndpoint
+	}
+	if len(urls) != len(rpcs) {
+		return nil, errors.New("number of urls and rpc clients mismatched")
+	}
+	if cfg.Admit != nil && cfg.HealthCheckMethod == "" {
+		return nil, errors.New("admission requires health check method")
+	}
+	affinity, err := lru.New[string, *endpoint](affinityCacheSize)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &EndpointPool{
+		cfg:      cfg,
+		log:      l.New("service", cfg.Service),
+		metr:     m,
+		affinity: affinity,
+	}
+	for i, r := range rpcs {
+		e := &endpoint{name: endpointName(i, urls[i]), rpc: r}
+		e.healthy.Store(true)
+		e.admitted.Store(cfg.Admit == nil)
+		p.endpoints = append(p.endpoints, e)
+		m.RecordProverEndpointHealth(cfg.Service, e.name, true)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	if cfg.HealthCheckMethod != "" && cfg.HealthCheckInterval > 0 {
+		p.wg.Add(1)
+		go p.healthCheckLoop(ctx)
+	}
+	return p, nil
+}
+
+// endpointName identifies the endpoint in logs and metrics without exposing credentials in its URL.
+func endpointName(index int, rawURL string) string {
+	if u, err := url.Parse(rawURL); err == nil && u.Host != "" {
+		return fmt.Sprintf("%d-%s", index, u.Host)
+	}
+	return fmt.Sprintf("%d", index)
+}
+
+// CheckHealth checks the health of every endpoint once, and admits the endpoints passing the admission check.
+// It returns ErrNoEndpoint if no endpoint is admitted, e.g. to check the endpoints before using the pool.
+func (p *EndpointPool) CheckHealth(ctx context.Context) error {
+	admitted := false
+	for _, e := range p.endpoints {
+		if err := p.checkHealth(ctx, e); err != nil {
+			p.log.Warn("health check failed", "endpoint", e.name, "err", err)
+		}
+		admitted = admitted || e.admitted.Load()
+	}
+	if !admitted {
+		return fmt.Errorf("%w: no endpoint of %s admitted", ErrNoEndpoint, p.cfg.Service)
+	}
+	return nil
+}
+
+// Close stops the health checks and closes the RPC clients of the endpoints.
+func (p *EndpointPool) Close() {
+	p.cancel()
+	p.wg.Wait()
+	for _, e := range p.endpoints {
+		e.rpc.Close()
+	}
+}
+
+func (p *EndpointPool) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	var key string
+	var start bool
+	if p.cfg.Affinity != nil {
+		key, start = p.cfg.Affinity(method, args)
+	}
+
+	candidates := p.candidates(key)
+	if len(candidates) == 0 {
+		return fmt.Errorf("%w: no endpoint of %s admitted", ErrNoEndpoint, p.cfg.Service)
+	}
+	var errs []error
+	for _, e := range candidates {
+		err := p.call(ctx, e, result, method, args...)
+		if err == nil {
+			if start {
+				p.affinity.Add(key, e)
+			}
+			return nil
+		}
+		if ctx.Err() != nil {
+			return err
+		}
+		p.log.Warn("call to endpoint failed, failing over", "endpoint", e.name, "method", method, "err", err)
+		errs = append(errs, fmt.Errorf("%s: %w", e.name, err))
+		if key != "" {
+			// the job on the failed endpoint is considered lost
+			p.affinity.Remove(key)
+		}
+	}
+	return fmt.Errorf("all endpoints of %s failed: %w", p.cfg.Service, errors.Join(errs...))
+}
+
+func (p *EndpointPool) BatchCallContext(_ context.Context, _ []rpc.BatchElem) error {
+	return errors.New("batch call is not supported by endpoint pool")
+}
+
+func (p *EndpointPool) EthSubscribe(_ context.Context, _ any, _ ...any) (ethereum.Subscription, error) {
+	return nil, errors.New("subscription is not supported by endpoint pool")
+}
+
+// candidates returns the admitted endpoints in the order to try. The endpoint of the job comes first, followed by the
+// healthy endpoints in round-robin order, then the unhealthy endpoints.
+func (p *EndpointPool) candidates(key string) []*endpoint {
+	var pinned *endpoint
+	if key != "" {
+		pinned, _ = p.affinity.Get(key)
+		if pinned != nil && !pinned.admitted.Load() {
+			pinned = nil
+		}
+	}
+
+	offset := int(p.next.Add(1) - 1)
+	healthy := make([]*endpoint, 0, len(p.endpoints))
+	var unhealthy []*endpoint
+	for i := range p.endpoints {
+		e := p.endpoints[(offset+i)%len(p.endpoints)]
+		if e == pinned || !e.admitted.Load() {
+			continue
+		}
+		if e.healthy.Load() {
+			healthy = append(healthy, e)
+		} else {
+			unhealthy = append(unhealthy, e)
+		}
+	}
+
+	candidates := make([]*endpoint, 0, len(p.endpoints))
+	if pinned != nil {
+		candidates = append(candidates, pinned)
+	}
+	candidates = append(candidates, healthy...)
+	return append(candidates, unhealthy...)
+}
+
+func (p *EndpointPool) call(ctx context.Context, e *endpoint, result any, method string, args ...any) error {
+	cCtx, cCancel := ctx, context.CancelFunc(func() {})
+	if p.cfg.CallTimeout > 0 {
+		cCtx, cCancel = context.WithTimeout(ctx, p.cfg.CallTimeout)
+	}
+	defer cCancel()
+
+	startTime := time.Now()
+	err := e.rpc.CallContext(cCtx, result, method, args...)
+	p.metr.RecordProverRequest(p.cfg.Service, e.name, method, err == nil, time.Since(startTime))
+
+	// an error returned by the server means the endpoint is still responsive
+	var rpcErr rpc.Error
+	p.setHealth(e, err == nil || errors.As(err, &rpcErr))
+	return err
+}
+
+func (p *EndpointPool) setHealth(e *endpoint, healthy bool) {
+	if e.healthy.Swap(healthy) == healthy {
+		return
+	}
+	if healthy {
+		p.log.Info("endpoint recovered", "endpoint", e.name)
+	} else {
+		p.log.Warn("endpoint became unhealthy", "endpoint", e.name)
+	}
+	p.metr.RecordProverEndpointHealth(p.cfg.Service, e.name, healthy)
+}
+
+func (p *EndpointPool) healthCheckLoop(ctx context.Context) {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.cfg.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			for _, e := range p.endpoints {
+				if err := p.checkHealth(ctx, e); err != nil {
+					p.log.Debug("health check failed", "endpoint", e.name, "err", err)
+				}
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// checkHealth calls the health check method of the endpoint, and admits or expels the endpoint by its result.
+// An endpoint that does not respond keeps its admission, since it is already tried last as unhealthy.
+func (p *EndpointPool) checkHealth(ctx context.Context, e *endpoint) error {
+	var res json.RawMessage
+	if err := p.call(ctx, e, &res, p.cfg.HealthCheckMethod); err != nil {
+		return err
+	}
+	if p.cfg.Admit == nil {
+		return nil
+	}
+	err := p.cfg.Admit(res)
+	if admitted := err == nil; e.admitted.Swap(admitted) != admitted {
+		if admitted {
+			p.log.Info("endpoint admitted", "endpoint", e.name)
+		} else {
+			p.log.Warn("endpoint expelled", "endpoint", e.name, "err", err)
+		}
+	}
+	return err
+}
@@ -0,0 +1,168 @@
+package challenge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+type noopEndpointMetrics struct{}
+
+func (noopEndpointMetrics) RecordProverRequest(_ string, _ string, _ string, _ bool, _ time.Duration) {
+}
+func (noopEndpointMetrics) RecordProverEndpointHealth(_ string, _ string, _ bool) {}
+
+// endpointStubRPC records the called methods and fails them while down.
+type endpointStubRPC struct {
+	down    bool
+	stall   time.Duration
+	version string
+	calls   []string
+}
+
+func (s *endpointStubRPC) Close() {}
+
+func (s *endpointStubRPC) BatchCallContext(_ context.Context, _ []rpc.BatchElem) error {
+	return errors.New("not supported")
+}
+
+func (s *endpointStubRPC) EthSubscribe(_ context.Context, _ any, _ ...any) (ethereum.Subscription, error) {
+	return nil, errors.New("not supported")
+}
+
+func (s *endpointStubRPC) CallContext(ctx context.Context, result any, method string, _ ...any) error {
+	s.calls = append(s.calls, method)
+	if s.stall > 0 {
+		select {
+		case <-time.After(s.stall):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if s.down {
+		return errors.New("connection refused")
+	}
+	if res, ok := result.(*json.RawMessage); ok {
+		*res = json.RawMessage(`"` + s.version + `"`)
+	}
+	return nil
+}
+
+func newTestEndpointPool(t *testing.T, cfg EndpointPoolConfig, stubs ...*endpointStubRPC) *EndpointPool {
+	urls := make([]string, len(stubs))
+	rpcs := make([]client.RPC, len(stubs))
+	for i, s := range stubs {
+		urls[i] = "http://prover"
+		rpcs[i] = s
+	}
+	p, err := NewEndpointPool(cfg, testlog.Logger(t, log.LevelInfo), noopEndpointMetrics{}, urls, rpcs)
+	require.NoError(t, err)
+	t.Cleanup(p.Close)
+	return p
+}
+
+func TestEndpointPoolFailover(t *testing.T) {
+	ctx := context.Background()
+	down := &endpointStubRPC{down: true}
+	stalled := &endpointStubRPC{stall: time.Minute}
+	up := &endpointStubRPC{}
+	p := newTestEndpointPool(t, EndpointPoolConfig{Service: "test", CallTimeout: 10 * time.Millisecond}, down, stalled, up)
+
+	require.NoError(t, p.CallContext(ctx, nil, "spec"))
+	require.Equal(t, []string{"spec"}, down.calls)
+	require.Equal(t, []string{"spec"}, stalled.calls)
+	require.Equal(t, []string{"spec"}, up.calls)
+	require.False(t, p.endpoints[0].healthy.Load())
+	require.False(t, p.endpoints[1].healthy.Load())
+	require.True(t, p.endpoints[2].healthy.Load())
+
+	// the healthy endpoint is tried first
+	require.NoError(t, p.CallContext(ctx, nil, "spec"))
+	require.Len(t, down.calls, 1)
+	require.Len(t, stalled.calls, 1)
+	require.Len(t, up.calls, 2)
+
+	up.down = true
+	require.Error(t, p.CallContext(ctx, nil, "spec"))
+}
+
+func TestEndpointPoolAffinity(t *testing.T) {
+	ctx := context.Background()
+	first := &endpointStubRPC{}
+	second := &endpointStubRPC{}
+	p := newTestEndpointPool(t, EndpointPoolConfig{Service: "test", Affinity: ZkVMAffinity}, first, second)
+
+	require.NoError(t, p.CallContext(ctx, nil, "requestProve", "0x01", "02", "witness"))
+	require.Equal(t, []string{"requestProve"}, first.calls)
+
+	// the polls are routed to the endpoint that accepted the request regardless of the round-robin
+	for i := 0; i < 3; i++ {
+		require.NoError(t, p.CallContext(ctx, nil, "getProof", "0x01", "02"))
+	}
+	require.Equal(t, []string{"requestProve", "getProof", "getProof", "getProof"}, first.calls)
+	require.Empty(t, second.calls)
+
+	// the job is failed over once the endpoint is down
+	first.down = true
+	require.NoError(t, p.CallContext(ctx, nil, "getProof", "0x01", "02"))
+	require.Equal(t, []string{"getProof"}, second.calls)
+}
+
+func TestZkVMAffinityKey(t *testing.T) {
+	key, start := ZkVMAffinity("requestWitness", []any{"0x01", "02"})
+	require.True(t, start)
+	other, _ := ZkVMAffinity("getWitness", []any{"0x010", "2"})
+	require.NotEqual(t, key, other)
+	same, start := ZkVMAffinity("getWitness", []any{"0x01", "02"})
+	require.False(t, start)
+	require.Equal(t, key, same)
+}
+
+func TestEndpointPoolAdmission(t *testing.T) {
+	ctx := context.Background()
+	down := &endpointStubRPC{down: true, version: "v1"}
+	other := &endpointStubRPC{version: "v2"}
+	up := &endpointStubRPC{version: "v1"}
+	p := newTestEndpointPool(t, EndpointPoolConfig{
+		Service:           "test",
+		HealthCheckMethod: "spec",
+		Admit: func(result json.RawMessage) error {
+			if string(result) != `"v1"` {
+				return errors.New("version mismatched")
+			}
+			return nil
+		},
+	}, down, other, up)
+
+	// no endpoint is called before being admitted
+	require.ErrorIs(t, p.CallContext(ctx, nil, "getProof"), ErrNoEndpoint)
+	require.NoError(t, p.CheckHealth(ctx))
+	for i := 0; i < 3; i++ {
+		require.NoError(t, p.CallContext(ctx, nil, "getProof"))
+	}
+	require.Equal(t, []string{"spec"}, down.calls)
+	require.Equal(t, []string{"spec"}, other.calls)
+	require.Len(t, up.calls, 4)
+
+	// the endpoint unreachable at first is admitted once it recovers, and the endpoint changing its version is expelled
+	down.down = false
+	up.version = "v2"
+	require.NoError(t, p.CheckHealth(ctx))
+	for i := 0; i < 3; i++ {
+		require.NoError(t, p.CallContext(ctx, nil, "getProof"))
+	}
+	require.Len(t, down.calls, 5)
+	require.Len(t, up.calls, 5)
+
+	down.version = "v2"
+	require.ErrorIs(t, p.CheckHealth(ctx), ErrNoEndpoint)
+}
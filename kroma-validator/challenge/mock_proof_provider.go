@@ -0,0 +1,70 @@
+package challenge
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MockProofProvider is a ProofProvider that generates the given proof locally without any prover.
+// It is intended for testing the challenge flow, so the proofs it returns are not verifiable unless the given
+// result is a proof generated for the target block.
+type MockProofProvider struct {
+	result *ProofResult
+	delay  time.Duration
+
+	mu       sync.Mutex
+	requests map[uint64]time.Time
+}
+
+var _ ProofProvider = (*MockProofProvider)(nil)
+
+// NewMockProofProvider creates a MockProofProvider that completes the requested proofs with the given result after
+// the given delay. If the delay is zero, the proofs are completed as soon as they are requested.
+func NewMockProofProvider(result *ProofResult, delay time.Duration) *MockProofProvider {
+	return &MockProofProvider{
+		result:   result,
+		delay:    delay,
+		requests: make(map[uint64]time.Time),
+	}
+}
+
+func (p *MockProofProvider) RequestProof(_ context.Context, req *ProofRequest) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.requests[req.L2BlockNumber] = time.Now()
+	return nil
+}
+
+func (p *MockProofProvider) ProofStatus(_ context.Context, req *ProofRequest) (RequestStatusType, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.status(req), nil
+}
+
+func (p *MockProofProvider) FetchProof(_ context.Context, req *ProofRequest) (*ProofResult, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.status(req) != RequestCompleted {
+		return nil, ErrProofNotReady
+	}
+	return p.result, nil
+}
+
+func (p *MockProofProvider) CancelProof(_ context.Context, req *ProofRequest) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.requests, req.L2BlockNumber)
+	return nil
+}
+
+func (p *MockProofProvider) status(req *ProofRequest) RequestStatusType {
+	requestedAt, ok := p.requests[req.L2BlockNumber]
+	if !ok {
+		return RequestNone
+	}
+	if time.Since(requestedAt) < p.delay {
+		return RequestProcessing
+	}
+	return RequestCompleted
+}
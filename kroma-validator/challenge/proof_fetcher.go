@@ -3,12 +3,14 @@ package challenge
 import (
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"strings"
 
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 type ZkEVMProofFetcher struct {
@@ -94,6 +96,11 @@ func (z *ZkVMProofFetcher) GetProof(ctx context.Context, blockHash string, l1Hea
 	return output, err
 }
 
+// MarshalJSON encodes the byte array as a 0x-prefixed hex string, so that it can be decoded by UnmarshalJSON.
+func (h HexBytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(hexutil.Encode(h))
+}
+
 // UnmarshalJSON handles the conversion from a hex string to a byte array.
 func (h *HexBytes) UnmarshalJSON(data []byte) error {
 	// Remove quotes around the hex string
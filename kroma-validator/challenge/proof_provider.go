@@ -0,0 +1,211 @@
+package challenge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var ErrProofNotReady = errors.New("proof is not ready")
+
+// ProofRequest identifies the fault proof to be generated for a target L2 block.
+type ProofRequest struct {
+	// L2BlockNumber is the number of the target L2 block to be proven.
+	L2BlockNumber uint64
+	// L2BlockHash is the hash of the target L2 block to be proven.
+	L2BlockHash common.Hash
+	// L1Head is the L1 block hash stored in the challenge.
+	L1Head common.Hash
+	// Trace is the block trace of the target L2 block, which is required only by zkEVM provers.
+	// It is set only when requesting the proof.
+	Trace []byte
+	// Witness is the witness of the target block, which is required only by zkVM provers.
+	// It is set by the provider once the witness is generated, so that the caller can persist it and set it again
+	// after a restart to skip the witness generation.
+	Witness string
+}
+
+// ProofResult is the generated fault proof. Only the field of the proof type generated by the provider is set.
+type ProofResult struct {
+	ZkEVMProof *ProofAndPair
+	ZkVMProof  *ZkVMProofResponse
+}
+
+// ProofProvider generates fault proofs with an async lifecycle.
+// The caller requests a proof, polls its status until it is completed, and then fetches the result.
+// A provider may complete the proof synchronously in RequestProof, so the caller should check the status right after
+// requesting.
+type ProofProvider interface {
+	// RequestProof requests the generation of the proof.
+	RequestProof(ctx context.Context, req *ProofRequest) error
+	// ProofStatus returns the status of the proof generation. RequestNone or RequestFailed means that the proof
+	// should be requested (again).
+	ProofStatus(ctx context.Context, req *ProofRequest) (RequestStatusType, error)
+	// FetchProof returns the generated proof. It returns ErrProofNotReady if the generation is not completed.
+	FetchProof(ctx context.Context, req *ProofRequest) (*ProofResult, error)
+	// CancelProof cancels the proof generation in progress. Providers that cannot cancel ignore it.
+	CancelProof(ctx context.Context, req *ProofRequest) error
+}
+
+// ZkEVMProofProvider adapts ZkEVMProofFetcher to ProofProvider.
+// The zkEVM prover generates the proof synchronously, so RequestProof blocks until the proof is generated and the
+// result is kept in memory until it is fetched.
+type ZkEVMProofProvider struct {
+	fetcher *ZkEVMProofFetcher
+
+	mu      sync.Mutex
+	results map[uint64]*ProofAndPair
+}
+
+var _ ProofProvider = (*ZkEVMProofProvider)(nil)
+
+func NewZkEVMProofProvider(fetcher *ZkEVMProofFetcher) *ZkEVMProofProvider {
+	return &ZkEVMProofProvider{
+		fetcher: fetcher,
+		results: make(map[uint64]*ProofAndPair),
+	}
+}
+
+func (p *ZkEVMProofProvider) RequestProof(ctx context.Context, req *ProofRequest) error {
+	if len(req.Trace) == 0 {
+		return errors.New("block trace is required to request zkEVM proof")
+	}
+	result, err := p.fetcher.FetchProofAndPair(ctx, string(req.Trace))
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.results[req.L2BlockNumber] = result
+	return nil
+}
+
+func (p *ZkEVMProofProvider) ProofStatus(_ context.Context, req *ProofRequest) (RequestStatusType, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.results[req.L2BlockNumber]; ok {
+		return RequestCompleted, nil
+	}
+	return RequestNone, nil
+}
+
+// FetchProof returns the generated proof and releases it, since the zkEVM prover does not keep the proof.
+func (p *ZkEVMProofProvider) FetchProof(_ context.Context, req *ProofRequest) (*ProofResult, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	result, ok := p.results[req.L2BlockNumber]
+	if !ok {
+		return nil, ErrProofNotReady
+	}
+	delete(p.results, req.L2BlockNumber)
+	return &ProofResult{ZkEVMProof: result}, nil
+}
+
+func (p *ZkEVMProofProvider) CancelProof(_ context.Context, req *ProofRequest) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.results, req.L2BlockNumber)
+	return nil
+}
+
+// ZkVMProofProvider adapts the pair of WitnessGenerator and ZkVMProofFetcher to ProofProvider.
+// The witness of the target block is generated first, then the proof is generated with the witness.
+type ZkVMProofProvider struct {
+	witnessGenerator *WitnessGenerator
+	fetcher          *ZkVMProofFetcher
+}
+
+var _ ProofProvider = (*ZkVMProofProvider)(nil)
+
+func NewZkVMProofProvider(witnessGenerator *WitnessGenerator, fetcher *ZkVMProofFetcher) *ZkVMProofProvider {
+	return &ZkVMProofProvider{
+		witnessGenerator: witnessGenerator,
+		fetcher:          fetcher,
+	}
+}
+
+// RequestProof requests the witness generation if the witness is not generated yet,
+// otherwise requests the proof generation with the witness.
+// The generated witness is set to the request, and a witness already set to the request is used as is.
+func (p *ZkVMProofProvider) RequestProof(ctx context.Context, req *ProofRequest) error {
+	blockHash, l1Head := zkVMRequestArgs(req)
+	if req.Witness == "" {
+		witnessResult, err := p.witnessGenerator.GetWitness(ctx, blockHash, l1Head)
+		if err != nil {
+			return fmt.Errorf("failed to get witness: %w", err)
+		}
+
+		switch witnessResult.RequestStatus {
+		case RequestNone, RequestFailed:
+			if _, err := p.witnessGenerator.RequestWitness(ctx, blockHash, l1Head); err != nil {
+				return fmt.Errorf("failed to request witness: %w", err)
+			}
+			return nil
+		case RequestProcessing:
+			return nil
+		case RequestCompleted:
+			req.Witness = witnessResult.Witness
+		default:
+			return fmt.Errorf("unknown request status of witness generation: %s", witnessResult.RequestStatus)
+		}
+	}
+
+	if _, err := p.fetcher.RequestProve(ctx, blockHash, l1Head, req.Witness); err != nil {
+		return fmt.Errorf("failed to request proof: %w", err)
+	}
+	return nil
+}
+
+// ProofStatus returns RequestProcessing while the witness is being generated,
+// otherwise the status of the proof generation.
+func (p *ZkVMProofProvider) ProofStatus(ctx context.Context, req *ProofRequest) (RequestStatusType, error) {
+	blockHash, l1Head := zkVMRequestArgs(req)
+	proofResult, err := p.fetcher.GetProof(ctx, blockHash, l1Head)
+	if err != nil {
+		return "", fmt.Errorf("failed to get proof: %w", err)
+	}
+
+	switch proofResult.RequestStatus {
+	case RequestProcessing, RequestCompleted:
+		return proofResult.RequestStatus, nil
+	case RequestNone, RequestFailed:
+		if req.Witness != "" {
+			return proofResult.RequestStatus, nil
+		}
+		witnessResult, err := p.witnessGenerator.GetWitness(ctx, blockHash, l1Head)
+		if err != nil {
+			return "", fmt.Errorf("failed to get witness: %w", err)
+		}
+		if witnessResult.RequestStatus == RequestProcessing {
+			return RequestProcessing, nil
+		}
+		return proofResult.RequestStatus, nil
+	default:
+		return "", fmt.Errorf("unknown request status of proof generation: %s", proofResult.RequestStatus)
+	}
+}
+
+func (p *ZkVMProofProvider) FetchProof(ctx context.Context, req *ProofRequest) (*ProofResult, error) {
+	blockHash, l1Head := zkVMRequestArgs(req)
+	proofResult, err := p.fetcher.GetProof(ctx, blockHash, l1Head)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proof: %w", err)
+	}
+	if proofResult.RequestStatus != RequestCompleted {
+		return nil, fmt.Errorf("%w: %s", ErrProofNotReady, proofResult.RequestStatus)
+	}
+	return &ProofResult{ZkVMProof: proofResult}, nil
+}
+
+// CancelProof is a no-op, since neither the witness generator nor the zkVM prover supports cancellation.
+func (p *ZkVMProofProvider) CancelProof(_ context.Context, _ *ProofRequest) error {
+	return nil
+}
+
+func zkVMRequestArgs(req *ProofRequest) (string, string) {
+	return req.L2BlockHash.Hex(), common.Bytes2Hex(req.L1Head[:])
+}
@@ -0,0 +1,150 @@
+package challenge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// zkVMStubRPC serves both the witness generator and the zkVM prover,
+// completing each request on the next status query.
+type zkVMStubRPC struct {
+	witnessStatus RequestStatusType
+	proofStatus   RequestStatusType
+	provedWitness string
+}
+
+func (s *zkVMStubRPC) Close() {}
+
+func (s *zkVMStubRPC) BatchCallContext(_ context.Context, _ []rpc.BatchElem) error {
+	return errors.New("not supported")
+}
+
+func (s *zkVMStubRPC) EthSubscribe(_ context.Context, _ any, _ ...any) (ethereum.Subscription, error) {
+	return nil, errors.New("not supported")
+}
+
+func (s *zkVMStubRPC) CallContext(_ context.Context, result any, method string, args ...any) error {
+	switch method {
+	case "requestWitness":
+		s.witnessStatus = RequestProcessing
+		*result.(**RequestStatusType) = &s.witnessStatus
+	case "getWitness":
+		res := &WitnessResponse{RequestStatus: s.witnessStatus}
+		if s.witnessStatus == RequestCompleted {
+			res.Witness = "witness"
+		}
+		*result.(**WitnessResponse) = res
+	case "requestProve":
+		s.provedWitness = args[2].(string)
+		s.proofStatus = RequestProcessing
+		*result.(**RequestStatusType) = &s.proofStatus
+	case "getProof":
+		res := &ZkVMProofResponse{RequestStatus: s.proofStatus}
+		if s.proofStatus == RequestCompleted {
+			res.Proof = HexBytes{0x01}
+		}
+		*result.(**ZkVMProofResponse) = res
+	default:
+		return fmt.Errorf("unknown method %s", method)
+	}
+	return nil
+}
+
+func TestZkVMProofProvider(t *testing.T) {
+	ctx := context.Background()
+	stub := &zkVMStubRPC{witnessStatus: RequestNone, proofStatus: RequestNone}
+	provider := NewZkVMProofProvider(NewWitnessGenerator(stub), NewZkVMProofFetcher(stub))
+	req := &ProofRequest{L2BlockNumber: 10, L2BlockHash: common.Hash{0x01}, L1Head: common.Hash{0x02}}
+
+	status, err := provider.ProofStatus(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, RequestNone, status)
+
+	// the witness is requested first
+	require.NoError(t, provider.RequestProof(ctx, req))
+	status, err = provider.ProofStatus(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, RequestProcessing, status)
+	_, err = provider.FetchProof(ctx, req)
+	require.ErrorIs(t, err, ErrProofNotReady)
+
+	// once the witness is generated, the proof should be requested with the witness
+	stub.witnessStatus = RequestCompleted
+	status, err = provider.ProofStatus(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, RequestNone, status)
+	require.NoError(t, provider.RequestProof(ctx, req))
+	require.Equal(t, "witness", stub.provedWitness)
+	require.Equal(t, "witness", req.Witness)
+	status, err = provider.ProofStatus(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, RequestProcessing, status)
+
+	stub.proofStatus = RequestCompleted
+	status, err = provider.ProofStatus(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, RequestCompleted, status)
+	result, err := provider.FetchProof(ctx, req)
+	require.NoError(t, err)
+	require.Nil(t, result.ZkEVMProof)
+	require.Equal(t, HexBytes{0x01}, result.ZkVMProof.Proof)
+}
+
+func TestZkVMProofProvider_StoredWitness(t *testing.T) {
+	ctx := context.Background()
+	// the witness generator does not have the witness anymore, e.g. after a restart
+	stub := &zkVMStubRPC{witnessStatus: RequestNone, proofStatus: RequestNone}
+	provider := NewZkVMProofProvider(NewWitnessGenerator(stub), NewZkVMProofFetcher(stub))
+	req := &ProofRequest{L2BlockNumber: 10, L2BlockHash: common.Hash{0x01}, L1Head: common.Hash{0x02}, Witness: "stored"}
+
+	// the proof is requested with the stored witness, without generating the witness again
+	require.NoError(t, provider.RequestProof(ctx, req))
+	require.Equal(t, "stored", stub.provedWitness)
+	require.Equal(t, RequestNone, stub.witnessStatus)
+	status, err := provider.ProofStatus(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, RequestProcessing, status)
+
+	// a failed proof generation is requested again instead of waiting for the witness
+	stub.proofStatus = RequestFailed
+	status, err = provider.ProofStatus(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, RequestFailed, status)
+}
+
+func TestMockProofProvider(t *testing.T) {
+	ctx := context.Background()
+	expected := &ProofResult{ZkVMProof: &ZkVMProofResponse{RequestStatus: RequestCompleted}}
+	req := &ProofRequest{L2BlockNumber: 10}
+
+	provider := NewMockProofProvider(expected, time.Hour)
+	status, err := provider.ProofStatus(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, RequestNone, status)
+
+	require.NoError(t, provider.RequestProof(ctx, req))
+	status, err = provider.ProofStatus(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, RequestProcessing, status)
+	_, err = provider.FetchProof(ctx, req)
+	require.ErrorIs(t, err, ErrProofNotReady)
+
+	require.NoError(t, provider.CancelProof(ctx, req))
+	status, err = provider.ProofStatus(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, RequestNone, status)
+
+	provider = NewMockProofProvider(expected, 0)
+	require.NoError(t, provider.RequestProof(ctx, req))
+	result, err := provider.FetchProof(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, expected, result)
+}
@@ -10,3 +10,23 @@ const (
 	StatusAsserterTimeout
 	StatusReadyToProve
 )
+
+// StatusString returns the human-readable name of the challenge status.
+func StatusString(status uint8) string {
+	switch status {
+	case StatusNone:
+		return "None"
+	case StatusChallengerTurn:
+		return "ChallengerTurn"
+	case StatusAsserterTurn:
+		return "AsserterTurn"
+	case StatusChallengerTimeout:
+		return "ChallengerTimeout"
+	case StatusAsserterTimeout:
+		return "AsserterTimeout"
+	case StatusReadyToProve:
+		return "ReadyToProve"
+	default:
+		return "Unknown"
+	}
+}
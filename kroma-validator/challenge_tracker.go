@@ -0,0 +1,117 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/op-service/optsutils"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/kroma-network/kroma/kroma-bindings/bindings"
+	chal "github.com/kroma-network/kroma/kroma-validator/challenge"
+)
+
+// challengeTracker finds the challenges in Colosseum involving the validator, either on its outputs or created by it.
+// Since a challenge can be created only before the output is finalized, the ChallengeCreated events are scanned from
+// the start of the finalization window, then incrementally. The challenges found are tracked until they are over.
+type challengeTracker struct {
+	log               log.Logger
+	cfg               Config
+	colosseumContract *bindings.Colosseum
+
+	// window is the number of L1 blocks in the finalization window.
+	window uint64
+	// scanned is the last L1 block whose events have been scanned.
+	scanned uint64
+	// challenges are the challenges found, which may not be over yet.
+	challenges map[challengeID]struct{}
+}
+
+func newChallengeTracker(cfg Config, l log.Logger) (*challengeTracker, error) {
+	colosseumContract, err := bindings.NewColosseum(cfg.ColosseumAddr, cfg.L1Client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &challengeTracker{
+		log:               l,
+		cfg:               cfg,
+		colosseumContract: colosseumContract,
+		challenges:        make(map[challengeID]struct{}),
+	}, nil
+}
+
+// setFinalizationPeriod sets the finalization window to scan the challenges within.
+func (t *challengeTracker) setFinalizationPeriod(finalizationPeriodSeconds *big.Int) {
+	// the L1 block time is assumed to be 12 seconds, as the challenger does when scanning the outputs
+	t.window = new(big.Int).Div(finalizationPeriodSeconds, big.NewInt(12)).Uint64()
+}
+
+// inChallenge returns whether any challenge involving the validator is in progress on-chain.
+func (t *challengeTracker) inChallenge(ctx context.Context) (bool, error) {
+	if err := t.scan(ctx); err != nil {
+		return false, err
+	}
+
+	for id := range t.challenges {
+		cCtx, cCancel := context.WithTimeout(ctx, t.cfg.NetworkTimeout)
+		status, err := t.colosseumContract.GetStatus(optsutils.NewSimpleCallOpts(cCtx), new(big.Int).SetUint64(id.outputIndex), id.challenger)
+		cCancel()
+		if err != nil {
+			return false, fmt.Errorf("failed to get challenge status(outputIndex: %d, challenger: %s): %w", id.outputIndex, id.challenger, err)
+		}
+		if status != chal.StatusNone {
+			return true, nil
+		}
+		delete(t.challenges, id)
+	}
+	return false, nil
+}
+
+// scan finds the challenges created since the last scan, as the asserter and as the challenger.
+func (t *challengeTracker) scan(ctx context.Context) error {
+	cCtx, cCancel := context.WithTimeout(ctx, t.cfg.NetworkTimeout)
+	defer cCancel()
+	head, err := t.cfg.L1Client.BlockNumber(cCtx)
+	if err != nil {
+		return fmt.Errorf("failed to get latest L1 block number: %w", err)
+	}
+
+	from := t.scanned + 1
+	if head > t.window {
+		from = max(from, head-t.window)
+	}
+	if from > head {
+		return nil
+	}
+
+	validator := t.cfg.TxManager.From()
+	for _, roles := range [][2][]common.Address{{{validator}, nil}, {nil, {validator}}} {
+		cCtx, cCancel := context.WithTimeout(ctx, t.cfg.NetworkTimeout)
+		iter, err := t.colosseumContract.FilterChallengeCreated(&bind.FilterOpts{
+			Start:   from,
+			End:     &head,
+			Context: cCtx,
+		}, nil, roles[0], roles[1])
+		if err != nil {
+			cCancel()
+			return fmt.Errorf("failed to filter challenge created events in blocks %d-%d: %w", from, head, err)
+		}
+		for iter.Next() {
+			ev := iter.Event
+			t.challenges[newChallengeID(ev.OutputIndex, ev.Challenger)] = struct{}{}
+		}
+		err = iter.Error()
+		iter.Close()
+		cCancel()
+		if err != nil {
+			return fmt.Errorf("failed to iterate challenge created events in blocks %d-%d: %w", from, head, err)
+		}
+	}
+
+	t.scanned = head
+	return nil
+}
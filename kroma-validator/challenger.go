@@ -3,12 +3,13 @@ package validator
 import (
 	"bytes"
 	"context"
-	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -16,15 +17,20 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/watcher"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/kroma-network/kroma/kroma-bindings/bindings"
 	chal "github.com/kroma-network/kroma/kroma-validator/challenge"
 	"github.com/kroma-network/kroma/kroma-validator/metrics"
+	"github.com/kroma-network/kroma/kroma-validator/rpc"
+	"github.com/kroma-network/kroma/kroma-validator/store"
 )
 
 var deletedOutputRoot = [32]byte{}
@@ -35,6 +41,12 @@ type Challenger struct {
 	ctx    context.Context
 	cancel context.CancelFunc
 	metr   metrics.Metricer
+	store  store.Store
+
+	txSender *txSender
+
+	zkEVMProofProvider chal.ProofProvider
+	zkVMProofProvider  chal.ProofProvider
 
 	l2OOContract      *bindings.L2OutputOracle
 	l2OOABI           *abi.ABI
@@ -48,9 +60,17 @@ type Challenger struct {
 	finalizationPeriodSeconds *big.Int
 	l2BlockTime               *big.Int
 	checkpoint                *big.Int
+	resumeCheckpoint          *store.Checkpoint
 	requiredBondAmountV1      *big.Int
 	requiredBondAmountV2      *big.Int
 	valPoolTerminationIndex   *big.Int
+	provingTimeout            *big.Int
+
+	// handledCheckpoint stores the checkpoint up to the outputs whose handling has completed.
+	handledCheckpoint *outputCheckpoint
+
+	// proofDurations is the history of the proof generations to project whether a proof can be generated in time.
+	proofDurations *chal.ProofDurationTracker
 
 	l2OutputSubmittedSub ethereum.Subscription
 	challengeCreatedSub  ethereum.Subscription
@@ -58,11 +78,19 @@ type Challenger struct {
 	l2OutputSubmittedEventChan chan *bindings.L2OutputOracleOutputSubmitted
 	challengeCreatedEventChan  chan *bindings.ColosseumChallengeCreated
 
+	// mu guards the checkpoint against the reads from RPC, and the in-flight challenges.
+	mu         sync.RWMutex
+	challenges map[challengeID]*rpc.ChallengeInfo
+	paused     atomic.Bool
+
+	// dryRunTxs are the hashes of the txs simulated for the in-flight challenges in dry-run mode.
+	dryRunTxs map[challengeID]common.Hash
+
 	wg sync.WaitGroup
 }
 
 func NewChallenger(cfg Config, l log.Logger, m metrics.Metricer) (*Challenger, error) {
-	colosseumContract, err := bindings.NewColosseum(cfg.ColosseumAddr, cfg.L1Client)
+	colosseumContract, err := bindings.NewColosseum(cfg.ColosseumAddr, cfg.L1Backend())
 	if err != nil {
 		return nil, err
 	}
@@ -72,7 +100,7 @@ func NewChallenger(cfg Config, l log.Logger, m metrics.Metricer) (*Challenger, e
 		return nil, err
 	}
 
-	l2OOContract, err := bindings.NewL2OutputOracle(cfg.L2OutputOracleAddr, cfg.L1Client)
+	l2OOContract, err := bindings.NewL2OutputOracle(cfg.L2OutputOracleAddr, cfg.L1Backend())
 	if err != nil {
 		return nil, err
 	}
@@ -97,10 +125,42 @@ func NewChallenger(cfg Config, l log.Logger, m metrics.Metricer) (*Challenger, e
 		return nil, err
 	}
 
+	challengeStore := cfg.Store
+	if challengeStore == nil {
+		challengeStore = store.Disabled
+	}
+
+	log := l.New("service", "challenge")
+	txSender, err := newTxSender(cfg, log, m)
+	if err != nil {
+		return nil, err
+	}
+
+	// fall back to the provers of the RPC clients if the proof providers are not given
+	zkEVMProofProvider := cfg.ZkEVMProofProvider
+	if zkEVMProofProvider == nil && cfg.ZkEVMProofFetcher != nil {
+		zkEVMProofProvider = chal.NewZkEVMProofProvider(cfg.ZkEVMProofFetcher)
+	}
+	zkVMProofProvider := cfg.ZkVMProofProvider
+	if zkVMProofProvider == nil && cfg.ZkVMProofFetcher != nil && cfg.WitnessGenerator != nil {
+		zkVMProofProvider = chal.NewZkVMProofProvider(cfg.WitnessGenerator, cfg.ZkVMProofFetcher)
+	}
+
 	return &Challenger{
-		log:  l.New("service", "challenge"),
-		cfg:  cfg,
-		metr: m,
+		log:      log,
+		cfg:      cfg,
+		metr:     m,
+		store:    challengeStore,
+		txSender: txSender,
+
+		handledCheckpoint: newOutputCheckpoint(store.CheckpointChallenger, challengeStore, log),
+
+		zkEVMProofProvider: zkEVMProofProvider,
+		zkVMProofProvider:  zkVMProofProvider,
+
+		challenges:     make(map[challengeID]*rpc.ChallengeInfo),
+		proofDurations: chal.NewProofDurationTracker(cfg.ProofDurationEstimate),
+		dryRunTxs:      make(map[challengeID]common.Hash),
 
 		l2OOContract:      l2OOContract,
 		l2OOABI:           l2OOABI,
@@ -113,7 +173,7 @@ func NewChallenger(cfg Config, l log.Logger, m metrics.Metricer) (*Challenger, e
 }
 
 func (c *Challenger) InitConfig(ctx context.Context) error {
-	contractWatcher := watcher.NewContractWatcher(ctx, c.cfg.L1Client, c.log)
+	contractWatcher := watcher.NewContractWatcher(ctx, c.cfg.L1Backend(), c.log)
 
 	err := contractWatcher.WatchUpgraded(c.cfg.L2OutputOracleAddr, func() error {
 		cCtx, cCancel := context.WithTimeout(ctx, c.cfg.NetworkTimeout)
@@ -169,6 +229,21 @@ func (c *Challenger) InitConfig(ctx context.Context) error {
 		return fmt.Errorf("failed to initiate valPool config: %w", err)
 	}
 
+	err = contractWatcher.WatchUpgraded(c.cfg.ColosseumAddr, func() error {
+		cCtx, cCancel := context.WithTimeout(ctx, c.cfg.NetworkTimeout)
+		defer cCancel()
+		provingTimeout, err := c.colosseumContract.PROVINGTIMEOUT(optsutils.NewSimpleCallOpts(cCtx))
+		if err != nil {
+			return fmt.Errorf("failed to get proving timeout: %w", err)
+		}
+		c.provingTimeout = provingTimeout
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initiate colosseum config: %w", err)
+	}
+
 	err = contractWatcher.WatchUpgraded(c.cfg.AssetManagerAddr, func() error {
 		cCtx, cCancel := context.WithTimeout(ctx, c.cfg.NetworkTimeout)
 		defer cCancel()
@@ -241,9 +316,58 @@ func (c *Challenger) Stop() error {
 	return nil
 }
 
+// Pause stops handling outputs and challenges until Resume is called.
+// Events are still watched while paused, so that they are handled after resuming.
+func (c *Challenger) Pause() {
+	c.paused.Store(true)
+	c.log.Info("challenger paused")
+}
+
+// Resume resumes handling outputs and challenges.
+func (c *Challenger) Resume() {
+	c.paused.Store(false)
+	c.log.Info("challenger resumed")
+}
+
+func (c *Challenger) Paused() bool {
+	return c.paused.Load()
+}
+
+// Checkpoint returns the last checked output index, or nil if it has not been set yet.
+func (c *Challenger) Checkpoint() *big.Int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.checkpoint == nil {
+		return nil
+	}
+	return new(big.Int).Set(c.checkpoint)
+}
+
+// InflightChallenges returns the challenges currently being handled in ascending order of output index.
+func (c *Challenger) InflightChallenges() []*rpc.ChallengeInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	challenges := make([]*rpc.ChallengeInfo, 0, len(c.challenges))
+	for _, info := range c.challenges {
+		infoCopy := *info
+		challenges = append(challenges, &infoCopy)
+	}
+	sort.Slice(challenges, func(i, j int) bool {
+		if challenges[i].OutputIndex != challenges[j].OutputIndex {
+			return challenges[i].OutputIndex < challenges[j].OutputIndex
+		}
+		return bytes.Compare(challenges[i].Challenger[:], challenges[j].Challenger[:]) < 0
+	})
+	return challenges
+}
+
 func (c *Challenger) loop() {
 	defer c.wg.Done()
 
+	if c.cfg.ChallengerEnabled {
+		c.resumeCheckpoint = loadCheckpoint(c.ctx, c.cfg, c.store, store.CheckpointChallenger, c.log)
+	}
+
 	ticker := time.NewTicker(time.Minute)
 	defer ticker.Stop()
 
@@ -263,6 +387,8 @@ func (c *Challenger) loop() {
 				c.log.Error("failed to scan previous outputs", "err", err)
 				continue
 			}
+			// the handling of the previous outputs has started, so the checkpoint can be stored from now on
+			c.handledCheckpoint.start()
 
 			// if challenge mode on, subscribe L2 output submission events
 			if c.cfg.ChallengerEnabled {
@@ -284,21 +410,44 @@ func (c *Challenger) loop() {
 func (c *Challenger) updateCheckpoint() error {
 	cCtx, cCancel := context.WithTimeout(c.ctx, c.cfg.NetworkTimeout)
 	defer cCancel()
-	nextOutputIndex, err := c.l2OOContract.NextOutputIndex(optsutils.NewSimpleCallOpts(cCtx))
+	l1Head, err := c.cfg.L1Client.HeaderByNumber(cCtx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get the L1 head: %w", err)
+	}
+
+	cCtx, cCancel = context.WithTimeout(c.ctx, c.cfg.NetworkTimeout)
+	defer cCancel()
+	nextOutputIndex, err := c.l2OOContract.NextOutputIndex(&bind.CallOpts{Context: cCtx, BlockNumber: l1Head.Number})
 	if err != nil {
 		return fmt.Errorf("failed to get the latest output index: %w", err)
 	}
 	if nextOutputIndex.Cmp(common.Big0) == 0 {
 		// if no outputs have been submitted, set checkpoint to 1 because genesis output cannot be challenged
-		c.checkpoint = common.Big1
+		c.setCheckpoint(common.Big1, eth.HeaderBlockID(l1Head))
 	} else {
 		// set checkpoint to latestOutputIndex (nextOutputIndex - 1)
-		c.checkpoint = new(big.Int).Sub(nextOutputIndex, common.Big1)
+		c.setCheckpoint(new(big.Int).Sub(nextOutputIndex, common.Big1), eth.HeaderBlockID(l1Head))
 	}
-	c.metr.RecordChallengeCheckpoint(c.checkpoint)
 	return nil
 }
 
+// setCheckpoint sets the checkpoint computed at the given L1 block. It is persisted once the handling of
+// the outputs up to it has completed.
+func (c *Challenger) setCheckpoint(outputIndex *big.Int, l1Block eth.BlockID) {
+	c.mu.Lock()
+	c.checkpoint = outputIndex
+	c.mu.Unlock()
+	c.metr.RecordChallengeCheckpoint(c.checkpoint)
+	c.handledCheckpoint.advance(outputIndex, l1Block)
+}
+
+// startHandleOutput handles the output in the background, holding back the stored checkpoint until it completes.
+func (c *Challenger) startHandleOutput(outputIndex *big.Int) {
+	c.handledCheckpoint.begin(outputIndex)
+	c.wg.Add(1)
+	go c.handleOutput(outputIndex)
+}
+
 // scanPrevOutputs scans all the previous outputs before current L1 block within the finalization window.
 // If there are invalid outputs, create challenge.
 // If there are challenges in progress, keep handling them.
@@ -314,6 +463,18 @@ func (c *Challenger) scanPrevOutputs() error {
 	// The fromBlock is the maximum value of either genesis block(1) or the first block of the finalization window
 	fromBlock := math.BigMax(common.Big1, finalizationStartL1Block)
 
+	// The outputs up to the stored checkpoint have been handled before restart, so skip them.
+	// If the stored checkpoint is older than the finalization window, scan all outputs within the window.
+	var handledCheckpoint *big.Int
+	if c.resumeCheckpoint != nil {
+		if c.resumeCheckpoint.L1Block.Number >= fromBlock.Uint64() {
+			handledCheckpoint = c.resumeCheckpoint.OutputIndex
+		} else {
+			c.log.Info("stored checkpoint is older than the finalization window, scanning all outputs",
+				"checkpoint", c.resumeCheckpoint.OutputIndex, "l1Block", c.resumeCheckpoint.L1Block, "fromBlock", fromBlock)
+		}
+	}
+
 	outputSubmittedEvent := c.l2OOABI.Events[KeyEventOutputSubmitted]
 	challengeCreatedEvent := c.colosseumABI.Events[KeyEventChallengeCreated]
 
@@ -338,6 +499,12 @@ func (c *Challenger) scanPrevOutputs() error {
 		return fmt.Errorf("failed to get event logs related to outputs: %w", err)
 	}
 
+	records, err := c.store.Challenges()
+	if err != nil {
+		return fmt.Errorf("failed to load stored challenges: %w", err)
+	}
+
+	handled := make(map[challengeID]bool)
 	for _, vLog := range logs {
 		switch vLog.Address {
 		// for OutputSubmitted event
@@ -347,9 +514,11 @@ func (c *Challenger) scanPrevOutputs() error {
 				c.log.Error("failed to parse output submitted event", "err", err)
 				continue
 			}
+			if handledCheckpoint != nil && ev.L2OutputIndex.Cmp(handledCheckpoint) <= 0 {
+				continue
+			}
 			// handle output
-			c.wg.Add(1)
-			go c.handleOutput(ev.L2OutputIndex)
+			c.startHandleOutput(ev.L2OutputIndex)
 		// for ChallengeCreated event
 		case c.cfg.ColosseumAddr:
 			ev, err := NewChallengeCreatedEvent(vLog)
@@ -358,6 +527,7 @@ func (c *Challenger) scanPrevOutputs() error {
 				continue
 			}
 			if ev.OutputIndex.Sign() == 1 && c.isRelatedChallenge(ev.Asserter, ev.Challenger) {
+				handled[newChallengeID(ev.OutputIndex, ev.Challenger)] = true
 				c.wg.Add(1)
 				go c.handleChallenge(ev.OutputIndex, ev.Asserter, ev.Challenger)
 			}
@@ -366,6 +536,16 @@ func (c *Challenger) scanPrevOutputs() error {
 		}
 	}
 
+	// resume the stored challenges whose creation events are not found in the scanned range
+	for _, record := range records {
+		if handled[newChallengeID(record.OutputIndex, record.Challenger)] || !c.isRelatedChallenge(record.Asserter, record.Challenger) {
+			continue
+		}
+		c.log.Info("resuming stored challenge", "outputIndex", record.OutputIndex, "challenger", record.Challenger, "status", record.Status)
+		c.wg.Add(1)
+		go c.handleChallenge(record.OutputIndex, record.Asserter, record.Challenger)
+	}
+
 	return nil
 }
 
@@ -382,17 +562,14 @@ func (c *Challenger) subscribeL2OutputSubmitted() {
 			c.log.Info("watched output submitted event", "l2BlockNumber", ev.L2BlockNumber, "outputRoot", ev.OutputRoot, "outputIndex", ev.L2OutputIndex)
 			// if the emitted output index is less than or equal to the checkpoint, it is considered reorg occurred.
 			if ev.L2OutputIndex.Cmp(c.checkpoint) <= 0 {
-				c.wg.Add(1)
-				go c.handleOutput(new(big.Int).Set(ev.L2OutputIndex))
+				c.startHandleOutput(new(big.Int).Set(ev.L2OutputIndex))
 			} else {
 				// validate all outputs between the checkpoint and the current outputIndex
 				for i := new(big.Int).Add(c.checkpoint, common.Big1); i.Cmp(ev.L2OutputIndex) != 1; i.Add(i, common.Big1) {
-					c.wg.Add(1)
-					go c.handleOutput(new(big.Int).Set(i))
+					c.startHandleOutput(new(big.Int).Set(i))
 				}
 			}
-			c.checkpoint = ev.L2OutputIndex
-			c.metr.RecordChallengeCheckpoint(c.checkpoint)
+			c.setCheckpoint(ev.L2OutputIndex, eth.BlockID{Hash: ev.Raw.BlockHash, Number: ev.Raw.BlockNumber})
 		case <-c.ctx.Done():
 			return
 		}
@@ -423,6 +600,12 @@ func (c *Challenger) subscribeChallengeCreated() {
 func (c *Challenger) handleOutput(outputIndex *big.Int) {
 	c.log.Info("handling output to detect invalid output", "outputIndex", outputIndex)
 	defer c.wg.Done()
+	defer func() {
+		// an output whose handling is interrupted by a stop stays unhandled, to be handled again after a restart
+		if c.ctx.Err() == nil {
+			c.handledCheckpoint.done(outputIndex)
+		}
+	}()
 
 	ticker := time.NewTicker(time.Minute)
 	defer ticker.Stop()
@@ -432,6 +615,10 @@ func (c *Challenger) handleOutput(outputIndex *big.Int) {
 		case <-c.ctx.Done():
 			return
 		default:
+			if c.Paused() {
+				continue
+			}
+
 			// check if challenge creation period is not past
 			isInCreationPeriod, err := c.IsInChallengeCreationPeriod(c.ctx, outputIndex)
 			if err != nil {
@@ -507,6 +694,27 @@ type ChallengeWithData struct {
 	Processing  bool
 	ZkVMWitness string
 	ZkVMProof   *chal.ZkVMProofResponse
+
+	// pendingProof is the proof generation requested but not fetched yet, to cancel it once it is no longer needed.
+	// It is kept in memory only: after a restart, the generation is resumed from the status reported by the provider,
+	// and the zkVM witness is restored from the stored challenge instead of being generated again.
+	pendingProof *pendingProof
+}
+
+type pendingProof struct {
+	provider    chal.ProofProvider
+	req         *chal.ProofRequest
+	requestedAt time.Time
+}
+
+// challengeID identifies a challenge in the Colosseum contract.
+type challengeID struct {
+	outputIndex uint64
+	challenger  common.Address
+}
+
+func newChallengeID(outputIndex *big.Int, challenger common.Address) challengeID {
+	return challengeID{outputIndex: outputIndex.Uint64(), challenger: challenger}
 }
 
 // handleChallenge handles related challenge according to its status and role when challenge created.
@@ -514,9 +722,23 @@ func (c *Challenger) handleChallenge(outputIndex *big.Int, asserter common.Addre
 	c.log.Info("handling related challenge", "outputIndex", outputIndex, "asserter", asserter, "challenger", challenger)
 	defer c.wg.Done()
 
+	if !c.registerChallenge(outputIndex, asserter, challenger) {
+		c.log.Info("challenge is already being handled", "outputIndex", outputIndex, "challenger", challenger)
+		return
+	}
+	defer c.unregisterChallenge(outputIndex, challenger)
+
 	isAsserter := asserter == c.cfg.TxManager.From()
 	isChallenger := challenger == c.cfg.TxManager.From()
+	record := c.loadChallengeRecord(outputIndex, asserter, challenger)
+	c.refreshChallenge(record)
 	var challengeWithData *ChallengeWithData
+	defer func() {
+		// cancel the proof generation no longer needed, unless shutting down to resume it after restart
+		if challengeWithData != nil && challengeWithData.pendingProof != nil && c.ctx.Err() == nil {
+			c.cancelProof(challengeWithData.pendingProof)
+		}
+	}()
 
 	ticker := time.NewTicker(c.cfg.ChallengePollInterval)
 	defer ticker.Stop()
@@ -526,6 +748,11 @@ func (c *Challenger) handleChallenge(outputIndex *big.Int, asserter common.Addre
 		case <-c.ctx.Done():
 			return
 		default:
+			// only the challenges of the challenger are paused, the outputs of the validator are always defended
+			if isChallenger && c.Paused() {
+				continue
+			}
+
 			// check the status of challenge
 			status, err := c.GetChallengeStatus(c.ctx, outputIndex, challenger)
 			if err != nil {
@@ -535,6 +762,7 @@ func (c *Challenger) handleChallenge(outputIndex *big.Int, asserter common.Addre
 			// if challenge is not in progress, terminate handling
 			if status == chal.StatusNone {
 				c.log.Info("challenge is not in progress", "outputIndex", outputIndex, "challenger", challenger)
+				c.forgetChallengeRecord(outputIndex, challenger)
 				return
 			}
 
@@ -544,10 +772,16 @@ func (c *Challenger) handleChallenge(outputIndex *big.Int, asserter common.Addre
 				continue
 			}
 			if challengeWithData == nil {
-				challengeWithData = &ChallengeWithData{Challenge: challenge}
+				challengeWithData = &ChallengeWithData{
+					Challenge:   challenge,
+					ZkVMWitness: record.ZkVMWitness,
+					ZkVMProof:   record.ZkVMProof,
+				}
 			} else {
 				challengeWithData.Challenge = challenge
 			}
+			c.updateChallengeRecord(record, challengeWithData, status)
+			c.checkDeadline(outputIndex, challenger, status, challengeWithData, isChallenger)
 
 			output, err := c.GetL2Output(c.ctx, outputIndex)
 			if err != nil {
@@ -567,11 +801,13 @@ func (c *Challenger) handleChallenge(outputIndex *big.Int, asserter common.Addre
 				// if output is already deleted, asserter has no incentives to handle challenge any further
 				if isOutputDeleted {
 					c.log.Info("do nothing because output is already deleted", "outputIndex", outputIndex, "challenger", challenger)
+					c.forgetChallengeRecord(outputIndex, challenger)
 					return
 				}
 				// if output is already finalized and not `ChallengerTimeout` status, terminate handling
 				if isOutputFinalized && status != chal.StatusChallengerTimeout {
 					c.log.Info("output is already finalized when handling challenge", "outputIndex", outputIndex, "challenger", challenger)
+					c.forgetChallengeRecord(outputIndex, challenger)
 					return
 				}
 				switch status {
@@ -581,7 +817,7 @@ func (c *Challenger) handleChallenge(outputIndex *big.Int, asserter common.Addre
 						c.log.Error("failed to create bisect tx", "err", err, "outputIndex", outputIndex, "challenger", challenger)
 						continue
 					}
-					if err := c.submitChallengeTx(tx); err != nil {
+					if err := c.submitInflightChallengeTx(outputIndex, challenger, tx); err != nil {
 						c.log.Error("failed to submit bisect tx", "err", err, "outputIndex", outputIndex, "challenger", challenger)
 						continue
 					}
@@ -592,7 +828,7 @@ func (c *Challenger) handleChallenge(outputIndex *big.Int, asserter common.Addre
 						c.log.Error("failed to create challenger timeout tx", "err", err, "outputIndex", outputIndex, "challenger", challenger)
 						continue
 					}
-					if err := c.submitChallengeTx(tx); err != nil {
+					if err := c.submitInflightChallengeTx(outputIndex, challenger, tx); err != nil {
 						c.log.Error("failed to submit challenger timeout tx", "err", err, "outputIndex", outputIndex, "challenger", challenger)
 						continue
 					}
@@ -610,17 +846,19 @@ func (c *Challenger) handleChallenge(outputIndex *big.Int, asserter common.Addre
 						c.log.Error("failed to create cancel challenge tx", "err", err, "outputIndex", outputIndex)
 						continue
 					}
-					if err := c.submitChallengeTx(tx); err != nil {
+					if err := c.submitInflightChallengeTx(outputIndex, challenger, tx); err != nil {
 						c.log.Error("failed to submit cancel challenge tx", "err", err, "outputIndex", outputIndex)
 						continue
 					}
 					c.log.Info("successfully canceled challenge", "outputIndex", outputIndex)
+					c.forgetChallengeRecord(outputIndex, challenger)
 					return
 				}
 
 				// if output is already finalized, terminate handling
 				if isOutputFinalized {
 					c.log.Info("output is already finalized when handling challenge", "outputIndex", outputIndex)
+					c.forgetChallengeRecord(outputIndex, challenger)
 					return
 				}
 
@@ -633,13 +871,14 @@ func (c *Challenger) handleChallenge(outputIndex *big.Int, asserter common.Addre
 						c.log.Error("failed to create bisect tx", "err", err, "outputIndex", outputIndex)
 						continue
 					}
-					if err := c.submitChallengeTx(tx); err != nil {
+					if err := c.submitInflightChallengeTx(outputIndex, challenger, tx); err != nil {
 						c.log.Error("failed to submit bisect tx", "err", err, "outputIndex", outputIndex)
 						continue
 					}
 				case chal.StatusAsserterTimeout, chal.StatusReadyToProve:
 					skipSelectFaultPosition := status == chal.StatusAsserterTimeout
 					tx, err := c.ProveFault(c.ctx, challengeWithData, outputIndex, skipSelectFaultPosition)
+					c.updateChallengeRecord(record, challengeWithData, status)
 					if err != nil {
 						c.log.Error("failed to create prove fault tx", "err", err, "outputIndex", outputIndex)
 						continue
@@ -648,7 +887,7 @@ func (c *Challenger) handleChallenge(outputIndex *big.Int, asserter common.Addre
 						challengeWithData.Processing = false
 						continue
 					}
-					if err := c.submitChallengeTx(tx); err != nil {
+					if err := c.submitInflightChallengeTx(outputIndex, challenger, tx); err != nil {
 						c.log.Error("failed to submit prove fault tx", "err", err, "outputIndex", outputIndex)
 						continue
 					}
@@ -660,8 +899,185 @@ func (c *Challenger) handleChallenge(outputIndex *big.Int, asserter common.Addre
 	}
 }
 
+// loadChallengeRecord loads the persisted state of the challenge, or returns a new record if nothing is stored.
+func (c *Challenger) loadChallengeRecord(outputIndex *big.Int, asserter common.Address, challenger common.Address) *store.ChallengeRecord {
+	record, err := c.store.GetChallenge(outputIndex, challenger)
+	if err == nil {
+		c.log.Info("loaded stored challenge", "outputIndex", outputIndex, "challenger", challenger,
+			"status", record.Status, "hasWitness", len(record.ZkVMWitness) > 0, "hasProof", record.ZkVMProof != nil)
+		return record
+	}
+	if !errors.Is(err, store.ErrNotFound) {
+		c.log.Error("failed to load stored challenge", "err", err, "outputIndex", outputIndex, "challenger", challenger)
+	}
+	return &store.ChallengeRecord{
+		OutputIndex: new(big.Int).Set(outputIndex),
+		Asserter:    asserter,
+		Challenger:  challenger,
+	}
+}
+
+// updateChallengeRecord persists the state of the challenge if it has been changed since the last update.
+func (c *Challenger) updateChallengeRecord(record *store.ChallengeRecord, challengeWithData *ChallengeWithData, status uint8) {
+	changed := false
+	if record.Status != status {
+		record.Status = status
+		changed = true
+	}
+	challenge := challengeWithData.Challenge
+	if last := record.LastSegments(); challenge.Turn > 0 && (last == nil || last.Turn != challenge.Turn) {
+		hashes := make([]common.Hash, len(challenge.Segments))
+		for i, h := range challenge.Segments {
+			hashes[i] = h
+		}
+		record.Segments = append(record.Segments, &store.SegmentsRecord{
+			Turn:   challenge.Turn,
+			Start:  challenge.SegStart.Uint64(),
+			Size:   challenge.SegSize.Uint64(),
+			Hashes: hashes,
+		})
+		changed = true
+	}
+	if record.ZkVMWitness != challengeWithData.ZkVMWitness {
+		record.ZkVMWitness = challengeWithData.ZkVMWitness
+		changed = true
+	}
+	if record.ZkVMProof != challengeWithData.ZkVMProof {
+		record.ZkVMProof = challengeWithData.ZkVMProof
+		changed = true
+	}
+	if !changed {
+		return
+	}
+	c.refreshChallenge(record)
+	if err := c.store.PutChallenge(record); err != nil {
+		c.log.Error("failed to store challenge", "err", err, "outputIndex", record.OutputIndex, "challenger", record.Challenger)
+	}
+}
+
+// registerChallenge adds the challenge to the in-flight challenges.
+// It returns false if the challenge is already being handled.
+func (c *Challenger) registerChallenge(outputIndex *big.Int, asserter common.Address, challenger common.Address) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	id := newChallengeID(outputIndex, challenger)
+	if _, ok := c.challenges[id]; ok {
+		return false
+	}
+	c.challenges[id] = &rpc.ChallengeInfo{
+		OutputIndex: hexutil.Uint64(outputIndex.Uint64()),
+		Asserter:    asserter,
+		Challenger:  challenger,
+	}
+	return true
+}
+
+// refreshChallenge updates the in-flight challenge with the state of the record.
+func (c *Challenger) refreshChallenge(record *store.ChallengeRecord) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	info, ok := c.challenges[newChallengeID(record.OutputIndex, record.Challenger)]
+	if !ok {
+		return
+	}
+	info.Status = record.Status
+	if last := record.LastSegments(); last != nil {
+		info.Turn = last.Turn
+	}
+	info.HasWitness = len(record.ZkVMWitness) > 0
+	info.HasProof = record.ZkVMProof != nil
+}
+
+func (c *Challenger) unregisterChallenge(outputIndex *big.Int, challenger common.Address) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.challenges, newChallengeID(outputIndex, challenger))
+	delete(c.dryRunTxs, newChallengeID(outputIndex, challenger))
+	c.metr.ClearChallengeDeadline(outputIndex, challenger)
+}
+
+// checkDeadline computes the remaining time of the current turn of the challenge, and alerts if the fault proof to be
+// submitted by the challenger is not expected to be generated before the deadline, projected from the durations of
+// the recent proof generations.
+func (c *Challenger) checkDeadline(
+	outputIndex *big.Int, challenger common.Address, status uint8, challengeWithData *ChallengeWithData, isChallenger bool,
+) {
+	deadline, ok := chal.TurnDeadline(status, challengeWithData.Challenge.TimeoutAt, c.provingTimeout.Uint64())
+	if !ok {
+		c.metr.ClearChallengeDeadline(outputIndex, challenger)
+		c.setChallengeDeadline(outputIndex, challenger, 0, false)
+		return
+	}
+	remaining := time.Until(time.Unix(int64(deadline), 0))
+	c.metr.RecordChallengeDeadline(outputIndex, challenger, remaining)
+
+	atRisk := false
+	proofRequired := status == chal.StatusReadyToProve || status == chal.StatusAsserterTimeout
+	if isChallenger && c.cfg.ChallengerEnabled && proofRequired && challengeWithData.ZkVMProof == nil {
+		projected := c.proofDurations.Projected()
+		if pending := challengeWithData.pendingProof; pending != nil && !pending.requestedAt.IsZero() {
+			projected -= time.Since(pending.requestedAt)
+		}
+		atRisk = projected > remaining
+		if atRisk {
+			c.log.Error("fault proof is not expected to be generated before the deadline", "outputIndex", outputIndex,
+				"challenger", challenger, "status", status, "remaining", remaining, "projected", projected)
+		}
+	}
+	c.metr.RecordChallengeAtRisk(outputIndex, challenger, atRisk)
+	c.setChallengeDeadline(outputIndex, challenger, deadline, atRisk)
+}
+
+// setChallengeDeadline updates the deadline of the in-flight challenge.
+func (c *Challenger) setChallengeDeadline(outputIndex *big.Int, challenger common.Address, deadline uint64, atRisk bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	info, ok := c.challenges[newChallengeID(outputIndex, challenger)]
+	if !ok {
+		return
+	}
+	info.Deadline = hexutil.Uint64(deadline)
+	info.AtRisk = atRisk
+}
+
+// forgetChallengeRecord removes the persisted state of the challenge whose handling has been terminated.
+func (c *Challenger) forgetChallengeRecord(outputIndex *big.Int, challenger common.Address) {
+	if err := c.store.DeleteChallenge(outputIndex, challenger); err != nil {
+		c.log.Error("failed to delete stored challenge", "err", err, "outputIndex", outputIndex, "challenger", challenger)
+	}
+}
+
 func (c *Challenger) submitChallengeTx(tx *types.Transaction) error {
-	return c.cfg.TxManager.SendTransaction(c.ctx, tx).Err
+	return c.txSender.SendTransaction(c.ctx, tx).Err
+}
+
+// submitInflightChallengeTx submits the tx of the in-flight challenge. In dry-run mode, the challenge never advances
+// after the simulation, so the same tx is simulated only once instead of on every poll.
+func (c *Challenger) submitInflightChallengeTx(outputIndex *big.Int, challenger common.Address, tx *types.Transaction) error {
+	if !c.cfg.DryRun {
+		return c.submitChallengeTx(tx)
+	}
+
+	id := newChallengeID(outputIndex, challenger)
+	txHash := crypto.Keccak256Hash(tx.To().Bytes(), tx.Data())
+	c.mu.RLock()
+	simulated := c.dryRunTxs[id] == txHash
+	c.mu.RUnlock()
+	if simulated {
+		c.log.Debug("dry-run: challenge tx already simulated", "outputIndex", outputIndex, "challenger", challenger)
+		return nil
+	}
+
+	if err := c.submitChallengeTx(tx); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// the challenge is no longer in-flight if its handling has been terminated meanwhile
+	if _, ok := c.challenges[id]; ok {
+		c.dryRunTxs[id] = txHash
+	}
+	return nil
 }
 
 // CanCreateChallenge checks if challenger is in the status that can create challenge.
@@ -816,6 +1232,11 @@ type Outputs struct {
 	LocalOutput  *eth.OutputResponse
 }
 
+// IsValid reports whether the output submitted to L2OutputOracle matches the output computed by the rollup node.
+func (o *Outputs) IsValid() bool {
+	return bytes.Equal(o.LocalOutput.OutputRoot[:], o.RemoteOutput.OutputRoot[:])
+}
+
 func (c *Challenger) OutputsAtIndex(ctx context.Context, outputIndex *big.Int) (*Outputs, error) {
 	remoteOutput, err := c.GetL2Output(ctx, outputIndex)
 	if err != nil {
@@ -848,7 +1269,7 @@ func (c *Challenger) ValidateOutput(outputIndex *big.Int, outputs *Outputs) *Out
 	start := outputs.RemoteOutput.L2BlockNumber.Uint64() - c.submissionInterval.Uint64()
 	end := outputs.RemoteOutput.L2BlockNumber.Uint64()
 
-	if !bytes.Equal(outputs.LocalOutput.OutputRoot[:], outputs.RemoteOutput.OutputRoot[:]) {
+	if !outputs.IsValid() {
 		c.log.Info(
 			"found invalid output",
 			"blockNumber", outputs.RemoteOutput.L2BlockNumber,
@@ -1013,76 +1434,41 @@ func (c *Challenger) ProveFault(
 
 	// if the target block time is after Kroma MPT time, generate zkVM proof otherwise zkEVM proof
 	if c.cfg.RollupConfig.IsKromaMPT(header.Time) {
-		return c.proveFaultWithZkVm(ctx, outputIndex, challengeWithData, targetBlockNumber, header.Hash().Hex(), position)
+		return c.proveFaultWithZkVm(ctx, outputIndex, challengeWithData, targetBlockNumber, header.Hash(), position)
 	} else {
-		return c.proveFaultWithZkEvm(ctx, outputIndex, challenge.Challenger, targetBlockNumber, position)
+		return c.proveFaultWithZkEvm(ctx, outputIndex, challengeWithData, targetBlockNumber, header.Hash(), position)
 	}
 }
 
-// proveFaultWithZkVm fetches zkVM witness data and proof to create proveFaultWithZkVm transaction.
-// It sends requests to external RPC and immediately returns, then continuously checks the status of request and moves to next step.
+// proveFaultWithZkVm generates zkVM proof to create proveFaultWithZkVm transaction.
+// It requests the proof to the zkVM proof provider and immediately returns, then continuously checks the status of
+// request and moves to next step.
 func (c *Challenger) proveFaultWithZkVm(
-	ctx context.Context, outputIndex *big.Int, challengeWithData *ChallengeWithData, targetBlockNumber *big.Int, blockHash string, position *big.Int,
+	ctx context.Context, outputIndex *big.Int, challengeWithData *ChallengeWithData, targetBlockNumber *big.Int, blockHash common.Hash, position *big.Int,
 ) (*types.Transaction, error) {
 	challenge := challengeWithData.Challenge
 	c.log.Info("crafting proveFaultWithZkVm tx", "outputIndex", outputIndex, "challenger", challenge.Challenger)
 
-	l1Head := hex.EncodeToString(challenge.L1Head[:])
-
-	if len(challengeWithData.ZkVMWitness) == 0 {
-		witnessResult, err := c.cfg.WitnessGenerator.GetWitness(ctx, blockHash, l1Head)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get witness(target block number: %s): %w", targetBlockNumber.String(), err)
+	if challengeWithData.ZkVMProof == nil {
+		if c.zkVMProofProvider == nil {
+			return nil, errors.New("zkVM proof provider is not configured")
 		}
-
-		switch witnessResult.RequestStatus {
-		case chal.RequestNone, chal.RequestFailed:
-			c.log.Info("witness generation should be requested",
-				"targetBlockNumber", targetBlockNumber, "status", witnessResult.RequestStatus)
-			_, err = c.cfg.WitnessGenerator.RequestWitness(ctx, blockHash, l1Head)
-			if err != nil {
-				return nil, fmt.Errorf("failed to request witness(target block number: %s): %w", targetBlockNumber.String(), err)
-			}
-			challengeWithData.Processing = true
-			return nil, nil
-		case chal.RequestProcessing:
-			c.log.Info("witness generation is in progress", "targetBlockNumber", targetBlockNumber)
-			challengeWithData.Processing = true
-			return nil, nil
-		case chal.RequestCompleted:
-			c.log.Info("witness generation is completed", "targetBlockNumber", targetBlockNumber)
-			challengeWithData.ZkVMWitness = witnessResult.Witness
-		default:
-			return nil, fmt.Errorf("unknown request status of witness generation: %s", witnessResult.RequestStatus)
+		req := &chal.ProofRequest{
+			L2BlockNumber: targetBlockNumber.Uint64(),
+			L2BlockHash:   blockHash,
+			L1Head:        challenge.L1Head,
+			Witness:       challengeWithData.ZkVMWitness,
 		}
-	}
-
-	if challengeWithData.ZkVMProof == nil {
-		proofResult, err := c.cfg.ZkVMProofFetcher.GetProof(ctx, blockHash, l1Head)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get proof(target block number: %s): %w", targetBlockNumber.String(), err)
+		result, err := c.generateProof(ctx, c.zkVMProofProvider, req, challengeWithData, nil)
+		// keep the generated witness to be stored, even if the proof is not generated yet
+		challengeWithData.ZkVMWitness = req.Witness
+		if err != nil || result == nil {
+			return nil, err
 		}
-
-		switch proofResult.RequestStatus {
-		case chal.RequestNone, chal.RequestFailed:
-			c.log.Info("proof generation should be requested",
-				"targetBlockNumber", targetBlockNumber, "status", proofResult.RequestStatus)
-			_, err = c.cfg.ZkVMProofFetcher.RequestProve(ctx, blockHash, l1Head, challengeWithData.ZkVMWitness)
-			if err != nil {
-				return nil, fmt.Errorf("failed to request proof(target block number: %s): %w", targetBlockNumber.String(), err)
-			}
-			challengeWithData.Processing = true
-			return nil, nil
-		case chal.RequestProcessing:
-			c.log.Info("proof generation is in progress", "targetBlockNumber", targetBlockNumber)
-			challengeWithData.Processing = true
-			return nil, nil
-		case chal.RequestCompleted:
-			c.log.Info("proof generation is completed", "targetBlockNumber", targetBlockNumber)
-			challengeWithData.ZkVMProof = proofResult
-		default:
-			return nil, fmt.Errorf("unknown request status of proof generation: %s", proofResult.RequestStatus)
+		if result.ZkVMProof == nil {
+			return nil, fmt.Errorf("zkVM proof is not provided(target block number: %s)", targetBlockNumber.String())
 		}
+		challengeWithData.ZkVMProof = result.ZkVMProof
 	}
 
 	txOpts := optsutils.NewSimpleTxOpts(ctx, c.cfg.TxManager.From(), c.cfg.TxManager.Signer)
@@ -1103,12 +1489,16 @@ func (c *Challenger) proveFaultWithZkVm(
 	return tx, nil
 }
 
-// proveFaultWithZkEvm fetches public input and zkEVM proof to create proveFaultWithZkEvm transaction.
-// It sends requests to external RPC and waits until responded.
+// proveFaultWithZkEvm fetches public input and generates zkEVM proof to create proveFaultWithZkEvm transaction.
 func (c *Challenger) proveFaultWithZkEvm(
-	ctx context.Context, outputIndex *big.Int, challenger common.Address, targetBlockNumber *big.Int, position *big.Int,
+	ctx context.Context, outputIndex *big.Int, challengeWithData *ChallengeWithData, targetBlockNumber *big.Int, blockHash common.Hash, position *big.Int,
 ) (*types.Transaction, error) {
-	c.log.Info("crafting proveFaultWithZkEvm tx", "outputIndex", outputIndex, "challenger", challenger)
+	challenge := challengeWithData.Challenge
+	c.log.Info("crafting proveFaultWithZkEvm tx", "outputIndex", outputIndex, "challenger", challenge.Challenger)
+
+	if c.zkEVMProofProvider == nil {
+		return nil, errors.New("zkEVM proof provider is not configured")
+	}
 
 	srcBlockNumber := new(big.Int).Sub(targetBlockNumber, common.Big1)
 	proof, err := c.PublicInputProof(ctx, srcBlockNumber.Uint64())
@@ -1116,21 +1506,35 @@ func (c *Challenger) proveFaultWithZkEvm(
 		return nil, fmt.Errorf("failed to get public input proof(prev block number: %s): %w", srcBlockNumber.String(), err)
 	}
 
-	cCtx, cCancel := context.WithTimeout(ctx, c.cfg.NetworkTimeout)
-	defer cCancel()
-	trace, err := c.cfg.L2Client.GetBlockTraceByNumber(cCtx, targetBlockNumber)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get block trace(target block number: %s): %w", targetBlockNumber.String(), err)
+	req := &chal.ProofRequest{
+		L2BlockNumber: targetBlockNumber.Uint64(),
+		L2BlockHash:   blockHash,
+		L1Head:        challenge.L1Head,
 	}
+	// the block trace is fetched only when the proof should be requested
+	fillTrace := func(req *chal.ProofRequest) error {
+		cCtx, cCancel := context.WithTimeout(ctx, c.cfg.NetworkTimeout)
+		defer cCancel()
+		trace, err := c.cfg.L2Client.GetBlockTraceByNumber(cCtx, targetBlockNumber)
+		if err != nil {
+			return fmt.Errorf("failed to get block trace(target block number: %s): %w", targetBlockNumber.String(), err)
+		}
 
-	traceBz, err := json.Marshal(trace)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal block trace(target block number: %s): %w", targetBlockNumber.String(), err)
+		traceBz, err := json.Marshal(trace)
+		if err != nil {
+			return fmt.Errorf("failed to marshal block trace(target block number: %s): %w", targetBlockNumber.String(), err)
+		}
+		req.Trace = traceBz
+		return nil
 	}
-
-	fetchResult, err := c.cfg.ZkEVMProofFetcher.FetchProofAndPair(ctx, string(traceBz))
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch proof and pair(target block number: %s): %w", targetBlockNumber.String(), err)
+	result, err := c.generateProof(ctx, c.zkEVMProofProvider, req, challengeWithData, fillTrace)
+	if err != nil || result == nil {
+		return nil, err
+	}
+	// NOTE(0xHansLee): the hash of public input (pair[4], pair[5]) is not needed in proving fault.
+	// It can be calculated using public input sent to colosseum contract.
+	if result.ZkEVMProof == nil || len(result.ZkEVMProof.Pair) < 4 {
+		return nil, fmt.Errorf("invalid zkEVM proof is provided(target block number: %s)", targetBlockNumber.String())
 	}
 
 	txOpts := optsutils.NewSimpleTxOpts(ctx, c.cfg.TxManager.From(), c.cfg.TxManager.Signer)
@@ -1140,10 +1544,8 @@ func (c *Challenger) proveFaultWithZkEvm(
 		position,
 		bindings.TypesZkEvmProof{
 			PublicInputProof: proof,
-			Proof:            fetchResult.Proof,
-			// NOTE(0xHansLee): the hash of public input (pair[4], pair[5]) is not needed in proving fault.
-			// It can be calculated using public input sent to colosseum contract.
-			Pair: fetchResult.Pair[:4],
+			Proof:            result.ZkEVMProof.Proof,
+			Pair:             result.ZkEVMProof.Pair[:4],
 		},
 	)
 	if err != nil {
@@ -1153,6 +1555,75 @@ func (c *Challenger) proveFaultWithZkEvm(
 	return tx, nil
 }
 
+// generateProof drives the proof generation of the provider one step forward.
+// It requests the proof if it has not been requested or has failed, and returns the proof once it is completed.
+// If the proof is being generated, it returns nil with challengeWithData.Processing set.
+// The fillRequest is called to complete the request only when the proof should be requested.
+func (c *Challenger) generateProof(
+	ctx context.Context, provider chal.ProofProvider, req *chal.ProofRequest, challengeWithData *ChallengeWithData, fillRequest func(*chal.ProofRequest) error,
+) (*chal.ProofResult, error) {
+	status, err := provider.ProofStatus(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proof status(target block number: %d): %w", req.L2BlockNumber, err)
+	}
+
+	if status == chal.RequestNone || status == chal.RequestFailed {
+		c.log.Info("proof generation should be requested", "targetBlockNumber", req.L2BlockNumber, "status", status)
+		if fillRequest != nil {
+			if err := fillRequest(req); err != nil {
+				return nil, err
+			}
+		}
+		if err := provider.RequestProof(ctx, req); err != nil {
+			return nil, fmt.Errorf("failed to request proof(target block number: %d): %w", req.L2BlockNumber, err)
+		}
+		// the generation may take several requests, e.g. for the witness and then for the proof, so its duration is
+		// measured from the first request
+		requestedAt := time.Now()
+		if pending := challengeWithData.pendingProof; pending != nil && !pending.requestedAt.IsZero() {
+			requestedAt = pending.requestedAt
+		}
+		challengeWithData.pendingProof = &pendingProof{provider: provider, req: req, requestedAt: requestedAt}
+
+		// check the status again since the provider may generate the proof synchronously
+		status, err = provider.ProofStatus(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get proof status(target block number: %d): %w", req.L2BlockNumber, err)
+		}
+	}
+
+	switch status {
+	case chal.RequestCompleted:
+		c.log.Info("proof generation is completed", "targetBlockNumber", req.L2BlockNumber)
+		result, err := provider.FetchProof(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch proof(target block number: %d): %w", req.L2BlockNumber, err)
+		}
+		if pending := challengeWithData.pendingProof; pending != nil && !pending.requestedAt.IsZero() {
+			duration := time.Since(pending.requestedAt)
+			c.proofDurations.Add(duration)
+			c.metr.RecordProofDuration(duration)
+		}
+		challengeWithData.pendingProof = nil
+		return result, nil
+	case chal.RequestNone, chal.RequestFailed, chal.RequestProcessing:
+		c.log.Info("proof generation is in progress", "targetBlockNumber", req.L2BlockNumber, "status", status)
+		challengeWithData.Processing = true
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown request status of proof generation: %s", status)
+	}
+}
+
+// cancelProof cancels the proof generation that is no longer needed.
+func (c *Challenger) cancelProof(pending *pendingProof) {
+	cCtx, cCancel := context.WithTimeout(c.ctx, c.cfg.NetworkTimeout)
+	defer cCancel()
+	if err := pending.provider.CancelProof(cCtx, pending.req); err != nil {
+		c.log.Warn("failed to cancel proof generation", "err", err, "targetBlockNumber", pending.req.L2BlockNumber)
+	}
+}
+
 // IsOutputDeleted checks if the output is deleted.
 func IsOutputDeleted(outputRoot [32]byte) bool {
 	return bytes.Equal(outputRoot[:], deletedOutputRoot[:])
@@ -0,0 +1,114 @@
+package validator
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	chal "github.com/kroma-network/kroma/kroma-validator/challenge"
+	"github.com/kroma-network/kroma/kroma-validator/metrics"
+	"github.com/kroma-network/kroma/kroma-validator/rpc"
+)
+
+// phasedProofProvider generates a proof in two phases, like the witness and the proof of zkVM provers.
+// Each request moves the generation to processing, and the test completes the phases by setting the status.
+type phasedProofProvider struct {
+	status   chal.RequestStatusType
+	requests int
+}
+
+func (p *phasedProofProvider) RequestProof(_ context.Context, _ *chal.ProofRequest) error {
+	p.requests++
+	p.status = chal.RequestProcessing
+	return nil
+}
+
+func (p *phasedProofProvider) ProofStatus(_ context.Context, _ *chal.ProofRequest) (chal.RequestStatusType, error) {
+	return p.status, nil
+}
+
+func (p *phasedProofProvider) FetchProof(_ context.Context, _ *chal.ProofRequest) (*chal.ProofResult, error) {
+	return &chal.ProofResult{ZkVMProof: &chal.ZkVMProofResponse{RequestStatus: chal.RequestCompleted}}, nil
+}
+
+func (p *phasedProofProvider) CancelProof(_ context.Context, _ *chal.ProofRequest) error {
+	return nil
+}
+
+func TestGenerateProofDuration(t *testing.T) {
+	ctx := context.Background()
+	c := &Challenger{
+		log:            testlog.Logger(t, log.LevelInfo),
+		metr:           metrics.NoopMetrics,
+		proofDurations: chal.NewProofDurationTracker(time.Minute),
+	}
+	provider := &phasedProofProvider{status: chal.RequestNone}
+	req := &chal.ProofRequest{L2BlockNumber: 10}
+	challengeWithData := &ChallengeWithData{}
+
+	// the witness is requested
+	result, err := c.generateProof(ctx, provider, req, challengeWithData, nil)
+	require.NoError(t, err)
+	require.Nil(t, result)
+	require.Equal(t, 1, provider.requests)
+	require.NotNil(t, challengeWithData.pendingProof)
+	requestedAt := time.Now().Add(-time.Hour)
+	challengeWithData.pendingProof.requestedAt = requestedAt
+
+	// once the witness is generated, the proof is requested without resetting the request time
+	provider.status = chal.RequestNone
+	result, err = c.generateProof(ctx, provider, req, challengeWithData, nil)
+	require.NoError(t, err)
+	require.Nil(t, result)
+	require.Equal(t, 2, provider.requests)
+	require.Equal(t, requestedAt, challengeWithData.pendingProof.requestedAt)
+
+	// the recorded duration covers both phases
+	provider.status = chal.RequestCompleted
+	result, err = c.generateProof(ctx, provider, req, challengeWithData, nil)
+	require.NoError(t, err)
+	require.NotNil(t, result.ZkVMProof)
+	require.Nil(t, challengeWithData.pendingProof)
+	require.GreaterOrEqual(t, c.proofDurations.Projected(), time.Hour)
+}
+
+func TestSubmitInflightChallengeTx_DryRun(t *testing.T) {
+	l1 := &testL1RPC{}
+	outputIndex := big.NewInt(3)
+	challenger := common.Address{0x01}
+	c := &Challenger{
+		log:        testlog.Logger(t, log.LevelInfo),
+		metr:       metrics.NoopMetrics,
+		cfg:        Config{DryRun: true},
+		ctx:        context.Background(),
+		txSender:   newDryRunTxSender(t, l1),
+		challenges: map[challengeID]*rpc.ChallengeInfo{newChallengeID(outputIndex, challenger): {}},
+		dryRunTxs:  make(map[challengeID]common.Hash),
+	}
+
+	to := common.Address{0xaa}
+	bisect := types.NewTx(&types.DynamicFeeTx{To: &to, Data: []byte{0x01, 0x02, 0x03, 0x04}})
+
+	// the same tx is simulated only once while the challenge is in-flight
+	require.NoError(t, c.submitInflightChallengeTx(outputIndex, challenger, bisect))
+	require.NoError(t, c.submitInflightChallengeTx(outputIndex, challenger, bisect))
+	require.Len(t, l1.calls, 1)
+
+	// a different tx of the challenge is simulated
+	proveFault := types.NewTx(&types.DynamicFeeTx{To: &to, Data: []byte{0x05, 0x06, 0x07, 0x08}})
+	require.NoError(t, c.submitInflightChallengeTx(outputIndex, challenger, proveFault))
+	require.Len(t, l1.calls, 2)
+
+	// the tx is simulated again once the challenge is handled again
+	c.unregisterChallenge(outputIndex, challenger)
+	c.challenges[newChallengeID(outputIndex, challenger)] = &rpc.ChallengeInfo{}
+	require.NoError(t, c.submitInflightChallengeTx(outputIndex, challenger, proveFault))
+	require.Len(t, l1.calls, 3)
+}
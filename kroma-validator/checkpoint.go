@@ -0,0 +1,130 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/kroma-network/kroma/kroma-validator/store"
+)
+
+// loadCheckpoint loads the stored checkpoint with the given name. It returns nil if there is no stored checkpoint,
+// or if the L1 block that the checkpoint was computed at is no longer canonical due to L1 reorg.
+func loadCheckpoint(ctx context.Context, cfg Config, st store.Store, name string, l log.Logger) *store.Checkpoint {
+	checkpoint, err := st.GetCheckpoint(name)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		l.Error("failed to load stored checkpoint", "name", name, "err", err)
+		return nil
+	}
+
+	cCtx, cCancel := context.WithTimeout(ctx, cfg.NetworkTimeout)
+	defer cCancel()
+	header, err := cfg.L1Client.HeaderByNumber(cCtx, new(big.Int).SetUint64(checkpoint.L1Block.Number))
+	if err != nil {
+		l.Error("failed to get L1 block of stored checkpoint", "name", name, "l1Block", checkpoint.L1Block, "err", err)
+		return nil
+	}
+	if header.Hash() != checkpoint.L1Block.Hash {
+		l.Warn("L1 block of stored checkpoint has been reorged out, ignoring it",
+			"name", name, "outputIndex", checkpoint.OutputIndex, "stored", checkpoint.L1Block, "canonical", header.Hash())
+		return nil
+	}
+
+	l.Info("loaded stored checkpoint", "name", name, "outputIndex", checkpoint.OutputIndex, "l1Block", checkpoint.L1Block)
+	return checkpoint
+}
+
+// storeCheckpoint persists the checkpoint with the given name computed at the given L1 block.
+func storeCheckpoint(st store.Store, name string, outputIndex *big.Int, l1Block eth.BlockID, l log.Logger) {
+	checkpoint := &store.Checkpoint{
+		OutputIndex: new(big.Int).Set(outputIndex),
+		L1Block:     l1Block,
+	}
+	if err := st.PutCheckpoint(name, checkpoint); err != nil {
+		l.Error("failed to store checkpoint", "name", name, "outputIndex", outputIndex, "err", err)
+	}
+}
+
+// outputCheckpoint persists the checkpoint of a validator role, the last output index up to which the handling of
+// all the outputs has completed. The outputs are handled concurrently, so the checkpoint is held back by the lowest
+// output still being handled, for an output whose handling is interrupted by a restart to be handled again.
+type outputCheckpoint struct {
+	name string
+	st   store.Store
+	log  log.Logger
+
+	mu sync.Mutex
+	// started is set once the outputs up to the frontier are being handled, the checkpoint is not stored before.
+	started bool
+	// frontier is the last output index whose handling has started, computed at l1Block.
+	frontier *big.Int
+	l1Block  eth.BlockID
+	// inflight counts the handlings in progress by output index, an output can be handled again on L1 reorg.
+	inflight map[uint64]int
+	stored   *big.Int
+}
+
+func newOutputCheckpoint(name string, st store.Store, l log.Logger) *outputCheckpoint {
+	return &outputCheckpoint{name: name, st: st, log: l, inflight: make(map[uint64]int)}
+}
+
+// begin records that the handling of the output started. It must be called before the frontier passes the output.
+func (c *outputCheckpoint) begin(outputIndex *big.Int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.inflight[outputIndex.Uint64()]++
+}
+
+// done records that the handling of the output completed, and stores the checkpoint if it advanced.
+func (c *outputCheckpoint) done(outputIndex *big.Int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	idx := outputIndex.Uint64()
+	if c.inflight[idx]--; c.inflight[idx] <= 0 {
+		delete(c.inflight, idx)
+	}
+	c.storeLocked()
+}
+
+// advance sets the last output index whose handling has started, and stores the checkpoint if it advanced.
+func (c *outputCheckpoint) advance(frontier *big.Int, l1Block eth.BlockID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.frontier = new(big.Int).Set(frontier)
+	c.l1Block = l1Block
+	c.storeLocked()
+}
+
+// start allows storing the checkpoint, once the handling of the outputs up to the frontier has started.
+func (c *outputCheckpoint) start() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.started = true
+	c.storeLocked()
+}
+
+func (c *outputCheckpoint) storeLocked() {
+	if !c.started || c.frontier == nil {
+		return
+	}
+	checkpoint := c.frontier
+	for idx := range c.inflight {
+		if idx == 0 {
+			continue
+		}
+		if handled := new(big.Int).SetUint64(idx - 1); handled.Cmp(checkpoint) < 0 {
+			checkpoint = handled
+		}
+	}
+	if c.stored != nil && c.stored.Cmp(checkpoint) == 0 {
+		return
+	}
+	c.stored = new(big.Int).Set(checkpoint)
+	storeCheckpoint(c.st, c.name, checkpoint, c.l1Block, c.log)
+}
@@ -0,0 +1,92 @@
+package validator
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/kroma-validator/metrics"
+	"github.com/kroma-network/kroma/kroma-validator/store"
+)
+
+func requireStoredCheckpoint(t *testing.T, st store.Store, name string, expected int64) {
+	checkpoint, err := st.GetCheckpoint(name)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(expected), checkpoint.OutputIndex)
+}
+
+func TestOutputCheckpoint(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	st, err := store.NewPebbleStore(logger, t.TempDir())
+	require.NoError(t, err)
+	defer st.Close()
+	l1Block := eth.BlockID{Hash: common.Hash{0x01}, Number: 100}
+
+	cp := newOutputCheckpoint(store.CheckpointChallenger, st, logger)
+	cp.begin(big.NewInt(5))
+	cp.begin(big.NewInt(6))
+	cp.advance(big.NewInt(6), l1Block)
+	// nothing is stored until the handling of the outputs up to the frontier has started
+	_, err = st.GetCheckpoint(store.CheckpointChallenger)
+	require.ErrorIs(t, err, store.ErrNotFound)
+	cp.start()
+	requireStoredCheckpoint(t, st, store.CheckpointChallenger, 4)
+
+	// the checkpoint is held back by the lowest output being handled
+	cp.done(big.NewInt(6))
+	requireStoredCheckpoint(t, st, store.CheckpointChallenger, 4)
+	cp.done(big.NewInt(5))
+	requireStoredCheckpoint(t, st, store.CheckpointChallenger, 6)
+
+	// an output handled again on L1 reorg holds back the checkpoint too
+	cp.begin(big.NewInt(3))
+	cp.begin(big.NewInt(7))
+	cp.advance(big.NewInt(7), l1Block)
+	requireStoredCheckpoint(t, st, store.CheckpointChallenger, 2)
+	cp.done(big.NewInt(3))
+	requireStoredCheckpoint(t, st, store.CheckpointChallenger, 6)
+	cp.done(big.NewInt(7))
+	requireStoredCheckpoint(t, st, store.CheckpointChallenger, 7)
+}
+
+func TestChallengerCheckpointRestartDuringHandling(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	dir := t.TempDir()
+	st, err := store.NewPebbleStore(logger, dir)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &Challenger{
+		log:               logger,
+		ctx:               ctx,
+		metr:              metrics.NoopMetrics,
+		store:             st,
+		handledCheckpoint: newOutputCheckpoint(store.CheckpointChallenger, st, logger),
+	}
+	c.setCheckpoint(big.NewInt(4), eth.BlockID{Hash: common.Hash{0x01}, Number: 100})
+	c.handledCheckpoint.start()
+	requireStoredCheckpoint(t, st, store.CheckpointChallenger, 4)
+
+	// the validator stops while the outputs submitted after the checkpoint are being handled
+	cancel()
+	c.startHandleOutput(big.NewInt(5))
+	c.startHandleOutput(big.NewInt(6))
+	c.setCheckpoint(big.NewInt(6), eth.BlockID{Hash: common.Hash{0x02}, Number: 110})
+	c.wg.Wait()
+	require.Equal(t, big.NewInt(6), c.Checkpoint())
+	require.NoError(t, st.Close())
+
+	// after the restart, the outputs whose handling was interrupted are handled again
+	st, err = store.NewPebbleStore(logger, dir)
+	require.NoError(t, err)
+	defer st.Close()
+	checkpoint, err := st.GetCheckpoint(store.CheckpointChallenger)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(4), checkpoint.OutputIndex)
+}
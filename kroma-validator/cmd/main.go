@@ -37,17 +37,20 @@ func main() {
 				cmd.TokenAmountFlag,
 				cmd.CommissionRateFlag,
 				cmd.WithdrawAccountFlag,
+				cmd.UnsignedFlag,
 			},
 			Action: cmd.Register,
 		},
 		{
 			Name:   "activate",
 			Usage:  "Activate the validator in ValidatorManager",
+			Flags:  []cli.Flag{cmd.UnsignedFlag},
 			Action: cmd.Activate,
 		},
 		{
 			Name:   "unjail",
 			Usage:  "Attempt to unjail the validator in ValidatorManager",
+			Flags:  []cli.Flag{cmd.UnsignedFlag},
 			Action: cmd.Unjail,
 		},
 		{
@@ -57,12 +60,13 @@ func main() {
 				{
 					Name:   "init",
 					Usage:  "Initiate the commission rate change",
-					Flags:  []cli.Flag{cmd.CommissionRateFlag},
+					Flags:  []cli.Flag{cmd.CommissionRateFlag, cmd.UnsignedFlag},
 					Action: cmd.InitCommissionChange,
 				},
 				{
 					Name:   "finalize",
 					Usage:  "Finalize the commission rate change",
+					Flags:  []cli.Flag{cmd.UnsignedFlag},
 					Action: cmd.FinalizeCommissionChange,
 				},
 			},
@@ -70,34 +74,63 @@ func main() {
 		{
 			Name:   "depositKro",
 			Usage:  "Attempt to deposit asset tokens to AssetManager to be used as bond",
-			Flags:  []cli.Flag{cmd.TokenAmountFlag},
+			Flags:  []cli.Flag{cmd.TokenAmountFlag, cmd.UnsignedFlag},
 			Action: cmd.DepositKro,
 		},
+		{
+			Name:   "withdrawKro",
+			Usage:  "Withdraw the validator KRO from AssetManager with the withdraw account",
+			Flags:  []cli.Flag{cmd.ValidatorAddressFlag, cmd.TokenAmountFlag, cmd.UnsignedFlag},
+			Action: cmd.WithdrawKro,
+		},
+		{
+			Name:   "status",
+			Usage:  "Show the state of the validator in ValidatorManager, AssetManager and ValidatorPool",
+			Flags:  []cli.Flag{cmd.ValidatorAddressFlag},
+			Action: cmd.Status,
+		},
+		{
+			Name:   "info",
+			Usage:  "Show the parameters of the validator system",
+			Action: cmd.Info,
+		},
+		{
+			Name:  "verify-outputs",
+			Usage: "Verify the submitted outputs in L2OutputOracle with the rollup node",
+			Flags: []cli.Flag{
+				cmd.FromIndexFlag,
+				cmd.ToIndexFlag,
+				cmd.ChallengeFromBlockFlag,
+				cmd.ChallengeLogsPageSizeFlag,
+			},
+			Action: cmd.VerifyOutputs,
+		},
 		{
 			Name:        "deposit",
 			Usage:       "(DEPRECATED) Deposit ETH into ValidatorPool to be used as bond",
 			Description: "This command is deprecated since the release of validator system V2. Please use the 'register' command to register as a validator.",
-			Flags:       []cli.Flag{cmd.EthAmountFlag},
+			Flags:       []cli.Flag{cmd.EthAmountFlag, cmd.UnsignedFlag},
 			Action:      cmd.Deposit,
 		},
 		{
 			Name:        "withdraw",
 			Usage:       "(DEPRECATED) Withdraw ETH from ValidatorPool",
 			Description: "This command is deprecated since the release of validator system V2. You can still use this command to withdraw your asset from the ValidatorPool.",
-			Flags:       []cli.Flag{cmd.EthAmountFlag},
+			Flags:       []cli.Flag{cmd.EthAmountFlag, cmd.UnsignedFlag},
 			Action:      cmd.Withdraw,
 		},
 		{
 			Name:        "withdrawTo",
 			Usage:       "(DEPRECATED) Withdraw ETH from ValidatorPool to specific address",
 			Description: "This command is deprecated since the release of validator system V2. You can still use this command to withdraw your asset from the ValidatorPool to specific address.",
-			Flags:       []cli.Flag{cmd.AddressFlag, cmd.EthAmountFlag},
+			Flags:       []cli.Flag{cmd.AddressFlag, cmd.EthAmountFlag, cmd.UnsignedFlag},
 			Action:      cmd.WithdrawTo,
 		},
 		{
 			Name:        "unbond",
 			Usage:       "(DEPRECATED) Attempt to unbond in ValidatorPool",
 			Description: "This command is deprecated since the release of validator system V2. You can still use this command to unbond your asset from the ValidatorPool.",
+			Flags:       []cli.Flag{cmd.UnsignedFlag},
 			Action:      cmd.Unbond,
 		},
 	}
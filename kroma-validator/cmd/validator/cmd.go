@@ -2,15 +2,19 @@ package validator
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
+	"time"
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/optsutils"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/urfave/cli/v2"
@@ -27,7 +31,7 @@ func Register(ctx *cli.Context) error {
 		return fmt.Errorf("failed to parse amount: %s", amount)
 	}
 
-	txManager, err := newTxManager(ctx)
+	sender, err := newTxSender(ctx)
 	if err != nil {
 		return err
 	}
@@ -37,7 +41,7 @@ func Register(ctx *cli.Context) error {
 		return fmt.Errorf("failed to parse AssetManager address: %w", err)
 	}
 
-	if err = approve(ctx, assets, txManager, assetManagerAddr); err != nil {
+	if err = approve(ctx, assets, sender, assetManagerAddr); err != nil {
 		return fmt.Errorf("failed to approve assets: %w", err)
 	}
 
@@ -62,11 +66,11 @@ func Register(ctx *cli.Context) error {
 		return fmt.Errorf("failed to parse ValidatorManager address: %w", err)
 	}
 
-	if err = sendTransaction(txManager, valMgrAddr, txData, "0"); err != nil {
+	if err = sendTransaction(sender, valMgrAddr, txData, "0"); err != nil {
 		return err
 	}
 
-	return nil
+	return sender.flush(ctx)
 }
 
 func Activate(ctx *cli.Context) error {
@@ -85,16 +89,16 @@ func Activate(ctx *cli.Context) error {
 		return fmt.Errorf("failed to parse ValidatorManager address: %w", err)
 	}
 
-	txManager, err := newTxManager(ctx)
+	sender, err := newTxSender(ctx)
 	if err != nil {
 		return err
 	}
 
-	if err = sendTransaction(txManager, valMgrAddr, txData, "0"); err != nil {
+	if err = sendTransaction(sender, valMgrAddr, txData, "0"); err != nil {
 		return err
 	}
 
-	return nil
+	return sender.flush(ctx)
 }
 
 func Unjail(ctx *cli.Context) error {
@@ -103,7 +107,7 @@ func Unjail(ctx *cli.Context) error {
 		return fmt.Errorf("failed to get ValidatorManager ABI: %w", err)
 	}
 
-	txManager, err := newTxManager(ctx)
+	sender, err := newTxSender(ctx)
 	if err != nil {
 		return err
 	}
@@ -118,17 +122,17 @@ func Unjail(ctx *cli.Context) error {
 		return fmt.Errorf("failed to parse ValidatorManager address: %w", err)
 	}
 
-	if err = sendTransaction(txManager, valMgrAddr, txData, "0"); err != nil {
+	if err = sendTransaction(sender, valMgrAddr, txData, "0"); err != nil {
 		return err
 	}
 
-	return nil
+	return sender.flush(ctx)
 }
 
 func InitCommissionChange(ctx *cli.Context) error {
 	commissionRate := uint8(ctx.Uint64("commission-rate"))
 
-	txManager, err := newTxManager(ctx)
+	sender, err := newTxSender(ctx)
 	if err != nil {
 		return err
 	}
@@ -148,15 +152,15 @@ func InitCommissionChange(ctx *cli.Context) error {
 		return fmt.Errorf("failed to parse ValidatorManager address: %w", err)
 	}
 
-	if err = sendTransaction(txManager, valMgrAddr, txData, "0"); err != nil {
+	if err = sendTransaction(sender, valMgrAddr, txData, "0"); err != nil {
 		return err
 	}
 
-	return nil
+	return sender.flush(ctx)
 }
 
 func FinalizeCommissionChange(ctx *cli.Context) error {
-	txManager, err := newTxManager(ctx)
+	sender, err := newTxSender(ctx)
 	if err != nil {
 		return err
 	}
@@ -176,11 +180,11 @@ func FinalizeCommissionChange(ctx *cli.Context) error {
 		return fmt.Errorf("failed to parse ValidatorManager address: %w", err)
 	}
 
-	if err = sendTransaction(txManager, valMgrAddr, txData, "0"); err != nil {
+	if err = sendTransaction(sender, valMgrAddr, txData, "0"); err != nil {
 		return err
 	}
 
-	return nil
+	return sender.flush(ctx)
 }
 
 func DepositKro(ctx *cli.Context) error {
@@ -191,7 +195,7 @@ func DepositKro(ctx *cli.Context) error {
 		return fmt.Errorf("failed to parse deposit amount: %s", amount)
 	}
 
-	txManager, err := newTxManager(ctx)
+	sender, err := newTxSender(ctx)
 	if err != nil {
 		return err
 	}
@@ -201,7 +205,7 @@ func DepositKro(ctx *cli.Context) error {
 		return fmt.Errorf("failed to parse AssetManager address: %w", err)
 	}
 
-	if err = approve(ctx, depositAmount, txManager, assetManagerAddr); err != nil {
+	if err = approve(ctx, depositAmount, sender, assetManagerAddr); err != nil {
 		return fmt.Errorf("failed to approve assets: %w", err)
 	}
 
@@ -215,11 +219,53 @@ func DepositKro(ctx *cli.Context) error {
 		return fmt.Errorf("failed to create deposit transaction data: %w", err)
 	}
 
-	if err = sendTransaction(txManager, assetManagerAddr, txData, "0"); err != nil {
+	if err = sendTransaction(sender, assetManagerAddr, txData, "0"); err != nil {
 		return err
 	}
 
-	return nil
+	return sender.flush(ctx)
+}
+
+// WithdrawKro withdraws the validator KRO from AssetManager. It must be sent by the withdraw account of the validator,
+// so it is usually used with the unsigned flag when the withdraw account is a multisig.
+func WithdrawKro(ctx *cli.Context) error {
+	amount := ctx.String("amount")
+
+	withdrawAmount, success := new(big.Int).SetString(amount, 10)
+	if !success {
+		return fmt.Errorf("failed to parse withdraw amount: %s", amount)
+	}
+
+	validatorAddr, err := opservice.ParseAddress(ctx.String(ValidatorAddressFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to parse validator address: %w", err)
+	}
+
+	assetManagerAddr, err := opservice.ParseAddress(ctx.String(flags.AssetManagerAddressFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to parse AssetManager address: %w", err)
+	}
+
+	assetManagerAbi, err := bindings.AssetManagerMetaData.GetAbi()
+	if err != nil {
+		return fmt.Errorf("failed to get AssetManager ABI: %w", err)
+	}
+
+	txData, err := assetManagerAbi.Pack("withdraw", validatorAddr, withdrawAmount)
+	if err != nil {
+		return fmt.Errorf("failed to create withdraw transaction data: %w", err)
+	}
+
+	sender, err := newTxSender(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err = sendTransaction(sender, assetManagerAddr, txData, "0"); err != nil {
+		return err
+	}
+
+	return sender.flush(ctx)
 }
 
 func Deposit(ctx *cli.Context) error {
@@ -240,16 +286,16 @@ func Deposit(ctx *cli.Context) error {
 		return fmt.Errorf("failed to parse ValidatorPool address: %w", err)
 	}
 
-	txManager, err := newTxManager(ctx)
+	sender, err := newTxSender(ctx)
 	if err != nil {
 		return err
 	}
 
-	if err = sendTransaction(txManager, valpoolAddr, txData, amount); err != nil {
+	if err = sendTransaction(sender, valpoolAddr, txData, amount); err != nil {
 		return err
 	}
 
-	return nil
+	return sender.flush(ctx)
 }
 
 func Withdraw(ctx *cli.Context) error {
@@ -275,16 +321,16 @@ func Withdraw(ctx *cli.Context) error {
 		return fmt.Errorf("failed to parse ValidatorPool address: %w", err)
 	}
 
-	txManager, err := newTxManager(ctx)
+	sender, err := newTxSender(ctx)
 	if err != nil {
 		return err
 	}
 
-	if err = sendTransaction(txManager, valpoolAddr, txData, "0"); err != nil {
+	if err = sendTransaction(sender, valpoolAddr, txData, "0"); err != nil {
 		return err
 	}
 
-	return nil
+	return sender.flush(ctx)
 }
 
 func WithdrawTo(ctx *cli.Context) error {
@@ -312,16 +358,16 @@ func WithdrawTo(ctx *cli.Context) error {
 		return fmt.Errorf("failed to parse ValidatorPool address: %w", err)
 	}
 
-	txManager, err := newTxManager(ctx)
+	sender, err := newTxSender(ctx)
 	if err != nil {
 		return err
 	}
 
-	if err = sendTransaction(txManager, valpoolAddr, txData, "0"); err != nil {
+	if err = sendTransaction(sender, valpoolAddr, txData, "0"); err != nil {
 		return err
 	}
 
-	return nil
+	return sender.flush(ctx)
 }
 
 func Unbond(ctx *cli.Context) error {
@@ -340,19 +386,19 @@ func Unbond(ctx *cli.Context) error {
 		return fmt.Errorf("failed to parse ValidatorPool address: %w", err)
 	}
 
-	txManager, err := newTxManager(ctx)
+	sender, err := newTxSender(ctx)
 	if err != nil {
 		return err
 	}
 
-	if err = sendTransaction(txManager, valpoolAddr, txData, "0"); err != nil {
+	if err = sendTransaction(sender, valpoolAddr, txData, "0"); err != nil {
 		return err
 	}
 
-	return nil
+	return sender.flush(ctx)
 }
 
-func approve(ctx *cli.Context, amount *big.Int, txManager *txmgr.SimpleTxManager, assetManagerAddr common.Address) error {
+func approve(ctx *cli.Context, amount *big.Int, sender *txSender, assetManagerAddr common.Address) error {
 	erc20Abi, err := bindings.ERC20MetaData.GetAbi()
 	if err != nil {
 		return fmt.Errorf("failed to get ERC20 ABI: %w", err)
@@ -363,7 +409,7 @@ func approve(ctx *cli.Context, amount *big.Int, txManager *txmgr.SimpleTxManager
 		return fmt.Errorf("failed to create approve transaction data: %w", err)
 	}
 
-	assetManagerContract, err := bindings.NewAssetManagerCaller(assetManagerAddr, txManager.Backend.(*ethclient.Client))
+	assetManagerContract, err := bindings.NewAssetManagerCaller(assetManagerAddr, sender.l1Client)
 	if err != nil {
 		return fmt.Errorf("failed to fetch AssetManager contract: %w", err)
 	}
@@ -373,19 +419,28 @@ func approve(ctx *cli.Context, amount *big.Int, txManager *txmgr.SimpleTxManager
 		return fmt.Errorf("failed to fetch asset token address: %w", err)
 	}
 
-	if err = sendTransaction(txManager, assetTokenAddr, txData, "0"); err != nil {
+	if err = sendTransaction(sender, assetTokenAddr, txData, "0"); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func sendTransaction(txManager *txmgr.SimpleTxManager, txTo common.Address, txData []byte, txValue string) error {
+func sendTransaction(sender *txSender, txTo common.Address, txData []byte, txValue string) error {
 	value, success := new(big.Int).SetString(txValue, 10)
 	if !success {
 		return errors.New("failed to parse tx value")
 	}
 
+	if sender.unsigned {
+		sender.txs = append(sender.txs, &unsignedTx{
+			To:    txTo,
+			Value: value.String(),
+			Data:  hexutil.Bytes(txData),
+		})
+		return nil
+	}
+
 	txCandidate := txmgr.TxCandidate{
 		TxData:   txData,
 		To:       &txTo,
@@ -393,7 +448,7 @@ func sendTransaction(txManager *txmgr.SimpleTxManager, txTo common.Address, txDa
 		Value:    value,
 	}
 
-	_, err := txManager.Send(context.Background(), txCandidate)
+	_, err := sender.txManager.Send(context.Background(), txCandidate)
 	if err != nil {
 		return fmt.Errorf("failed to send transaction: %w", err)
 	}
@@ -401,6 +456,89 @@ func sendTransaction(txManager *txmgr.SimpleTxManager, txTo common.Address, txDa
 	return nil
 }
 
+// txSender sends the transactions of a command with the tx manager, or collects them without signing in unsigned
+// mode, so that they can be executed by another account such as a multisig.
+type txSender struct {
+	txManager *txmgr.SimpleTxManager
+	l1Client  *ethclient.Client
+
+	unsigned bool
+	txs      []*unsignedTx
+}
+
+// unsignedTx is a transaction of the Safe Transaction Builder batch.
+type unsignedTx struct {
+	To    common.Address `json:"to"`
+	Value string         `json:"value"`
+	Data  hexutil.Bytes  `json:"data"`
+}
+
+// unsignedTxBatch is the batch of transactions in the format of the Safe Transaction Builder.
+type unsignedTxBatch struct {
+	Version      string         `json:"version"`
+	ChainID      string         `json:"chainId"`
+	CreatedAt    int64          `json:"createdAt"`
+	Meta         unsignedTxMeta `json:"meta"`
+	Transactions []*unsignedTx  `json:"transactions"`
+}
+
+type unsignedTxMeta struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func newTxSender(ctx *cli.Context) (*txSender, error) {
+	if !ctx.Bool(UnsignedFlag.Name) {
+		txManager, err := newTxManager(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &txSender{
+			txManager: txManager,
+			l1Client:  txManager.Backend.(*ethclient.Client),
+		}, nil
+	}
+
+	l1Client, err := dial.DialEthClientWithTimeout(ctx.Context, dial.DefaultDialTimeout, log.New(), ctx.String(flags.L1EthRpcFlag.Name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial L1 RPC: %w", err)
+	}
+	return &txSender{
+		l1Client: l1Client,
+		unsigned: true,
+	}, nil
+}
+
+// flush prints the collected transactions in unsigned mode. It does nothing if the transactions have been sent.
+func (s *txSender) flush(ctx *cli.Context) error {
+	if !s.unsigned {
+		return nil
+	}
+	defer s.l1Client.Close()
+
+	chainID, err := s.l1Client.ChainID(ctx.Context)
+	if err != nil {
+		return fmt.Errorf("failed to get L1 chain ID: %w", err)
+	}
+	batch := &unsignedTxBatch{
+		Version:   "1.0",
+		ChainID:   chainID.String(),
+		CreatedAt: time.Now().UnixMilli(),
+		Meta: unsignedTxMeta{
+			Name:        ctx.Command.FullName(),
+			Description: ctx.Command.Usage,
+		},
+		Transactions: s.txs,
+	}
+
+	out, err := json.MarshalIndent(batch, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal unsigned transactions: %w", err)
+	}
+	_, err = fmt.Fprintln(ctx.App.Writer, string(out))
+	return err
+}
+
 func newTxManager(ctx *cli.Context) (*txmgr.SimpleTxManager, error) {
 	txMgrConfig := txmgr.ReadCLIConfig(ctx)
 	txManager, err := txmgr.NewSimpleTxManager("validator-cmd", log.New(), &metrics.NoopTxMetrics{}, txMgrConfig)
@@ -33,3 +33,36 @@ var WithdrawAccountFlag = &cli.StringFlag{
 	Usage:    "Address to withdraw deposited asset token",
 	Required: true,
 }
+
+var FromIndexFlag = &cli.Uint64Flag{
+	Name:     "from-index",
+	Usage:    "The first output index to verify",
+	Required: true,
+}
+
+var ToIndexFlag = &cli.Uint64Flag{
+	Name:  "to-index",
+	Usage: "The last output index to verify. Defaults to the latest output index",
+}
+
+var ChallengeFromBlockFlag = &cli.Uint64Flag{
+	Name:  "challenge-from-block",
+	Usage: "The L1 block number to start searching the challenges of the outputs from. Defaults to the L1 block in which the first output was submitted",
+}
+
+var ChallengeLogsPageSizeFlag = &cli.Uint64Flag{
+	Name:  "challenge-logs-page-size",
+	Usage: "The number of L1 blocks to search the challenges in per query",
+	Value: 5000,
+}
+
+var ValidatorAddressFlag = &cli.StringFlag{
+	Name:     "validator",
+	Usage:    "Address of the validator",
+	Required: true,
+}
+
+var UnsignedFlag = &cli.BoolFlag{
+	Name:  "unsigned",
+	Usage: "Print the unsigned transactions as a Safe Transaction Builder batch instead of sending them, to be executed by another account such as a multisig",
+}
@@ -0,0 +1,290 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"text/tabwriter"
+	"time"
+
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/dial"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/optsutils"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+
+	"github.com/kroma-network/kroma/kroma-bindings/bindings"
+	"github.com/kroma-network/kroma/kroma-validator"
+	"github.com/kroma-network/kroma/kroma-validator/flags"
+)
+
+// validatorStatusNames are the names of the validator statuses in ValidatorManager.
+var validatorStatusNames = map[uint8]string{
+	validator.StatusNone:       "None",
+	validator.StatusExited:     "Exited",
+	validator.StatusRegistered: "Registered",
+	validator.StatusReady:      "Ready",
+	validator.StatusInactive:   "Inactive",
+	validator.StatusActive:     "Active",
+}
+
+// validatorContracts are the callers of the validator system contracts.
+type validatorContracts struct {
+	l1Client     *ethclient.Client
+	valMgr       *bindings.ValidatorManagerCaller
+	assetManager *bindings.AssetManagerCaller
+	valPool      *bindings.ValidatorPoolCaller
+	l2oo         *batching.BoundContract
+	caller       *batching.MultiCaller
+}
+
+func newValidatorContracts(ctx *cli.Context) (*validatorContracts, error) {
+	valMgrAddr, err := opservice.ParseAddress(ctx.String(flags.ValMgrAddressFlag.Name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ValidatorManager address: %w", err)
+	}
+	assetManagerAddr, err := opservice.ParseAddress(ctx.String(flags.AssetManagerAddressFlag.Name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse AssetManager address: %w", err)
+	}
+	valPoolAddr, err := opservice.ParseAddress(ctx.String(flags.ValPoolAddressFlag.Name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ValidatorPool address: %w", err)
+	}
+	l2ooAddr, err := opservice.ParseAddress(ctx.String(flags.L2OOAddressFlag.Name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse L2OutputOracle address: %w", err)
+	}
+
+	l := oplog.NewLogger(oplog.AppOut(ctx), oplog.ReadCLIConfig(ctx))
+	l1Client, err := dial.DialEthClientWithTimeout(ctx.Context, dial.DefaultDialTimeout, l, ctx.String(flags.L1EthRpcFlag.Name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial L1 RPC: %w", err)
+	}
+	valMgr, err := bindings.NewValidatorManagerCaller(valMgrAddr, l1Client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch ValidatorManager contract: %w", err)
+	}
+	assetManager, err := bindings.NewAssetManagerCaller(assetManagerAddr, l1Client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch AssetManager contract: %w", err)
+	}
+	valPool, err := bindings.NewValidatorPoolCaller(valPoolAddr, l1Client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch ValidatorPool contract: %w", err)
+	}
+	l2ooABI, err := bindings.L2OutputOracleMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get L2OutputOracle ABI: %w", err)
+	}
+
+	return &validatorContracts{
+		l1Client:     l1Client,
+		valMgr:       valMgr,
+		assetManager: assetManager,
+		valPool:      valPool,
+		l2oo:         batching.NewBoundContract(l2ooABI, l2ooAddr),
+		caller:       batching.NewMultiCaller(l1Client.Client(), batching.DefaultBatchSize),
+	}, nil
+}
+
+// queryField is a row of the query result, fetched from the contracts.
+type queryField struct {
+	name  string
+	fetch func(opts *bind.CallOpts) (any, error)
+}
+
+// printFields fetches the fields and prints them in a table.
+func printFields(ctx *cli.Context, fields []queryField) error {
+	opts := optsutils.NewSimpleCallOpts(ctx.Context)
+	w := tabwriter.NewWriter(ctx.App.Writer, 0, 0, 2, ' ', 0)
+	for _, f := range fields {
+		value, err := f.fetch(opts)
+		if err != nil {
+			return fmt.Errorf("failed to get %s: %w", f.name, err)
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%v\n", f.name, value)
+	}
+	return w.Flush()
+}
+
+// Status prints the state of the validator in ValidatorManager, AssetManager and ValidatorPool.
+func Status(ctx *cli.Context) error {
+	addr, err := opservice.ParseAddress(ctx.String(ValidatorAddressFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to parse validator address: %w", err)
+	}
+
+	c, err := newValidatorContracts(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.l1Client.Close()
+
+	err = printFields(ctx, []queryField{
+		{"Address", func(_ *bind.CallOpts) (any, error) { return addr, nil }},
+		// ValidatorManager
+		{"Status", func(opts *bind.CallOpts) (any, error) {
+			status, err := c.valMgr.GetStatus(opts, addr)
+			if err != nil {
+				return nil, err
+			}
+			return validatorStatusNames[status], nil
+		}},
+		{"Weight", func(opts *bind.CallOpts) (any, error) { return c.valMgr.GetWeight(opts, addr) }},
+		{"Commission Rate (%)", func(opts *bind.CallOpts) (any, error) { return c.valMgr.GetCommissionRate(opts, addr) }},
+		{"Pending Commission Rate (%)", func(opts *bind.CallOpts) (any, error) { return c.valMgr.GetPendingCommissionRate(opts, addr) }},
+		{"Commission Change Finalizable At", func(opts *bind.CallOpts) (any, error) {
+			return formatTimestamp(c.valMgr.CanFinalizeCommissionChangeAt(opts, addr))
+		}},
+		{"In Jail", func(opts *bind.CallOpts) (any, error) { return c.valMgr.InJail(opts, addr) }},
+		{"Jail Expires At", func(opts *bind.CallOpts) (any, error) {
+			return formatTimestamp(c.valMgr.JailExpiresAt(opts, addr))
+		}},
+		{"No Submission Count", func(opts *bind.CallOpts) (any, error) { return c.valMgr.NoSubmissionCount(opts, addr) }},
+		// AssetManager
+		{"Withdraw Account", func(opts *bind.CallOpts) (any, error) { return c.assetManager.GetWithdrawAccount(opts, addr) }},
+		{"Validator KRO (incl. rewards)", func(opts *bind.CallOpts) (any, error) { return c.assetManager.TotalValidatorKro(opts, addr) }},
+		{"Validator KRO Bonded", func(opts *bind.CallOpts) (any, error) { return c.assetManager.TotalValidatorKroBonded(opts, addr) }},
+		{"Validator KRO Not Bonded", func(opts *bind.CallOpts) (any, error) {
+			return c.assetManager.TotalValidatorKroNotBonded(opts, addr)
+		}},
+		{"Withdrawable At", func(opts *bind.CallOpts) (any, error) {
+			return formatTimestamp(c.assetManager.CanWithdrawAt(opts, addr))
+		}},
+		{"Delegated KRO", func(opts *bind.CallOpts) (any, error) { return c.assetManager.TotalKroAssets(opts, addr) }},
+		{"Delegated KGH", func(opts *bind.CallOpts) (any, error) { return c.assetManager.TotalKghNum(opts, addr) }},
+		{"Reflective Weight", func(opts *bind.CallOpts) (any, error) { return c.assetManager.ReflectiveWeight(opts, addr) }},
+		// ValidatorPool (deprecated)
+		{"ValidatorPool Balance (ETH)", func(opts *bind.CallOpts) (any, error) { return c.valPool.BalanceOf(opts, addr) }},
+	})
+	if err != nil {
+		return err
+	}
+
+	return printUnbondQueue(ctx, c, addr)
+}
+
+// pendingBond is a bond of the validator, which is unbonded when the output is finalized.
+type pendingBond struct {
+	outputIndex *big.Int
+	unbondAt    *big.Int
+}
+
+// fetchUnbondQueue returns the bonds of the outputs submitted by the validator that are not finalized yet, in the order
+// of being unbonded. The outputs are queried from L2OutputOracle in batches.
+func fetchUnbondQueue(
+	ctx context.Context, caller *batching.MultiCaller, l2oo *batching.BoundContract, validator common.Address,
+) ([]pendingBond, error) {
+	results, err := caller.Call(ctx, rpcblock.Latest, l2oo.Call("nextFinalizeOutputIndex"), l2oo.Call("latestOutputIndex"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get range of outputs not finalized: %w", err)
+	}
+	nextFinalizeIndex, latestIndex := results[0].GetBigInt(0), results[1].GetBigInt(0)
+
+	var submitterCalls []batching.Call
+	for i := new(big.Int).Set(nextFinalizeIndex); i.Cmp(latestIndex) <= 0; i = new(big.Int).Add(i, common.Big1) {
+		submitterCalls = append(submitterCalls, l2oo.Call("getSubmitter", i))
+	}
+	if len(submitterCalls) == 0 {
+		return nil, nil
+	}
+	submitters, err := caller.Call(ctx, rpcblock.Latest, submitterCalls...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get submitters of outputs: %w", err)
+	}
+
+	var bonds []pendingBond
+	var finalizedAtCalls []batching.Call
+	for i, submitter := range submitters {
+		if submitter.GetAddress(0) != validator {
+			continue
+		}
+		outputIndex := new(big.Int).Add(nextFinalizeIndex, big.NewInt(int64(i)))
+		bonds = append(bonds, pendingBond{outputIndex: outputIndex})
+		finalizedAtCalls = append(finalizedAtCalls, l2oo.Call("finalizedAt", outputIndex))
+	}
+	if len(bonds) == 0 {
+		return nil, nil
+	}
+	finalizedAts, err := caller.Call(ctx, rpcblock.Latest, finalizedAtCalls...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get finalization time of outputs: %w", err)
+	}
+	for i, finalizedAt := range finalizedAts {
+		bonds[i].unbondAt = finalizedAt.GetBigInt(0)
+	}
+	return bonds, nil
+}
+
+// printUnbondQueue prints the bonds of the validator waiting for the outputs to be finalized.
+func printUnbondQueue(ctx *cli.Context, c *validatorContracts, addr common.Address) error {
+	bonds, err := fetchUnbondQueue(ctx.Context, c.caller, c.l2oo, addr)
+	if err != nil {
+		return fmt.Errorf("failed to get unbond queue: %w", err)
+	}
+	bondAmount, err := c.assetManager.BONDAMOUNT(optsutils.NewSimpleCallOpts(ctx.Context))
+	if err != nil {
+		return fmt.Errorf("failed to get bond amount: %w", err)
+	}
+
+	w := tabwriter.NewWriter(ctx.App.Writer, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "\nUnbond Queue\t%d bonds of %s asset tokens (in wei)\n", len(bonds), bondAmount)
+	if len(bonds) > 0 {
+		_, _ = fmt.Fprintf(w, "Output Index\tUnbond At\n")
+	}
+	for _, bond := range bonds {
+		unbondAt, _ := formatTimestamp(bond.unbondAt, nil)
+		_, _ = fmt.Fprintf(w, "%s\t%v\n", bond.outputIndex, unbondAt)
+	}
+	return w.Flush()
+}
+
+// Info prints the parameters of the validator system.
+func Info(ctx *cli.Context) error {
+	c, err := newValidatorContracts(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.l1Client.Close()
+
+	return printFields(ctx, []queryField{
+		// ValidatorManager
+		{"Activated Validator Count", func(opts *bind.CallOpts) (any, error) { return c.valMgr.ActivatedValidatorCount(opts) }},
+		{"Activated Validator Total Weight", func(opts *bind.CallOpts) (any, error) {
+			return c.valMgr.ActivatedValidatorTotalWeight(opts)
+		}},
+		{"Next Validator", func(opts *bind.CallOpts) (any, error) { return c.valMgr.NextValidator(opts) }},
+		{"Trusted Validator", func(opts *bind.CallOpts) (any, error) { return c.valMgr.TRUSTEDVALIDATOR(opts) }},
+		{"Min Register Amount", func(opts *bind.CallOpts) (any, error) { return c.valMgr.MINREGISTERAMOUNT(opts) }},
+		{"Min Activate Amount", func(opts *bind.CallOpts) (any, error) { return c.valMgr.MINACTIVATEAMOUNT(opts) }},
+		{"Base Reward", func(opts *bind.CallOpts) (any, error) { return c.valMgr.BASEREWARD(opts) }},
+		{"Commission Change Delay (s)", func(opts *bind.CallOpts) (any, error) { return c.valMgr.COMMISSIONCHANGEDELAYSECONDS(opts) }},
+		{"Round Duration (s)", func(opts *bind.CallOpts) (any, error) { return c.valMgr.ROUNDDURATIONSECONDS(opts) }},
+		{"Jail Threshold", func(opts *bind.CallOpts) (any, error) { return c.valMgr.JAILTHRESHOLD(opts) }},
+		{"Soft Jail Period (s)", func(opts *bind.CallOpts) (any, error) { return c.valMgr.SOFTJAILPERIODSECONDS(opts) }},
+		{"Hard Jail Period (s)", func(opts *bind.CallOpts) (any, error) { return c.valMgr.HARDJAILPERIODSECONDS(opts) }},
+		// AssetManager
+		{"Asset Token", func(opts *bind.CallOpts) (any, error) { return c.assetManager.ASSETTOKEN(opts) }},
+		{"Bond Amount", func(opts *bind.CallOpts) (any, error) { return c.assetManager.BONDAMOUNT(opts) }},
+		{"Min Delegation Period (s)", func(opts *bind.CallOpts) (any, error) { return c.assetManager.MINDELEGATIONPERIOD(opts) }},
+		// ValidatorPool (deprecated)
+		{"ValidatorPool Terminate Output Index", func(opts *bind.CallOpts) (any, error) { return c.valPool.TERMINATEOUTPUTINDEX(opts) }},
+	})
+}
+
+// formatTimestamp formats the unix timestamp returned by a contract call, or "-" if it is not set.
+func formatTimestamp(timestamp *big.Int, err error) (any, error) {
+	if err != nil {
+		return nil, err
+	}
+	if timestamp.Sign() == 0 {
+		return "-", nil
+	}
+	return fmt.Sprintf("%s (%s)", timestamp, time.Unix(timestamp.Int64(), 0).UTC().Format(time.RFC3339)), nil
+}
@@ -0,0 +1,64 @@
+package validator
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	batchingTest "github.com/ethereum-optimism/optimism/op-service/sources/batching/test"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/kroma-bindings/bindings"
+)
+
+// countingRpc counts the batch calls sent to the stub.
+type countingRpc struct {
+	*batchingTest.AbiBasedRpc
+	batches int
+}
+
+func (r *countingRpc) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	r.batches++
+	return r.AbiBasedRpc.BatchCallContext(ctx, b)
+}
+
+// newTestOutputOracle holds the submitters of the outputs, and finalizes each output 100 seconds after its index.
+func newTestOutputOracle(t *testing.T, nextFinalizeIndex int64, submitters []common.Address) (*countingRpc, *batching.BoundContract) {
+	l2ooAddr := common.Address{0xaa}
+	l2ooABI, err := bindings.L2OutputOracleMetaData.GetAbi()
+	require.NoError(t, err)
+	stub := &countingRpc{AbiBasedRpc: batchingTest.NewAbiBasedRpc(t, l2ooAddr, l2ooABI)}
+	stub.SetResponse(l2ooAddr, "nextFinalizeOutputIndex", rpcblock.Latest, nil, []any{big.NewInt(nextFinalizeIndex)})
+	stub.SetResponse(l2ooAddr, "latestOutputIndex", rpcblock.Latest, nil, []any{big.NewInt(int64(len(submitters) - 1))})
+	for i, submitter := range submitters {
+		stub.SetResponse(l2ooAddr, "getSubmitter", rpcblock.Latest, []any{big.NewInt(int64(i))}, []any{submitter})
+		stub.SetResponse(l2ooAddr, "finalizedAt", rpcblock.Latest, []any{big.NewInt(int64(i))}, []any{big.NewInt(int64(i + 100))})
+	}
+	return stub, batching.NewBoundContract(l2ooABI, l2ooAddr)
+}
+
+func TestFetchUnbondQueue(t *testing.T) {
+	ctx := context.Background()
+	validator, other := common.Address{0x01}, common.Address{0x02}
+	submitters := []common.Address{validator, validator, other, validator, other, validator}
+
+	stub, l2oo := newTestOutputOracle(t, 2, submitters)
+	bonds, err := fetchUnbondQueue(ctx, batching.NewMultiCaller(stub, batching.DefaultBatchSize), l2oo, validator)
+	require.NoError(t, err)
+	require.Equal(t, []pendingBond{
+		{outputIndex: big.NewInt(3), unbondAt: big.NewInt(103)},
+		{outputIndex: big.NewInt(5), unbondAt: big.NewInt(105)},
+	}, bonds)
+	// the outputs are queried in a batch per kind of query, regardless of the number of outputs
+	require.Equal(t, 3, stub.batches)
+
+	// all outputs are finalized
+	stub, l2oo = newTestOutputOracle(t, 6, submitters)
+	bonds, err = fetchUnbondQueue(ctx, batching.NewMultiCaller(stub, batching.DefaultBatchSize), l2oo, validator)
+	require.NoError(t, err)
+	require.Empty(t, bonds)
+}
@@ -0,0 +1,288 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/dial"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/optsutils"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/urfave/cli/v2"
+
+	"github.com/kroma-network/kroma/kroma-bindings/bindings"
+	"github.com/kroma-network/kroma/kroma-validator"
+	chal "github.com/kroma-network/kroma/kroma-validator/challenge"
+	"github.com/kroma-network/kroma/kroma-validator/flags"
+)
+
+const (
+	outputMatched      = "match"
+	outputMismatched   = "MISMATCH"
+	outputDeleted      = "deleted"
+	outputInconsistent = "INCONSISTENT"
+)
+
+// outputVerification is the result of the verification of a submitted output.
+type outputVerification struct {
+	outputIndex   *big.Int
+	l2BlockNumber uint64
+	result        string
+	remoteRoot    common.Hash
+	localRoot     common.Hash
+	challenges    []string
+}
+
+// VerifyOutputs recomputes the outputs submitted to L2OutputOracle within the given index range with the rollup node,
+// and prints whether they match. It returns an error if any mismatched output is found, or if the rollup node gives
+// any output root inconsistent with its elements.
+func VerifyOutputs(ctx *cli.Context) error {
+	l2OOAddr, err := opservice.ParseAddress(ctx.String(flags.L2OOAddressFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to parse L2OutputOracle address: %w", err)
+	}
+	colosseumAddr, err := opservice.ParseAddress(ctx.String(flags.ColosseumAddressFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to parse Colosseum address: %w", err)
+	}
+
+	l := oplog.NewLogger(oplog.AppOut(ctx), oplog.ReadCLIConfig(ctx))
+	l1Client, err := dial.DialEthClientWithTimeout(ctx.Context, dial.DefaultDialTimeout, l, ctx.String(flags.L1EthRpcFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to dial L1 RPC: %w", err)
+	}
+	defer l1Client.Close()
+	rollupClient, err := dial.DialRollupClientWithTimeout(ctx.Context, dial.DefaultDialTimeout, l, ctx.String(flags.RollupRpcFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to dial rollup node RPC: %w", err)
+	}
+	defer rollupClient.Close()
+
+	l2OOContract, err := bindings.NewL2OutputOracle(l2OOAddr, l1Client)
+	if err != nil {
+		return fmt.Errorf("failed to fetch L2OutputOracle contract: %w", err)
+	}
+	colosseumContract, err := bindings.NewColosseum(colosseumAddr, l1Client)
+	if err != nil {
+		return fmt.Errorf("failed to fetch Colosseum contract: %w", err)
+	}
+
+	fromIndex := new(big.Int).SetUint64(ctx.Uint64(FromIndexFlag.Name))
+	var toIndex *big.Int
+	if ctx.IsSet(ToIndexFlag.Name) {
+		toIndex = new(big.Int).SetUint64(ctx.Uint64(ToIndexFlag.Name))
+	} else {
+		toIndex, err = l2OOContract.LatestOutputIndex(optsutils.NewSimpleCallOpts(ctx.Context))
+		if err != nil {
+			return fmt.Errorf("failed to get latest output index: %w", err)
+		}
+	}
+	if fromIndex.Cmp(toIndex) > 0 {
+		return fmt.Errorf("from index %s is greater than to index %s", fromIndex, toIndex)
+	}
+
+	toBlock, err := l1Client.BlockNumber(ctx.Context)
+	if err != nil {
+		return fmt.Errorf("failed to get latest L1 block number: %w", err)
+	}
+	var fromBlock uint64
+	if ctx.IsSet(ChallengeFromBlockFlag.Name) {
+		fromBlock = ctx.Uint64(ChallengeFromBlockFlag.Name)
+	} else {
+		// a challenge can be created only after the output is submitted
+		firstOutput, err := l2OOContract.GetL2Output(optsutils.NewSimpleCallOpts(ctx.Context), fromIndex)
+		if err != nil {
+			return fmt.Errorf("failed to get output at index %s: %w", fromIndex, err)
+		}
+		fromBlock, err = findL1BlockAt(ctx.Context, l1Client, toBlock, firstOutput.Timestamp.Uint64())
+		if err != nil {
+			return err
+		}
+	}
+
+	challenges, err := fetchChallenges(ctx.Context, colosseumContract, fromIndex, toIndex, fromBlock, toBlock, ctx.Uint64(ChallengeLogsPageSizeFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	var results []*outputVerification
+	mismatched, inconsistent := 0, 0
+	for idx := new(big.Int).Set(fromIndex); idx.Cmp(toIndex) <= 0; idx = new(big.Int).Add(idx, common.Big1) {
+		result, err := verifyOutput(ctx, l2OOContract, rollupClient, idx)
+		if err != nil {
+			return err
+		}
+		result.challenges = challenges[idx.Uint64()]
+		switch result.result {
+		case outputMismatched:
+			mismatched++
+		case outputInconsistent:
+			l.Warn("output root given by rollup node is inconsistent with its elements",
+				"outputIndex", idx, "l2BlockNumber", result.l2BlockNumber)
+			inconsistent++
+		}
+		results = append(results, result)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "INDEX\tL2 BLOCK\tRESULT\tSUBMITTED ROOT\tLOCAL ROOT\tCHALLENGES")
+	for _, r := range results {
+		challengesStr := "-"
+		if len(r.challenges) > 0 {
+			challengesStr = strings.Join(r.challenges, ", ")
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\n", r.outputIndex, r.l2BlockNumber, r.result, r.remoteRoot, r.localRoot, challengesStr)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if mismatched > 0 || inconsistent > 0 {
+		return fmt.Errorf("found %d mismatched outputs and %d outputs inconsistent in rollup node", mismatched, inconsistent)
+	}
+	return nil
+}
+
+// verifyOutput compares the submitted output at the given index with the output recomputed by the rollup node.
+func verifyOutput(
+	ctx *cli.Context, l2OOContract *bindings.L2OutputOracle, rollupClient *sources.RollupClient, outputIndex *big.Int,
+) (*outputVerification, error) {
+	remoteOutput, err := l2OOContract.GetL2Output(optsutils.NewSimpleCallOpts(ctx.Context), outputIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get output at index %s: %w", outputIndex, err)
+	}
+	localOutput, err := rollupClient.OutputAtBlock(ctx.Context, remoteOutput.L2BlockNumber.Uint64())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get local output at block %d: %w", remoteOutput.L2BlockNumber, err)
+	}
+	return compareOutputs(outputIndex, &validator.Outputs{RemoteOutput: &remoteOutput, LocalOutput: localOutput})
+}
+
+// compareOutputs validates the submitted output as the challenger does, after checking that the output root given by
+// the rollup node is consistent with its elements.
+func compareOutputs(outputIndex *big.Int, outputs *validator.Outputs) (*outputVerification, error) {
+	result := &outputVerification{
+		outputIndex:   new(big.Int).Set(outputIndex),
+		l2BlockNumber: outputs.RemoteOutput.L2BlockNumber.Uint64(),
+		remoteRoot:    outputs.RemoteOutput.OutputRoot,
+		localRoot:     common.Hash(outputs.LocalOutput.OutputRoot),
+	}
+
+	consistent, err := isOutputRootConsistent(outputs.LocalOutput)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recompute output root at block %d: %w", result.l2BlockNumber, err)
+	}
+
+	switch {
+	case !consistent:
+		result.result = outputInconsistent
+	case validator.IsOutputDeleted(outputs.RemoteOutput.OutputRoot):
+		result.result = outputDeleted
+	case outputs.IsValid():
+		result.result = outputMatched
+	default:
+		result.result = outputMismatched
+	}
+	return result, nil
+}
+
+// isOutputRootConsistent recomputes the output root from the elements of the output given by the rollup node,
+// to ensure that the output root of the node is consistent with its elements.
+func isOutputRootConsistent(output *eth.OutputResponse) (bool, error) {
+	outputRoot, err := rollup.ComputeKromaL2OutputRoot(&bindings.TypesOutputRootProof{
+		Version:                  output.Version,
+		StateRoot:                output.StateRoot,
+		MessagePasserStorageRoot: output.WithdrawalStorageRoot,
+		LatestBlockhash:          output.BlockRef.Hash,
+		NextBlockHash:            output.NextBlockRef.Hash,
+	})
+	if err != nil {
+		return false, err
+	}
+	return outputRoot == output.OutputRoot, nil
+}
+
+// L1Headers fetches the L1 block headers.
+type L1Headers interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// findL1BlockAt returns the number of the first L1 block up to latest whose timestamp is not before the given one.
+func findL1BlockAt(ctx context.Context, l1 L1Headers, latest uint64, timestamp uint64) (uint64, error) {
+	lo, hi := uint64(0), latest
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		header, err := l1.HeaderByNumber(ctx, new(big.Int).SetUint64(mid))
+		if err != nil {
+			return 0, fmt.Errorf("failed to get L1 block header %d: %w", mid, err)
+		}
+		if header.Time < timestamp {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo, nil
+}
+
+// fetchChallenges returns the challenges created for the outputs within the given index range with their statuses,
+// keyed by the output index. The events are searched within the L1 block range [fromBlock, toBlock] in pages of
+// pageSize blocks, and filtered by the output index locally to keep the queries within the limits of the providers.
+func fetchChallenges(
+	ctx context.Context, colosseumContract *bindings.Colosseum, fromIndex, toIndex *big.Int, fromBlock, toBlock, pageSize uint64,
+) (map[uint64][]string, error) {
+	if pageSize == 0 {
+		return nil, errors.New("page size of challenge logs must not be zero")
+	}
+
+	challenges := make(map[uint64][]string)
+	seen := make(map[string]bool)
+	for start := fromBlock; start <= toBlock; start += pageSize {
+		end := min(start+pageSize-1, toBlock)
+		iter, err := colosseumContract.FilterChallengeCreated(&bind.FilterOpts{
+			Start:   start,
+			End:     &end,
+			Context: ctx,
+		}, nil, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to filter challenge created events in blocks %d-%d: %w", start, end, err)
+		}
+
+		for iter.Next() {
+			ev := iter.Event
+			if ev.OutputIndex.Cmp(fromIndex) < 0 || ev.OutputIndex.Cmp(toIndex) > 0 {
+				continue
+			}
+			// a challenger may create a challenge again for the same output after the previous one is over
+			key := ev.OutputIndex.String() + ev.Challenger.Hex()
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			status, err := colosseumContract.GetStatus(optsutils.NewSimpleCallOpts(ctx), ev.OutputIndex, ev.Challenger)
+			if err != nil {
+				iter.Close()
+				return nil, fmt.Errorf("failed to get status of challenge(outputIndex: %s, challenger: %s): %w", ev.OutputIndex, ev.Challenger, err)
+			}
+			challenges[ev.OutputIndex.Uint64()] = append(challenges[ev.OutputIndex.Uint64()],
+				fmt.Sprintf("%s(%s)", ev.Challenger, chal.StatusString(status)))
+		}
+		err = iter.Error()
+		iter.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate challenge created events in blocks %d-%d: %w", start, end, err)
+		}
+	}
+	return challenges, nil
+}
@@ -0,0 +1,165 @@
+package validator
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/kroma-bindings/bindings"
+	"github.com/kroma-network/kroma/kroma-validator"
+	chal "github.com/kroma-network/kroma/kroma-validator/challenge"
+)
+
+type testL1Headers []uint64
+
+func (h testL1Headers) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	if number.Uint64() >= uint64(len(h)) {
+		return nil, ethereum.NotFound
+	}
+	return &types.Header{Number: number, Time: h[number.Uint64()]}, nil
+}
+
+func TestFindL1BlockAt(t *testing.T) {
+	ctx := context.Background()
+	// blocks are not produced at some slots
+	headers := testL1Headers{0, 12, 24, 48, 60, 96, 108}
+	for _, test := range []struct {
+		timestamp uint64
+		block     uint64
+	}{
+		{0, 0},
+		{12, 1},
+		{30, 3},
+		{48, 3},
+		{100, 6},
+		{200, 6},
+	} {
+		block, err := findL1BlockAt(ctx, headers, uint64(len(headers)-1), test.timestamp)
+		require.NoError(t, err)
+		require.Equal(t, test.block, block, "timestamp %d", test.timestamp)
+	}
+}
+
+// testColosseumRPC serves the ChallengeCreated logs and the statuses of the challenges.
+type testColosseumRPC struct {
+	logs   []types.Log
+	ranges [][2]uint64
+}
+
+func (r *testColosseumRPC) GetLogs(query map[string]any) ([]types.Log, error) {
+	from, err := hexutil.DecodeUint64(query["fromBlock"].(string))
+	if err != nil {
+		return nil, err
+	}
+	to, err := hexutil.DecodeUint64(query["toBlock"].(string))
+	if err != nil {
+		return nil, err
+	}
+	r.ranges = append(r.ranges, [2]uint64{from, to})
+	var logs []types.Log
+	for _, l := range r.logs {
+		if l.BlockNumber >= from && l.BlockNumber <= to {
+			logs = append(logs, l)
+		}
+	}
+	return logs, nil
+}
+
+func (r *testColosseumRPC) Call(_ map[string]any, _ string) hexutil.Bytes {
+	status := make([]byte, 32)
+	status[31] = chal.StatusChallengerTurn
+	return status
+}
+
+func challengeCreatedLog(t *testing.T, blockNumber uint64, outputIndex int64, challenger common.Address) types.Log {
+	colosseumABI, err := bindings.ColosseumMetaData.GetAbi()
+	require.NoError(t, err)
+	return types.Log{
+		Address: common.Address{0xcc},
+		Topics: []common.Hash{
+			colosseumABI.Events["ChallengeCreated"].ID,
+			common.BigToHash(big.NewInt(outputIndex)),
+			common.BytesToHash(common.Address{0xaa}.Bytes()),
+			common.BytesToHash(challenger.Bytes()),
+		},
+		Data:        common.BigToHash(big.NewInt(1)).Bytes(),
+		BlockNumber: blockNumber,
+	}
+}
+
+func TestFetchChallenges(t *testing.T) {
+	challenger1, challenger2 := common.Address{0x01}, common.Address{0x02}
+	backend := &testColosseumRPC{
+		logs: []types.Log{
+			challengeCreatedLog(t, 105, 9, challenger1),
+			challengeCreatedLog(t, 110, 10, challenger1),
+			challengeCreatedLog(t, 130, 11, challenger2),
+			challengeCreatedLog(t, 150, 10, challenger1),
+			challengeCreatedLog(t, 165, 12, challenger1),
+		},
+	}
+	srv := gethrpc.NewServer()
+	require.NoError(t, srv.RegisterName("eth", backend))
+	t.Cleanup(srv.Stop)
+	client := ethclient.NewClient(gethrpc.DialInProc(srv))
+	colosseum, err := bindings.NewColosseum(common.Address{0xcc}, client)
+	require.NoError(t, err)
+
+	challenges, err := fetchChallenges(context.Background(), colosseum, big.NewInt(10), big.NewInt(11), 100, 160, 25)
+	require.NoError(t, err)
+	require.Equal(t, [][2]uint64{{100, 124}, {125, 149}, {150, 160}}, backend.ranges)
+	status := chal.StatusString(chal.StatusChallengerTurn)
+	require.Equal(t, map[uint64][]string{
+		10: {challenger1.String() + "(" + status + ")"},
+		11: {challenger2.String() + "(" + status + ")"},
+	}, challenges)
+
+	_, err = fetchChallenges(context.Background(), colosseum, big.NewInt(10), big.NewInt(11), 100, 160, 0)
+	require.Error(t, err)
+}
+
+func TestCompareOutputs(t *testing.T) {
+	local := &eth.OutputResponse{
+		Version:               eth.OutputVersionV0,
+		StateRoot:             common.Hash{0x01},
+		WithdrawalStorageRoot: common.Hash{0x02},
+		BlockRef:              eth.L2BlockRef{Hash: common.Hash{0x03}},
+		NextBlockRef:          eth.L2BlockRef{Hash: common.Hash{0x04}},
+	}
+	root, err := rollup.ComputeKromaL2OutputRoot(&bindings.TypesOutputRootProof{
+		Version:                  local.Version,
+		StateRoot:                local.StateRoot,
+		MessagePasserStorageRoot: local.WithdrawalStorageRoot,
+		LatestBlockhash:          local.BlockRef.Hash,
+		NextBlockHash:            local.NextBlockRef.Hash,
+	})
+	require.NoError(t, err)
+	local.OutputRoot = root
+	compare := func(remoteRoot common.Hash, local *eth.OutputResponse) string {
+		result, err := compareOutputs(big.NewInt(1), &validator.Outputs{
+			RemoteOutput: &bindings.TypesCheckpointOutput{OutputRoot: remoteRoot, L2BlockNumber: big.NewInt(1800)},
+			LocalOutput:  local,
+		})
+		require.NoError(t, err)
+		require.Equal(t, uint64(1800), result.l2BlockNumber)
+		return result.result
+	}
+
+	require.Equal(t, outputMatched, compare(common.Hash(root), local))
+	require.Equal(t, outputMismatched, compare(common.Hash{0xff}, local))
+
+	// an inconsistent output root of the rollup node is reported instead of being compared
+	inconsistent := *local
+	inconsistent.OutputRoot = eth.Bytes32{0xff}
+	require.Equal(t, outputInconsistent, compare(common.Hash{0xff}, &inconsistent))
+}
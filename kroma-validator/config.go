@@ -2,11 +2,14 @@ package validator
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
 	"net/http"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	"github.com/ethereum-optimism/optimism/op-service/client"
@@ -15,8 +18,11 @@ import (
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	pprof "github.com/ethereum-optimism/optimism/op-service/pprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
@@ -26,6 +32,14 @@ import (
 	chal "github.com/kroma-network/kroma/kroma-validator/challenge"
 	"github.com/kroma-network/kroma/kroma-validator/flags"
 	"github.com/kroma-network/kroma/kroma-validator/metrics"
+	"github.com/kroma-network/kroma/kroma-validator/store"
+)
+
+const (
+	// ProverRPC generates fault proofs with the zkEVM prover, or the zkVM witness generator and prover via RPC.
+	ProverRPC = "rpc"
+	// ProverMock generates mock fault proofs locally. It is intended for testing.
+	ProverMock = "mock"
 )
 
 // Config contains the well typed fields that are used to initialize the output submitter.
@@ -40,6 +54,8 @@ type Config struct {
 	NetworkTimeout                  time.Duration
 	TxManager                       *txmgr.BufferedTxManager
 	L1Client                        *ethclient.Client
+	L1LogFeed                       *L1LogFeed
+	SharedL1                        bool
 	L2Client                        *ethclient.Client
 	RollupClient                    *sources.RollupClient
 	RollupConfig                    *rollup.Config
@@ -49,12 +65,39 @@ type Config struct {
 	OutputSubmitterAllowPublicRound bool
 	OutputSubmitterRetryInterval    time.Duration
 	OutputSubmitterRoundBuffer      uint64
+	OutputAttestationSigner         p2p.Signer
 	ChallengerEnabled               bool
 	ZkEVMProofFetcher               *chal.ZkEVMProofFetcher
 	ZkVMProofFetcher                *chal.ZkVMProofFetcher
 	WitnessGenerator                *chal.WitnessGenerator
-	GuardianEnabled                 bool
-	GuardianPollInterval            time.Duration
+	ZkEVMProofProvider              chal.ProofProvider
+	ZkVMProofProvider               chal.ProofProvider
+	// ProverEndpointPools are the pools of the prover endpoints, which are closed when the validator stops.
+	ProverEndpointPools     []*chal.EndpointPool
+	ProofDurationEstimate   time.Duration
+	RewardPolicyEnabled     bool
+	RewardPolicyInterval    time.Duration
+	RewardCompoundThreshold *big.Int
+	RewardSweepThreshold    *big.Int
+	RewardMinStake          *big.Int
+	RewardBondReserve       uint64
+	GuardianEnabled         bool
+	GuardianPollInterval    time.Duration
+	GuardianTxMgrConfig     txmgr.CLIConfig
+	GuardianTxManager       *txmgr.BufferedTxManager
+	// GuardianRoleTxManager is the tx manager of the guardians of multiple chains sharing L1, so that the signer is
+	// rotated for all of them at once. It is started and stopped by the caller.
+	GuardianRoleTxManager *RoleTxManager
+	Store                 store.Store
+	DryRun                bool
+}
+
+// L1Backend returns the contract backend of L1, which subscribes to the logs through the L1 log feed if any.
+func (c *Config) L1Backend() bind.ContractBackend {
+	if c.L1LogFeed != nil {
+		return &l1Backend{Client: c.L1Client, feed: c.L1LogFeed}
+	}
+	return c.L1Client
 }
 
 // Check ensures that the [Config] is valid.
@@ -113,36 +156,93 @@ type CLIConfig struct {
 	// OutputSubmitterRoundBuffer is how many blocks before each round to start trying submission.
 	OutputSubmitterRoundBuffer uint64
 
+	// OutputSubmitterAttest is whether to sign the submitted output roots and publish them as output attestations.
+	OutputSubmitterAttest bool
+
 	ChallengerEnabled bool
 
-	// ZkEVMProverRPC is the URL of zkEVM prover JSON-RPC server.
-	ZkEVMProverRPC string
+	// Prover is the prover backend to generate fault proofs.
+	Prover string
+
+	// ZkEVMProverRPC is the URLs of zkEVM prover JSON-RPC servers.
+	ZkEVMProverRPC []string
 
 	// ZkEVMNetworkTimeout is timeout to be connected with zkEVM prover.
 	ZkEVMNetworkTimeout time.Duration
 
-	// ZkVMProverRPC is the URL of zkVM prover JSON-RPC server.
-	ZkVMProverRPC string
+	// ZkVMProverRPC is the URLs of zkVM prover JSON-RPC servers.
+	ZkVMProverRPC []string
+
+	// WitnessGeneratorRPC is the URLs of zkVM witness generator JSON-RPC servers.
+	WitnessGeneratorRPC []string
+
+	// ProverCallTimeout is the max duration of a request to a zkVM prover or witness generator endpoint
+	// before failing over to the next endpoint.
+	ProverCallTimeout time.Duration
+
+	// ProverHealthCheckInterval is the interval of the health checks of zkVM prover and witness generator endpoints.
+	ProverHealthCheckInterval time.Duration
+
+	// ProofDurationEstimate is the estimated duration of a fault proof generation before any proof is generated.
+	ProofDurationEstimate time.Duration
+
+	// RewardPolicyEnabled is whether to apply the reward policy periodically.
+	RewardPolicyEnabled bool
+
+	// RewardPolicyInterval is how frequently to apply the reward policy.
+	RewardPolicyInterval time.Duration
+
+	// RewardCompoundThreshold is the min amount of asset tokens held by the validator to deposit them as stake.
+	RewardCompoundThreshold string
 
-	// WitnessGeneratorRPC is the URL of zkVM witness generator JSON-RPC server.
-	WitnessGeneratorRPC string
+	// RewardSweepThreshold is the min amount of stake above the min stake to withdraw to the withdraw account.
+	RewardSweepThreshold string
+
+	// RewardMinStake is the amount of stake to be kept when sweeping.
+	RewardMinStake string
+
+	// RewardBondReserve is the number of bonds to keep available for the output submissions.
+	RewardBondReserve uint64
 
 	GuardianEnabled bool
 
 	// GuardianPollInterval is how frequently to poll L1 for inspection.
 	GuardianPollInterval time.Duration
 
-	TxMgrConfig   txmgr.CLIConfig
-	RPCConfig     oprpc.CLIConfig
-	LogConfig     oplog.CLIConfig
-	MetricsConfig opmetrics.CLIConfig
-	PprofConfig   pprof.CLIConfig
+	// GuardianPrivateKey is the private key to sign the guardian transactions with.
+	GuardianPrivateKey string
+
+	// GuardianMnemonic is the mnemonic used to derive the guardian wallet.
+	GuardianMnemonic string
+
+	// GuardianHDPath is the HD path used to derive the guardian wallet from the guardian mnemonic.
+	GuardianHDPath string
+
+	// DataDir is the directory to persist the validator state. If empty, the state is kept only in memory.
+	DataDir string
+
+	// DryRun is whether to simulate the transactions instead of broadcasting them.
+	DryRun bool
+
+	// ChainsConfig is the path to the file listing the chain deployments to run against.
+	ChainsConfig string
+
+	TxMgrConfig txmgr.CLIConfig
+	// GuardianSignerConfig is the remote signer config to sign the guardian transactions with.
+	GuardianSignerConfig opsigner.CLIConfig
+	RPCConfig            oprpc.CLIConfig
+	LogConfig            oplog.CLIConfig
+	MetricsConfig        opmetrics.CLIConfig
+	PprofConfig          pprof.CLIConfig
 }
 
 func (c CLIConfig) Check() error {
 	if !(c.OutputSubmitterEnabled || c.ChallengerEnabled || c.GuardianEnabled) {
 		return errors.New("one of output submitter, challenger, guardian should be enabled")
 	}
+	if c.Prover != ProverRPC && c.Prover != ProverMock {
+		return fmt.Errorf("unknown prover backend: %s", c.Prover)
+	}
 	if err := c.RPCConfig.Check(); err != nil {
 		return err
 	}
@@ -155,9 +255,35 @@ func (c CLIConfig) Check() error {
 	if err := c.TxMgrConfig.Check(); err != nil {
 		return err
 	}
+	if err := c.GuardianSignerConfig.Check(); err != nil {
+		return fmt.Errorf("invalid guardian signer config: %w", err)
+	}
+	if c.OutputSubmitterAttest && c.TxMgrConfig.SignerCLIConfig.Enabled() {
+		return errors.New("output attestations cannot be signed by the remote signer")
+	}
 	return nil
 }
 
+// hasGuardianKey returns whether a key is configured for the guardian apart from the validator key.
+func (c CLIConfig) hasGuardianKey() bool {
+	return c.GuardianPrivateKey != "" || c.GuardianMnemonic != "" || c.GuardianSignerConfig.Enabled()
+}
+
+// guardianTxMgrConfig returns the tx manager config of the guardian, which is the same as the one of the validator
+// except for the key if a key is configured for the guardian.
+func (c CLIConfig) guardianTxMgrConfig() txmgr.CLIConfig {
+	txMgrConfig := c.TxMgrConfig
+	if c.hasGuardianKey() {
+		txMgrConfig.PrivateKey = c.GuardianPrivateKey
+		txMgrConfig.Mnemonic = c.GuardianMnemonic
+		txMgrConfig.HDPath = c.GuardianHDPath
+		txMgrConfig.SequencerHDPath = ""
+		txMgrConfig.L2OutputHDPath = ""
+		txMgrConfig.SignerCLIConfig = c.GuardianSignerConfig
+	}
+	return txMgrConfig
+}
+
 // NewConfig parses the Config from the provided flags or environment variables.
 func NewConfig(ctx *cli.Context) CLIConfig {
 	return CLIConfig{
@@ -179,24 +305,96 @@ func NewConfig(ctx *cli.Context) CLIConfig {
 		OutputSubmitterRetryInterval:    ctx.Duration(flags.OutputSubmitterRetryIntervalFlag.Name),
 		OutputSubmitterRoundBuffer:      ctx.Uint64(flags.OutputSubmitterRoundBufferFlag.Name),
 		OutputSubmitterAllowPublicRound: ctx.Bool(flags.OutputSubmitterAllowPublicRoundFlag.Name),
-		ZkEVMProverRPC:                  ctx.String(flags.ZkEVMProverRPCFlag.Name),
+		OutputSubmitterAttest:           ctx.Bool(flags.OutputSubmitterAttestFlag.Name),
+		ZkEVMProverRPC:                  ctx.StringSlice(flags.ZkEVMProverRPCFlag.Name),
 		ZkEVMNetworkTimeout:             ctx.Duration(flags.ZkEVMNetworkTimeoutFlag.Name),
-		ZkVMProverRPC:                   ctx.String(flags.ZkVMProverRPCFlag.Name),
-		WitnessGeneratorRPC:             ctx.String(flags.WitnessGeneratorRPCFlag.Name),
+		ZkVMProverRPC:                   ctx.StringSlice(flags.ZkVMProverRPCFlag.Name),
+		WitnessGeneratorRPC:             ctx.StringSlice(flags.WitnessGeneratorRPCFlag.Name),
+		ProverCallTimeout:               ctx.Duration(flags.ProverCallTimeoutFlag.Name),
+		ProverHealthCheckInterval:       ctx.Duration(flags.ProverHealthCheckIntervalFlag.Name),
+		ProofDurationEstimate:           ctx.Duration(flags.ProofDurationEstimateFlag.Name),
+		RewardPolicyEnabled:             ctx.Bool(flags.RewardPolicyEnabledFlag.Name),
+		RewardPolicyInterval:            ctx.Duration(flags.RewardPolicyIntervalFlag.Name),
+		RewardCompoundThreshold:         ctx.String(flags.RewardPolicyCompoundThresholdFlag.Name),
+		RewardSweepThreshold:            ctx.String(flags.RewardPolicySweepThresholdFlag.Name),
+		RewardMinStake:                  ctx.String(flags.RewardPolicyMinStakeFlag.Name),
+		RewardBondReserve:               ctx.Uint64(flags.RewardPolicyBondReserveFlag.Name),
+		Prover:                          ctx.String(flags.ProverFlag.Name),
 		GuardianEnabled:                 ctx.Bool(flags.GuardianEnabledFlag.Name),
 		SecurityCouncilAddress:          ctx.String(flags.SecurityCouncilAddressFlag.Name),
 		GuardianPollInterval:            ctx.Duration(flags.GuardianPollIntervalFlag.Name),
+		GuardianPrivateKey:              ctx.String(flags.GuardianPrivateKeyFlag.Name),
+		GuardianMnemonic:                ctx.String(flags.GuardianMnemonicFlag.Name),
+		GuardianHDPath:                  ctx.String(flags.GuardianHDPathFlag.Name),
+		DataDir:                         ctx.String(flags.DataDirFlag.Name),
+		DryRun:                          ctx.Bool(flags.DryRunFlag.Name),
+		ChainsConfig:                    ctx.String(flags.ChainsConfigFlag.Name),
+
+		TxMgrConfig:          txmgr.ReadCLIConfig(ctx),
+		GuardianSignerConfig: readGuardianSignerConfig(ctx),
+		RPCConfig:            oprpc.ReadCLIConfig(ctx),
+		LogConfig:            oplog.ReadCLIConfig(ctx),
+		MetricsConfig:        opmetrics.ReadCLIConfig(ctx),
+		PprofConfig:          pprof.ReadCLIConfig(ctx),
+	}
+}
 
-		TxMgrConfig:   txmgr.ReadCLIConfig(ctx),
-		RPCConfig:     oprpc.ReadCLIConfig(ctx),
-		LogConfig:     oplog.ReadCLIConfig(ctx),
-		MetricsConfig: opmetrics.ReadCLIConfig(ctx),
-		PprofConfig:   pprof.ReadCLIConfig(ctx),
+func readGuardianSignerConfig(ctx *cli.Context) opsigner.CLIConfig {
+	return opsigner.CLIConfig{
+		Endpoint:  ctx.String(flags.GuardianSignerEndpointFlag.Name),
+		Address:   ctx.String(flags.GuardianSignerAddressFlag.Name),
+		TLSConfig: optls.ReadCLIConfigWithPrefix(ctx, flags.GuardianSignerFlagPrefix),
 	}
 }
 
 // NewValidatorConfig creates a validator config with given the CLIConfig
 func NewValidatorConfig(cfg CLIConfig, l log.Logger, m metrics.Metricer) (*Config, error) {
+	return NewChainValidatorConfig(cfg, l, m, nil)
+}
+
+// SharedL1 is the L1 resources shared by the validators of multiple chains.
+// They are started and stopped by the caller instead of each validator.
+type SharedL1 struct {
+	Client            *ethclient.Client
+	LogFeed           *L1LogFeed
+	TxManager         *txmgr.BufferedTxManager
+	GuardianTxManager *txmgr.BufferedTxManager
+	// GuardianRoleTxManager is the tx manager shared by the guardians of the chains, if the guardian is enabled.
+	GuardianRoleTxManager *RoleTxManager
+}
+
+// NewSharedL1 creates the L1 client and the tx managers with the L1 flags in the CLIConfig.
+// A dedicated tx manager is created for the guardian if a key is configured for it, to keep the SecurityCouncil key
+// apart from the validator key. The L1 log feed and the guardian role tx manager are left to be set by the caller.
+func NewSharedL1(cfg CLIConfig, guardianEnabled bool, l log.Logger, m metrics.Metricer) (*SharedL1, error) {
+	txManager, err := txmgr.NewBufferedTxManager("validator", l, m, cfg.TxMgrConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var guardianTxManager *txmgr.BufferedTxManager
+	if guardianEnabled && cfg.hasGuardianKey() {
+		guardianTxManager, err = txmgr.NewBufferedTxManager("guardian", l, m, cfg.guardianTxMgrConfig())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create guardian tx manager: %w", err)
+		}
+	}
+
+	l1Client, err := dial.DialEthClientWithTimeout(context.Background(), dial.DefaultDialTimeout, l, cfg.L1EthRpc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial L1 RPC: %w", err)
+	}
+
+	return &SharedL1{
+		Client:            l1Client,
+		TxManager:         txManager,
+		GuardianTxManager: guardianTxManager,
+	}, nil
+}
+
+// NewChainValidatorConfig creates a validator config of a chain with given the CLIConfig.
+// If shared is not nil, the validator uses the shared L1 resources instead of creating its own.
+func NewChainValidatorConfig(cfg CLIConfig, l log.Logger, m metrics.Metricer, shared *SharedL1) (*Config, error) {
 	l2OOAddress, err := opservice.ParseAddress(cfg.L2OOAddress)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse L2OOAddress: %w", err)
@@ -230,16 +428,27 @@ func NewValidatorConfig(cfg CLIConfig, l log.Logger, m metrics.Metricer) (*Confi
 		return nil, fmt.Errorf("failed to parse AssetManagerAddress: %w", err)
 	}
 
-	txManager, err := txmgr.NewBufferedTxManager("validator", l, m, cfg.TxMgrConfig)
+	rewardCompoundThreshold, err := parseWei(cfg.RewardCompoundThreshold)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to parse reward compound threshold: %w", err)
+	}
+	rewardSweepThreshold, err := parseWei(cfg.RewardSweepThreshold)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse reward sweep threshold: %w", err)
+	}
+	rewardMinStake, err := parseWei(cfg.RewardMinStake)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse reward min stake: %w", err)
 	}
 
 	// Connect to L1 and L2 providers. Perform these last since they are the most expensive.
 	ctx := context.Background()
-	l1Client, err := dial.DialEthClientWithTimeout(ctx, dial.DefaultDialTimeout, l, cfg.L1EthRpc)
-	if err != nil {
-		return nil, fmt.Errorf("failed to dial L1 RPC: %w", err)
+	sharedL1 := shared != nil
+	if !sharedL1 {
+		shared, err = NewSharedL1(cfg, cfg.GuardianEnabled, l, m)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	l2Client, err := dial.DialEthClientWithTimeout(ctx, dial.DefaultDialTimeout, l, cfg.L2EthRpc)
@@ -261,31 +470,79 @@ func NewValidatorConfig(cfg CLIConfig, l log.Logger, m metrics.Metricer) (*Confi
 	var zkEVMProofFetcher *chal.ZkEVMProofFetcher
 	var zkVMProofFetcher *chal.ZkVMProofFetcher
 	var witnessGenerator *chal.WitnessGenerator
-	if cfg.ChallengerEnabled {
+	var zkEVMProofProvider chal.ProofProvider
+	var zkVMProofProvider chal.ProofProvider
+	var proverPools []*chal.EndpointPool
+	if cfg.ChallengerEnabled && cfg.Prover == ProverMock {
+		l.Warn("mock prover enabled, the fault proofs are not verifiable on-chain")
+		zkEVMProofProvider = chal.NewMockProofProvider(&chal.ProofResult{
+			ZkEVMProof: &chal.ProofAndPair{Pair: []*big.Int{common.Big0, common.Big0, common.Big0, common.Big0}},
+		}, 0)
+		zkVMProofProvider = chal.NewMockProofProvider(&chal.ProofResult{
+			ZkVMProof: &chal.ZkVMProofResponse{RequestStatus: chal.RequestCompleted},
+		}, 0)
+	} else if cfg.ChallengerEnabled {
 		if rollupConfig.IsKromaMPT(uint64(time.Now().Unix())) {
-			pc, err := client.NewRPC(ctx, l.New("service", "prover"), cfg.ZkVMProverRPC)
+			proverRPCs, err := dialProverRPCs(ctx, l, cfg.ZkVMProverRPC)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create zkVM prover rpc client: %w", err)
 			}
-			zkVMProofFetcher = chal.NewZkVMProofFetcher(pc)
-
-			wc, err := client.NewRPC(ctx, l.New("service", "witness"), cfg.WitnessGeneratorRPC)
+			witnessGenRPCs, err := dialProverRPCs(ctx, l, cfg.WitnessGeneratorRPC)
 			if err != nil {
+				closeRPCs(proverRPCs)
 				return nil, fmt.Errorf("failed to create witness generator rpc client: %w", err)
 			}
-			witnessGenerator = chal.NewWitnessGenerator(wc)
 
-			proverSpec, err := zkVMProofFetcher.Spec(ctx)
+			// every endpoint should serve the same SP1 version, since a proof request can be failed over to any of them
+			sp1Version, err := checkSP1Version(l, proverRPCs, func(r client.RPC) (*chal.SpecResponse, error) {
+				return chal.NewZkVMProofFetcher(r).Spec(ctx)
+			})
+			if err != nil {
+				closeRPCs(proverRPCs)
+				closeRPCs(witnessGenRPCs)
+				return nil, fmt.Errorf("failed to check zkVM provers: %w", err)
+			}
+
+			proverPool, err := chal.NewEndpointPool(chal.EndpointPoolConfig{
+				Service:             chal.ServiceZkVMProver,
+				CallTimeout:         cfg.ProverCallTimeout,
+				HealthCheckMethod:   "spec",
+				HealthCheckInterval: cfg.ProverHealthCheckInterval,
+				Admit:               admitSP1Version(sp1Version),
+				Affinity:            chal.ZkVMAffinity,
+			}, l, m, cfg.ZkVMProverRPC, proverRPCs)
 			if err != nil {
-				return nil, fmt.Errorf("failed to request spec of zkVM prover: %w", err)
+				closeRPCs(proverRPCs)
+				closeRPCs(witnessGenRPCs)
+				return nil, fmt.Errorf("failed to create zkVM prover endpoint pool: %w", err)
 			}
-			witnessGenSpec, err := witnessGenerator.Spec(ctx)
+			proverPools = append(proverPools, proverPool)
+			zkVMProofFetcher = chal.NewZkVMProofFetcher(proverPool)
+
+			witnessGenPool, err := chal.NewEndpointPool(chal.EndpointPoolConfig{
+				Service:             chal.ServiceWitnessGenerator,
+				CallTimeout:         cfg.ProverCallTimeout,
+				HealthCheckMethod:   "spec",
+				HealthCheckInterval: cfg.ProverHealthCheckInterval,
+				Admit:               admitSP1Version(sp1Version),
+				Affinity:            chal.ZkVMAffinity,
+			}, l, m, cfg.WitnessGeneratorRPC, witnessGenRPCs)
 			if err != nil {
-				return nil, fmt.Errorf("failed to request spec of witness generator: %w", err)
+				closeEndpointPools(proverPools)
+				closeRPCs(witnessGenRPCs)
+				return nil, fmt.Errorf("failed to create witness generator endpoint pool: %w", err)
 			}
-			if proverSpec.SP1Version != witnessGenSpec.SP1Version {
-				return nil, errors.New("SP1 version of zkVM prover and witness generator mismatched")
+			proverPools = append(proverPools, witnessGenPool)
+
+			// the endpoints that are unreachable or serve another SP1 version are kept out of the pools until the
+			// health checks find them serving the SP1 version
+			for _, pool := range proverPools {
+				if err := pool.CheckHealth(ctx); err != nil {
+					closeEndpointPools(proverPools)
+					return nil, fmt.Errorf("failed to check prover endpoints: %w", err)
+				}
 			}
+			witnessGenerator = chal.NewWitnessGenerator(witnessGenPool)
 		} else {
 			clientOpt := rpc.WithHTTPClient(&http.Client{
 				Timeout: cfg.ZkEVMNetworkTimeout,
@@ -293,11 +550,38 @@ func NewValidatorConfig(cfg CLIConfig, l log.Logger, m metrics.Metricer) (*Confi
 			opts := []client.RPCOption{
 				client.WithGethRPCOptions(clientOpt),
 			}
-			pc, err := client.NewRPC(ctx, l.New("service", "prover"), cfg.ZkEVMProverRPC, opts...)
+			proverRPCs, err := dialProverRPCs(ctx, l, cfg.ZkEVMProverRPC, opts...)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create zkEVM prover rpc client: %w", err)
 			}
-			zkEVMProofFetcher = chal.NewZkEVMProofFetcher(pc)
+			// the zkEVM prover generates the proof synchronously within the network timeout, so neither the call
+			// timeout nor the affinity is applied
+			proverPool, err := chal.NewEndpointPool(chal.EndpointPoolConfig{
+				Service: chal.ServiceZkEVMProver,
+			}, l, m, cfg.ZkEVMProverRPC, proverRPCs)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create zkEVM prover endpoint pool: %w", err)
+			}
+			proverPools = append(proverPools, proverPool)
+			zkEVMProofFetcher = chal.NewZkEVMProofFetcher(proverPool)
+		}
+	}
+
+	var outputAttestationSigner p2p.Signer
+	if cfg.OutputSubmitterEnabled && cfg.OutputSubmitterAttest {
+		key, err := cfg.TxMgrConfig.LocalKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the key to sign output attestations: %w", err)
+		}
+		outputAttestationSigner = p2p.NewLocalSigner(key)
+	}
+
+	var validatorStore store.Store = store.Disabled
+	if cfg.DataDir != "" {
+		l.Info("validator store enabled", "path", cfg.DataDir)
+		validatorStore, err = store.NewPebbleStore(l.New("service", "store"), cfg.DataDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open validator store at %v: %w", cfg.DataDir, err)
 		}
 	}
 
@@ -309,8 +593,10 @@ func NewValidatorConfig(cfg CLIConfig, l log.Logger, m metrics.Metricer) (*Confi
 		ValidatorManagerAddr:            valMgrAddress,
 		AssetManagerAddr:                assetManagerAddress,
 		NetworkTimeout:                  cfg.TxMgrConfig.NetworkTimeout,
-		TxManager:                       txManager,
-		L1Client:                        l1Client,
+		TxManager:                       shared.TxManager,
+		L1Client:                        shared.Client,
+		L1LogFeed:                       shared.LogFeed,
+		SharedL1:                        sharedL1,
 		L2Client:                        l2Client,
 		RollupClient:                    rollupClient,
 		RollupConfig:                    rollupConfig,
@@ -320,11 +606,121 @@ func NewValidatorConfig(cfg CLIConfig, l log.Logger, m metrics.Metricer) (*Confi
 		OutputSubmitterAllowPublicRound: cfg.OutputSubmitterAllowPublicRound,
 		OutputSubmitterRetryInterval:    cfg.OutputSubmitterRetryInterval,
 		OutputSubmitterRoundBuffer:      cfg.OutputSubmitterRoundBuffer,
+		OutputAttestationSigner:         outputAttestationSigner,
 		ChallengerEnabled:               cfg.ChallengerEnabled,
 		ZkEVMProofFetcher:               zkEVMProofFetcher,
 		ZkVMProofFetcher:                zkVMProofFetcher,
 		WitnessGenerator:                witnessGenerator,
+		ZkEVMProofProvider:              zkEVMProofProvider,
+		ZkVMProofProvider:               zkVMProofProvider,
+		ProverEndpointPools:             proverPools,
+		ProofDurationEstimate:           cfg.ProofDurationEstimate,
+		RewardPolicyEnabled:             cfg.RewardPolicyEnabled,
+		RewardPolicyInterval:            cfg.RewardPolicyInterval,
+		RewardCompoundThreshold:         rewardCompoundThreshold,
+		RewardSweepThreshold:            rewardSweepThreshold,
+		RewardMinStake:                  rewardMinStake,
+		RewardBondReserve:               cfg.RewardBondReserve,
 		GuardianEnabled:                 cfg.GuardianEnabled,
 		GuardianPollInterval:            cfg.GuardianPollInterval,
+		GuardianTxMgrConfig:             cfg.guardianTxMgrConfig(),
+		GuardianTxManager:               shared.GuardianTxManager,
+		GuardianRoleTxManager:           shared.GuardianRoleTxManager,
+		Store:                           validatorStore,
+		DryRun:                          cfg.DryRun,
 	}, nil
 }
+
+// dialProverRPCs creates the RPC clients of the given prover endpoints.
+func dialProverRPCs(ctx context.Context, l log.Logger, urls []string, opts ...client.RPCOption) ([]client.RPC, error) {
+	if len(urls) == 0 {
+		return nil, chal.ErrNoEndpoint
+	}
+	rpcs := make([]client.RPC, 0, len(urls))
+	for i, url := range urls {
+		r, err := client.NewRPC(ctx, l.New("service", "prover", "endpoint", i), url, opts...)
+		if err != nil {
+			closeRPCs(rpcs)
+			return nil, fmt.Errorf("failed to dial endpoint %d: %w", i, err)
+		}
+		rpcs = append(rpcs, r)
+	}
+	return rpcs, nil
+}
+
+// checkSP1Version checks that the endpoints serve the same SP1 version as each other, and returns the version.
+// An endpoint that does not respond is skipped, so that the validator starts with the other endpoints, but at least one
+// endpoint should respond to check the version.
+func checkSP1Version(l log.Logger, rpcs []client.RPC, spec func(client.RPC) (*chal.SpecResponse, error)) (string, error) {
+	sp1Version := ""
+	for i, r := range rpcs {
+		res, err := spec(r)
+		if err != nil {
+			l.Warn("failed to request spec of prover endpoint, starting without it until it recovers", "endpoint", i, "err", err)
+			continue
+		}
+		if sp1Version == "" {
+			sp1Version = res.SP1Version
+		} else if res.SP1Version != sp1Version {
+			return "", fmt.Errorf("SP1 version of endpoint %d mismatched, %s != %s", i, res.SP1Version, sp1Version)
+		}
+	}
+	if sp1Version == "" {
+		return "", fmt.Errorf("%w: no endpoint responded to the spec request", chal.ErrNoEndpoint)
+	}
+	return sp1Version, nil
+}
+
+// admitSP1Version admits the prover endpoints whose spec reports the given SP1 version.
+func admitSP1Version(sp1Version string) func(json.RawMessage) error {
+	return func(result json.RawMessage) error {
+		var spec chal.SpecResponse
+		if err := json.Unmarshal(result, &spec); err != nil {
+			return fmt.Errorf("failed to decode spec: %w", err)
+		}
+		if spec.SP1Version != sp1Version {
+			return fmt.Errorf("SP1 version mismatched, %s != %s", spec.SP1Version, sp1Version)
+		}
+		return nil
+	}
+}
+
+func closeRPCs(rpcs []client.RPC) {
+	for _, r := range rpcs {
+		r.Close()
+	}
+}
+
+func closeEndpointPools(pools []*chal.EndpointPool) {
+	for _, pool := range pools {
+		pool.Close()
+	}
+}
+
+// closeResources closes the prover endpoint pools and the store opened by NewValidatorConfig.
+func (c *Config) closeResources() error {
+	closeEndpointPools(c.ProverEndpointPools)
+	if c.OutputAttestationSigner != nil {
+		if err := c.OutputAttestationSigner.Close(); err != nil {
+			return fmt.Errorf("failed to close output attestation signer: %w", err)
+		}
+	}
+	if c.Store != nil {
+		if err := c.Store.Close(); err != nil {
+			return fmt.Errorf("failed to close validator store: %w", err)
+		}
+	}
+	return nil
+}
+
+// parseWei parses the non-negative amount in wei. An empty string is regarded as zero.
+func parseWei(amount string) (*big.Int, error) {
+	if amount == "" {
+		return new(big.Int), nil
+	}
+	value, ok := new(big.Int).SetString(amount, 10)
+	if !ok || value.Sign() < 0 {
+		return nil, fmt.Errorf("invalid amount: %s", amount)
+	}
+	return value, nil
+}
@@ -0,0 +1,107 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	chal "github.com/kroma-network/kroma/kroma-validator/challenge"
+	"github.com/kroma-network/kroma/kroma-validator/metrics"
+)
+
+// specStubRPC serves the spec of a prover endpoint, or fails if it has no version.
+type specStubRPC struct {
+	sp1Version string
+	calls      int
+}
+
+func (s *specStubRPC) Close() {}
+
+func (s *specStubRPC) BatchCallContext(_ context.Context, _ []rpc.BatchElem) error {
+	return errors.New("not supported")
+}
+
+func (s *specStubRPC) EthSubscribe(_ context.Context, _ any, _ ...any) (ethereum.Subscription, error) {
+	return nil, errors.New("not supported")
+}
+
+func (s *specStubRPC) CallContext(_ context.Context, result any, _ string, _ ...any) error {
+	s.calls++
+	if s.sp1Version == "" {
+		return errors.New("connection refused")
+	}
+	spec := &chal.SpecResponse{SP1Version: s.sp1Version}
+	switch result := result.(type) {
+	case **chal.SpecResponse:
+		*result = spec
+	case *json.RawMessage:
+		*result, _ = json.Marshal(spec)
+	}
+	return nil
+}
+
+func TestCheckSP1Version(t *testing.T) {
+	ctx := context.Background()
+	logger := testlog.Logger(t, log.LevelInfo)
+	spec := func(r client.RPC) (*chal.SpecResponse, error) {
+		return chal.NewZkVMProofFetcher(r).Spec(ctx)
+	}
+
+	// an endpoint that is down does not prevent starting with the others
+	rpcs := []client.RPC{&specStubRPC{}, &specStubRPC{sp1Version: "v1"}, &specStubRPC{sp1Version: "v1"}}
+	version, err := checkSP1Version(logger, rpcs, spec)
+	require.NoError(t, err)
+	require.Equal(t, "v1", version)
+
+	rpcs = []client.RPC{&specStubRPC{sp1Version: "v1"}, &specStubRPC{sp1Version: "v2"}}
+	_, err = checkSP1Version(logger, rpcs, spec)
+	require.ErrorContains(t, err, "mismatched")
+
+	rpcs = []client.RPC{&specStubRPC{}, &specStubRPC{}}
+	_, err = checkSP1Version(logger, rpcs, spec)
+	require.ErrorIs(t, err, chal.ErrNoEndpoint)
+}
+
+func TestAdmitSP1Version(t *testing.T) {
+	ctx := context.Background()
+	logger := testlog.Logger(t, log.LevelInfo)
+	down := &specStubRPC{}
+	other := &specStubRPC{sp1Version: "v2"}
+	rpcs := []client.RPC{down, other, &specStubRPC{sp1Version: "v1"}}
+	pool, err := chal.NewEndpointPool(chal.EndpointPoolConfig{
+		Service:           chal.ServiceWitnessGenerator,
+		HealthCheckMethod: "spec",
+		Admit:             admitSP1Version("v1"),
+	}, logger, metrics.NoopMetrics, make([]string, len(rpcs)), rpcs)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+	require.NoError(t, pool.CheckHealth(ctx))
+
+	// only the endpoint serving the SP1 version is called
+	generator := chal.NewWitnessGenerator(pool)
+	for i := 0; i < 3; i++ {
+		_, err := generator.Spec(ctx)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 1, down.calls)
+	require.Equal(t, 1, other.calls)
+
+	// the endpoint unreachable at startup is admitted once it serves the SP1 version
+	down.sp1Version = "v1"
+	other.sp1Version = "v1"
+	require.NoError(t, pool.CheckHealth(ctx))
+	for i := 0; i < 3; i++ {
+		_, err := generator.Spec(ctx)
+		require.NoError(t, err)
+	}
+	require.Greater(t, down.calls, 2)
+	require.Greater(t, other.calls, 2)
+}
@@ -2,6 +2,7 @@ package flags
 
 import (
 	"fmt"
+	"slices"
 	"time"
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
@@ -9,6 +10,7 @@ import (
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/oppprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/urfave/cli/v2"
 )
@@ -113,9 +115,21 @@ var (
 		Usage:   "Allows l2 output submitter in public round",
 		EnvVars: prefixEnvVars("OUTPUT_SUBMITTER_ALLOW_PUBLIC_ROUND"),
 	}
-	ZkEVMProverRPCFlag = &cli.StringFlag{
+	OutputSubmitterAttestFlag = &cli.BoolFlag{
+		Name: "output-submitter.attest",
+		Usage: "Sign the submitted output roots and publish them to the output attestations gossip topic of the rollup node. " +
+			"Requires the validator key to be given by the private key or the mnemonic, not by the remote signer",
+		EnvVars: prefixEnvVars("OUTPUT_SUBMITTER_ATTEST"),
+	}
+	ProverFlag = &cli.StringFlag{
+		Name:    "challenger.prover",
+		Usage:   "Prover backend to generate fault proofs. Options: 'rpc' (the zkEVM/zkVM prover RPCs), 'mock' (local mock proofs for testing, not verifiable on-chain)",
+		EnvVars: prefixEnvVars("CHALLENGER_PROVER"),
+		Value:   "rpc",
+	}
+	ZkEVMProverRPCFlag = &cli.StringSliceFlag{
 		Name:    "challenger.zkevm-prover-rpc",
-		Usage:   "JSON-RPC URL for zkEVM prover, required before Kroma MPT time",
+		Usage:   "JSON-RPC URLs for zkEVM prover, required before Kroma MPT time. Multiple URLs can be given for failover",
 		EnvVars: prefixEnvVars("CHALLENGER_ZKEVM_PROVER_RPC"),
 	}
 	ZkEVMNetworkTimeoutFlag = &cli.DurationFlag{
@@ -124,16 +138,69 @@ var (
 		EnvVars: prefixEnvVars("CHALLENGER_ZKEVM_NETWORK_TIMEOUT"),
 		Value:   time.Hour * 4,
 	}
-	ZkVMProverRPCFlag = &cli.StringFlag{
+	ZkVMProverRPCFlag = &cli.StringSliceFlag{
 		Name:    "challenger.zkvm-prover-rpc",
-		Usage:   "JSON-RPC URL for zkVM prover, required after Kroma MPT time",
+		Usage:   "JSON-RPC URLs for zkVM prover, required after Kroma MPT time. Multiple URLs can be given for failover",
 		EnvVars: prefixEnvVars("CHALLENGER_ZKVM_PROVER_RPC"),
 	}
-	WitnessGeneratorRPCFlag = &cli.StringFlag{
+	WitnessGeneratorRPCFlag = &cli.StringSliceFlag{
 		Name:    "challenger.witness-generator-rpc",
-		Usage:   "JSON-RPC URL for zkVM witness generator, required after Kroma MPT time",
+		Usage:   "JSON-RPC URLs for zkVM witness generator, required after Kroma MPT time. Multiple URLs can be given for failover",
 		EnvVars: prefixEnvVars("CHALLENGER_WITNESS_GENERATOR_RPC"),
 	}
+	ProverCallTimeoutFlag = &cli.DurationFlag{
+		Name:    "challenger.prover-call-timeout",
+		Usage:   "Max duration of a request to a zkVM prover or witness generator before failing over to the next endpoint",
+		EnvVars: prefixEnvVars("CHALLENGER_PROVER_CALL_TIMEOUT"),
+		Value:   time.Minute,
+	}
+	ProverHealthCheckIntervalFlag = &cli.DurationFlag{
+		Name:    "challenger.prover-health-check-interval",
+		Usage:   "Interval of the health checks of zkVM prover and witness generator endpoints",
+		EnvVars: prefixEnvVars("CHALLENGER_PROVER_HEALTH_CHECK_INTERVAL"),
+		Value:   30 * time.Second,
+	}
+	ProofDurationEstimateFlag = &cli.DurationFlag{
+		Name:    "challenger.proof-duration-estimate",
+		Usage:   "Estimated duration of a fault proof generation, used to alert the challenges at risk until any proof is generated",
+		EnvVars: prefixEnvVars("CHALLENGER_PROOF_DURATION_ESTIMATE"),
+		Value:   time.Hour,
+	}
+	RewardPolicyEnabledFlag = &cli.BoolFlag{
+		Name:    "reward-policy.enabled",
+		Usage:   "Enable the reward policy to compound the rewards, keep the bond available and sweep the excess stake",
+		EnvVars: prefixEnvVars("REWARD_POLICY_ENABLED"),
+	}
+	RewardPolicyIntervalFlag = &cli.DurationFlag{
+		Name:    "reward-policy.interval",
+		Usage:   "Interval to apply the reward policy",
+		EnvVars: prefixEnvVars("REWARD_POLICY_INTERVAL"),
+		Value:   10 * time.Minute,
+	}
+	RewardPolicyCompoundThresholdFlag = &cli.StringFlag{
+		Name:    "reward-policy.compound-threshold",
+		Usage:   "Min amount of asset tokens (in wei) held by the validator to claim the KGH rewards and deposit them as stake. When sweeping, the swept stake and the tokens held at the start are not compounded. 0 disables compounding",
+		EnvVars: prefixEnvVars("REWARD_POLICY_COMPOUND_THRESHOLD"),
+		Value:   "0",
+	}
+	RewardPolicySweepThresholdFlag = &cli.StringFlag{
+		Name:    "reward-policy.sweep-threshold",
+		Usage:   "Min amount of stake (in wei) above the min stake to withdraw to the withdraw account. 0 disables sweeping",
+		EnvVars: prefixEnvVars("REWARD_POLICY_SWEEP_THRESHOLD"),
+		Value:   "0",
+	}
+	RewardPolicyMinStakeFlag = &cli.StringFlag{
+		Name:    "reward-policy.min-stake",
+		Usage:   "Amount of stake (in wei) to be kept when sweeping",
+		EnvVars: prefixEnvVars("REWARD_POLICY_MIN_STAKE"),
+		Value:   "0",
+	}
+	RewardPolicyBondReserveFlag = &cli.Uint64Flag{
+		Name:    "reward-policy.bond-reserve",
+		Usage:   "Number of bonds to keep available for the output submissions, topped up from the asset tokens held by the validator. 0 disables topping up",
+		EnvVars: prefixEnvVars("REWARD_POLICY_BOND_RESERVE"),
+		Value:   1,
+	}
 	GuardianEnabledFlag = &cli.BoolFlag{
 		Name:    "guardian.enabled",
 		Usage:   "Enable guardian",
@@ -150,8 +217,52 @@ var (
 		EnvVars: prefixEnvVars("GUARDIAN_POLL_INTERVAL"),
 		Value:   time.Minute,
 	}
+	GuardianPrivateKeyFlag = &cli.StringFlag{
+		Name:    "guardian.private-key",
+		Usage:   "The private key to sign the guardian transactions with. If no guardian key is set, the validator key is used",
+		EnvVars: prefixEnvVars("GUARDIAN_PRIVATE_KEY"),
+	}
+	GuardianMnemonicFlag = &cli.StringFlag{
+		Name:    "guardian.mnemonic",
+		Usage:   "The mnemonic used to derive the guardian wallet. Must not be used with guardian.private-key",
+		EnvVars: prefixEnvVars("GUARDIAN_MNEMONIC"),
+	}
+	GuardianHDPathFlag = &cli.StringFlag{
+		Name:    "guardian.hd-path",
+		Usage:   "The HD path used to derive the guardian wallet from the guardian mnemonic",
+		EnvVars: prefixEnvVars("GUARDIAN_HD_PATH"),
+	}
+	GuardianSignerEndpointFlag = &cli.StringFlag{
+		Name:    "guardian.signer.endpoint",
+		Usage:   "Remote signer endpoint to sign the guardian transactions with",
+		EnvVars: prefixEnvVars("GUARDIAN_SIGNER_ENDPOINT"),
+	}
+	GuardianSignerAddressFlag = &cli.StringFlag{
+		Name:    "guardian.signer.address",
+		Usage:   "Address the guardian remote signer is signing transactions for",
+		EnvVars: prefixEnvVars("GUARDIAN_SIGNER_ADDRESS"),
+	}
+	DataDirFlag = &cli.StringFlag{
+		Name:    "data-dir",
+		Usage:   "Directory to persist the validator state such as in-flight challenges. If empty, the state is kept only in memory",
+		EnvVars: prefixEnvVars("DATA_DIR"),
+	}
+	ChainsConfigFlag = &cli.StringFlag{
+		Name: "chains-config",
+		Usage: "Path to the JSON file listing the chain deployments to run against in a single process. " +
+			"If set, the chain specific flags such as the L2 RPCs and the contract addresses are read from the file",
+		EnvVars: prefixEnvVars("CHAINS_CONFIG"),
+	}
+	DryRunFlag = &cli.BoolFlag{
+		Name:    "dry-run",
+		Usage:   "Build, sign and simulate the transactions against L1 without broadcasting them",
+		EnvVars: prefixEnvVars("DRY_RUN"),
+	}
 )
 
+// GuardianSignerFlagPrefix is the prefix of the TLS flags of the guardian remote signer.
+const GuardianSignerFlagPrefix = "guardian.signer"
+
 var requiredFlags = []cli.Flag{
 	L1EthRpcFlag,
 	L2EthRpcFlag,
@@ -171,13 +282,46 @@ var optionalFlags = []cli.Flag{
 	OutputSubmitterRetryIntervalFlag,
 	OutputSubmitterRoundBufferFlag,
 	OutputSubmitterAllowPublicRoundFlag,
+	OutputSubmitterAttestFlag,
+	ProverFlag,
 	ZkEVMProverRPCFlag,
 	ZkEVMNetworkTimeoutFlag,
 	ZkVMProverRPCFlag,
 	WitnessGeneratorRPCFlag,
+	ProverCallTimeoutFlag,
+	ProverHealthCheckIntervalFlag,
+	ProofDurationEstimateFlag,
+	RewardPolicyEnabledFlag,
+	RewardPolicyIntervalFlag,
+	RewardPolicyCompoundThresholdFlag,
+	RewardPolicySweepThresholdFlag,
+	RewardPolicyMinStakeFlag,
+	RewardPolicyBondReserveFlag,
 	GuardianEnabledFlag,
 	SecurityCouncilAddressFlag,
 	GuardianPollIntervalFlag,
+	GuardianPrivateKeyFlag,
+	GuardianMnemonicFlag,
+	GuardianHDPathFlag,
+	GuardianSignerEndpointFlag,
+	GuardianSignerAddressFlag,
+	DataDirFlag,
+	ChainsConfigFlag,
+	DryRunFlag,
+}
+
+// chainFlags are the required flags specific to a chain deployment, which are read from the chains config instead
+// if it is set.
+var chainFlags = []cli.Flag{
+	L2EthRpcFlag,
+	RollupRpcFlag,
+	L2OOAddressFlag,
+	ColosseumAddressFlag,
+	ValPoolAddressFlag,
+	ValMgrAddressFlag,
+	AssetManagerAddressFlag,
+	OutputSubmitterEnabledFlag,
+	ChallengerEnabledFlag,
 }
 
 func init() {
@@ -186,6 +330,7 @@ func init() {
 	optionalFlags = append(optionalFlags, opmetrics.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, oppprof.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, txmgr.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, optls.CLIFlagsWithFlagPrefix(EnvVarPrefix+"_GUARDIAN_SIGNER", GuardianSignerFlagPrefix)...)
 
 	Flags = append(requiredFlags, optionalFlags...)
 }
@@ -194,7 +339,11 @@ func init() {
 var Flags []cli.Flag
 
 func CheckRequired(ctx *cli.Context) error {
+	multiChain := ctx.IsSet(ChainsConfigFlag.Name)
 	for _, f := range requiredFlags {
+		if multiChain && slices.Contains(chainFlags, f) {
+			continue
+		}
 		if !ctx.IsSet(f.Names()[0]) {
 			return fmt.Errorf("flag %s is required", f.Names()[0])
 		}
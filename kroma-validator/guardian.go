@@ -7,10 +7,12 @@ import (
 	"math/big"
 	_ "net/http/pprof"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/optsutils"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum-optimism/optimism/op-service/watcher"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -22,6 +24,9 @@ import (
 
 	"github.com/kroma-network/kroma/kroma-bindings/bindings"
 	"github.com/kroma-network/kroma/kroma-validator/challenge"
+	"github.com/kroma-network/kroma/kroma-validator/metrics"
+	"github.com/kroma-network/kroma/kroma-validator/rpc"
+	"github.com/kroma-network/kroma/kroma-validator/store"
 )
 
 // Guardian is responsible for validating outputs.
@@ -31,6 +36,11 @@ type Guardian struct {
 	ctx    context.Context
 	cancel context.CancelFunc
 	wg     sync.WaitGroup
+	store  store.Store
+
+	txMgr    *RoleTxManager
+	txSender *txSender
+	metr     metrics.Metricer
 
 	l2ooContract            *bindings.L2OutputOracle
 	securityCouncilContract *bindings.SecurityCouncil
@@ -50,17 +60,23 @@ type Guardian struct {
 	deletionRequestedChan   chan *bindings.SecurityCouncilDeletionRequested
 	challengeCreatedChan    chan *bindings.ColosseumChallengeCreated
 
+	// mu guards the checkpoint against the reads from RPC.
+	mu         sync.RWMutex
 	checkpoint *big.Int
+	paused     atomic.Bool
+
+	// inspectedCheckpoint stores the checkpoint up to the outputs whose inspection has completed.
+	inspectedCheckpoint *outputCheckpoint
 }
 
 // NewGuardian creates a new Guardian.
-func NewGuardian(cfg Config, l log.Logger) (*Guardian, error) {
-	securityCouncilContract, err := bindings.NewSecurityCouncil(cfg.SecurityCouncilAddr, cfg.L1Client)
+func NewGuardian(cfg Config, l log.Logger, m metrics.Metricer) (*Guardian, error) {
+	securityCouncilContract, err := bindings.NewSecurityCouncil(cfg.SecurityCouncilAddr, cfg.L1Backend())
 	if err != nil {
 		return nil, err
 	}
 
-	l2ooContract, err := bindings.NewL2OutputOracle(cfg.L2OutputOracleAddr, cfg.L1Client)
+	l2ooContract, err := bindings.NewL2OutputOracle(cfg.L2OutputOracleAddr, cfg.L1Backend())
 	if err != nil {
 		return nil, err
 	}
@@ -70,14 +86,37 @@ func NewGuardian(cfg Config, l log.Logger) (*Guardian, error) {
 		return nil, err
 	}
 
-	colosseumContract, err := bindings.NewColosseum(cfg.ColosseumAddr, cfg.L1Client)
+	colosseumContract, err := bindings.NewColosseum(cfg.ColosseumAddr, cfg.L1Backend())
+	if err != nil {
+		return nil, err
+	}
+
+	guardianStore := cfg.Store
+	if guardianStore == nil {
+		guardianStore = store.Disabled
+	}
+
+	log := l.New("service", "guardian")
+	var txMgr *RoleTxManager
+	if cfg.GuardianRoleTxManager != nil {
+		// the guardian tx manager is shared by the guardians of multiple chains, to rotate the signer of all of them
+		txMgr = cfg.GuardianRoleTxManager
+	} else {
+		txMgr = NewRoleTxManager(rpc.RoleGuardian, log, m, cfg.TxManager, cfg.GuardianTxManager)
+	}
+	txSender, err := newRoleTxSender(cfg, log, m, txMgr)
 	if err != nil {
 		return nil, err
 	}
 
 	return &Guardian{
-		log:                     l.New("service", "guardian"),
+		log:                     log,
 		cfg:                     cfg,
+		store:                   guardianStore,
+		inspectedCheckpoint:     newOutputCheckpoint(store.CheckpointGuardian, guardianStore, log),
+		txMgr:                   txMgr,
+		txSender:                txSender,
+		metr:                    m,
 		securityCouncilContract: securityCouncilContract,
 		l2ooContract:            l2ooContract,
 		colosseumContract:       colosseumContract,
@@ -87,7 +126,7 @@ func NewGuardian(cfg Config, l log.Logger) (*Guardian, error) {
 }
 
 func (g *Guardian) InitConfig(ctx context.Context) error {
-	contractWatcher := watcher.NewContractWatcher(ctx, g.cfg.L1Client, g.log)
+	contractWatcher := watcher.NewContractWatcher(ctx, g.cfg.L1Backend(), g.log)
 
 	err := contractWatcher.WatchUpgraded(g.cfg.L2OutputOracleAddr, func() error {
 		cCtx, cCancel := context.WithTimeout(ctx, g.cfg.NetworkTimeout)
@@ -133,6 +172,12 @@ func (g *Guardian) InitConfig(ctx context.Context) error {
 func (g *Guardian) Start(ctx context.Context) error {
 	g.ctx, g.cancel = context.WithCancel(ctx)
 
+	if g.cfg.GuardianRoleTxManager == nil {
+		if err := g.txMgr.Start(g.ctx); err != nil {
+			return err
+		}
+	}
+
 	if err := g.InitConfig(g.ctx); err != nil {
 		return err
 	}
@@ -162,7 +207,10 @@ func (g *Guardian) Stop() error {
 	close(g.deletionRequestedChan)
 	close(g.challengeCreatedChan)
 
-	return nil
+	if g.cfg.GuardianRoleTxManager != nil {
+		return nil
+	}
+	return g.txMgr.Stop()
 }
 
 func (g *Guardian) initSub() {
@@ -193,6 +241,74 @@ func (g *Guardian) initSub() {
 	})
 }
 
+// Pause stops inspecting outputs and processing requests until Resume is called.
+// Events are still watched while paused, so that they are processed after resuming.
+func (g *Guardian) Pause() {
+	g.paused.Store(true)
+	g.log.Info("guardian paused")
+}
+
+// Resume resumes inspecting outputs and processing requests.
+func (g *Guardian) Resume() {
+	g.paused.Store(false)
+	g.log.Info("guardian resumed")
+}
+
+func (g *Guardian) Paused() bool {
+	return g.paused.Load()
+}
+
+// Signer returns the address that the guardian currently signs the transactions with.
+func (g *Guardian) Signer() common.Address {
+	return g.txMgr.From()
+}
+
+// RotateSigner switches the guardian to sign the transactions with the given remote signer.
+// The transactions in flight are sent with the previous signer, while the new ones are sent with the given signer.
+// The signer is not switched if the given signer has no votes in SecurityCouncil.
+// If the chains share L1, the guardians of all the chains are switched.
+func (g *Guardian) RotateSigner(ctx context.Context, endpoint string, address common.Address) error {
+	txMgrConfig := g.cfg.GuardianTxMgrConfig
+	txMgrConfig.PrivateKey = ""
+	txMgrConfig.Mnemonic = ""
+	txMgrConfig.HDPath = ""
+	txMgrConfig.SequencerHDPath = ""
+	txMgrConfig.L2OutputHDPath = ""
+	txMgrConfig.SignerCLIConfig.Endpoint = endpoint
+	txMgrConfig.SignerCLIConfig.Address = address.Hex()
+
+	cCtx, cCancel := context.WithTimeout(ctx, g.cfg.NetworkTimeout)
+	defer cCancel()
+	votes, err := g.securityCouncilContract.GetVotes(optsutils.NewSimpleCallOpts(cCtx), address)
+	if err != nil {
+		return fmt.Errorf("failed to get votes of the new signer: %w", err)
+	}
+	// a signer without votes could not confirm any transaction, so the current signer is kept
+	if votes.Sign() == 0 {
+		return fmt.Errorf("new guardian signer %s has no votes in SecurityCouncil", address)
+	}
+
+	txMgr, err := txmgr.NewBufferedTxManager("guardian", g.log, g.metr, txMgrConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create guardian tx manager: %w", err)
+	}
+	if err := g.txMgr.Rotate(txMgr); err != nil {
+		txMgr.Close()
+		return err
+	}
+	return nil
+}
+
+// Checkpoint returns the last inspected output index, or nil if it has not been set yet.
+func (g *Guardian) Checkpoint() *big.Int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if g.checkpoint == nil {
+		return nil
+	}
+	return new(big.Int).Set(g.checkpoint)
+}
+
 // scanPrevChallenges scans all the previous challenges before current L1 head within the finalization window to handle challenger timeout.
 func (g *Guardian) scanPrevChallenges() {
 	ticker := time.NewTicker(g.cfg.GuardianPollInterval)
@@ -255,6 +371,10 @@ func (g *Guardian) scanPrevChallenges() {
 func (g *Guardian) inspectorLoop() {
 	defer g.wg.Done()
 
+	resumeCheckpoint := loadCheckpoint(g.ctx, g.cfg, g.store, store.CheckpointGuardian, g.log)
+	// the outputs are dispatched for inspection before the checkpoint advances past them
+	g.inspectedCheckpoint.start()
+
 	ticker := time.NewTicker(g.cfg.GuardianPollInterval)
 	defer ticker.Stop()
 
@@ -263,6 +383,10 @@ func (g *Guardian) inspectorLoop() {
 		case <-g.ctx.Done():
 			return
 		default:
+			if g.Paused() {
+				continue
+			}
+
 			status, err := g.cfg.RollupClient.SyncStatus(g.ctx)
 			if err != nil {
 				g.log.Error("failed to get sync status", "err", err)
@@ -303,6 +427,16 @@ func (g *Guardian) inspectorLoop() {
 						return
 					}
 
+					// resume from the stored checkpoint if it is within the finalization window
+					if resumeCheckpoint != nil {
+						if nextOutputIndex := new(big.Int).Add(resumeCheckpoint.OutputIndex, common.Big1); nextOutputIndex.Cmp(startOutputIndex) >= 0 {
+							startOutputIndex = nextOutputIndex
+						} else {
+							g.log.Info("stored checkpoint is older than the finalization window, scanning all outputs",
+								"checkpoint", resumeCheckpoint.OutputIndex, "startOutputIndex", startOutputIndex)
+						}
+					}
+
 					cCtx, cCancel = context.WithTimeout(g.ctx, g.cfg.NetworkTimeout)
 					defer cCancel()
 					endOutputIndex, err := g.l2ooContract.GetL2OutputIndexAfter(optsutils.NewSimpleCallOpts(cCtx), creationEndedL2)
@@ -312,11 +446,10 @@ func (g *Guardian) inspectorLoop() {
 					}
 
 					for i := startOutputIndex; i.Cmp(endOutputIndex) < 0; i.Add(i, common.Big1) {
-						g.wg.Add(1)
-						go g.inspectOutput(new(big.Int).Set(i), new(big.Int).Set(finalizedL1), new(big.Int).Set(headL1))
+						g.startInspectOutput(new(big.Int).Set(i), new(big.Int).Set(finalizedL1), new(big.Int).Set(headL1))
 					}
 
-					g.checkpoint = endOutputIndex.Sub(endOutputIndex, common.Big1)
+					g.setCheckpoint(endOutputIndex.Sub(endOutputIndex, common.Big1), status.HeadL1.ID())
 				}()
 			} else {
 				func() {
@@ -329,21 +462,42 @@ func (g *Guardian) inspectorLoop() {
 					}
 
 					for i := new(big.Int).Add(g.checkpoint, common.Big1); i.Cmp(outputIndex) < 0; i.Add(i, common.Big1) {
-						g.wg.Add(1)
-						go g.inspectOutput(new(big.Int).Set(i), new(big.Int).Set(finalizedL1), new(big.Int).Set(headL1))
+						g.startInspectOutput(new(big.Int).Set(i), new(big.Int).Set(finalizedL1), new(big.Int).Set(headL1))
 					}
 
-					g.checkpoint = outputIndex.Sub(outputIndex, common.Big1)
+					g.setCheckpoint(outputIndex.Sub(outputIndex, common.Big1), status.HeadL1.ID())
 				}()
 			}
 		}
 	}
 }
 
+// setCheckpoint sets the checkpoint computed at the given L1 block. It is persisted once the inspection of
+// the outputs up to it has completed.
+func (g *Guardian) setCheckpoint(outputIndex *big.Int, l1Block eth.BlockID) {
+	g.mu.Lock()
+	g.checkpoint = outputIndex
+	g.mu.Unlock()
+	g.inspectedCheckpoint.advance(outputIndex, l1Block)
+}
+
+// startInspectOutput inspects the output in the background, holding back the stored checkpoint until it completes.
+func (g *Guardian) startInspectOutput(outputIndex, fromBlock, toBlock *big.Int) {
+	g.inspectedCheckpoint.begin(outputIndex)
+	g.wg.Add(1)
+	go g.inspectOutput(outputIndex, fromBlock, toBlock)
+}
+
 // inspectOutput inspects if the output fails zk fault proof due to an undeniable bug.
 func (g *Guardian) inspectOutput(outputIndex, fromBlock, toBlock *big.Int) {
 	g.log.Info("inspect output if there is an undeniable bug", "outputIndex", outputIndex)
 	defer g.wg.Done()
+	defer func() {
+		// an output whose inspection is interrupted by a stop stays uninspected, to be inspected again after a restart
+		if g.ctx.Err() == nil {
+			g.inspectedCheckpoint.done(outputIndex)
+		}
+	}()
 
 	ticker := time.NewTicker(g.cfg.GuardianPollInterval)
 	defer ticker.Stop()
@@ -353,6 +507,10 @@ func (g *Guardian) inspectOutput(outputIndex, fromBlock, toBlock *big.Int) {
 		case <-g.ctx.Done():
 			return
 		default:
+			if g.Paused() {
+				continue
+			}
+
 			inGuardianPeriod, retry, err := g.isInGuardianPeriod(outputIndex)
 			if err != nil {
 				g.log.Error("unable to fetch if output is in guardian period", "err", err, "outputIndex", outputIndex)
@@ -382,7 +540,7 @@ func (g *Guardian) inspectOutput(outputIndex, fromBlock, toBlock *big.Int) {
 				continue
 			}
 
-			if txResponse := g.cfg.TxManager.SendTransaction(g.ctx, tx); txResponse.Err != nil {
+			if txResponse := g.txSender.SendTransaction(g.ctx, tx); txResponse.Err != nil {
 				g.log.Error("failed to send deletion request tx", "err", txResponse.Err, "outputIndex", outputIndex)
 				continue
 			}
@@ -428,6 +586,10 @@ func (g *Guardian) processOutputValidation(event *bindings.SecurityCouncilValida
 		case <-g.ctx.Done():
 			return
 		default:
+			if g.Paused() {
+				continue
+			}
+
 			if err := g.tryConfirmRequestValidationTx(event); err != nil {
 				g.log.Error("failed to create confirmation tx for output validation request", "err", err, "transactionId", event.TransactionId.String())
 				continue
@@ -452,6 +614,10 @@ func (g *Guardian) processOutputDeletion(event *bindings.SecurityCouncilDeletion
 		case <-g.ctx.Done():
 			return
 		default:
+			if g.Paused() {
+				continue
+			}
+
 			if err := g.tryConfirmRequestDeletionTx(event); err != nil {
 				g.log.Error("failed to create confirmation tx for output deletion request", "err", err, "transactionId", event.TransactionId.String())
 				continue
@@ -477,6 +643,10 @@ func (g *Guardian) processChallengerTimeout(event *bindings.ColosseumChallengeCr
 		case <-g.ctx.Done():
 			return
 		default:
+			if g.Paused() {
+				continue
+			}
+
 			inGuardianPeriod, retry, err := g.isInGuardianPeriod(outputIndex)
 			if err != nil {
 				g.log.Error("unable to fetch if output in guardian period", "err", err, "outputIndex", outputIndex)
@@ -531,7 +701,7 @@ func (g *Guardian) tryConfirmRequestValidationTx(event *bindings.SecurityCouncil
 			return fmt.Errorf("failed to create confirm tx. (transactionId: %d): %w", event.TransactionId.Int64(), err)
 		}
 
-		if txResponse := g.cfg.TxManager.SendTransaction(g.ctx, tx); txResponse.Err != nil {
+		if txResponse := g.txSender.SendTransaction(g.ctx, tx); txResponse.Err != nil {
 			return fmt.Errorf("failed to send confirm tx. (transactionId: %d): %w", event.TransactionId.Int64(), txResponse.Err)
 		}
 	} else {
@@ -573,7 +743,7 @@ func (g *Guardian) tryConfirmRequestDeletionTx(event *bindings.SecurityCouncilDe
 		return fmt.Errorf("failed to create confirm tx. (transactionId: %d): %w", event.TransactionId.Int64(), err)
 	}
 
-	if txResponse := g.cfg.TxManager.SendTransaction(g.ctx, tx); txResponse.Err != nil {
+	if txResponse := g.txSender.SendTransaction(g.ctx, tx); txResponse.Err != nil {
 		return fmt.Errorf("failed to send confirm tx. (transactionId: %d): %w", event.TransactionId.Int64(), txResponse.Err)
 	}
 
@@ -655,7 +825,7 @@ func (g *Guardian) tryChallengerTimeoutTx(outputIndex *big.Int, challenger commo
 			return true, fmt.Errorf("failed to create challenger timeout tx: %w", err)
 		}
 
-		if txResponse := g.cfg.TxManager.SendTransaction(g.ctx, tx); txResponse.Err != nil {
+		if txResponse := g.txSender.SendTransaction(g.ctx, tx); txResponse.Err != nil {
 			return true, fmt.Errorf("failed to send challenger timeout tx: %w", txResponse.Err)
 		}
 
@@ -722,19 +892,22 @@ func (g *Guardian) ValidateL2Output(ctx context.Context, outputRoot eth.Bytes32,
 
 func (g *Guardian) ConfirmTransaction(ctx context.Context, transactionId *big.Int) (*types.Transaction, error) {
 	g.log.Info("crafting confirm tx", "transactionId", transactionId)
-	txOpts := optsutils.NewSimpleTxOpts(ctx, g.cfg.TxManager.From(), g.cfg.TxManager.Signer)
+	txMgr := g.txMgr.TxManager()
+	txOpts := optsutils.NewSimpleTxOpts(ctx, txMgr.From(), txMgr.Signer)
 	return g.securityCouncilContract.ConfirmTransaction(txOpts, transactionId)
 }
 
 func (g *Guardian) RequestDeletion(ctx context.Context, outputIndex *big.Int) (*types.Transaction, error) {
 	g.log.Info("crafting requestDeletion tx", "outputIndex", outputIndex)
-	txOpts := optsutils.NewSimpleTxOpts(ctx, g.cfg.TxManager.From(), g.cfg.TxManager.Signer)
+	txMgr := g.txMgr.TxManager()
+	txOpts := optsutils.NewSimpleTxOpts(ctx, txMgr.From(), txMgr.Signer)
 	return g.securityCouncilContract.RequestDeletion(txOpts, outputIndex, false)
 }
 
 func (g *Guardian) challengerTimeout(ctx context.Context, outputIndex *big.Int, challenger common.Address) (*types.Transaction, error) {
 	g.log.Info("crafting challenger timeout tx", "outputIndex", outputIndex, "challenger", challenger)
-	txOpts := optsutils.NewSimpleTxOpts(ctx, g.cfg.TxManager.From(), g.cfg.TxManager.Signer)
+	txMgr := g.txMgr.TxManager()
+	txOpts := optsutils.NewSimpleTxOpts(ctx, txMgr.From(), txMgr.Signer)
 	return g.colosseumContract.ChallengerTimeout(txOpts, outputIndex, challenger)
 }
 
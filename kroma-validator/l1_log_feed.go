@@ -0,0 +1,267 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// logFeedSubBufferSize is the number of logs buffered for each subscription of the L1LogFeed.
+const logFeedSubBufferSize = 128
+
+// ErrLogFeedSubLagging is reported to the subscription of the L1LogFeed dropped for not consuming its logs in time.
+var ErrLogFeedSubLagging = errors.New("L1 log subscription fell behind")
+
+// L1LogFeed subscribes to the logs of the given L1 contracts once, and fans them out to the log subscriptions of the
+// validators of multiple chains, instead of each validator role subscribing to L1 on its own.
+type L1LogFeed struct {
+	log       log.Logger
+	client    *ethclient.Client
+	addresses []common.Address
+	covered   map[common.Address]struct{}
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	sub    event.Subscription
+	logs   chan types.Log
+
+	mu   sync.Mutex
+	subs map[*logFeedSub]struct{}
+}
+
+// NewL1LogFeed creates a new L1LogFeed for the logs of the given contracts.
+func NewL1LogFeed(l log.Logger, client *ethclient.Client, addresses []common.Address) *L1LogFeed {
+	covered := make(map[common.Address]struct{}, len(addresses))
+	var unique []common.Address
+	for _, addr := range addresses {
+		if _, ok := covered[addr]; ok || addr == (common.Address{}) {
+			continue
+		}
+		covered[addr] = struct{}{}
+		unique = append(unique, addr)
+	}
+
+	return &L1LogFeed{
+		log:       l.New("service", "l1_log_feed"),
+		client:    client,
+		addresses: unique,
+		covered:   covered,
+		subs:      make(map[*logFeedSub]struct{}),
+	}
+}
+
+func (f *L1LogFeed) Start(ctx context.Context) error {
+	f.ctx, f.cancel = context.WithCancel(ctx)
+	f.logs = make(chan types.Log, 128)
+	f.sub = event.ResubscribeErr(time.Second*10, func(ctx context.Context, err error) (event.Subscription, error) {
+		if err != nil {
+			f.log.Warn("resubscribing after failed L1 log subscription", "err", err)
+			// the logs may have been missed until resubscribed, so the subscribers are to resubscribe and catch up
+			f.terminateSubs(fmt.Errorf("L1 log subscription failed: %w", err))
+		}
+		return f.client.SubscribeFilterLogs(ctx, ethereum.FilterQuery{Addresses: f.addresses}, f.logs)
+	})
+
+	f.wg.Add(1)
+	go f.loop()
+
+	f.log.Info("L1 log feed started", "contracts", len(f.addresses))
+	return nil
+}
+
+func (f *L1LogFeed) Stop() error {
+	f.sub.Unsubscribe()
+	f.cancel()
+	f.wg.Wait()
+	f.terminateSubs(nil)
+	return nil
+}
+
+func (f *L1LogFeed) loop() {
+	defer f.wg.Done()
+
+	for {
+		select {
+		case l := <-f.logs:
+			f.dispatch(l)
+		case <-f.ctx.Done():
+			return
+		}
+	}
+}
+
+// dispatch buffers the log for every subscription matching it. A subscriber whose buffer is full is dropped with
+// ErrLogFeedSubLagging, so that it does not hold up the other subscribers.
+func (f *L1LogFeed) dispatch(l types.Log) {
+	for _, s := range f.snapshotSubs() {
+		if !s.matches(l) {
+			continue
+		}
+		select {
+		case s.buf <- l:
+		default:
+			f.log.Warn("dropping L1 log subscriber falling behind", "addresses", s.query.Addresses)
+			s.terminate(ErrLogFeedSubLagging)
+		}
+	}
+}
+
+// terminateSubs terminates every subscription with the given error.
+func (f *L1LogFeed) terminateSubs(err error) {
+	for _, s := range f.snapshotSubs() {
+		s.terminate(err)
+	}
+}
+
+func (f *L1LogFeed) snapshotSubs() []*logFeedSub {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	subs := make([]*logFeedSub, 0, len(f.subs))
+	for s := range f.subs {
+		subs = append(subs, s)
+	}
+	return subs
+}
+
+// SubscribeFilterLogs implements ethereum.LogFilterer. The queries on the contracts out of the feed, or on the past
+// blocks are subscribed to L1 directly.
+func (f *L1LogFeed) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	if q.FromBlock != nil || q.ToBlock != nil || q.BlockHash != nil || !f.covers(q.Addresses) {
+		return f.client.SubscribeFilterLogs(ctx, q, ch)
+	}
+
+	s := &logFeedSub{
+		feed:  f,
+		query: q,
+		ch:    ch,
+		buf:   make(chan types.Log, logFeedSubBufferSize),
+		quit:  make(chan struct{}),
+		err:   make(chan error, 1),
+	}
+	f.mu.Lock()
+	f.subs[s] = struct{}{}
+	f.mu.Unlock()
+	go s.forward()
+	return s, nil
+}
+
+func (f *L1LogFeed) covers(addresses []common.Address) bool {
+	if len(addresses) == 0 {
+		return false
+	}
+	for _, addr := range addresses {
+		if _, ok := f.covered[addr]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// logFeedSub is a log subscription served by the L1LogFeed. The logs are buffered, and forwarded to the channel of
+// the subscriber at its own pace.
+type logFeedSub struct {
+	feed  *L1LogFeed
+	query ethereum.FilterQuery
+	ch    chan<- types.Log
+	buf   chan types.Log
+	quit  chan struct{}
+	err   chan error
+
+	terminated   sync.Once
+	unsubscribed sync.Once
+}
+
+// forward forwards the buffered logs to the subscriber until the subscription is terminated.
+func (s *logFeedSub) forward() {
+	for {
+		select {
+		case l := <-s.buf:
+			select {
+			case s.ch <- l:
+			case <-s.quit:
+				return
+			}
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// terminate removes the subscription from the feed and stops forwarding the logs. The error, if any, is reported
+// through Err.
+func (s *logFeedSub) terminate(err error) {
+	s.terminated.Do(func() {
+		s.feed.mu.Lock()
+		delete(s.feed.subs, s)
+		s.feed.mu.Unlock()
+		if err != nil {
+			s.err <- err
+		}
+		close(s.quit)
+	})
+}
+
+func (s *logFeedSub) Unsubscribe() {
+	s.terminate(nil)
+	s.unsubscribed.Do(func() {
+		close(s.err)
+	})
+}
+
+func (s *logFeedSub) Err() <-chan error {
+	return s.err
+}
+
+// matches returns whether the log matches the address and the topics of the query.
+func (s *logFeedSub) matches(l types.Log) bool {
+	addressMatched := false
+	for _, addr := range s.query.Addresses {
+		if addr == l.Address {
+			addressMatched = true
+			break
+		}
+	}
+	if !addressMatched {
+		return false
+	}
+
+	if len(s.query.Topics) > len(l.Topics) {
+		return false
+	}
+	for i, candidates := range s.query.Topics {
+		if len(candidates) == 0 {
+			continue
+		}
+		topicMatched := false
+		for _, topic := range candidates {
+			if topic == l.Topics[i] {
+				topicMatched = true
+				break
+			}
+		}
+		if !topicMatched {
+			return false
+		}
+	}
+	return true
+}
+
+// l1Backend is the contract backend of L1, whose log subscriptions are served by the L1LogFeed.
+type l1Backend struct {
+	*ethclient.Client
+	feed *L1LogFeed
+}
+
+func (b *l1Backend) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return b.feed.SubscribeFilterLogs(ctx, q, ch)
+}
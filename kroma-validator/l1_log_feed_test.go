@@ -0,0 +1,90 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestLogFeedSubMatches(t *testing.T) {
+	addr := common.HexToAddress("0x01")
+	topic := common.HexToHash("0x02")
+	s := &logFeedSub{
+		query: ethereum.FilterQuery{Addresses: []common.Address{addr}, Topics: [][]common.Hash{{topic}}},
+	}
+
+	require.True(t, s.matches(types.Log{Address: addr, Topics: []common.Hash{topic, common.HexToHash("0x03")}}))
+	require.False(t, s.matches(types.Log{Address: common.HexToAddress("0x04"), Topics: []common.Hash{topic}}))
+	require.False(t, s.matches(types.Log{Address: addr, Topics: []common.Hash{common.HexToHash("0x03")}}))
+	require.False(t, s.matches(types.Log{Address: addr}))
+}
+
+func TestLogFeedDropsLaggingSub(t *testing.T) {
+	addr := common.HexToAddress("0x01")
+	f := NewL1LogFeed(testlog.Logger(t, log.LevelInfo), nil, []common.Address{addr})
+	q := ethereum.FilterQuery{Addresses: []common.Address{addr}}
+
+	lagging := make(chan types.Log)
+	laggingSub, err := f.SubscribeFilterLogs(context.Background(), q, lagging)
+	require.NoError(t, err)
+	defer laggingSub.Unsubscribe()
+	active := make(chan types.Log, 2*logFeedSubBufferSize)
+	activeSub, err := f.SubscribeFilterLogs(context.Background(), q, active)
+	require.NoError(t, err)
+	defer activeSub.Unsubscribe()
+
+	// the lagging subscriber holds one log being forwarded, and its buffer overflows afterward,
+	// while the active subscriber keeps up
+	drained := func(sub ethereum.Subscription) func() bool {
+		return func() bool { return len(sub.(*logFeedSub).buf) == 0 }
+	}
+	for i := 0; i < logFeedSubBufferSize+2; i++ {
+		f.dispatch(types.Log{Address: addr, Index: uint(i)})
+		if i == 0 {
+			require.Eventually(t, drained(laggingSub), time.Second, time.Millisecond)
+		}
+		require.Eventually(t, drained(activeSub), time.Second, time.Millisecond)
+	}
+
+	select {
+	case err := <-laggingSub.Err():
+		require.ErrorIs(t, err, ErrLogFeedSubLagging)
+	case <-time.After(time.Second):
+		t.Fatal("lagging subscriber is not reported")
+	}
+	require.Len(t, f.snapshotSubs(), 1)
+
+	f.dispatch(types.Log{Address: addr, Index: logFeedSubBufferSize + 2})
+	require.Eventually(t, func() bool { return len(active) == logFeedSubBufferSize+3 }, time.Second, time.Millisecond)
+}
+
+func TestLogFeedForwardsUpstreamError(t *testing.T) {
+	addr := common.HexToAddress("0x01")
+	f := NewL1LogFeed(testlog.Logger(t, log.LevelInfo), nil, []common.Address{addr})
+	q := ethereum.FilterQuery{Addresses: []common.Address{addr}}
+
+	var subs []ethereum.Subscription
+	for i := 0; i < 2; i++ {
+		sub, err := f.SubscribeFilterLogs(context.Background(), q, make(chan types.Log))
+		require.NoError(t, err)
+		defer sub.Unsubscribe()
+		subs = append(subs, sub)
+	}
+
+	upstreamErr := errors.New("connection lost")
+	f.terminateSubs(upstreamErr)
+
+	for _, sub := range subs {
+		require.ErrorIs(t, <-sub.Err(), upstreamErr)
+	}
+	require.Empty(t, f.snapshotSubs())
+}
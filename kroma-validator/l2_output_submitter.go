@@ -8,25 +8,35 @@ import (
 	"math/big"
 	_ "net/http/pprof"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/optsutils"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum-optimism/optimism/op-service/watcher"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/kroma-network/kroma/kroma-bindings/bindings"
 	"github.com/kroma-network/kroma/kroma-validator/metrics"
+	"github.com/kroma-network/kroma/kroma-validator/rpc"
 )
 
 const (
 	roundNums      = 2
 	publicRoundHex = "0xffffffffffffffffffffffffffffffffffffffff"
+
+	// outputAttestationPublishAttempts and outputAttestationPublishRetryDelay bound the retries of publishing an output
+	// attestation, covering the time for the rollup node to check the validator on L1.
+	outputAttestationPublishAttempts   = 5
+	outputAttestationPublishRetryDelay = 10 * time.Second
 )
 
 var PublicRoundAddress = common.HexToAddress(publicRoundHex)
@@ -36,9 +46,10 @@ type L2OutputSubmitter struct {
 	ctx    context.Context
 	cancel context.CancelFunc
 
-	cfg  Config
-	log  log.Logger
-	metr metrics.Metricer
+	cfg      Config
+	log      log.Logger
+	metr     metrics.Metricer
+	txSender *txSender
 
 	l2OOContract     *bindings.L2OutputOracleCaller
 	l2OOABI          *abi.ABI
@@ -53,6 +64,7 @@ type L2OutputSubmitter struct {
 	valPoolTerminationIndex *big.Int
 
 	submitChan chan struct{}
+	paused     atomic.Bool
 
 	wg sync.WaitGroup
 }
@@ -84,10 +96,17 @@ func NewL2OutputSubmitter(cfg Config, l log.Logger, m metrics.Metricer) (*L2Outp
 		return nil, err
 	}
 
+	log := l.New("service", "submitter")
+	txSender, err := newTxSender(cfg, log, m)
+	if err != nil {
+		return nil, err
+	}
+
 	return &L2OutputSubmitter{
 		cfg:              cfg,
-		log:              l.New("service", "submitter"),
+		log:              log,
 		metr:             m,
+		txSender:         txSender,
 		l2OOContract:     l2OOContract,
 		l2OOABI:          parsedL2OOAbi,
 		valPoolContract:  valPoolContract,
@@ -97,7 +116,7 @@ func NewL2OutputSubmitter(cfg Config, l log.Logger, m metrics.Metricer) (*L2Outp
 }
 
 func (l *L2OutputSubmitter) InitConfig(ctx context.Context) error {
-	contractWatcher := watcher.NewContractWatcher(ctx, l.cfg.L1Client, l.log)
+	contractWatcher := watcher.NewContractWatcher(ctx, l.cfg.L1Backend(), l.log)
 
 	err := contractWatcher.WatchUpgraded(l.cfg.L2OutputOracleAddr, func() error {
 		cCtx, cCancel := context.WithTimeout(ctx, l.cfg.NetworkTimeout)
@@ -187,6 +206,22 @@ func (l *L2OutputSubmitter) Stop() error {
 	return nil
 }
 
+// Pause stops submitting outputs until Resume is called.
+func (l *L2OutputSubmitter) Pause() {
+	l.paused.Store(true)
+	l.log.Info("output submitter paused")
+}
+
+// Resume resumes submitting outputs.
+func (l *L2OutputSubmitter) Resume() {
+	l.paused.Store(false)
+	l.log.Info("output submitter resumed")
+}
+
+func (l *L2OutputSubmitter) Paused() bool {
+	return l.paused.Load()
+}
+
 func (l *L2OutputSubmitter) loop() {
 	defer l.wg.Done()
 
@@ -223,6 +258,11 @@ func (l *L2OutputSubmitter) repeatSubmitL2Output(ctx context.Context) {
 func (l *L2OutputSubmitter) trySubmitL2Output(ctx context.Context) (time.Duration, error) {
 	defaultWaitTime := l.cfg.OutputSubmitterRetryInterval
 
+	if l.Paused() {
+		l.log.Debug("output submitter is paused, skipping submission")
+		return defaultWaitTime, nil
+	}
+
 	nextBlockNumber, err := l.FetchNextBlockNumber(ctx)
 	if err != nil {
 		return defaultWaitTime, err
@@ -247,6 +287,11 @@ func (l *L2OutputSubmitter) trySubmitL2Output(ctx context.Context) (time.Duratio
 		return defaultWaitTime, err
 	}
 
+	// the output is not submitted actually in dry-run mode, so wait not to simulate the same submission repeatedly.
+	if l.cfg.DryRun {
+		return defaultWaitTime, nil
+	}
+
 	// successfully submitted. start next loop immediately.
 	return 0, nil
 }
@@ -267,13 +312,45 @@ func (l *L2OutputSubmitter) doSubmitL2Output(ctx context.Context, nextBlockNumbe
 		return txResponse.Err
 	}
 
+	if l.cfg.DryRun {
+		return nil
+	}
+
 	// Successfully submitted
 	l.log.Info("L2output successfully submitted", "blockNumber", output.BlockRef.Number)
 	l.metr.RecordL2OutputSubmitted(output.BlockRef)
+	if l.cfg.OutputAttestationSigner != nil {
+		l.wg.Add(1)
+		go l.publishOutputAttestation(ctx, output)
+	}
 	// go to try next submission immediately
 	return nil
 }
 
+// publishOutputAttestation signs the submitted output root, and publishes it to the output attestations gossip topic
+// of the rollup node, so that the nodes can detect the validators diverging from each other. The rollup node does not
+// publish the attestation until it knows the validator is active, so it is retried for a while. A failure does not
+// affect the submission.
+func (l *L2OutputSubmitter) publishOutputAttestation(ctx context.Context, output *eth.OutputResponse) {
+	defer l.wg.Done()
+
+	att, err := p2p.SignOutputAttestation(ctx, l.cfg.RollupConfig, l.cfg.OutputAttestationSigner, output.BlockRef.Number, output.OutputRoot)
+	if err != nil {
+		l.log.Warn("failed to sign output attestation", "blockNumber", output.BlockRef.Number, "err", err)
+		return
+	}
+	_, err = retry.Do(ctx, outputAttestationPublishAttempts, retry.Fixed(outputAttestationPublishRetryDelay), func() (bool, error) {
+		cCtx, cCancel := context.WithTimeout(ctx, l.cfg.NetworkTimeout)
+		defer cCancel()
+		return true, l.cfg.RollupClient.PublishOutputAttestation(cCtx, att)
+	})
+	if err != nil {
+		l.log.Warn("failed to publish output attestation", "blockNumber", output.BlockRef.Number, "err", err)
+		return
+	}
+	l.log.Info("output attestation published", "blockNumber", output.BlockRef.Number, "outputRoot", output.OutputRoot)
+}
+
 // CalculateWaitTime checks the conditions for submitting L2Output and calculates the required latency.
 // Returns time 0 if the conditions are such that submission is possible immediately.
 func (l *L2OutputSubmitter) CalculateWaitTime(ctx context.Context, nextBlockNumber *big.Int, outputIndex *big.Int) time.Duration {
@@ -509,6 +586,42 @@ func (l *L2OutputSubmitter) fetchCurrentRound(ctx context.Context, outputIndex *
 	return ri, nil
 }
 
+// FetchRoundInfo fetches the submission round of the next output without recording it.
+func (l *L2OutputSubmitter) FetchRoundInfo(ctx context.Context) (*rpc.RoundInfo, error) {
+	nextBlockNumber, err := l.FetchNextBlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	outputIndex, err := l.FetchNextOutputIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cCtx, cCancel := context.WithTimeout(ctx, l.cfg.NetworkTimeout)
+	defer cCancel()
+	nextValidator, err := l.getNextValidatorAddress(cCtx, outputIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch next validator: %w", err)
+	}
+
+	ri := roundInfo{
+		isPublicRound:       nextValidator == PublicRoundAddress,
+		isPriorityValidator: nextValidator == l.cfg.TxManager.From(),
+		canJoinPublicRound:  l.cfg.OutputSubmitterAllowPublicRound,
+	}
+
+	return &rpc.RoundInfo{
+		OutputIndex:         hexutil.Uint64(outputIndex.Uint64()),
+		NextBlockNumber:     hexutil.Uint64(nextBlockNumber.Uint64()),
+		NextValidator:       nextValidator,
+		IsPublicRound:       ri.isPublicRound,
+		IsPriorityValidator: ri.isPriorityValidator,
+		CanJoinPublicRound:  ri.canJoinPublicRound,
+		CanJoinRound:        ri.canJoinRound(),
+	}, nil
+}
+
 // getNextValidatorAddress selects the appropriate contract and retrieves the next validator address.
 func (l *L2OutputSubmitter) getNextValidatorAddress(ctx context.Context, outputIndex *big.Int) (common.Address, error) {
 	opts := optsutils.NewSimpleCallOpts(ctx)
@@ -625,7 +738,7 @@ func (l *L2OutputSubmitter) submitL2OutputTx(data []byte, outputIndex *big.Int)
 		}
 	}
 
-	return l.cfg.TxManager.SendTxCandidate(l.ctx, &txmgr.TxCandidate{
+	return l.txSender.SendTxCandidate(l.ctx, &txmgr.TxCandidate{
 		TxData:     data,
 		To:         to,
 		GasLimit:   estimatedGas * 3 / 2,
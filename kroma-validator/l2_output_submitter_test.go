@@ -0,0 +1,63 @@
+package validator
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/p2p"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// fakeAttestationsAPI records the output attestations published to the rollup node.
+type fakeAttestationsAPI struct {
+	published []*eth.OutputAttestation
+}
+
+func (f *fakeAttestationsAPI) PublishOutputAttestation(_ context.Context, att *eth.OutputAttestation) error {
+	f.published = append(f.published, att)
+	return nil
+}
+
+func TestPublishOutputAttestation(t *testing.T) {
+	api := &fakeAttestationsAPI{}
+	srv := gethrpc.NewServer()
+	require.NoError(t, srv.RegisterName("kroma", api))
+	defer srv.Stop()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	rollupCfg := &rollup.Config{L2ChainID: big.NewInt(901)}
+	l := &L2OutputSubmitter{
+		cfg: Config{
+			RollupClient:            sources.NewRollupClient(client.NewBaseRPCClient(gethrpc.DialInProc(srv))),
+			RollupConfig:            rollupCfg,
+			OutputAttestationSigner: p2p.NewLocalSigner(key),
+			NetworkTimeout:          time.Second,
+		},
+		log: testlog.Logger(t, log.LevelInfo),
+	}
+
+	output := &eth.OutputResponse{
+		OutputRoot: eth.Bytes32{0x01},
+		BlockRef:   eth.L2BlockRef{Number: 1800},
+	}
+	l.wg.Add(1)
+	l.publishOutputAttestation(context.Background(), output)
+
+	require.Len(t, api.published, 1)
+	att := api.published[0]
+	require.EqualValues(t, 1800, att.L2BlockNumber)
+	require.Equal(t, output.OutputRoot, att.OutputRoot)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), att.Validator)
+}
@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"math/big"
+
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ChainIDLabel is the label of the chain ID, attached to every metric of the validator running against multiple chains.
+const ChainIDLabel = "chain_id"
+
+// NewChainMetrics creates the metrics of a chain in the registry shared by multiple chains.
+// Every metric is labeled with the given chain ID, so that the metrics of the chains can be distinguished.
+func NewChainMetrics(procName string, registry *prometheus.Registry, chainID *big.Int) *Metrics {
+	return newMetrics(procName, registry, &chainFactory{
+		Factory: opmetrics.With(registry),
+		labels:  prometheus.Labels{ChainIDLabel: chainID.String()},
+	})
+}
+
+// chainFactory creates the metrics with the constant labels of a chain.
+type chainFactory struct {
+	opmetrics.Factory
+	labels prometheus.Labels
+}
+
+func (f *chainFactory) constLabels(labels prometheus.Labels) prometheus.Labels {
+	merged := make(prometheus.Labels, len(labels)+len(f.labels))
+	for k, v := range labels {
+		merged[k] = v
+	}
+	for k, v := range f.labels {
+		merged[k] = v
+	}
+	return merged
+}
+
+func (f *chainFactory) NewCounter(opts prometheus.CounterOpts) prometheus.Counter {
+	opts.ConstLabels = f.constLabels(opts.ConstLabels)
+	return f.Factory.NewCounter(opts)
+}
+
+func (f *chainFactory) NewCounterVec(opts prometheus.CounterOpts, labelNames []string) *prometheus.CounterVec {
+	opts.ConstLabels = f.constLabels(opts.ConstLabels)
+	return f.Factory.NewCounterVec(opts, labelNames)
+}
+
+func (f *chainFactory) NewGauge(opts prometheus.GaugeOpts) prometheus.Gauge {
+	opts.ConstLabels = f.constLabels(opts.ConstLabels)
+	return f.Factory.NewGauge(opts)
+}
+
+func (f *chainFactory) NewGaugeVec(opts prometheus.GaugeOpts, labelNames []string) *prometheus.GaugeVec {
+	opts.ConstLabels = f.constLabels(opts.ConstLabels)
+	return f.Factory.NewGaugeVec(opts, labelNames)
+}
+
+func (f *chainFactory) NewHistogram(opts prometheus.HistogramOpts) prometheus.Histogram {
+	opts.ConstLabels = f.constLabels(opts.ConstLabels)
+	return f.Factory.NewHistogram(opts)
+}
+
+func (f *chainFactory) NewHistogramVec(opts prometheus.HistogramOpts, labelNames []string) *prometheus.HistogramVec {
+	opts.ConstLabels = f.constLabels(opts.ConstLabels)
+	return f.Factory.NewHistogramVec(opts, labelNames)
+}
+
+func (f *chainFactory) NewSummary(opts prometheus.SummaryOpts) prometheus.Summary {
+	opts.ConstLabels = f.constLabels(opts.ConstLabels)
+	return f.Factory.NewSummary(opts)
+}
+
+func (f *chainFactory) NewSummaryVec(opts prometheus.SummaryOpts, labelNames []string) *prometheus.SummaryVec {
+	opts.ConstLabels = f.constLabels(opts.ConstLabels)
+	return f.Factory.NewSummaryVec(opts, labelNames)
+}
@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"math/big"
+	"testing"
+
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainMetricsSharedRegistry(t *testing.T) {
+	registry := opmetrics.NewRegistry()
+	m1 := NewChainMetrics("default", registry, big.NewInt(255))
+	m2 := NewChainMetrics("default", registry, big.NewInt(2358))
+
+	m1.RecordValidatorStatus(1)
+	m2.RecordValidatorStatus(5)
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	values := make(map[string]float64)
+	for _, family := range families {
+		if family.GetName() != Namespace+"_default_validator_status" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == ChainIDLabel {
+					values[label.GetValue()] = metric.GetGauge().GetValue()
+				}
+			}
+		}
+	}
+	require.Equal(t, map[string]float64{"255": 1, "2358": 5}, values)
+}
@@ -3,6 +3,7 @@ package metrics
 import (
 	"context"
 	"math/big"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
@@ -17,6 +18,10 @@ import (
 const (
 	Namespace         = "kroma_validator"
 	L2OutputSubmitted = "submitted"
+
+	ValidatorKroWallet    = "wallet"
+	ValidatorKroTotal     = "total"
+	ValidatorKroNotBonded = "not_bonded"
 )
 
 type Metricer interface {
@@ -29,11 +34,24 @@ type Metricer interface {
 	// Record Tx metrics
 	txmetrics.TxMetricer
 
+	// Record RPC metrics
+	opmetrics.RPCMetricer
+
 	RecordL2OutputSubmitted(l2ref eth.L2BlockRef)
 	RecordUnbondedDepositAmount(amount *big.Int)
 	RecordValidatorStatus(status uint8)
 	RecordNextValidator(address common.Address)
 	RecordChallengeCheckpoint(outputIndex *big.Int)
+	RecordDryRunTx(method string, success bool)
+	RecordProverRequest(service string, endpoint string, method string, success bool, duration time.Duration)
+	RecordProverEndpointHealth(service string, endpoint string, healthy bool)
+	RecordChallengeDeadline(outputIndex *big.Int, challenger common.Address, remaining time.Duration)
+	RecordChallengeAtRisk(outputIndex *big.Int, challenger common.Address, atRisk bool)
+	ClearChallengeDeadline(outputIndex *big.Int, challenger common.Address)
+	RecordProofDuration(duration time.Duration)
+	RecordRewardPolicyAction(action string, success bool)
+	RecordValidatorKro(kind string, amount *big.Int)
+	RecordRoleSigner(role string, address common.Address)
 }
 
 type Metrics struct {
@@ -51,19 +69,31 @@ type Metrics struct {
 	ValidatorStatus       prometheus.Gauge
 	NextValidator         prometheus.GaugeVec
 	ChallengeCheckpoint   prometheus.Gauge
+	DryRunTxs             prometheus.CounterVec
+	ProverRequests        prometheus.CounterVec
+	ProverRequestDuration prometheus.HistogramVec
+	ProverEndpointHealthy prometheus.GaugeVec
+	ChallengeDeadline     prometheus.GaugeVec
+	ChallengeAtRisk       prometheus.GaugeVec
+	ProofDuration         prometheus.Histogram
+	RewardPolicyActions   prometheus.CounterVec
+	ValidatorKro          prometheus.GaugeVec
+	RoleSigner            prometheus.GaugeVec
 }
 
 var _ Metricer = (*Metrics)(nil)
 
 func NewMetrics(procName string) *Metrics {
+	registry := opmetrics.NewRegistry()
+	return newMetrics(procName, registry, opmetrics.With(registry))
+}
+
+func newMetrics(procName string, registry *prometheus.Registry, factory opmetrics.Factory) *Metrics {
 	if procName == "" {
 		procName = "default"
 	}
 	ns := Namespace + "_" + procName
 
-	registry := opmetrics.NewRegistry()
-	factory := opmetrics.With(registry)
-
 	return &Metrics{
 		ns:       ns,
 		registry: registry,
@@ -107,6 +137,87 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "challenge_checkpoint",
 			Help:      "The output index that the challenge function last checked",
 		}),
+		DryRunTxs: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "dry_run_txs_total",
+			Help:      "Number of transactions simulated in dry-run mode instead of being broadcast",
+		}, []string{
+			"method",
+			"result",
+		}),
+		ProverRequests: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "prover_requests_total",
+			Help:      "Number of requests to the prover endpoints",
+		}, []string{
+			"service",
+			"endpoint",
+			"method",
+			"result",
+		}),
+		ProverRequestDuration: *factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "prover_request_duration_seconds",
+			Help:      "Duration of requests to the prover endpoints",
+			Buckets:   []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60, 300, 900, 3600},
+		}, []string{
+			"service",
+			"endpoint",
+			"method",
+		}),
+		ProverEndpointHealthy: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "prover_endpoint_healthy",
+			Help:      "1 if the prover endpoint is healthy, 0 otherwise",
+		}, []string{
+			"service",
+			"endpoint",
+		}),
+		ChallengeDeadline: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "challenge_deadline_remaining_seconds",
+			Help:      "Remaining seconds until the deadline of the current turn of the challenge",
+		}, []string{
+			"output_index",
+			"challenger",
+		}),
+		ChallengeAtRisk: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "challenge_at_risk",
+			Help:      "1 if the projected proof generation is not expected to complete before the deadline of the challenge",
+		}, []string{
+			"output_index",
+			"challenger",
+		}),
+		ProofDuration: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "proof_generation_duration_seconds",
+			Help:      "Duration from requesting a fault proof to its completion",
+			Buckets:   []float64{60, 300, 600, 1200, 1800, 3600, 7200, 10800, 14400, 21600, 43200},
+		}),
+		RewardPolicyActions: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "reward_policy_actions_total",
+			Help:      "Number of transactions sent by the reward policy",
+		}, []string{
+			"action",
+			"result",
+		}),
+		ValidatorKro: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "validator_kro",
+			Help:      "The amount of KRO of the validator, held by the validator account or deposited to the AssetManager contract",
+		}, []string{
+			"type",
+		}),
+		RoleSigner: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "role_signer",
+			Help:      "The address that each validator role currently signs the transactions with",
+		}, []string{
+			"role",
+			"address",
+		}),
 	}
 }
 
@@ -161,3 +272,76 @@ func (m *Metrics) RecordNextValidator(address common.Address) {
 func (m *Metrics) RecordChallengeCheckpoint(outputIndex *big.Int) {
 	m.ChallengeCheckpoint.Set(float64(outputIndex.Uint64()))
 }
+
+// RecordDryRunTx increments the number of transactions simulated in dry-run mode.
+func (m *Metrics) RecordDryRunTx(method string, success bool) {
+	result := "success"
+	if !success {
+		result = "failed"
+	}
+	m.DryRunTxs.WithLabelValues(method, result).Inc()
+}
+
+// RecordProverRequest records the result and the duration of a request to a prover endpoint.
+func (m *Metrics) RecordProverRequest(service string, endpoint string, method string, success bool, duration time.Duration) {
+	result := "success"
+	if !success {
+		result = "failed"
+	}
+	m.ProverRequests.WithLabelValues(service, endpoint, method, result).Inc()
+	m.ProverRequestDuration.WithLabelValues(service, endpoint, method).Observe(duration.Seconds())
+}
+
+// RecordProverEndpointHealth sets the health of a prover endpoint.
+func (m *Metrics) RecordProverEndpointHealth(service string, endpoint string, healthy bool) {
+	var v float64
+	if healthy {
+		v = 1
+	}
+	m.ProverEndpointHealthy.WithLabelValues(service, endpoint).Set(v)
+}
+
+// RecordChallengeDeadline sets the remaining time until the deadline of the current turn of the challenge.
+func (m *Metrics) RecordChallengeDeadline(outputIndex *big.Int, challenger common.Address, remaining time.Duration) {
+	m.ChallengeDeadline.WithLabelValues(outputIndex.String(), challenger.Hex()).Set(remaining.Seconds())
+}
+
+// RecordChallengeAtRisk sets whether the proof of the challenge is not expected to be generated before the deadline.
+func (m *Metrics) RecordChallengeAtRisk(outputIndex *big.Int, challenger common.Address, atRisk bool) {
+	var v float64
+	if atRisk {
+		v = 1
+	}
+	m.ChallengeAtRisk.WithLabelValues(outputIndex.String(), challenger.Hex()).Set(v)
+}
+
+// ClearChallengeDeadline removes the deadline metrics of the challenge that is no longer handled.
+func (m *Metrics) ClearChallengeDeadline(outputIndex *big.Int, challenger common.Address) {
+	m.ChallengeDeadline.DeleteLabelValues(outputIndex.String(), challenger.Hex())
+	m.ChallengeAtRisk.DeleteLabelValues(outputIndex.String(), challenger.Hex())
+}
+
+// RecordProofDuration records the duration of a completed proof generation.
+func (m *Metrics) RecordProofDuration(duration time.Duration) {
+	m.ProofDuration.Observe(duration.Seconds())
+}
+
+// RecordRewardPolicyAction increments the number of transactions sent by the reward policy.
+func (m *Metrics) RecordRewardPolicyAction(action string, success bool) {
+	result := "success"
+	if !success {
+		result = "failed"
+	}
+	m.RewardPolicyActions.WithLabelValues(action, result).Inc()
+}
+
+// RecordValidatorKro sets the amount of KRO of the validator by the given type.
+func (m *Metrics) RecordValidatorKro(kind string, amount *big.Int) {
+	m.ValidatorKro.WithLabelValues(kind).Set(opmetrics.WeiToEther(amount))
+}
+
+// RecordRoleSigner sets the address that the given role currently signs the transactions with.
+func (m *Metrics) RecordRoleSigner(role string, address common.Address) {
+	m.RoleSigner.DeletePartialMatch(prometheus.Labels{"role": role})
+	m.RoleSigner.WithLabelValues(role, address.String()).Set(1)
+}
@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 
@@ -13,6 +14,7 @@ import (
 type noopMetrics struct {
 	opmetrics.NoopRefMetrics
 	txmetrics.NoopTxMetrics
+	opmetrics.NoopRPCMetrics
 }
 
 var NoopMetrics Metricer = new(noopMetrics)
@@ -25,3 +27,19 @@ func (*noopMetrics) RecordUnbondedDepositAmount(amount *big.Int)    {}
 func (*noopMetrics) RecordValidatorStatus(status uint8)             {}
 func (*noopMetrics) RecordNextValidator(address common.Address)     {}
 func (*noopMetrics) RecordChallengeCheckpoint(outputIndex *big.Int) {}
+func (*noopMetrics) RecordDryRunTx(method string, success bool)     {}
+
+func (*noopMetrics) RecordProverRequest(service string, endpoint string, method string, success bool, duration time.Duration) {
+}
+func (*noopMetrics) RecordProverEndpointHealth(service string, endpoint string, healthy bool) {}
+
+func (*noopMetrics) RecordChallengeDeadline(outputIndex *big.Int, challenger common.Address, remaining time.Duration) {
+}
+func (*noopMetrics) RecordChallengeAtRisk(outputIndex *big.Int, challenger common.Address, atRisk bool) {
+}
+func (*noopMetrics) ClearChallengeDeadline(outputIndex *big.Int, challenger common.Address) {}
+func (*noopMetrics) RecordProofDuration(duration time.Duration)                             {}
+
+func (*noopMetrics) RecordRewardPolicyAction(action string, success bool) {}
+func (*noopMetrics) RecordValidatorKro(kind string, amount *big.Int)      {}
+func (*noopMetrics) RecordRoleSigner(role string, address common.Address) {}
@@ -0,0 +1,242 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/dial"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/monitoring"
+	"github.com/ethereum-optimism/optimism/op-service/opio"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/kroma-network/kroma/kroma-validator/flags"
+	"github.com/kroma-network/kroma/kroma-validator/metrics"
+	"github.com/kroma-network/kroma/kroma-validator/rpc"
+)
+
+// chainValidator is the validator of a chain run by mainMultiChain.
+type chainValidator struct {
+	name      string
+	log       log.Logger
+	validator *Validator
+	server    *oprpc.Server
+	// started is whether the validator is started successfully.
+	started bool
+}
+
+// mainMultiChain runs the validators of the chains in the chains config in a single process. The validators share
+// the L1 client, the tx managers and the L1 log subscription, and the metrics of each chain are labeled with its
+// L2 chain ID.
+func mainMultiChain(version string, cliCtx *cli.Context, cfg CLIConfig) error {
+	chainsCfg, err := LoadChainsConfig(cfg.ChainsConfig)
+	if err != nil {
+		return err
+	}
+
+	chainCfgs := make([]CLIConfig, len(chainsCfg.Chains))
+	guardianEnabled := false
+	for i, chain := range chainsCfg.Chains {
+		chainCfgs[i] = cfg.ForChain(chain)
+		if err := chainCfgs[i].Check(); err != nil {
+			return fmt.Errorf("invalid config of chain %s: %w", chain.Name, err)
+		}
+		guardianEnabled = guardianEnabled || chain.GuardianEnabled
+	}
+	contracts, err := chainsCfg.Contracts()
+	if err != nil {
+		return err
+	}
+
+	l := oplog.NewLogger(oplog.AppOut(cliCtx), cfg.LogConfig)
+	oplog.SetGlobalLogHandler(l.Handler())
+	opservice.ValidateEnvVars(flags.EnvVarPrefix, flags.Flags, l)
+	l.Info("initializing Validator", "chains", len(chainsCfg.Chains))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l1ChainID, err := fetchL1ChainID(ctx, l, cfg.L1EthRpc)
+	if err != nil {
+		return err
+	}
+	registry := opmetrics.NewRegistry()
+	m := metrics.NewChainMetrics("default", registry, l1ChainID)
+
+	shared, err := NewSharedL1(cfg, guardianEnabled, l, m)
+	if err != nil {
+		l.Error("Unable to create shared L1 resources", "err", err)
+		return err
+	}
+	defer shared.Client.Close()
+	shared.LogFeed = NewL1LogFeed(l, shared.Client, contracts)
+	if guardianEnabled {
+		shared.GuardianRoleTxManager = NewRoleTxManager(rpc.RoleGuardian, l.New("service", "guardian"), m,
+			shared.TxManager, shared.GuardianTxManager)
+	}
+
+	chains := make([]*chainValidator, 0, len(chainCfgs))
+	running := false
+	defer func() {
+		for _, chain := range chains {
+			if chain.server == nil {
+				continue
+			}
+			if err := chain.server.Stop(); err != nil {
+				chain.log.Error("Error shutting down http server", "err", err)
+			}
+		}
+	}()
+	defer func() {
+		if running {
+			return
+		}
+		// a chain failed before all validators are running, so the validators already started are stopped, and the
+		// resources of the ones not started yet are released. The one failed to start is left as it may be partially
+		// started.
+		for _, chain := range chains {
+			if chain.started || chain.validator.cancel == nil {
+				if err := chain.validator.Stop(); err != nil {
+					chain.log.Error("failed to stop validator", "err", err)
+				}
+			}
+		}
+	}()
+	for i, chainCfg := range chainCfgs {
+		name := chainsCfg.Chains[i].Name
+		chainLog := l.New("chain", name)
+
+		l2ChainID, err := fetchL2ChainID(ctx, chainLog, chainCfg.RollupRpc)
+		if err != nil {
+			return fmt.Errorf("chain %s: %w", name, err)
+		}
+		if l2ChainID.Cmp(new(big.Int).SetUint64(chainsCfg.Chains[i].L2ChainID)) != 0 {
+			return fmt.Errorf("chain %s: l2 chain id %d is given, but the rollup RPC serves chain %d", name,
+				chainsCfg.Chains[i].L2ChainID, l2ChainID)
+		}
+		chainMetrics := metrics.NewChainMetrics("default", registry, l2ChainID)
+
+		validatorCfg, err := NewChainValidatorConfig(chainCfg, chainLog, chainMetrics, shared)
+		if err != nil {
+			chainLog.Error("Unable to create validator config", "err", err)
+			return err
+		}
+		validator, err := NewValidator(*validatorCfg, chainLog, chainMetrics)
+		if err != nil {
+			return fmt.Errorf("chain %s: %w", name, err)
+		}
+
+		chain := &chainValidator{name: name, log: chainLog, validator: validator}
+		if chainCfg.RPCConfig.ListenPort != 0 {
+			chain.server, err = startRPC(chainCfg.RPCConfig, version, validator, chainLog, chainMetrics)
+			if err != nil {
+				return fmt.Errorf("chain %s: %w", name, err)
+			}
+		}
+		chains = append(chains, chain)
+
+		chainMetrics.RecordInfo(version)
+		chainMetrics.RecordUp()
+	}
+
+	monitoring.MaybeStartPprof(ctx, cfg.PprofConfig, l)
+	monitoring.MaybeStartMetrics(ctx, cfg.MetricsConfig, l, m, shared.Client, shared.TxManager.From())
+	m.RecordInfo(version)
+	m.RecordUp()
+
+	if err := shared.TxManager.Start(ctx); err != nil {
+		return fmt.Errorf("cannot start tx manager: %w", err)
+	}
+	if shared.GuardianRoleTxManager != nil {
+		if err := shared.GuardianRoleTxManager.Start(ctx); err != nil {
+			return err
+		}
+	}
+	if err := shared.LogFeed.Start(ctx); err != nil {
+		return fmt.Errorf("cannot start L1 log feed: %w", err)
+	}
+
+	// Validator.Start blocks until the L2 of the chain is synced, so the validators are started concurrently.
+	errs := make(chan error, len(chains))
+	for _, chain := range chains {
+		go func(chain *chainValidator) {
+			if err := chain.validator.Start(); err != nil {
+				chain.log.Error("failed to start validator", "err", err)
+				errs <- fmt.Errorf("chain %s: %w", chain.name, err)
+				return
+			}
+			chain.started = true
+			errs <- nil
+		}(chain)
+	}
+	var startErr error
+	for range chains {
+		startErr = errors.Join(startErr, <-errs)
+	}
+	if startErr != nil {
+		return startErr
+	}
+	running = true
+
+	opio.BlockOnInterrupts()
+
+	var stopErr error
+	for _, chain := range chains {
+		if err := chain.validator.Stop(); err != nil {
+			chain.log.Error("failed to stop validator", "err", err)
+			stopErr = errors.Join(stopErr, fmt.Errorf("chain %s: %w", chain.name, err))
+		}
+	}
+	if err := shared.LogFeed.Stop(); err != nil {
+		stopErr = errors.Join(stopErr, fmt.Errorf("failed to stop L1 log feed: %w", err))
+	}
+	if shared.GuardianRoleTxManager != nil {
+		if err := shared.GuardianRoleTxManager.Stop(); err != nil {
+			stopErr = errors.Join(stopErr, fmt.Errorf("failed to stop guardian tx manager: %w", err))
+		}
+	}
+	if err := shared.TxManager.Stop(); err != nil {
+		stopErr = errors.Join(stopErr, fmt.Errorf("failed to stop tx manager: %w", err))
+	}
+	return stopErr
+}
+
+// fetchL1ChainID fetches the chain ID of L1 to label the metrics shared by the chains.
+func fetchL1ChainID(ctx context.Context, l log.Logger, url string) (*big.Int, error) {
+	client, err := dial.DialEthClientWithTimeout(ctx, dial.DefaultDialTimeout, l, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial L1 RPC: %w", err)
+	}
+	defer client.Close()
+
+	cCtx, cCancel := context.WithTimeout(ctx, dial.DefaultDialTimeout)
+	defer cCancel()
+	chainID, err := client.ChainID(cCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch L1 chain ID: %w", err)
+	}
+	return chainID, nil
+}
+
+// fetchL2ChainID fetches the L2 chain ID from the rollup config to label the metrics of the chain.
+func fetchL2ChainID(ctx context.Context, l log.Logger, url string) (*big.Int, error) {
+	client, err := dial.DialRollupClientWithTimeout(ctx, dial.DefaultDialTimeout, l, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial rollup RPC: %w", err)
+	}
+	defer client.Close()
+
+	cCtx, cCancel := context.WithTimeout(ctx, dial.DefaultDialTimeout)
+	defer cCancel()
+	rollupCfg, err := client.RollupConfig(cCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rollup config: %w", err)
+	}
+	return rollupCfg.L2ChainID, nil
+}
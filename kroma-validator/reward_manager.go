@@ -0,0 +1,516 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/optsutils"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum-optimism/optimism/op-service/watcher"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/kroma-network/kroma/kroma-bindings/bindings"
+	"github.com/kroma-network/kroma/kroma-validator/metrics"
+)
+
+const (
+	RewardActionClaim    = "claim"
+	RewardActionApprove  = "approve"
+	RewardActionDeposit  = "deposit"
+	RewardActionWithdraw = "withdraw"
+)
+
+// rewardState is the asset state of the validator that the reward policy is applied to.
+type rewardState struct {
+	// walletBalance is the amount of asset tokens held by the validator account.
+	walletBalance *big.Int
+	// totalKro is the amount of KRO deposited by the validator, including the accumulated rewards.
+	totalKro *big.Int
+	// notBonded is the amount of KRO deposited by the validator, which is not bonded to the submitted outputs.
+	notBonded *big.Int
+	// canWithdraw is whether the withdrawal is possible now.
+	canWithdraw bool
+	// swept is the part of the wallet balance which has been swept from the stake. It is not compounded, otherwise
+	// the swept KRO would be deposited again.
+	swept *big.Int
+}
+
+// rewardPlan is the amounts of KRO to deposit and withdraw, computed by the reward policy.
+type rewardPlan struct {
+	deposit  *big.Int
+	withdraw *big.Int
+}
+
+// rewardPolicy decides how the rewards of the validator are handled.
+type rewardPolicy struct {
+	// bondTarget is the amount of KRO which should not be bonded to cover the next output submissions.
+	bondTarget *big.Int
+	// compoundThreshold is the min wallet balance to deposit as stake. Zero disables compounding.
+	compoundThreshold *big.Int
+	// sweepThreshold is the min amount above the min stake to withdraw. Zero disables sweeping.
+	sweepThreshold *big.Int
+	// minStake is the amount of KRO to be kept deposited when sweeping.
+	minStake *big.Int
+}
+
+// plan computes the amounts to deposit and withdraw for the given state.
+// The wallet balance except the swept KRO is deposited when it exceeds the compound threshold, otherwise only the
+// shortfall of the bond target is deposited. The stake above both the bond target and the min stake is withdrawn when it exceeds the
+// sweep threshold.
+func (p *rewardPolicy) plan(s *rewardState) *rewardPlan {
+	result := &rewardPlan{
+		deposit:  new(big.Int),
+		withdraw: new(big.Int),
+	}
+
+	compoundable := new(big.Int).Sub(s.walletBalance, s.swept)
+	if p.compoundThreshold.Sign() > 0 && compoundable.Cmp(p.compoundThreshold) >= 0 {
+		result.deposit.Set(compoundable)
+	} else if s.notBonded.Cmp(p.bondTarget) < 0 {
+		shortfall := new(big.Int).Sub(p.bondTarget, s.notBonded)
+		result.deposit = minBig(shortfall, s.walletBalance)
+	}
+
+	if p.sweepThreshold.Sign() > 0 && s.canWithdraw && result.deposit.Sign() == 0 {
+		excess := minBig(
+			new(big.Int).Sub(s.notBonded, p.bondTarget),
+			new(big.Int).Sub(s.totalKro, p.minStake),
+		)
+		if excess.Cmp(p.sweepThreshold) >= 0 {
+			result.withdraw = excess
+		}
+	}
+
+	return result
+}
+
+func minBig(a, b *big.Int) *big.Int {
+	if a.Cmp(b) < 0 {
+		return new(big.Int).Set(a)
+	}
+	return new(big.Int).Set(b)
+}
+
+// rewardTxSender sends the transactions of the reward policy.
+type rewardTxSender interface {
+	SendTxCandidate(ctx context.Context, candidate *txmgr.TxCandidate) *txmgr.TxResponse
+}
+
+// RewardManager periodically applies the reward policy to the validator, which compounds the rewards into stake,
+// keeps the stake available for the output submission bonds and sweeps the excess stake to the withdraw account.
+type RewardManager struct {
+	log    log.Logger
+	cfg    Config
+	metr   metrics.Metricer
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	txSender rewardTxSender
+
+	// challenges tracks the challenges involving the validator on-chain.
+	challenges *challengeTracker
+
+	assetManagerContract *bindings.AssetManagerCaller
+	assetManagerABI      *abi.ABI
+	valMgrContract       *bindings.ValidatorManagerCaller
+	l2ooContract         *bindings.L2OutputOracleCaller
+	erc20ABI             *abi.ABI
+
+	assetTokenContract *bindings.ERC20Caller
+	assetTokenAddr     common.Address
+	policy             *rewardPolicy
+	// sweepEnabled is whether the validator account is its own withdraw account, which is the only one able to withdraw.
+	sweepEnabled bool
+	// swept is the amount of KRO swept to the validator account and still held by it. It is nil until the first state
+	// is fetched, when the wallet balance at the start is regarded as swept, since it cannot be told apart after a
+	// restart.
+	swept *big.Int
+
+	paused atomic.Bool
+}
+
+// NewRewardManager creates a new RewardManager.
+func NewRewardManager(cfg Config, l log.Logger, m metrics.Metricer) (*RewardManager, error) {
+	assetManagerContract, err := bindings.NewAssetManagerCaller(cfg.AssetManagerAddr, cfg.L1Client)
+	if err != nil {
+		return nil, err
+	}
+
+	assetManagerABI, err := bindings.AssetManagerMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	valMgrContract, err := bindings.NewValidatorManagerCaller(cfg.ValidatorManagerAddr, cfg.L1Client)
+	if err != nil {
+		return nil, err
+	}
+
+	l2ooContract, err := bindings.NewL2OutputOracleCaller(cfg.L2OutputOracleAddr, cfg.L1Client)
+	if err != nil {
+		return nil, err
+	}
+
+	erc20ABI, err := bindings.ERC20MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	log := l.New("service", "reward_manager")
+	txSender, err := newTxSender(cfg, log, m)
+	if err != nil {
+		return nil, err
+	}
+
+	challenges, err := newChallengeTracker(cfg, log)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RewardManager{
+		log:                  log,
+		cfg:                  cfg,
+		metr:                 m,
+		txSender:             txSender,
+		challenges:           challenges,
+		assetManagerContract: assetManagerContract,
+		assetManagerABI:      assetManagerABI,
+		valMgrContract:       valMgrContract,
+		l2ooContract:         l2ooContract,
+		erc20ABI:             erc20ABI,
+	}, nil
+}
+
+func (r *RewardManager) InitConfig(ctx context.Context) error {
+	contractWatcher := watcher.NewContractWatcher(ctx, r.cfg.L1Backend(), r.log)
+
+	err := contractWatcher.WatchUpgraded(r.cfg.AssetManagerAddr, func() error {
+		cCtx, cCancel := context.WithTimeout(ctx, r.cfg.NetworkTimeout)
+		defer cCancel()
+		bondAmount, err := r.assetManagerContract.BONDAMOUNT(optsutils.NewSimpleCallOpts(cCtx))
+		if err != nil {
+			return fmt.Errorf("failed to get bond amount: %w", err)
+		}
+
+		cCtx, cCancel = context.WithTimeout(ctx, r.cfg.NetworkTimeout)
+		defer cCancel()
+		assetTokenAddr, err := r.assetManagerContract.ASSETTOKEN(optsutils.NewSimpleCallOpts(cCtx))
+		if err != nil {
+			return fmt.Errorf("failed to get asset token address: %w", err)
+		}
+		assetTokenContract, err := bindings.NewERC20Caller(assetTokenAddr, r.cfg.L1Client)
+		if err != nil {
+			return fmt.Errorf("failed to fetch asset token contract: %w", err)
+		}
+		r.assetTokenAddr = assetTokenAddr
+		r.assetTokenContract = assetTokenContract
+
+		cCtx, cCancel = context.WithTimeout(ctx, r.cfg.NetworkTimeout)
+		defer cCancel()
+		withdrawAccount, err := r.assetManagerContract.GetWithdrawAccount(optsutils.NewSimpleCallOpts(cCtx), r.cfg.TxManager.From())
+		if err != nil {
+			return fmt.Errorf("failed to get withdraw account: %w", err)
+		}
+		r.sweepEnabled = r.cfg.RewardSweepThreshold.Sign() > 0 && withdrawAccount == r.cfg.TxManager.From()
+		if r.cfg.RewardSweepThreshold.Sign() > 0 && !r.sweepEnabled {
+			r.log.Warn("sweeping is disabled since only the withdraw account can withdraw", "withdrawAccount", withdrawAccount)
+		}
+
+		r.policy = &rewardPolicy{
+			bondTarget:        new(big.Int).Mul(bondAmount, new(big.Int).SetUint64(r.cfg.RewardBondReserve)),
+			compoundThreshold: r.cfg.RewardCompoundThreshold,
+			sweepThreshold:    r.cfg.RewardSweepThreshold,
+			minStake:          r.cfg.RewardMinStake,
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initiate asset manager config: %w", err)
+	}
+
+	err = contractWatcher.WatchUpgraded(r.cfg.L2OutputOracleAddr, func() error {
+		cCtx, cCancel := context.WithTimeout(ctx, r.cfg.NetworkTimeout)
+		defer cCancel()
+		finalizationPeriodSeconds, err := r.l2ooContract.FINALIZATIONPERIODSECONDS(optsutils.NewSimpleCallOpts(cCtx))
+		if err != nil {
+			return fmt.Errorf("failed to get finalization period seconds: %w", err)
+		}
+		r.challenges.setFinalizationPeriod(finalizationPeriodSeconds)
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initiate l2OO config: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RewardManager) Start(ctx context.Context) error {
+	r.ctx, r.cancel = context.WithCancel(ctx)
+
+	if err := r.InitConfig(r.ctx); err != nil {
+		return err
+	}
+
+	r.wg.Add(1)
+	go r.loop()
+
+	return nil
+}
+
+func (r *RewardManager) Stop() error {
+	r.cancel()
+	r.wg.Wait()
+
+	return nil
+}
+
+// Pause stops applying the reward policy until Resume is called.
+func (r *RewardManager) Pause() {
+	r.paused.Store(true)
+	r.log.Info("reward manager paused")
+}
+
+// Resume resumes applying the reward policy.
+func (r *RewardManager) Resume() {
+	r.paused.Store(false)
+	r.log.Info("reward manager resumed")
+}
+
+func (r *RewardManager) Paused() bool {
+	return r.paused.Load()
+}
+
+func (r *RewardManager) loop() {
+	ticker := time.NewTicker(r.cfg.RewardPolicyInterval)
+	defer func() {
+		ticker.Stop()
+		r.wg.Done()
+	}()
+
+	for {
+		if !r.Paused() {
+			if err := r.applyPolicy(r.ctx); err != nil {
+				r.log.Error("failed to apply reward policy", "err", err)
+			}
+		}
+
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// applyPolicy claims the KGH rewards if compounding is enabled, and deposits or withdraws KRO as the policy plans.
+// Nothing is done while the validator is not registered, is in jail or is involved in a challenge, since the stake
+// may be slashed or locked in the meantime.
+func (r *RewardManager) applyPolicy(ctx context.Context) error {
+	from := r.cfg.TxManager.From()
+
+	inChallenge, err := r.challenges.inChallenge(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check the challenges of the validator: %w", err)
+	}
+	if inChallenge {
+		r.log.Info("skip applying reward policy since the validator is in a challenge")
+		return nil
+	}
+
+	cCtx, cCancel := context.WithTimeout(ctx, r.cfg.NetworkTimeout)
+	defer cCancel()
+	status, err := r.valMgrContract.GetStatus(optsutils.NewSimpleCallOpts(cCtx), from)
+	if err != nil {
+		return fmt.Errorf("failed to fetch the validator status: %w", err)
+	}
+	if status < StatusRegistered {
+		r.log.Info("skip applying reward policy since the validator is not registered", "status", status)
+		return nil
+	}
+
+	cCtx, cCancel = context.WithTimeout(ctx, r.cfg.NetworkTimeout)
+	defer cCancel()
+	inJail, err := r.valMgrContract.InJail(optsutils.NewSimpleCallOpts(cCtx), from)
+	if err != nil {
+		return fmt.Errorf("failed to fetch the jail status: %w", err)
+	}
+	if inJail {
+		r.log.Info("skip applying reward policy since the validator is in jail")
+		return nil
+	}
+
+	if r.cfg.RewardCompoundThreshold.Sign() > 0 {
+		if err := r.claimKghReward(ctx); err != nil {
+			return err
+		}
+	}
+
+	state, err := r.fetchState(ctx)
+	if err != nil {
+		return err
+	}
+
+	plan := r.policy.plan(state)
+	if state.notBonded.Cmp(r.policy.bondTarget) < 0 && plan.deposit.Cmp(new(big.Int).Sub(r.policy.bondTarget, state.notBonded)) < 0 {
+		r.log.Warn("insufficient asset tokens to keep the bond target", "notBonded", state.notBonded,
+			"bondTarget", r.policy.bondTarget, "walletBalance", state.walletBalance)
+	}
+
+	if plan.deposit.Sign() > 0 {
+		if err := r.deposit(ctx, plan.deposit); err != nil {
+			return err
+		}
+	}
+	if plan.withdraw.Sign() > 0 {
+		if err := r.withdraw(ctx, plan.withdraw); err != nil {
+			return err
+		}
+		if !r.cfg.DryRun {
+			r.swept.Add(r.swept, plan.withdraw)
+		}
+	}
+
+	return nil
+}
+
+// fetchState fetches the asset state of the validator and records it to the metrics.
+func (r *RewardManager) fetchState(ctx context.Context) (*rewardState, error) {
+	from := r.cfg.TxManager.From()
+
+	cCtx, cCancel := context.WithTimeout(ctx, r.cfg.NetworkTimeout)
+	defer cCancel()
+	walletBalance, err := r.assetTokenContract.BalanceOf(optsutils.NewSimpleCallOpts(cCtx), from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the asset token balance: %w", err)
+	}
+
+	cCtx, cCancel = context.WithTimeout(ctx, r.cfg.NetworkTimeout)
+	defer cCancel()
+	totalKro, err := r.assetManagerContract.TotalValidatorKro(optsutils.NewSimpleCallOpts(cCtx), from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the validator kro: %w", err)
+	}
+
+	cCtx, cCancel = context.WithTimeout(ctx, r.cfg.NetworkTimeout)
+	defer cCancel()
+	notBonded, err := r.assetManagerContract.TotalValidatorKroNotBonded(optsutils.NewSimpleCallOpts(cCtx), from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the validator kro not bonded: %w", err)
+	}
+
+	cCtx, cCancel = context.WithTimeout(ctx, r.cfg.NetworkTimeout)
+	defer cCancel()
+	canWithdrawAt, err := r.assetManagerContract.CanWithdrawAt(optsutils.NewSimpleCallOpts(cCtx), from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the withdrawable time: %w", err)
+	}
+
+	if r.swept == nil {
+		r.swept = new(big.Int)
+		if r.sweepEnabled {
+			r.swept.Set(walletBalance)
+		}
+	}
+	// the swept KRO which is no longer held by the validator account, e.g. deposited to keep the bond target or
+	// transferred out, is not tracked anymore.
+	r.swept = minBig(r.swept, walletBalance)
+
+	r.metr.RecordValidatorKro(metrics.ValidatorKroWallet, walletBalance)
+	r.metr.RecordValidatorKro(metrics.ValidatorKroTotal, totalKro)
+	r.metr.RecordValidatorKro(metrics.ValidatorKroNotBonded, notBonded)
+
+	return &rewardState{
+		walletBalance: walletBalance,
+		totalKro:      totalKro,
+		notBonded:     notBonded,
+		canWithdraw:   r.sweepEnabled && canWithdrawAt.Uint64() <= uint64(time.Now().Unix()),
+		swept:         new(big.Int).Set(r.swept),
+	}, nil
+}
+
+// claimKghReward claims the boosted reward of the KGHs delegated by the validator account, if any.
+func (r *RewardManager) claimKghReward(ctx context.Context) error {
+	from := r.cfg.TxManager.From()
+
+	cCtx, cCancel := context.WithTimeout(ctx, r.cfg.NetworkTimeout)
+	defer cCancel()
+	kghReward, err := r.assetManagerContract.GetKghReward(optsutils.NewSimpleCallOpts(cCtx), from, from)
+	if err != nil {
+		return fmt.Errorf("failed to fetch the KGH reward: %w", err)
+	}
+	if kghReward.Sign() == 0 {
+		return nil
+	}
+
+	data, err := r.assetManagerABI.Pack("claimKghReward", from)
+	if err != nil {
+		return fmt.Errorf("failed to create claimKghReward transaction data: %w", err)
+	}
+	return r.sendTx(ctx, RewardActionClaim, r.cfg.AssetManagerAddr, data, "amount", kghReward)
+}
+
+// deposit approves the asset tokens to AssetManager if needed, and deposits them as the stake of the validator.
+func (r *RewardManager) deposit(ctx context.Context, amount *big.Int) error {
+	data, err := r.erc20ABI.Pack("approve", r.cfg.AssetManagerAddr, amount)
+	if err != nil {
+		return fmt.Errorf("failed to create approve transaction data: %w", err)
+	}
+	if err := r.sendTx(ctx, RewardActionApprove, r.assetTokenAddr, data, "amount", amount); err != nil {
+		return err
+	}
+
+	data, err = r.assetManagerABI.Pack("deposit", amount)
+	if err != nil {
+		return fmt.Errorf("failed to create deposit transaction data: %w", err)
+	}
+	return r.sendTx(ctx, RewardActionDeposit, r.cfg.AssetManagerAddr, data, "amount", amount)
+}
+
+// withdraw withdraws the stake of the validator to the validator account, which is its own withdraw account.
+func (r *RewardManager) withdraw(ctx context.Context, amount *big.Int) error {
+	data, err := r.assetManagerABI.Pack("withdraw", r.cfg.TxManager.From(), amount)
+	if err != nil {
+		return fmt.Errorf("failed to create withdraw transaction data: %w", err)
+	}
+	return r.sendTx(ctx, RewardActionWithdraw, r.cfg.AssetManagerAddr, data, "amount", amount)
+}
+
+func (r *RewardManager) sendTx(ctx context.Context, action string, to common.Address, data []byte, logCtx ...any) error {
+	txResponse := r.txSender.SendTxCandidate(ctx, &txmgr.TxCandidate{
+		TxData: data,
+		To:     &to,
+	})
+	if txResponse.Err != nil {
+		r.metr.RecordRewardPolicyAction(action, false)
+		return fmt.Errorf("failed to send %s transaction: %w", action, txResponse.Err)
+	}
+
+	// the transaction is only simulated in dry-run mode, so there is no receipt.
+	if r.cfg.DryRun {
+		r.log.Info("dry-run: reward policy action simulated", append([]any{"action", action}, logCtx...)...)
+		return nil
+	}
+
+	receipt := txResponse.Receipt
+	success := receipt != nil && receipt.Status == types.ReceiptStatusSuccessful
+	r.metr.RecordRewardPolicyAction(action, success)
+	if receipt == nil {
+		return fmt.Errorf("no receipt of %s transaction", action)
+	}
+	if !success {
+		return fmt.Errorf("%s transaction reverted: %s", action, receipt.TxHash)
+	}
+	r.log.Info("reward policy action done", append([]any{"action", action, "txHash", receipt.TxHash}, logCtx...)...)
+	return nil
+}
@@ -0,0 +1,449 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/kroma-bindings/bindings"
+	chal "github.com/kroma-network/kroma/kroma-validator/challenge"
+	"github.com/kroma-network/kroma/kroma-validator/metrics"
+)
+
+func TestRewardPolicyPlan(t *testing.T) {
+	policy := &rewardPolicy{
+		bondTarget:        big.NewInt(100),
+		compoundThreshold: big.NewInt(50),
+		sweepThreshold:    big.NewInt(30),
+		minStake:          big.NewInt(500),
+	}
+
+	tests := []struct {
+		name     string
+		state    *rewardState
+		deposit  int64
+		withdraw int64
+	}{
+		{
+			name:    "compound wallet balance",
+			state:   &rewardState{big.NewInt(60), big.NewInt(1000), big.NewInt(200), true, big.NewInt(0)},
+			deposit: 60,
+		},
+		{
+			name:    "top up bond shortfall",
+			state:   &rewardState{big.NewInt(40), big.NewInt(1000), big.NewInt(80), true, big.NewInt(0)},
+			deposit: 20,
+		},
+		{
+			name:    "top up bond with insufficient wallet",
+			state:   &rewardState{big.NewInt(10), big.NewInt(1000), big.NewInt(50), true, big.NewInt(0)},
+			deposit: 10,
+		},
+		{
+			name:     "sweep excess above min stake",
+			state:    &rewardState{big.NewInt(0), big.NewInt(600), big.NewInt(300), true, big.NewInt(0)},
+			withdraw: 100,
+		},
+		{
+			name:     "sweep excess above bond target",
+			state:    &rewardState{big.NewInt(0), big.NewInt(1000), big.NewInt(140), true, big.NewInt(0)},
+			withdraw: 40,
+		},
+		{
+			name:  "excess below sweep threshold",
+			state: &rewardState{big.NewInt(0), big.NewInt(520), big.NewInt(300), true, big.NewInt(0)},
+		},
+		{
+			name:     "swept balance is not compounded",
+			state:    &rewardState{big.NewInt(60), big.NewInt(1000), big.NewInt(140), true, big.NewInt(60)},
+			withdraw: 40,
+		},
+		{
+			name:    "compound balance except swept",
+			state:   &rewardState{big.NewInt(120), big.NewInt(1000), big.NewInt(200), true, big.NewInt(60)},
+			deposit: 60,
+		},
+		{
+			name:    "swept balance tops up bond shortfall",
+			state:   &rewardState{big.NewInt(60), big.NewInt(1000), big.NewInt(80), true, big.NewInt(60)},
+			deposit: 20,
+		},
+		{
+			name:  "cannot withdraw",
+			state: &rewardState{big.NewInt(0), big.NewInt(1000), big.NewInt(300), false, big.NewInt(0)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := policy.plan(tt.state)
+			require.Equal(t, big.NewInt(tt.deposit), plan.deposit)
+			require.Equal(t, big.NewInt(tt.withdraw), plan.withdraw)
+		})
+	}
+}
+
+var (
+	testValidatorAddr    = common.Address{0x01}
+	testAssetManagerAddr = common.Address{0xa1}
+	testValMgrAddr       = common.Address{0xa2}
+	testAssetTokenAddr   = common.Address{0xa3}
+	testColosseumAddr    = common.Address{0xa4}
+)
+
+// testRewardL1 serves the calls to the validator system contracts and the asset token from the asset state.
+type testRewardL1 struct {
+	t             *testing.T
+	walletBalance *big.Int
+	totalKro      *big.Int
+	notBonded     *big.Int
+	canWithdrawAt *big.Int
+	// challenges are the statuses of the challenges created at the L1 head, keyed by their creation events.
+	challenges map[*bindings.ColosseumChallengeCreated]uint8
+	head       uint64
+}
+
+type testCallArgs struct {
+	To    common.Address `json:"to"`
+	Input hexutil.Bytes  `json:"input"`
+}
+
+func (l *testRewardL1) Call(args testCallArgs, _ string) (hexutil.Bytes, error) {
+	contracts := map[common.Address]*bind.MetaData{
+		testAssetManagerAddr: bindings.AssetManagerMetaData,
+		testValMgrAddr:       bindings.ValidatorManagerMetaData,
+		testAssetTokenAddr:   bindings.ERC20MetaData,
+		testColosseumAddr:    bindings.ColosseumMetaData,
+	}
+	contractABI, err := contracts[args.To].GetAbi()
+	require.NoError(l.t, err)
+	method, err := contractABI.MethodById(args.Input[:4])
+	require.NoError(l.t, err)
+
+	var result any
+	switch method.Name {
+	case "getStatus":
+		result = uint8(StatusActive)
+		if args.To == testColosseumAddr {
+			result = l.challengeStatus(args.Input)
+		}
+	case "inJail":
+		result = false
+	case "balanceOf":
+		result = l.walletBalance
+	case "totalValidatorKro":
+		result = l.totalKro
+	case "totalValidatorKroNotBonded":
+		result = l.notBonded
+	case "canWithdrawAt":
+		result = l.canWithdrawAt
+	case "getKghReward":
+		result = new(big.Int)
+	default:
+		l.t.Fatalf("unexpected call of %s", method.Name)
+	}
+	return method.Outputs.Pack(result)
+}
+
+func (l *testRewardL1) challengeStatus(input hexutil.Bytes) uint8 {
+	colosseumABI, err := bindings.ColosseumMetaData.GetAbi()
+	require.NoError(l.t, err)
+	args, err := colosseumABI.Methods["getStatus"].Inputs.Unpack(input[4:])
+	require.NoError(l.t, err)
+	for ev, status := range l.challenges {
+		if ev.OutputIndex.Cmp(args[0].(*big.Int)) == 0 && ev.Challenger == args[1].(common.Address) {
+			return status
+		}
+	}
+	return chal.StatusNone
+}
+
+func (l *testRewardL1) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(l.head)
+}
+
+type testFilterArgs struct {
+	FromBlock *hexutil.Big    `json:"fromBlock"`
+	ToBlock   *hexutil.Big    `json:"toBlock"`
+	Topics    [][]common.Hash `json:"topics"`
+}
+
+// GetLogs returns the ChallengeCreated events matching the asserter and challenger topics of the filter.
+func (l *testRewardL1) GetLogs(args testFilterArgs) []types.Log {
+	colosseumABI, err := bindings.ColosseumMetaData.GetAbi()
+	require.NoError(l.t, err)
+	matches := func(i int, topic common.Hash) bool {
+		if len(args.Topics) <= i || len(args.Topics[i]) == 0 {
+			return true
+		}
+		return args.Topics[i][0] == topic
+	}
+
+	logs := []types.Log{}
+	if args.FromBlock.ToInt().Uint64() > l.head || args.ToBlock.ToInt().Uint64() < l.head {
+		return logs
+	}
+	for ev := range l.challenges {
+		topics := []common.Hash{
+			colosseumABI.Events["ChallengeCreated"].ID,
+			common.BigToHash(ev.OutputIndex),
+			common.BytesToHash(ev.Asserter.Bytes()),
+			common.BytesToHash(ev.Challenger.Bytes()),
+		}
+		if !matches(2, topics[2]) || !matches(3, topics[3]) {
+			continue
+		}
+		data, err := colosseumABI.Events["ChallengeCreated"].Inputs.NonIndexed().Pack(new(big.Int))
+		require.NoError(l.t, err)
+		logs = append(logs, types.Log{Address: testColosseumAddr, Topics: topics, Data: data, BlockNumber: l.head})
+	}
+	return logs
+}
+
+// testRewardTxSender applies the transactions of the reward policy to the asset state, or fails them.
+type testRewardTxSender struct {
+	t       *testing.T
+	l1      *testRewardL1
+	actions []string
+	// fail returns the response of a failed transaction, or nil if the transaction succeeds.
+	fail func() *txmgr.TxResponse
+}
+
+func (s *testRewardTxSender) SendTxCandidate(_ context.Context, candidate *txmgr.TxCandidate) *txmgr.TxResponse {
+	contractABI, err := bindings.AssetManagerMetaData.GetAbi()
+	require.NoError(s.t, err)
+	if *candidate.To == testAssetTokenAddr {
+		contractABI, err = bindings.ERC20MetaData.GetAbi()
+		require.NoError(s.t, err)
+	}
+	method, err := contractABI.MethodById(candidate.TxData[:4])
+	require.NoError(s.t, err)
+	args, err := method.Inputs.Unpack(candidate.TxData[4:])
+	require.NoError(s.t, err)
+	s.actions = append(s.actions, method.Name)
+
+	if s.fail != nil {
+		if res := s.fail(); res != nil {
+			return res
+		}
+	}
+
+	switch method.Name {
+	case "deposit":
+		amount := args[0].(*big.Int)
+		s.l1.walletBalance.Sub(s.l1.walletBalance, amount)
+		s.l1.totalKro.Add(s.l1.totalKro, amount)
+		s.l1.notBonded.Add(s.l1.notBonded, amount)
+	case "withdraw":
+		amount := args[1].(*big.Int)
+		s.l1.walletBalance.Add(s.l1.walletBalance, amount)
+		s.l1.totalKro.Sub(s.l1.totalKro, amount)
+		s.l1.notBonded.Sub(s.l1.notBonded, amount)
+	}
+	return &txmgr.TxResponse{Receipt: &types.Receipt{Status: types.ReceiptStatusSuccessful}}
+}
+
+func newTestRewardManager(t *testing.T, l1 *testRewardL1) (*RewardManager, *testRewardTxSender) {
+	l1.t = t
+	srv := gethrpc.NewServer()
+	require.NoError(t, srv.RegisterName("eth", l1))
+	t.Cleanup(srv.Stop)
+	client := ethclient.NewClient(gethrpc.DialInProc(srv))
+
+	cfg := Config{
+		L1Client:             client,
+		NetworkTimeout:       time.Second,
+		TxManager:            newTestTxManager(testValidatorAddr),
+		AssetManagerAddr:     testAssetManagerAddr,
+		ValidatorManagerAddr: testValMgrAddr,
+		ColosseumAddr:        testColosseumAddr,
+
+		RewardCompoundThreshold: big.NewInt(50),
+		RewardSweepThreshold:    big.NewInt(30),
+		RewardMinStake:          big.NewInt(500),
+	}
+	r, err := NewRewardManager(cfg, testlog.Logger(t, log.LevelInfo), metrics.NoopMetrics)
+	require.NoError(t, err)
+	r.assetTokenAddr = testAssetTokenAddr
+	r.assetTokenContract, err = bindings.NewERC20Caller(testAssetTokenAddr, client)
+	require.NoError(t, err)
+	r.policy = &rewardPolicy{
+		bondTarget:        big.NewInt(100),
+		compoundThreshold: cfg.RewardCompoundThreshold,
+		sweepThreshold:    cfg.RewardSweepThreshold,
+		minStake:          cfg.RewardMinStake,
+	}
+	r.sweepEnabled = true
+
+	sender := &testRewardTxSender{t: t, l1: l1}
+	r.txSender = sender
+	return r, sender
+}
+
+func TestRewardManagerSweepEligibility(t *testing.T) {
+	tests := []struct {
+		name          string
+		sweepEnabled  bool
+		canWithdrawAt int64
+		actions       []string
+	}{
+		{name: "sweep", sweepEnabled: true, actions: []string{"withdraw"}},
+		{name: "not withdraw account", sweepEnabled: false},
+		{name: "withdrawal locked", sweepEnabled: true, canWithdrawAt: time.Now().Add(time.Hour).Unix()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l1 := &testRewardL1{
+				walletBalance: big.NewInt(0),
+				totalKro:      big.NewInt(1000),
+				notBonded:     big.NewInt(200),
+				canWithdrawAt: big.NewInt(tt.canWithdrawAt),
+			}
+			r, sender := newTestRewardManager(t, l1)
+			r.sweepEnabled = tt.sweepEnabled
+
+			require.NoError(t, r.applyPolicy(context.Background()))
+			require.Equal(t, tt.actions, sender.actions)
+		})
+	}
+}
+
+func TestRewardManagerSweepThenCompound(t *testing.T) {
+	ctx := context.Background()
+	l1 := &testRewardL1{
+		walletBalance: big.NewInt(0),
+		totalKro:      big.NewInt(1000),
+		notBonded:     big.NewInt(200),
+		canWithdrawAt: big.NewInt(0),
+	}
+	r, sender := newTestRewardManager(t, l1)
+
+	// the excess stake is swept to the validator account
+	require.NoError(t, r.applyPolicy(ctx))
+	require.Equal(t, []string{"withdraw"}, sender.actions)
+	require.Equal(t, big.NewInt(100), l1.walletBalance)
+
+	// the swept KRO is not compounded back
+	require.NoError(t, r.applyPolicy(ctx))
+	require.Equal(t, []string{"withdraw"}, sender.actions)
+	require.Equal(t, big.NewInt(100), l1.walletBalance)
+
+	// the KRO received afterwards is compounded
+	l1.walletBalance.Add(l1.walletBalance, big.NewInt(60))
+	require.NoError(t, r.applyPolicy(ctx))
+	require.Equal(t, []string{"withdraw", "approve", "deposit"}, sender.actions)
+	require.Equal(t, big.NewInt(100), l1.walletBalance)
+
+	// the compounded KRO above the bond target is swept once, and stays in the validator account
+	require.NoError(t, r.applyPolicy(ctx))
+	require.Equal(t, []string{"withdraw", "approve", "deposit", "withdraw"}, sender.actions)
+	require.Equal(t, big.NewInt(160), l1.walletBalance)
+	require.NoError(t, r.applyPolicy(ctx))
+	require.Len(t, sender.actions, 4)
+}
+
+func TestRewardManagerFailedTx(t *testing.T) {
+	tests := []struct {
+		name string
+		res  *txmgr.TxResponse
+		err  string
+	}{
+		{name: "send error", res: &txmgr.TxResponse{Err: errors.New("nonce too low")}, err: "nonce too low"},
+		{name: "reverted", res: &txmgr.TxResponse{Receipt: &types.Receipt{Status: types.ReceiptStatusFailed}}, err: "reverted"},
+		{name: "no receipt", res: &txmgr.TxResponse{}, err: "no receipt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l1 := &testRewardL1{
+				walletBalance: big.NewInt(0),
+				totalKro:      big.NewInt(1000),
+				notBonded:     big.NewInt(200),
+				canWithdrawAt: big.NewInt(0),
+			}
+			r, sender := newTestRewardManager(t, l1)
+			sender.fail = func() *txmgr.TxResponse { return tt.res }
+
+			require.ErrorContains(t, r.applyPolicy(context.Background()), tt.err)
+			require.Equal(t, []string{"withdraw"}, sender.actions)
+			require.Zero(t, r.swept.Sign())
+
+			// the failed sweep is retried, and the KRO swept then is not compounded
+			sender.fail = nil
+			require.NoError(t, r.applyPolicy(context.Background()))
+			require.NoError(t, r.applyPolicy(context.Background()))
+			require.Equal(t, []string{"withdraw", "withdraw"}, sender.actions)
+		})
+	}
+}
+
+func TestRewardManagerInChallenge(t *testing.T) {
+	ctx := context.Background()
+	onOutput := &bindings.ColosseumChallengeCreated{OutputIndex: big.NewInt(3), Asserter: testValidatorAddr, Challenger: common.Address{0x02}}
+	byValidator := &bindings.ColosseumChallengeCreated{OutputIndex: big.NewInt(4), Asserter: common.Address{0x02}, Challenger: testValidatorAddr}
+	others := &bindings.ColosseumChallengeCreated{OutputIndex: big.NewInt(5), Asserter: common.Address{0x02}, Challenger: common.Address{0x03}}
+	l1 := &testRewardL1{
+		walletBalance: big.NewInt(0),
+		totalKro:      big.NewInt(1000),
+		notBonded:     big.NewInt(200),
+		canWithdrawAt: big.NewInt(0),
+		challenges: map[*bindings.ColosseumChallengeCreated]uint8{
+			onOutput:    chal.StatusAsserterTurn,
+			byValidator: chal.StatusChallengerTurn,
+			others:      chal.StatusChallengerTurn,
+		},
+		head: 100,
+	}
+	r, sender := newTestRewardManager(t, l1)
+	r.challenges.setFinalizationPeriod(big.NewInt(600))
+
+	// nothing is done while the validator is in a challenge, and only the challenges involving it are tracked
+	require.NoError(t, r.applyPolicy(ctx))
+	require.Empty(t, sender.actions)
+	require.Len(t, r.challenges.challenges, 2)
+
+	// the challenge created by the validator keeps the policy from being applied
+	l1.challenges[onOutput] = chal.StatusNone
+	l1.head = 101
+	require.NoError(t, r.applyPolicy(ctx))
+	require.Empty(t, sender.actions)
+
+	// the policy is applied once the challenges are over
+	l1.challenges[byValidator] = chal.StatusNone
+	require.NoError(t, r.applyPolicy(ctx))
+	require.Equal(t, []string{"withdraw"}, sender.actions)
+	require.Empty(t, r.challenges.challenges)
+}
+
+func TestRewardManagerStop(t *testing.T) {
+	r := &RewardManager{
+		log: testlog.Logger(t, log.LevelInfo),
+		cfg: Config{RewardPolicyInterval: time.Hour},
+	}
+	r.Pause()
+	r.ctx, r.cancel = context.WithCancel(context.Background())
+	r.wg.Add(1)
+	go r.loop()
+
+	stopped := make(chan struct{})
+	go func() {
+		require.NoError(t, r.Stop())
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("reward manager did not stop before the next interval")
+	}
+}
@@ -0,0 +1,151 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/kroma-network/kroma/kroma-validator/metrics"
+)
+
+// RoleTxManager is the tx manager used by a validator role, whose signer can be rotated at runtime.
+// It is either shared with the other roles, or dedicated to the role. A dedicated tx manager is started and stopped
+// along with the role.
+type RoleTxManager struct {
+	role string
+	log  log.Logger
+	metr metrics.Metricer
+
+	// mu guards the current tx manager. It is only held to read or swap it, so that the rotation does not block
+	// the readers during the in-flight transactions.
+	mu      sync.RWMutex
+	current *roleTxMgr
+	ctx     context.Context
+
+	// rotateMu serializes the rotations.
+	rotateMu sync.Mutex
+}
+
+// roleTxMgr is a tx manager used by the role, with the transactions being sent by it.
+type roleTxMgr struct {
+	txMgr     *txmgr.BufferedTxManager
+	dedicated bool
+	// inflight is the transactions being sent, which the rotation waits for before stopping the tx manager.
+	inflight sync.WaitGroup
+}
+
+// NewRoleTxManager creates a new RoleTxManager. If dedicated is nil, the shared tx manager is used.
+func NewRoleTxManager(role string, l log.Logger, m metrics.Metricer, shared, dedicated *txmgr.BufferedTxManager) *RoleTxManager {
+	r := &RoleTxManager{
+		role:    role,
+		log:     l,
+		metr:    m,
+		current: &roleTxMgr{txMgr: shared},
+	}
+	if dedicated != nil {
+		r.current = &roleTxMgr{txMgr: dedicated, dedicated: true}
+	}
+	return r
+}
+
+// Start starts the tx manager if it is dedicated to the role.
+func (r *RoleTxManager) Start(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ctx = ctx
+	if r.current.dedicated {
+		if err := r.current.txMgr.Start(ctx); err != nil {
+			return fmt.Errorf("cannot start %s tx manager: %w", r.role, err)
+		}
+	}
+	r.metr.RecordRoleSigner(r.role, r.current.txMgr.From())
+	r.log.Info("using signer", "role", r.role, "address", r.current.txMgr.From(), "dedicated", r.current.dedicated)
+	return nil
+}
+
+// Stop stops the tx manager if it is dedicated to the role.
+func (r *RoleTxManager) Stop() error {
+	r.rotateMu.Lock()
+	defer r.rotateMu.Unlock()
+
+	r.mu.RLock()
+	current := r.current
+	r.mu.RUnlock()
+
+	if current.dedicated {
+		return current.txMgr.Stop()
+	}
+	return nil
+}
+
+// TxManager returns the current tx manager.
+func (r *RoleTxManager) TxManager() *txmgr.BufferedTxManager {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.current.txMgr
+}
+
+// From returns the address of the current signer.
+func (r *RoleTxManager) From() common.Address {
+	return r.TxManager().From()
+}
+
+// acquire returns the current tx manager, counting a transaction in flight until it is released.
+func (r *RoleTxManager) acquire() *roleTxMgr {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	r.current.inflight.Add(1)
+	return r.current
+}
+
+func (r *RoleTxManager) SendTxCandidate(ctx context.Context, candidate *txmgr.TxCandidate) *txmgr.TxResponse {
+	current := r.acquire()
+	defer current.inflight.Done()
+	return current.txMgr.SendTxCandidate(ctx, candidate)
+}
+
+func (r *RoleTxManager) SendTransaction(ctx context.Context, tx *types.Transaction) *txmgr.TxResponse {
+	current := r.acquire()
+	defer current.inflight.Done()
+	return current.txMgr.SendTransaction(ctx, tx)
+}
+
+// Rotate replaces the tx manager with the given one, which becomes dedicated to the role.
+// The new transactions are sent with the given tx manager right after it is started. Then it waits for the in-flight
+// transactions of the previous tx manager to be done, and stops it if it was dedicated.
+func (r *RoleTxManager) Rotate(txMgr *txmgr.BufferedTxManager) error {
+	r.rotateMu.Lock()
+	defer r.rotateMu.Unlock()
+
+	r.mu.RLock()
+	ctx := r.ctx
+	r.mu.RUnlock()
+	if ctx == nil {
+		return fmt.Errorf("%s tx manager is not started", r.role)
+	}
+	if err := txMgr.Start(ctx); err != nil {
+		return fmt.Errorf("cannot start %s tx manager: %w", r.role, err)
+	}
+
+	r.mu.Lock()
+	prev := r.current
+	r.current = &roleTxMgr{txMgr: txMgr, dedicated: true}
+	r.mu.Unlock()
+
+	r.metr.RecordRoleSigner(r.role, txMgr.From())
+	r.log.Info("signer rotated", "role", r.role, "prev", prev.txMgr.From(), "new", txMgr.From())
+
+	prev.inflight.Wait()
+	if prev.dedicated {
+		if err := prev.txMgr.Stop(); err != nil {
+			r.log.Error("failed to stop previous tx manager", "role", r.role, "err", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package validator
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/kroma-bindings/bindings"
+	"github.com/kroma-network/kroma/kroma-validator/metrics"
+)
+
+func newTestTxManager(from common.Address) *txmgr.BufferedTxManager {
+	return &txmgr.BufferedTxManager{
+		SimpleTxManager: txmgr.SimpleTxManager{
+			Config: txmgr.Config{
+				From:         from,
+				TxBufferSize: 1,
+			},
+		},
+	}
+}
+
+func TestRoleTxManagerRotate(t *testing.T) {
+	shared := newTestTxManager(common.Address{0x01})
+	r := NewRoleTxManager("guardian", testlog.Logger(t, log.LevelInfo), metrics.NoopMetrics, shared, nil)
+
+	// rotation is not allowed before starting
+	require.Error(t, r.Rotate(newTestTxManager(common.Address{0x02})))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, r.Start(ctx))
+	require.Equal(t, common.Address{0x01}, r.From())
+
+	rotated := newTestTxManager(common.Address{0x02})
+	require.NoError(t, r.Rotate(rotated))
+	require.Equal(t, common.Address{0x02}, r.From())
+	require.Same(t, rotated, r.TxManager())
+
+	require.NoError(t, r.Rotate(newTestTxManager(common.Address{0x03})))
+	require.Equal(t, common.Address{0x03}, r.From())
+
+	require.NoError(t, r.Stop())
+}
+
+func TestRoleTxManagerRotateInFlight(t *testing.T) {
+	dedicated := newTestTxManager(common.Address{0x01})
+	r := NewRoleTxManager("guardian", testlog.Logger(t, log.LevelInfo), metrics.NoopMetrics, nil, dedicated)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, r.Start(ctx))
+
+	// a transaction is being sent with the previous signer
+	inflight := r.acquire()
+
+	rotated := make(chan error)
+	go func() {
+		rotated <- r.Rotate(newTestTxManager(common.Address{0x02}))
+	}()
+
+	// the new signer is used without waiting for the in-flight transaction
+	require.Eventually(t, func() bool {
+		return r.From() == common.Address{0x02}
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the previous tx manager is stopped after the in-flight transaction is done
+	select {
+	case <-rotated:
+		t.Fatal("rotation returned before the in-flight transaction is done")
+	case <-time.After(100 * time.Millisecond):
+	}
+	inflight.inflight.Done()
+	require.NoError(t, <-rotated)
+
+	require.NoError(t, r.Stop())
+}
+
+// testVotesL1 serves the votes of every account in SecurityCouncil.
+type testVotesL1 struct {
+	votes *big.Int
+}
+
+func (l *testVotesL1) Call(_ map[string]any, _ string) (hexutil.Bytes, error) {
+	return common.BigToHash(l.votes).Bytes(), nil
+}
+
+func TestGuardianRotateSignerWithoutVotes(t *testing.T) {
+	srv := gethrpc.NewServer()
+	require.NoError(t, srv.RegisterName("eth", &testVotesL1{votes: new(big.Int)}))
+	t.Cleanup(srv.Stop)
+	securityCouncil, err := bindings.NewSecurityCouncil(common.Address{0xaa}, ethclient.NewClient(gethrpc.DialInProc(srv)))
+	require.NoError(t, err)
+
+	logger := testlog.Logger(t, log.LevelInfo)
+	g := &Guardian{
+		log:                     logger,
+		cfg:                     Config{NetworkTimeout: time.Second},
+		securityCouncilContract: securityCouncil,
+		txMgr:                   NewRoleTxManager("guardian", logger, metrics.NoopMetrics, newTestTxManager(common.Address{0x01}), nil),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, g.txMgr.Start(ctx))
+
+	// the current signer is kept if the new signer could not confirm any transaction
+	err = g.RotateSigner(ctx, "http://signer", common.Address{0x02})
+	require.ErrorContains(t, err, "no votes")
+	require.Equal(t, common.Address{0x01}, g.Signer())
+}
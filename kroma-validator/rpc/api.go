@@ -0,0 +1,215 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/rpc"
+)
+
+const (
+	RoleOutputSubmitter = "outputSubmitter"
+	RoleChallenger      = "challenger"
+	RoleGuardian        = "guardian"
+	RoleRewardManager   = "rewardManager"
+)
+
+// RoundInfo is the submission round of the next output.
+type RoundInfo struct {
+	OutputIndex         hexutil.Uint64 `json:"outputIndex"`
+	NextBlockNumber     hexutil.Uint64 `json:"nextBlockNumber"`
+	NextValidator       common.Address `json:"nextValidator"`
+	IsPublicRound       bool           `json:"isPublicRound"`
+	IsPriorityValidator bool           `json:"isPriorityValidator"`
+	CanJoinPublicRound  bool           `json:"canJoinPublicRound"`
+	CanJoinRound        bool           `json:"canJoinRound"`
+}
+
+// ValidatorStatus is the status of the validator in the ValidatorManager contract.
+type ValidatorStatus struct {
+	Address       common.Address `json:"address"`
+	Status        uint8          `json:"status"`
+	InJail        bool           `json:"inJail"`
+	JailExpiresAt hexutil.Uint64 `json:"jailExpiresAt"`
+}
+
+// Checkpoints are the output indexes that the challenger and the guardian last checked.
+// A checkpoint is nil if the role is disabled or has not set its checkpoint yet.
+type Checkpoints struct {
+	Challenger *hexutil.Big `json:"challenger"`
+	Guardian   *hexutil.Big `json:"guardian"`
+}
+
+// ChallengeInfo is a challenge that the validator is currently handling.
+type ChallengeInfo struct {
+	OutputIndex hexutil.Uint64 `json:"outputIndex"`
+	Asserter    common.Address `json:"asserter"`
+	Challenger  common.Address `json:"challenger"`
+	Status      uint8          `json:"status"`
+	Turn        uint8          `json:"turn"`
+	HasWitness  bool           `json:"hasWitness"`
+	HasProof    bool           `json:"hasProof"`
+	// Deadline is the unix timestamp until when the current turn should be taken, zero if the challenge timed out.
+	Deadline hexutil.Uint64 `json:"deadline"`
+	// AtRisk is whether the fault proof is not expected to be generated before the deadline.
+	AtRisk bool `json:"atRisk"`
+}
+
+// RoleInfo is the runtime state of a validator role.
+type RoleInfo struct {
+	Enabled bool `json:"enabled"`
+	Paused  bool `json:"paused"`
+	// Signer is the address that the role currently signs the transactions with.
+	Signer common.Address `json:"signer"`
+}
+
+type ValidatorBackend interface {
+	RoundInfo(ctx context.Context) (*RoundInfo, error)
+	NextValidator(ctx context.Context) (common.Address, error)
+	ValidatorStatus(ctx context.Context) (*ValidatorStatus, error)
+	Checkpoints() *Checkpoints
+	Challenges() []*ChallengeInfo
+	Roles() map[string]*RoleInfo
+}
+
+type AdminBackend interface {
+	SetRolePaused(role string, paused bool) error
+	RotateGuardianSigner(ctx context.Context, endpoint string, address common.Address) error
+}
+
+type validatorAPI struct {
+	b   ValidatorBackend
+	m   metrics.RPCMetricer
+	log log.Logger
+}
+
+func NewValidatorAPI(b ValidatorBackend, m metrics.RPCMetricer, log log.Logger) *validatorAPI {
+	return &validatorAPI{
+		b:   b,
+		m:   m,
+		log: log,
+	}
+}
+
+func GetValidatorAPI(api *validatorAPI) gethrpc.API {
+	return gethrpc.API{
+		Namespace: "validator",
+		Service:   api,
+	}
+}
+
+func (v *validatorAPI) RoundInfo(ctx context.Context) (*RoundInfo, error) {
+	recordDur := v.m.RecordRPCServerRequest("validator_roundInfo")
+	defer recordDur()
+	return v.b.RoundInfo(ctx)
+}
+
+func (v *validatorAPI) NextValidator(ctx context.Context) (common.Address, error) {
+	recordDur := v.m.RecordRPCServerRequest("validator_nextValidator")
+	defer recordDur()
+	return v.b.NextValidator(ctx)
+}
+
+func (v *validatorAPI) Status(ctx context.Context) (*ValidatorStatus, error) {
+	recordDur := v.m.RecordRPCServerRequest("validator_status")
+	defer recordDur()
+	return v.b.ValidatorStatus(ctx)
+}
+
+func (v *validatorAPI) Checkpoints(_ context.Context) (*Checkpoints, error) {
+	recordDur := v.m.RecordRPCServerRequest("validator_checkpoints")
+	defer recordDur()
+	return v.b.Checkpoints(), nil
+}
+
+func (v *validatorAPI) Challenges(_ context.Context) ([]*ChallengeInfo, error) {
+	recordDur := v.m.RecordRPCServerRequest("validator_challenges")
+	defer recordDur()
+	return v.b.Challenges(), nil
+}
+
+func (v *validatorAPI) Roles(_ context.Context) (map[string]*RoleInfo, error) {
+	recordDur := v.m.RecordRPCServerRequest("validator_roles")
+	defer recordDur()
+	return v.b.Roles(), nil
+}
+
+type adminAPI struct {
+	*rpc.CommonAdminAPI
+	b AdminBackend
+}
+
+func NewAdminAPI(b AdminBackend, m metrics.RPCMetricer, log log.Logger) *adminAPI {
+	return &adminAPI{
+		CommonAdminAPI: rpc.NewCommonAdminAPI(m, log),
+		b:              b,
+	}
+}
+
+func GetAdminAPI(api *adminAPI) gethrpc.API {
+	return gethrpc.API{
+		Namespace: "admin",
+		Service:   api,
+	}
+}
+
+func (a *adminAPI) PauseOutputSubmitter(_ context.Context) error {
+	recordDur := a.M.RecordRPCServerRequest("admin_pauseOutputSubmitter")
+	defer recordDur()
+	return a.b.SetRolePaused(RoleOutputSubmitter, true)
+}
+
+func (a *adminAPI) ResumeOutputSubmitter(_ context.Context) error {
+	recordDur := a.M.RecordRPCServerRequest("admin_resumeOutputSubmitter")
+	defer recordDur()
+	return a.b.SetRolePaused(RoleOutputSubmitter, false)
+}
+
+func (a *adminAPI) PauseChallenger(_ context.Context) error {
+	recordDur := a.M.RecordRPCServerRequest("admin_pauseChallenger")
+	defer recordDur()
+	return a.b.SetRolePaused(RoleChallenger, true)
+}
+
+func (a *adminAPI) ResumeChallenger(_ context.Context) error {
+	recordDur := a.M.RecordRPCServerRequest("admin_resumeChallenger")
+	defer recordDur()
+	return a.b.SetRolePaused(RoleChallenger, false)
+}
+
+func (a *adminAPI) PauseGuardian(_ context.Context) error {
+	recordDur := a.M.RecordRPCServerRequest("admin_pauseGuardian")
+	defer recordDur()
+	return a.b.SetRolePaused(RoleGuardian, true)
+}
+
+func (a *adminAPI) ResumeGuardian(_ context.Context) error {
+	recordDur := a.M.RecordRPCServerRequest("admin_resumeGuardian")
+	defer recordDur()
+	return a.b.SetRolePaused(RoleGuardian, false)
+}
+
+func (a *adminAPI) PauseRewardManager(_ context.Context) error {
+	recordDur := a.M.RecordRPCServerRequest("admin_pauseRewardManager")
+	defer recordDur()
+	return a.b.SetRolePaused(RoleRewardManager, true)
+}
+
+func (a *adminAPI) ResumeRewardManager(_ context.Context) error {
+	recordDur := a.M.RecordRPCServerRequest("admin_resumeRewardManager")
+	defer recordDur()
+	return a.b.SetRolePaused(RoleRewardManager, false)
+}
+
+// RotateGuardianSigner switches the guardian to sign the transactions with the given remote signer without restarting.
+// If the chains share L1, the guardians of all the chains are switched.
+func (a *adminAPI) RotateGuardianSigner(ctx context.Context, endpoint string, address common.Address) error {
+	recordDur := a.M.RecordRPCServerRequest("admin_rotateGuardianSigner")
+	defer recordDur()
+	return a.b.RotateGuardianSigner(ctx, endpoint, address)
+}
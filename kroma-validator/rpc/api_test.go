@@ -0,0 +1,67 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+type mockAdminBackend struct {
+	paused  map[string]bool
+	rotated common.Address
+	err     error
+}
+
+func (m *mockAdminBackend) SetRolePaused(role string, paused bool) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.paused[role] = paused
+	return nil
+}
+
+func (m *mockAdminBackend) RotateGuardianSigner(_ context.Context, _ string, address common.Address) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.rotated = address
+	return nil
+}
+
+func TestAdminAPI(t *testing.T) {
+	ctx := context.Background()
+	b := &mockAdminBackend{paused: make(map[string]bool)}
+	api := NewAdminAPI(b, &metrics.NoopRPCMetrics{}, testlog.Logger(t, log.LevelInfo))
+
+	tests := []struct {
+		role   string
+		pause  func(context.Context) error
+		resume func(context.Context) error
+	}{
+		{RoleOutputSubmitter, api.PauseOutputSubmitter, api.ResumeOutputSubmitter},
+		{RoleChallenger, api.PauseChallenger, api.ResumeChallenger},
+		{RoleGuardian, api.PauseGuardian, api.ResumeGuardian},
+		{RoleRewardManager, api.PauseRewardManager, api.ResumeRewardManager},
+	}
+	for _, test := range tests {
+		t.Run(test.role, func(t *testing.T) {
+			require.NoError(t, test.pause(ctx))
+			require.True(t, b.paused[test.role])
+			require.NoError(t, test.resume(ctx))
+			require.False(t, b.paused[test.role])
+		})
+	}
+
+	require.NoError(t, api.RotateGuardianSigner(ctx, "http://signer", common.Address{0x02}))
+	require.Equal(t, common.Address{0x02}, b.rotated)
+
+	b.err = errors.New("role is not enabled")
+	require.ErrorIs(t, api.PauseChallenger(ctx), b.err)
+	require.ErrorIs(t, api.RotateGuardianSigner(ctx, "http://signer", common.Address{0x03}), b.err)
+}
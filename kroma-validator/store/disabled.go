@@ -0,0 +1,45 @@
+package store
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type DisabledStore struct{}
+
+var Disabled = &DisabledStore{}
+
+var _ Store = (*DisabledStore)(nil)
+
+func (s *DisabledStore) Enabled() bool {
+	return false
+}
+
+func (s *DisabledStore) PutChallenge(_ *ChallengeRecord) error {
+	return nil
+}
+
+func (s *DisabledStore) GetChallenge(_ *big.Int, _ common.Address) (*ChallengeRecord, error) {
+	return nil, ErrNotFound
+}
+
+func (s *DisabledStore) DeleteChallenge(_ *big.Int, _ common.Address) error {
+	return nil
+}
+
+func (s *DisabledStore) Challenges() ([]*ChallengeRecord, error) {
+	return nil, nil
+}
+
+func (s *DisabledStore) PutCheckpoint(_ string, _ *Checkpoint) error {
+	return nil
+}
+
+func (s *DisabledStore) GetCheckpoint(_ string) (*Checkpoint, error) {
+	return nil, ErrNotFound
+}
+
+func (s *DisabledStore) Close() error {
+	return nil
+}
@@ -0,0 +1,270 @@
+package store
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	chal "github.com/kroma-network/kroma/kroma-validator/challenge"
+)
+
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrInvalidEntry = errors.New("invalid db entry")
+	ErrClosed       = errors.New("store is closed")
+)
+
+const (
+	// Keys are prefixed with a constant byte to allow us to differentiate different "columns" within the data
+	keyPrefixChallenge  byte = 0
+	keyPrefixCheckpoint byte = 1
+)
+
+const (
+	// CheckpointChallenger is the name of the checkpoint of the output index that the challenger last checked.
+	CheckpointChallenger = "challenger"
+	// CheckpointGuardian is the name of the checkpoint of the output index that the guardian last inspected.
+	CheckpointGuardian = "guardian"
+)
+
+// Store persists the state of the validator that must survive restarts.
+type Store interface {
+	Enabled() bool
+	PutChallenge(record *ChallengeRecord) error
+	GetChallenge(outputIndex *big.Int, challenger common.Address) (*ChallengeRecord, error)
+	DeleteChallenge(outputIndex *big.Int, challenger common.Address) error
+	Challenges() ([]*ChallengeRecord, error)
+	PutCheckpoint(name string, checkpoint *Checkpoint) error
+	GetCheckpoint(name string) (*Checkpoint, error)
+	Close() error
+}
+
+// Checkpoint is the last output index handled by a validator role, along with the L1 block it was computed at.
+// The L1 block is used to detect if the checkpoint has been invalidated by L1 reorg.
+type Checkpoint struct {
+	OutputIndex *big.Int    `json:"outputIndex"`
+	L1Block     eth.BlockID `json:"l1Block"`
+}
+
+// SegmentsRecord is a snapshot of the segments of a challenge at a specific turn.
+type SegmentsRecord struct {
+	Turn   uint8         `json:"turn"`
+	Start  uint64        `json:"start"`
+	Size   uint64        `json:"size"`
+	Hashes []common.Hash `json:"hashes"`
+}
+
+// ChallengeRecord is the locally persisted state of a challenge that the validator is involved in.
+type ChallengeRecord struct {
+	OutputIndex *big.Int                `json:"outputIndex"`
+	Asserter    common.Address          `json:"asserter"`
+	Challenger  common.Address          `json:"challenger"`
+	Status      uint8                   `json:"status"`
+	Segments    []*SegmentsRecord       `json:"segments,omitempty"`
+	ZkVMWitness string                  `json:"zkVMWitness,omitempty"`
+	ZkVMProof   *chal.ZkVMProofResponse `json:"zkVMProof,omitempty"`
+}
+
+// LastSegments returns the segments of the latest turn recorded, or nil if no segments are recorded.
+func (r *ChallengeRecord) LastSegments() *SegmentsRecord {
+	if len(r.Segments) == 0 {
+		return nil
+	}
+	return r.Segments[len(r.Segments)-1]
+}
+
+func challengeKey(outputIndex *big.Int, challenger common.Address) []byte {
+	key := make([]byte, 0, 29)
+	key = append(key, keyPrefixChallenge)
+	key = binary.BigEndian.AppendUint64(key, outputIndex.Uint64())
+	key = append(key, challenger.Bytes()...)
+	return key
+}
+
+func checkpointKey(name string) []byte {
+	key := make([]byte, 0, 1+len(name))
+	key = append(key, keyPrefixCheckpoint)
+	key = append(key, name...)
+	return key
+}
+
+func prefixIterRange(prefix byte) *pebble.IterOptions {
+	return &pebble.IterOptions{
+		LowerBound: []byte{prefix},
+		UpperBound: []byte{prefix + 1},
+	}
+}
+
+type PebbleStore struct {
+	// m ensures all read iterators are closed before closing the database by preventing concurrent read and write
+	// operations (with close considered a write operation).
+	m   sync.RWMutex
+	log log.Logger
+	db  *pebble.DB
+
+	writeOpts *pebble.WriteOptions
+
+	closed bool
+}
+
+var _ Store = (*PebbleStore)(nil)
+
+func NewPebbleStore(logger log.Logger, path string) (*PebbleStore, error) {
+	db, err := pebble.Open(path, &pebble.Options{})
+	if err != nil {
+		return nil, err
+	}
+	return &PebbleStore{
+		log:       logger,
+		db:        db,
+		writeOpts: &pebble.WriteOptions{Sync: true},
+	}, nil
+}
+
+func (s *PebbleStore) Enabled() bool {
+	return true
+}
+
+func (s *PebbleStore) PutChallenge(record *ChallengeRecord) error {
+	val, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode challenge record: %w", err)
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.closed {
+		return ErrClosed
+	}
+	if err := s.db.Set(challengeKey(record.OutputIndex, record.Challenger), val, s.writeOpts); err != nil {
+		return fmt.Errorf("failed to record challenge: %w", err)
+	}
+	return nil
+}
+
+func (s *PebbleStore) GetChallenge(outputIndex *big.Int, challenger common.Address) (*ChallengeRecord, error) {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	if s.closed {
+		return nil, ErrClosed
+	}
+	val, closer, err := s.db.Get(challengeKey(outputIndex, challenger))
+	if errors.Is(err, pebble.ErrNotFound) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+	return decodeChallengeRecord(val)
+}
+
+func (s *PebbleStore) DeleteChallenge(outputIndex *big.Int, challenger common.Address) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.closed {
+		return ErrClosed
+	}
+	if err := s.db.Delete(challengeKey(outputIndex, challenger), s.writeOpts); err != nil {
+		return fmt.Errorf("failed to delete challenge: %w", err)
+	}
+	return nil
+}
+
+// Challenges returns all the challenge records in ascending order of output index.
+func (s *PebbleStore) Challenges() ([]*ChallengeRecord, error) {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	if s.closed {
+		return nil, ErrClosed
+	}
+	iter, err := s.db.NewIter(prefixIterRange(keyPrefixChallenge))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+
+	var records []*ChallengeRecord
+	for valid := iter.First(); valid; valid = iter.Next() {
+		val, err := iter.ValueAndErr()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read entry: %w", err)
+		}
+		record, err := decodeChallengeRecord(val)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func (s *PebbleStore) PutCheckpoint(name string, checkpoint *Checkpoint) error {
+	val, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.closed {
+		return ErrClosed
+	}
+	if err := s.db.Set(checkpointKey(name), val, s.writeOpts); err != nil {
+		return fmt.Errorf("failed to record %s checkpoint: %w", name, err)
+	}
+	return nil
+}
+
+func (s *PebbleStore) GetCheckpoint(name string) (*Checkpoint, error) {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	if s.closed {
+		return nil, ErrClosed
+	}
+	val, closer, err := s.db.Get(checkpointKey(name))
+	if errors.Is(err, pebble.ErrNotFound) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+
+	var checkpoint Checkpoint
+	if err := json.Unmarshal(val, &checkpoint); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEntry, err)
+	}
+	if checkpoint.OutputIndex == nil {
+		return nil, ErrInvalidEntry
+	}
+	return &checkpoint, nil
+}
+
+func (s *PebbleStore) Close() error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.closed {
+		// Already closed
+		return nil
+	}
+	s.closed = true
+	return s.db.Close()
+}
+
+func decodeChallengeRecord(val []byte) (*ChallengeRecord, error) {
+	var record ChallengeRecord
+	if err := json.Unmarshal(val, &record); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEntry, err)
+	}
+	if record.OutputIndex == nil {
+		return nil, ErrInvalidEntry
+	}
+	return &record, nil
+}
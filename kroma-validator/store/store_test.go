@@ -0,0 +1,145 @@
+package store
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	chal "github.com/kroma-network/kroma/kroma-validator/challenge"
+)
+
+func TestStoreChallenges(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	dir := t.TempDir()
+	db, err := NewPebbleStore(logger, dir)
+	require.NoError(t, err)
+	defer db.Close()
+
+	recA := &ChallengeRecord{
+		OutputIndex: big.NewInt(3),
+		Asserter:    common.Address{0xaa},
+		Challenger:  common.Address{0xbb},
+		Status:      chal.StatusReadyToProve,
+		Segments: []*SegmentsRecord{
+			{Turn: 1, Start: 10, Size: 20, Hashes: []common.Hash{{0x01}, {0x02}, {0x03}}},
+			{Turn: 2, Start: 10, Size: 10, Hashes: []common.Hash{{0x01}, {0x04}}},
+		},
+		ZkVMWitness: "witness",
+		ZkVMProof: &chal.ZkVMProofResponse{
+			RequestStatus: chal.RequestCompleted,
+			ProgramVKey:   common.Hash{0xcc},
+			RequestID:     "id",
+			PublicValues:  chal.HexBytes{0x01, 0x02},
+			Proof:         chal.HexBytes{0x03, 0x04},
+		},
+	}
+	recB := &ChallengeRecord{
+		OutputIndex: big.NewInt(1),
+		Asserter:    common.Address{0xbb},
+		Challenger:  common.Address{0xaa},
+		Status:      chal.StatusChallengerTurn,
+	}
+	require.NoError(t, db.PutChallenge(recA))
+	require.NoError(t, db.PutChallenge(recB))
+
+	verifyChallenges := func(db *PebbleStore) {
+		actual, err := db.GetChallenge(recA.OutputIndex, recA.Challenger)
+		require.NoError(t, err)
+		require.Equal(t, recA, actual)
+		require.Equal(t, recA.Segments[1], actual.LastSegments())
+
+		_, err = db.GetChallenge(recA.OutputIndex, recA.Asserter)
+		require.ErrorIs(t, err, ErrNotFound)
+
+		records, err := db.Challenges()
+		require.NoError(t, err)
+		require.Equal(t, []*ChallengeRecord{recB, recA}, records)
+	}
+	// Verify loading the records with the already open DB
+	verifyChallenges(db)
+
+	// Close the DB and open a new instance
+	require.NoError(t, db.Close())
+	newDB, err := NewPebbleStore(logger, dir)
+	require.NoError(t, err)
+	defer newDB.Close()
+	// Verify the data is reloaded correctly
+	verifyChallenges(newDB)
+
+	require.NoError(t, newDB.DeleteChallenge(recB.OutputIndex, recB.Challenger))
+	records, err := newDB.Challenges()
+	require.NoError(t, err)
+	require.Equal(t, []*ChallengeRecord{recA}, records)
+}
+
+func TestStoreCheckpoints(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	dir := t.TempDir()
+	db, err := NewPebbleStore(logger, dir)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.GetCheckpoint(CheckpointChallenger)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	challengerCp := &Checkpoint{
+		OutputIndex: big.NewInt(10),
+		L1Block:     eth.BlockID{Hash: common.Hash{0x01}, Number: 100},
+	}
+	guardianCp := &Checkpoint{
+		OutputIndex: big.NewInt(7),
+		L1Block:     eth.BlockID{Hash: common.Hash{0x02}, Number: 90},
+	}
+	require.NoError(t, db.PutCheckpoint(CheckpointChallenger, challengerCp))
+	require.NoError(t, db.PutCheckpoint(CheckpointGuardian, guardianCp))
+
+	actual, err := db.GetCheckpoint(CheckpointChallenger)
+	require.NoError(t, err)
+	require.Equal(t, challengerCp, actual)
+
+	actual, err = db.GetCheckpoint(CheckpointGuardian)
+	require.NoError(t, err)
+	require.Equal(t, guardianCp, actual)
+
+	// checkpoints must not be listed as challenges
+	records, err := db.Challenges()
+	require.NoError(t, err)
+	require.Empty(t, records)
+}
+
+func TestStoreClosed(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewPebbleStore(logger, t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+	// closing again is a no-op
+	require.NoError(t, db.Close())
+
+	rec := &ChallengeRecord{OutputIndex: big.NewInt(1), Challenger: common.Address{0x01}}
+	require.ErrorIs(t, db.PutChallenge(rec), ErrClosed)
+	_, err = db.GetChallenge(rec.OutputIndex, rec.Challenger)
+	require.ErrorIs(t, err, ErrClosed)
+	require.ErrorIs(t, db.DeleteChallenge(rec.OutputIndex, rec.Challenger), ErrClosed)
+	_, err = db.Challenges()
+	require.ErrorIs(t, err, ErrClosed)
+	require.ErrorIs(t, db.PutCheckpoint(CheckpointChallenger, &Checkpoint{OutputIndex: big.NewInt(1)}), ErrClosed)
+	_, err = db.GetCheckpoint(CheckpointChallenger)
+	require.ErrorIs(t, err, ErrClosed)
+}
+
+func TestDisabledStore(t *testing.T) {
+	require.False(t, Disabled.Enabled())
+	require.NoError(t, Disabled.PutChallenge(&ChallengeRecord{OutputIndex: common.Big1}))
+	_, err := Disabled.GetChallenge(common.Big1, common.Address{})
+	require.ErrorIs(t, err, ErrNotFound)
+	records, err := Disabled.Challenges()
+	require.NoError(t, err)
+	require.Empty(t, records)
+	_, err = Disabled.GetCheckpoint(CheckpointGuardian)
+	require.ErrorIs(t, err, ErrNotFound)
+}
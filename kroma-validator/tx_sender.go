@@ -0,0 +1,191 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/kroma-network/kroma/kroma-bindings/bindings"
+	"github.com/kroma-network/kroma/kroma-validator/metrics"
+)
+
+const maxLoggedBytesLen = 64
+
+// txSender sends the transactions of the validator roles through the tx manager.
+// In dry-run mode, the transactions are built, signed and simulated against L1 instead of being broadcast.
+type txSender struct {
+	cfg   Config
+	log   log.Logger
+	metr  metrics.Metricer
+	txMgr *RoleTxManager
+
+	// abis are used to decode the arguments of the simulated transactions.
+	abis []*abi.ABI
+}
+
+// newTxSender creates a txSender which sends the transactions through the tx manager shared by the roles.
+func newTxSender(cfg Config, l log.Logger, m metrics.Metricer) (*txSender, error) {
+	return newRoleTxSender(cfg, l, m, NewRoleTxManager("", l, m, cfg.TxManager, nil))
+}
+
+// newRoleTxSender creates a txSender which sends the transactions through the tx manager of a role.
+func newRoleTxSender(cfg Config, l log.Logger, m metrics.Metricer, txMgr *RoleTxManager) (*txSender, error) {
+	var abis []*abi.ABI
+	for _, md := range []*bind.MetaData{
+		bindings.L2OutputOracleMetaData,
+		bindings.ColosseumMetaData,
+		bindings.SecurityCouncilMetaData,
+		bindings.AssetManagerMetaData,
+		bindings.ERC20MetaData,
+	} {
+		parsed, err := md.GetAbi()
+		if err != nil {
+			return nil, err
+		}
+		abis = append(abis, parsed)
+	}
+
+	return &txSender{
+		cfg:   cfg,
+		log:   l,
+		metr:  m,
+		txMgr: txMgr,
+		abis:  abis,
+	}, nil
+}
+
+// SendTxCandidate sends the tx candidate, or simulates it in dry-run mode.
+func (s *txSender) SendTxCandidate(ctx context.Context, candidate *txmgr.TxCandidate) *txmgr.TxResponse {
+	if s.cfg.DryRun {
+		return s.dryRun(ctx, candidate)
+	}
+	return s.txMgr.SendTxCandidate(ctx, candidate)
+}
+
+// SendTransaction sends the transaction built by contract bindings, or simulates it in dry-run mode.
+func (s *txSender) SendTransaction(ctx context.Context, tx *types.Transaction) *txmgr.TxResponse {
+	if s.cfg.DryRun {
+		return s.dryRun(ctx, &txmgr.TxCandidate{
+			TxData: tx.Data(),
+			To:     tx.To(),
+			Value:  tx.Value(),
+		})
+	}
+	return s.txMgr.SendTransaction(ctx, tx)
+}
+
+// dryRun builds and signs the transaction as the tx manager would, and simulates it against the latest L1 state.
+// The transaction is never broadcast, and no receipt is returned.
+func (s *txSender) dryRun(ctx context.Context, candidate *txmgr.TxCandidate) *txmgr.TxResponse {
+	method, args := s.decode(candidate.TxData)
+	txLog := s.log.New(append([]any{"method", method, "to", candidate.To}, args...)...)
+
+	tx, err := s.simulate(ctx, candidate)
+	s.metr.RecordDryRunTx(method, err == nil)
+	if err != nil {
+		txLog.Warn("dry-run: transaction simulation failed", "err", err)
+		return &txmgr.TxResponse{Err: fmt.Errorf("dry-run simulation of %s failed: %w", method, err)}
+	}
+
+	txLog.Info("dry-run: transaction simulated, not broadcasting",
+		"txHash", tx.Hash(), "nonce", tx.Nonce(), "gasLimit", tx.Gas(), "gasFeeCap", tx.GasFeeCap(), "gasTipCap", tx.GasTipCap())
+	return &txmgr.TxResponse{}
+}
+
+func (s *txSender) simulate(ctx context.Context, candidate *txmgr.TxCandidate) (*types.Transaction, error) {
+	txMgr := s.txMgr.TxManager()
+	from := txMgr.From()
+
+	cCtx, cCancel := context.WithTimeout(ctx, s.cfg.NetworkTimeout)
+	defer cCancel()
+	gasTipCap, baseFee, _, err := txMgr.SuggestGasPriceCaps(cCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price info: %w", err)
+	}
+	gasFeeCap := txmgr.CalcGasFeeCap(baseFee, gasTipCap)
+
+	msg := ethereum.CallMsg{
+		From:      from,
+		To:        candidate.To,
+		GasFeeCap: gasFeeCap,
+		GasTipCap: gasTipCap,
+		Data:      candidate.TxData,
+		Value:     candidate.Value,
+	}
+
+	cCtx, cCancel = context.WithTimeout(ctx, s.cfg.NetworkTimeout)
+	defer cCancel()
+	if _, err := s.cfg.L1Client.CallContract(cCtx, msg, nil); err != nil {
+		return nil, fmt.Errorf("failed to call: %w", err)
+	}
+
+	gasLimit := candidate.GasLimit
+	if gasLimit == 0 {
+		cCtx, cCancel = context.WithTimeout(ctx, s.cfg.NetworkTimeout)
+		defer cCancel()
+		gasLimit, err = s.cfg.L1Client.EstimateGas(cCtx, msg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate gas: %w", err)
+		}
+	}
+
+	cCtx, cCancel = context.WithTimeout(ctx, s.cfg.NetworkTimeout)
+	defer cCancel()
+	nonce, err := s.cfg.L1Client.PendingNonceAt(cCtx, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce: %w", err)
+	}
+
+	txData := &types.DynamicFeeTx{
+		ChainID:    txMgr.Config.ChainID,
+		Nonce:      nonce,
+		GasTipCap:  gasTipCap,
+		GasFeeCap:  gasFeeCap,
+		Gas:        gasLimit,
+		To:         candidate.To,
+		Value:      candidate.Value,
+		Data:       candidate.TxData,
+		AccessList: candidate.AccessList,
+	}
+
+	cCtx, cCancel = context.WithTimeout(ctx, s.cfg.NetworkTimeout)
+	defer cCancel()
+	tx, err := txMgr.Config.Signer(cCtx, from, types.NewTx(txData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// decode returns the method name and the named arguments of the tx data to be logged.
+func (s *txSender) decode(data []byte) (string, []any) {
+	if len(data) < 4 {
+		return "unknown", nil
+	}
+	for _, parsed := range s.abis {
+		method, err := parsed.MethodById(data[:4])
+		if err != nil {
+			continue
+		}
+		values, err := method.Inputs.Unpack(data[4:])
+		if err != nil {
+			return method.RawName, nil
+		}
+		args := make([]any, 0, 2*len(values))
+		for i, value := range values {
+			// avoid flooding the logs with proofs
+			if b, ok := value.([]byte); ok && len(b) > maxLoggedBytesLen {
+				value = fmt.Sprintf("%d bytes", len(b))
+			}
+			args = append(args, method.Inputs[i].Name, value)
+		}
+		return method.RawName, args
+	}
+	return "unknown", nil
+}
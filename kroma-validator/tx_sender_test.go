@@ -0,0 +1,113 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	txmetrics "github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/kroma-validator/metrics"
+)
+
+// testL1RPC serves the eth namespace methods used to simulate a transaction.
+type testL1RPC struct {
+	callErr error
+	calls   []map[string]any
+	sent    int
+}
+
+func (r *testL1RPC) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(900))
+}
+
+func (r *testL1RPC) MaxPriorityFeePerGas() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(1))
+}
+
+func (r *testL1RPC) GetBlockByNumber(_ string, _ bool) *types.Header {
+	return &types.Header{Number: big.NewInt(1), BaseFee: big.NewInt(10), Difficulty: common.Big0}
+}
+
+func (r *testL1RPC) Call(args map[string]any, _ string) (hexutil.Bytes, error) {
+	r.calls = append(r.calls, args)
+	return nil, r.callErr
+}
+
+func (r *testL1RPC) EstimateGas(_ map[string]any) hexutil.Uint64 {
+	return 21_000
+}
+
+func (r *testL1RPC) GetTransactionCount(_ common.Address, _ string) hexutil.Uint64 {
+	return 7
+}
+
+func (r *testL1RPC) SendRawTransaction(_ hexutil.Bytes) error {
+	r.sent++
+	return errors.New("unexpected broadcast")
+}
+
+func newDryRunTxSender(t *testing.T, l1 *testL1RPC) *txSender {
+	srv := gethrpc.NewServer()
+	require.NoError(t, srv.RegisterName("eth", l1))
+	t.Cleanup(srv.Stop)
+	client := ethclient.NewClient(gethrpc.DialInProc(srv))
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	l1Server := httptest.NewServer(srv)
+	t.Cleanup(l1Server.Close)
+	txMgrCfg := txmgr.NewCLIConfig(l1Server.URL, txmgr.DefaultBatcherFlagValues)
+	txMgrCfg.PrivateKey = hexutil.Encode(crypto.FromECDSA(key))[2:]
+	logger := testlog.Logger(t, log.LevelInfo)
+	txMgr, err := txmgr.NewBufferedTxManager("test", logger, &txmetrics.NoopTxMetrics{}, txMgrCfg)
+	require.NoError(t, err)
+
+	cfg := Config{L1Client: client, NetworkTimeout: time.Second, DryRun: true}
+	s, err := newRoleTxSender(cfg, logger, metrics.NoopMetrics, NewRoleTxManager("", logger, metrics.NoopMetrics, txMgr, nil))
+	require.NoError(t, err)
+	return s
+}
+
+func TestDryRunSendTransaction(t *testing.T) {
+	l1 := &testL1RPC{}
+	s := newDryRunTxSender(t, l1)
+
+	to := common.Address{0xaa}
+	value := big.NewInt(1234)
+	tx := types.NewTx(&types.DynamicFeeTx{To: &to, Value: value, Data: []byte{0x01, 0x02, 0x03, 0x04}})
+	res := s.SendTransaction(context.Background(), tx)
+	require.NoError(t, res.Err)
+	require.Nil(t, res.Receipt)
+	require.Zero(t, l1.sent)
+
+	require.Len(t, l1.calls, 1)
+	require.Equal(t, (*hexutil.Big)(value).String(), l1.calls[0]["value"])
+	require.Equal(t, to.Hex(), common.HexToAddress(l1.calls[0]["to"].(string)).Hex())
+}
+
+func TestDryRunSendTxCandidate_SimulationFailure(t *testing.T) {
+	l1 := &testL1RPC{callErr: errors.New("execution reverted")}
+	s := newDryRunTxSender(t, l1)
+
+	to := common.Address{0xaa}
+	res := s.SendTxCandidate(context.Background(), &txmgr.TxCandidate{To: &to, TxData: []byte{0x01, 0x02, 0x03, 0x04}})
+	require.ErrorContains(t, res.Err, "execution reverted")
+	require.Nil(t, res.Receipt)
+	require.Zero(t, l1.sent)
+	_, ok := l1.calls[0]["value"]
+	require.False(t, ok)
+}
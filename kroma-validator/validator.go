@@ -13,11 +13,13 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/optsutils"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 	"github.com/urfave/cli/v2"
 
 	"github.com/kroma-network/kroma/kroma-bindings/bindings"
 	"github.com/kroma-network/kroma/kroma-validator/flags"
 	"github.com/kroma-network/kroma/kroma-validator/metrics"
+	"github.com/kroma-network/kroma/kroma-validator/rpc"
 )
 
 // Main is the entrypoint into the Validator. This method executes the
@@ -27,6 +29,9 @@ func Main(version string, cliCtx *cli.Context) error {
 		return err
 	}
 	cfg := NewConfig(cliCtx)
+	if cfg.ChainsConfig != "" {
+		return mainMultiChain(version, cliCtx, cfg)
+	}
 	if err := cfg.Check(); err != nil {
 		return fmt.Errorf("invalid CLI flags: %w", err)
 	}
@@ -48,8 +53,17 @@ func Main(version string, cliCtx *cli.Context) error {
 
 	monitoring.MaybeStartPprof(ctx, cfg.PprofConfig, l)
 	monitoring.MaybeStartMetrics(ctx, cfg.MetricsConfig, l, m, validatorCfg.L1Client, validatorCfg.TxManager.From())
-	server, err := monitoring.StartRPC(cfg.RPCConfig, version, oprpc.WithLogger(l))
+
+	validator, err := NewValidator(*validatorCfg, l, m)
+	if err != nil {
+		return err
+	}
+
+	server, err := startRPC(cfg.RPCConfig, version, validator, l, m)
 	if err != nil {
+		if closeErr := validatorCfg.closeResources(); closeErr != nil {
+			l.Error("failed to close validator resources", "err", closeErr)
+		}
 		return err
 	}
 	defer func() {
@@ -61,11 +75,6 @@ func Main(version string, cliCtx *cli.Context) error {
 	m.RecordInfo(version)
 	m.RecordUp()
 
-	validator, err := NewValidator(*validatorCfg, l, m)
-	if err != nil {
-		return err
-	}
-
 	if err := validator.Start(); err != nil {
 		l.Error("failed to start validator", "err", err)
 		return err
@@ -79,6 +88,18 @@ func Main(version string, cliCtx *cli.Context) error {
 	return nil
 }
 
+// startRPC starts the RPC server serving the validator API, and the admin API if enabled.
+func startRPC(cfg oprpc.CLIConfig, version string, validator *Validator, l log.Logger, m metrics.Metricer) (*oprpc.Server, error) {
+	apis := []gethrpc.API{
+		rpc.GetValidatorAPI(rpc.NewValidatorAPI(validator, m, l)),
+	}
+	if cfg.EnableAdmin {
+		apis = append(apis, rpc.GetAdminAPI(rpc.NewAdminAPI(validator, m, l)))
+		l.Info("Admin RPC enabled")
+	}
+	return monitoring.StartRPC(cfg, version, oprpc.WithLogger(l), oprpc.WithAPIs(apis))
+}
+
 type Validator struct {
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -89,17 +110,28 @@ type Validator struct {
 	l2os       *L2OutputSubmitter
 	challenger *Challenger
 	guardian   *Guardian
+	// rewardManager applies the reward policy to the validator.
+	rewardManager *RewardManager
 
-	l2ooContract *bindings.L2OutputOracleCaller
+	l2ooContract   *bindings.L2OutputOracleCaller
+	valMgrContract *bindings.ValidatorManagerCaller
 }
 
-func NewValidator(cfg Config, l log.Logger, m metrics.Metricer) (*Validator, error) {
+// NewValidator creates the validator of the config. The resources of the config are closed if it fails.
+func NewValidator(cfg Config, l log.Logger, m metrics.Metricer) (validator *Validator, err error) {
+	defer func() {
+		if err != nil {
+			if closeErr := cfg.closeResources(); closeErr != nil {
+				l.Error("failed to close validator resources", "err", closeErr)
+			}
+		}
+	}()
+
 	// Validate the validator config
 	if err := cfg.Check(); err != nil {
 		return nil, err
 	}
 
-	var err error
 	var l2os *L2OutputSubmitter
 	if cfg.OutputSubmitterEnabled {
 		l2os, err = NewL2OutputSubmitter(cfg, l, m)
@@ -118,7 +150,15 @@ func NewValidator(cfg Config, l log.Logger, m metrics.Metricer) (*Validator, err
 
 	var guardian *Guardian
 	if cfg.GuardianEnabled {
-		guardian, err = NewGuardian(cfg, l)
+		guardian, err = NewGuardian(cfg, l, m)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var rewardManager *RewardManager
+	if cfg.RewardPolicyEnabled {
+		rewardManager, err = NewRewardManager(cfg, l, m)
 		if err != nil {
 			return nil, err
 		}
@@ -129,30 +169,49 @@ func NewValidator(cfg Config, l log.Logger, m metrics.Metricer) (*Validator, err
 		return nil, err
 	}
 
+	valMgrContract, err := bindings.NewValidatorManagerCaller(cfg.ValidatorManagerAddr, cfg.L1Client)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Validator{
-		cfg:          cfg,
-		l:            l,
-		metr:         m,
-		l2os:         l2os,
-		challenger:   challenger,
-		guardian:     guardian,
-		l2ooContract: l2ooContract,
+		cfg:            cfg,
+		l:              l,
+		metr:           m,
+		l2os:           l2os,
+		challenger:     challenger,
+		guardian:       guardian,
+		rewardManager:  rewardManager,
+		l2ooContract:   l2ooContract,
+		valMgrContract: valMgrContract,
 	}, nil
 }
 
 func (v *Validator) Start() error {
 	v.ctx, v.cancel = context.WithCancel(context.Background())
-	v.l.Info("starting Validator", "outputSubmitter", v.cfg.OutputSubmitterEnabled, "challenger", v.cfg.ChallengerEnabled, "guardian", v.cfg.GuardianEnabled)
+	v.l.Info("starting Validator", "outputSubmitter", v.cfg.OutputSubmitterEnabled, "challenger", v.cfg.ChallengerEnabled, "guardian", v.cfg.GuardianEnabled, "rewardPolicy", v.cfg.RewardPolicyEnabled)
 
 	// wait for kroma node to sync completed
 	v.waitSyncCompleted()
 
-	if v.cfg.TxManager != nil {
+	if v.cfg.TxManager != nil && !v.cfg.SharedL1 {
 		if err := v.cfg.TxManager.Start(v.ctx); err != nil {
 			return fmt.Errorf("cannot start TxManager: %w", err)
 		}
 	}
 
+	if v.cfg.TxManager != nil {
+		for role, enabled := range map[string]bool{
+			rpc.RoleOutputSubmitter: v.l2os != nil,
+			rpc.RoleChallenger:      v.challenger != nil && v.cfg.ChallengerEnabled,
+			rpc.RoleRewardManager:   v.rewardManager != nil,
+		} {
+			if enabled {
+				v.metr.RecordRoleSigner(role, v.cfg.TxManager.From())
+			}
+		}
+	}
+
 	if v.l2os != nil {
 		if err := v.l2os.Start(v.ctx); err != nil {
 			return fmt.Errorf("cannot start l2 output submitter: %w", err)
@@ -171,13 +230,24 @@ func (v *Validator) Start() error {
 		}
 	}
 
+	if v.rewardManager != nil {
+		if err := v.rewardManager.Start(v.ctx); err != nil {
+			return fmt.Errorf("cannot start reward manager: %w", err)
+		}
+	}
+
 	return nil
 }
 
 func (v *Validator) Stop() error {
 	v.l.Info("stopping Validator")
 
-	if v.cfg.TxManager != nil {
+	if v.cancel == nil {
+		// never started, so only the resources are released
+		return v.cfg.closeResources()
+	}
+
+	if v.cfg.TxManager != nil && !v.cfg.SharedL1 {
 		if err := v.cfg.TxManager.Stop(); err != nil {
 			return fmt.Errorf("failed to stop TxManager: %w", err)
 		}
@@ -201,9 +271,15 @@ func (v *Validator) Stop() error {
 		}
 	}
 
+	if v.rewardManager != nil {
+		if err := v.rewardManager.Stop(); err != nil {
+			return fmt.Errorf("failed to stop reward manager: %w", err)
+		}
+	}
+
 	v.cancel()
 
-	return nil
+	return v.cfg.closeResources()
 }
 
 func (v *Validator) waitSyncCompleted() {
@@ -0,0 +1,35 @@
+package chaos
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestFaults(t *testing.T) {
+	for _, kind := range []FaultKind{Partition, Delay, Crash, HealthFlap, SequencerHang} {
+		kind := kind
+		t.Run(kind.String(), func(t *testing.T) {
+			h := NewHarness(t, DefaultConfig())
+			// inject the fault into the leader, which is the first node after the setup.
+			h.Run(Schedule{
+				Faults: []Fault{{Kind: kind, Node: 0, Duration: time.Second, Delay: 100 * time.Millisecond}},
+				Settle: 2 * time.Second,
+			})
+		})
+	}
+}
+
+func TestRandomSchedule(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping random fault schedules in short mode")
+	}
+	for seed := int64(1); seed <= 3; seed++ {
+		seed := seed
+		t.Run(fmt.Sprintf("seed-%d", seed), func(t *testing.T) {
+			cfg := DefaultConfig()
+			h := NewHarness(t, cfg)
+			h.Run(RandomSchedule(seed, cfg.Nodes, 5))
+		})
+	}
+}
@@ -0,0 +1,466 @@
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/hashicorp/raft"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slog"
+
+	"github.com/ethereum-optimism/optimism/op-conductor/conductor"
+	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
+	"github.com/ethereum-optimism/optimism/op-conductor/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// Config is the configuration of the Harness.
+type Config struct {
+	// Nodes is the number of conductors in the cluster.
+	Nodes int
+	// BlockTime is the interval between the blocks built by the active sequencer.
+	BlockTime time.Duration
+	// HealthInterval is the interval between the health updates sent to the conductors.
+	HealthInterval time.Duration
+	// MaxActiveOverlap is how long more than one sequencer may be active at the same time, e.g. while the old leader
+	// steps down after losing the quorum.
+	MaxActiveOverlap time.Duration
+	// LogLevel is the level of the logs of the conductors.
+	LogLevel slog.Level
+}
+
+// DefaultConfig returns the default configuration of a cluster of 3 conductors.
+func DefaultConfig() Config {
+	return Config{
+		Nodes:            3,
+		BlockTime:        50 * time.Millisecond,
+		HealthInterval:   50 * time.Millisecond,
+		MaxActiveOverlap: time.Second,
+		LogLevel:         log.LevelCrit,
+	}
+}
+
+type node struct {
+	id   string
+	addr raft.ServerAddress
+
+	// the raft stores survive the crash of the node.
+	logs  *raft.InmemStore
+	snaps *raft.InmemSnapshotStore
+
+	seq *fakeSequencer
+
+	trans   *delayTransport
+	hmon    *fakeHealthMonitor
+	con     *conductor.OpConductor
+	running bool
+}
+
+// Harness runs a cluster of conductors in-process, connected by in-memory raft transports and controlling fake
+// sequencers. Faults are injected into the nodes while the invariants below are checked continuously:
+//   - at most one sequencer is active, for longer than Config.MaxActiveOverlap.
+//   - no unsafe payload is committed twice at different hashes.
+//
+// Raft runs in real time, so a schedule reproduces the same faults, but not necessarily the same interleaving.
+type Harness struct {
+	t   testing.TB
+	cfg Config
+	log log.Logger
+
+	genesis *eth.ExecutionPayloadEnvelope
+
+	// mu guards the nodes, the partitions and the invariants.
+	mu        sync.Mutex
+	nodes     []*node
+	isolated  map[int]bool
+	committed map[uint64]common.Hash
+	// overlapSince is the time since more than one sequencer is active.
+	overlapSince time.Time
+	violations   []string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewHarness starts a cluster of conductors, and waits for the first leader to start sequencing.
+func NewHarness(t testing.TB, cfg Config) *Harness {
+	ctx, cancel := context.WithCancel(context.Background())
+	h := &Harness{
+		t:         t,
+		cfg:       cfg,
+		log:       testlog.Logger(t, cfg.LogLevel),
+		genesis:   newGenesisPayload(),
+		isolated:  make(map[int]bool),
+		committed: make(map[uint64]common.Hash),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+	t.Cleanup(h.Close)
+
+	for i := 0; i < cfg.Nodes; i++ {
+		id := fmt.Sprintf("sequencer-%d", i)
+		h.nodes = append(h.nodes, &node{
+			id:    id,
+			addr:  raft.ServerAddress(id),
+			logs:  raft.NewInmemStore(),
+			snaps: raft.NewInmemSnapshotStore(),
+			seq:   newFakeSequencer(id, h.genesis),
+		})
+	}
+
+	for i := range h.nodes {
+		h.mu.Lock()
+		err := h.startNode(i, i == 0)
+		h.mu.Unlock()
+		require.NoError(t, err)
+	}
+
+	first := h.nodes[0]
+	require.Eventually(t, func() bool { return first.con.Leader(ctx) }, 10*time.Second, 10*time.Millisecond, "first node did not become leader")
+	for _, n := range h.nodes[1:] {
+		require.NoError(t, first.con.AddServerAsVoter(ctx, n.id, string(n.addr)))
+	}
+	require.NoError(t, first.con.CommitUnsafePayload(ctx, h.genesis))
+
+	h.wg.Add(2)
+	go h.sequence()
+	go h.checkInvariants()
+
+	h.RequireConverged(10 * time.Second)
+	return h
+}
+
+func (h *Harness) conductorConfig(id string) *conductor.Config {
+	now := uint64(time.Now().Unix())
+	return &conductor.Config{
+		ConsensusAddr:  "127.0.0.1",
+		ConsensusPort:  0,
+		RaftServerID:   id,
+		RaftStorageDir: "unused",
+		NodeRPC:        "unused",
+		ExecutionRPC:   "unused",
+		HealthCheck: conductor.HealthCheckConfig{
+			Interval:       1,
+			UnsafeInterval: 1,
+			SafeInterval:   1,
+			MinPeerCount:   1,
+		},
+		RollupCfg: rollup.Config{
+			Genesis: rollup.Genesis{
+				L1:     eth.BlockID{Hash: common.Hash{1}, Number: 1},
+				L2:     eth.BlockID{Hash: h.genesis.ExecutionPayload.BlockHash},
+				L2Time: now,
+				SystemConfig: eth.SystemConfig{
+					BatcherAddr: common.Address{1},
+					Overhead:    eth.Bytes32{1},
+					Scalar:      eth.Bytes32{1},
+					GasLimit:    30_000_000,
+				},
+			},
+			BlockTime:              1,
+			MaxSequencerDrift:      600,
+			SeqWindowSize:          3600,
+			ChannelTimeout:         300,
+			L1ChainID:              big.NewInt(1),
+			L2ChainID:              big.NewInt(2),
+			BatchInboxAddress:      common.Address{1, 2},
+			DepositContractAddress: common.Address{2, 3},
+			L1SystemConfigAddress:  common.Address{3, 4},
+		},
+		RPC: oprpc.CLIConfig{ListenAddr: "127.0.0.1", ListenPort: 0},
+	}
+}
+
+// startNode starts the conductor of the node on its existing raft stores, h.mu must be held.
+func (h *Harness) startNode(i int, bootstrap bool) error {
+	n := h.nodes[i]
+	n.trans = newDelayTransport(n.addr)
+	n.hmon = newFakeHealthMonitor(h.cfg.HealthInterval)
+	h.connect(i)
+
+	cfg := h.conductorConfig(n.id)
+	log := h.log.New("node", n.id)
+	cons, err := consensus.NewRaftConsensusWithStorage(log, &consensus.RaftConsensusConfig{
+		ServerID:             n.id,
+		ServerAddr:           string(n.addr),
+		Bootstrap:            bootstrap,
+		UnsafePayloadHistory: 16,
+		SnapshotHistory:      true,
+		RollupCfg:            &cfg.RollupCfg,
+		HeartbeatTimeout:     200 * time.Millisecond,
+		ElectionTimeout:      200 * time.Millisecond,
+		LeaderLeaseTimeout:   100 * time.Millisecond,
+	}, consensus.RaftStorage{
+		Transport:     n.trans,
+		LogStore:      n.logs,
+		StableStore:   n.logs,
+		SnapshotStore: n.snaps,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create consensus of %s: %w", n.id, err)
+	}
+	con, err := conductor.NewOpConductor(h.ctx, cfg, log, metrics.NoopMetrics, "chaos", n.seq, cons, n.hmon)
+	if err != nil {
+		_ = cons.Shutdown()
+		return fmt.Errorf("failed to create conductor of %s: %w", n.id, err)
+	}
+	if err := con.Start(h.ctx); err != nil {
+		_ = con.Stop(h.ctx)
+		return fmt.Errorf("failed to start conductor of %s: %w", n.id, err)
+	}
+	n.con = con
+	n.running = true
+	return nil
+}
+
+// stopNode crashes the node, h.mu must be held.
+func (h *Harness) stopNode(i int) error {
+	n := h.nodes[i]
+	if !n.running {
+		return nil
+	}
+	n.running = false
+	n.seq.crash()
+	return n.con.Stop(context.Background())
+}
+
+// connect connects the transport of the node with the transports of the reachable nodes, h.mu must be held.
+func (h *Harness) connect(i int) {
+	for j, peer := range h.nodes {
+		if j == i || peer.trans == nil || !h.reachable(i, j) {
+			continue
+		}
+		h.nodes[i].trans.Connect(peer.addr, peer.trans.InmemTransport)
+		peer.trans.Connect(h.nodes[i].addr, h.nodes[i].trans.InmemTransport)
+	}
+}
+
+// reachable returns true if the nodes are not partitioned from each other, h.mu must be held.
+func (h *Harness) reachable(i, j int) bool {
+	return !h.isolated[i] && !h.isolated[j]
+}
+
+// Inject injects the fault, and returns a function to heal it.
+func (h *Harness) Inject(f Fault) (heal func()) {
+	h.t.Logf("injecting %s", f)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	n := h.nodes[f.Node]
+
+	switch f.Kind {
+	case Partition:
+		h.isolated[f.Node] = true
+		n.trans.DisconnectAll()
+		for j, peer := range h.nodes {
+			if j != f.Node {
+				peer.trans.Disconnect(n.addr)
+			}
+		}
+		return func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			delete(h.isolated, f.Node)
+			h.connect(f.Node)
+		}
+	case Delay:
+		n.trans.setDelay(f.Delay)
+		return func() { n.trans.setDelay(0) }
+	case Crash:
+		if err := h.stopNode(f.Node); err != nil {
+			h.t.Errorf("failed to crash %s: %v", n.id, err)
+		}
+		return func() {
+			h.mu.Lock()
+			err := h.startNode(f.Node, false)
+			h.mu.Unlock()
+			require.NoError(h.t, err)
+		}
+	case HealthFlap:
+		n.hmon.flap(f.Duration)
+		return func() {}
+	case SequencerHang:
+		n.seq.hang(f.Duration)
+		return func() {}
+	}
+	h.t.Errorf("unknown fault %s", f.Kind)
+	return func() {}
+}
+
+// Run injects the faults of the schedule one after another. After each fault is healed, it waits for the nodes to
+// settle and requires the cluster to converge to a single active sequencer.
+func (h *Harness) Run(schedule Schedule) {
+	for _, f := range schedule.Faults {
+		heal := h.Inject(f)
+		select {
+		case <-time.After(f.Duration):
+		case <-h.ctx.Done():
+			return
+		}
+		heal()
+		h.RequireConverged(schedule.Settle + 10*time.Second)
+		h.RequireNoViolation()
+	}
+}
+
+// sequence builds a block on every active sequencer at every block time, commits it through the conductor and gossips
+// it to the reachable sequencers once it is committed.
+func (h *Harness) sequence() {
+	defer h.wg.Done()
+	ticker := time.NewTicker(h.cfg.BlockTime)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-h.ctx.Done():
+			return
+		}
+
+		h.mu.Lock()
+		nodes := make(map[int]*node)
+		for i, n := range h.nodes {
+			if n.running && n.seq.Active() {
+				nodes[i] = n
+			}
+		}
+		h.mu.Unlock()
+
+		for i, n := range nodes {
+			payload := n.seq.build()
+			if err := n.con.CommitUnsafePayload(h.ctx, payload); err != nil {
+				continue
+			}
+			h.recordCommitted(n.id, payload)
+			n.seq.insert(payload)
+			h.gossip(i)
+		}
+	}
+}
+
+// gossip syncs the reachable sequencers with the chain of the node.
+func (h *Harness) gossip(i int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for j, peer := range h.nodes {
+		if j != i && peer.running && h.reachable(i, j) {
+			peer.seq.sync(h.nodes[i].seq)
+		}
+	}
+}
+
+func (h *Harness) recordCommitted(source string, payload *eth.ExecutionPayloadEnvelope) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.recordCommittedLocked(source, payload)
+}
+
+func (h *Harness) recordCommittedLocked(source string, payload *eth.ExecutionPayloadEnvelope) {
+	number := uint64(payload.ExecutionPayload.BlockNumber)
+	hash := payload.ExecutionPayload.BlockHash
+	if prev, ok := h.committed[number]; ok && prev != hash {
+		h.violations = append(h.violations, fmt.Sprintf("block %d committed at %s and %s (seen by %s)", number, prev, hash, source))
+		return
+	}
+	h.committed[number] = hash
+}
+
+// checkInvariants samples the state of the nodes until the harness is closed.
+func (h *Harness) checkInvariants() {
+	defer h.wg.Done()
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-h.ctx.Done():
+			return
+		}
+
+		h.mu.Lock()
+		var active []string
+		for _, n := range h.nodes {
+			if n.seq.Active() {
+				active = append(active, n.id)
+			}
+			if n.running {
+				if payload := n.con.LatestUnsafePayload(h.ctx); payload != nil {
+					h.recordCommittedLocked(n.id, payload)
+				}
+			}
+		}
+		now := time.Now()
+		switch {
+		case len(active) <= 1:
+			h.overlapSince = time.Time{}
+		case h.overlapSince.IsZero():
+			h.overlapSince = now
+		case now.Sub(h.overlapSince) > h.cfg.MaxActiveOverlap:
+			h.violations = append(h.violations, fmt.Sprintf("sequencers %v active at the same time for %s", active, now.Sub(h.overlapSince)))
+			h.overlapSince = now
+		}
+		h.mu.Unlock()
+	}
+}
+
+// Violations returns the violations of the invariants found so far.
+func (h *Harness) Violations() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]string(nil), h.violations...)
+}
+
+// RequireNoViolation fails the test if any invariant is violated.
+func (h *Harness) RequireNoViolation() {
+	require.Empty(h.t, h.Violations(), "invariants violated")
+}
+
+// RequireConverged waits for a single sequencer to be active and to make progress.
+func (h *Harness) RequireConverged(timeout time.Duration) {
+	var (
+		active string
+		head   uint64
+	)
+	require.Eventually(h.t, func() bool {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		var actives []*node
+		for _, n := range h.nodes {
+			if n.running && n.seq.Active() {
+				actives = append(actives, n)
+			}
+		}
+		if len(actives) != 1 {
+			return false
+		}
+		number := uint64(actives[0].seq.Head().ExecutionPayload.BlockNumber)
+		if actives[0].id != active {
+			active, head = actives[0].id, number
+			return false
+		}
+		// require a few blocks to be built by the same sequencer
+		return number >= head+3
+	}, timeout, h.cfg.BlockTime, "cluster did not converge to a single active sequencer")
+}
+
+// Close stops all the nodes.
+func (h *Harness) Close() {
+	h.cancel()
+	h.wg.Wait()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for i := range h.nodes {
+		if err := h.stopNode(i); err != nil {
+			h.t.Logf("failed to stop %s: %v", h.nodes[i].id, err)
+		}
+	}
+}
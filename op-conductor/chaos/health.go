@@ -0,0 +1,86 @@
+package chaos
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-conductor/health"
+)
+
+var _ health.HealthMonitor = (*fakeHealthMonitor)(nil)
+
+// fakeHealthMonitor implements health.HealthMonitor, reporting the sequencer as healthy unless it is flapped.
+type fakeHealthMonitor struct {
+	interval time.Duration
+	healthCh chan error
+
+	mu             sync.Mutex
+	unhealthyUntil time.Time
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+func newFakeHealthMonitor(interval time.Duration) *fakeHealthMonitor {
+	return &fakeHealthMonitor{
+		interval: interval,
+		healthCh: make(chan error),
+		done:     make(chan struct{}),
+	}
+}
+
+// flap reports the sequencer as unhealthy for the given duration.
+func (m *fakeHealthMonitor) flap(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.unhealthyUntil = time.Now().Add(d)
+}
+
+func (m *fakeHealthMonitor) check() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if time.Now().Before(m.unhealthyUntil) {
+		return health.ErrSequencerNotHealthy
+	}
+	return nil
+}
+
+// Subscribe implements health.HealthMonitor.
+func (m *fakeHealthMonitor) Subscribe() <-chan error {
+	return m.healthCh
+}
+
+// Report implements health.HealthMonitor.
+func (m *fakeHealthMonitor) Report() *health.Report {
+	return nil
+}
+
+// Start implements health.HealthMonitor.
+func (m *fakeHealthMonitor) Start() error {
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				select {
+				case m.healthCh <- m.check():
+				case <-m.done:
+					return
+				}
+			case <-m.done:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop implements health.HealthMonitor.
+func (m *fakeHealthMonitor) Stop() error {
+	close(m.done)
+	m.wg.Wait()
+	return nil
+}
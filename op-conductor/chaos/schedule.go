@@ -0,0 +1,82 @@
+package chaos
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// FaultKind is the kind of fault injected into a node.
+type FaultKind int
+
+const (
+	// Partition isolates the node from the other nodes, both for raft and for gossip.
+	Partition FaultKind = iota
+	// Delay delays the outgoing raft messages of the node.
+	Delay
+	// Crash stops the conductor and the sequencer of the node, which are restarted when the fault is healed.
+	Crash
+	// HealthFlap reports the sequencer of the node as unhealthy.
+	HealthFlap
+	// SequencerHang makes the sequencer RPCs of the node hang.
+	SequencerHang
+)
+
+func (k FaultKind) String() string {
+	switch k {
+	case Partition:
+		return "partition"
+	case Delay:
+		return "delay"
+	case Crash:
+		return "crash"
+	case HealthFlap:
+		return "health_flap"
+	case SequencerHang:
+		return "sequencer_hang"
+	}
+	return "unknown"
+}
+
+// Fault is a fault injected into a node for a duration.
+type Fault struct {
+	Kind FaultKind
+	// Node is the index of the node the fault is injected into.
+	Node int
+	// Duration is how long the fault lasts before it is healed.
+	Duration time.Duration
+	// Delay is the delay of the outgoing messages, only used by the Delay fault.
+	Delay time.Duration
+}
+
+func (f Fault) String() string {
+	if f.Kind == Delay {
+		return fmt.Sprintf("%s(node=%d, duration=%s, delay=%s)", f.Kind, f.Node, f.Duration, f.Delay)
+	}
+	return fmt.Sprintf("%s(node=%d, duration=%s)", f.Kind, f.Node, f.Duration)
+}
+
+// Schedule is the sequence of faults injected one after another, the nodes settle for Settle after each fault.
+type Schedule struct {
+	Faults []Fault
+	Settle time.Duration
+}
+
+// RandomSchedule generates a schedule of n faults over the given number of nodes, the schedule is fully determined by
+// the seed so a failing run can be replayed.
+func RandomSchedule(seed int64, nodes int, n int) Schedule {
+	rng := rand.New(rand.NewSource(seed))
+	faults := make([]Fault, 0, n)
+	for i := 0; i < n; i++ {
+		fault := Fault{
+			Kind:     FaultKind(rng.Intn(int(SequencerHang) + 1)),
+			Node:     rng.Intn(nodes),
+			Duration: time.Duration(200+rng.Intn(800)) * time.Millisecond,
+		}
+		if fault.Kind == Delay {
+			fault.Delay = time.Duration(10+rng.Intn(90)) * time.Millisecond
+		}
+		faults = append(faults, fault)
+	}
+	return Schedule{Faults: faults, Settle: 2 * time.Second}
+}
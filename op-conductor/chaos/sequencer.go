@@ -0,0 +1,204 @@
+package chaos
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-conductor/client"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+var _ client.SequencerControl = (*fakeSequencer)(nil)
+
+// fakeSequencer implements client.SequencerControl with an in-memory chain of unsafe payloads.
+// The harness builds a block on top of the head while it is active, and gossips the committed blocks to the other
+// sequencers.
+type fakeSequencer struct {
+	id string
+
+	mu     sync.Mutex
+	chain  []*eth.ExecutionPayloadEnvelope // chain[i] is the payload of block i
+	active bool
+	// hangUntil makes the RPCs hang until the given time, modeling an unresponsive sequencer.
+	hangUntil time.Time
+}
+
+func newFakeSequencer(id string, genesis *eth.ExecutionPayloadEnvelope) *fakeSequencer {
+	return &fakeSequencer{id: id, chain: []*eth.ExecutionPayloadEnvelope{genesis}}
+}
+
+// newGenesisPayload creates the payload of block 0 shared by all sequencers.
+func newGenesisPayload() *eth.ExecutionPayloadEnvelope {
+	return newPayload(common.Hash{}, 0, "genesis")
+}
+
+// newPayload creates a payload of the given block number, the hash of the block commits to the parent and the builder,
+// so the blocks of the same number built by different sequencers have different hashes.
+func newPayload(parent common.Hash, number uint64, builder string) *eth.ExecutionPayloadEnvelope {
+	zero := hexutil.Uint64(0)
+	root := common.Hash{}
+	return &eth.ExecutionPayloadEnvelope{
+		ParentBeaconBlockRoot: &root,
+		ExecutionPayload: &eth.ExecutionPayload{
+			ParentHash:    parent,
+			BlockNumber:   eth.Uint64Quantity(number),
+			BlockHash:     crypto.Keccak256Hash(parent[:], []byte(builder), binary.BigEndian.AppendUint64(nil, number)),
+			Withdrawals:   &types.Withdrawals{},
+			ExcessBlobGas: &zero,
+			BlobGasUsed:   &zero,
+		},
+	}
+}
+
+func (s *fakeSequencer) wait(ctx context.Context) error {
+	s.mu.Lock()
+	until := s.hangUntil
+	s.mu.Unlock()
+	if d := time.Until(until); d > 0 {
+		select {
+		case <-time.After(d):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (s *fakeSequencer) hang(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hangUntil = time.Now().Add(d)
+}
+
+// LatestUnsafeBlock implements client.SequencerControl.
+func (s *fakeSequencer) LatestUnsafeBlock(ctx context.Context) (eth.BlockInfo, error) {
+	if err := s.wait(ctx); err != nil {
+		return nil, err
+	}
+	head := s.Head()
+	return &testutils.MockBlockInfo{
+		InfoHash:       head.ExecutionPayload.BlockHash,
+		InfoParentHash: head.ExecutionPayload.ParentHash,
+		InfoNum:        uint64(head.ExecutionPayload.BlockNumber),
+	}, nil
+}
+
+// StartSequencer implements client.SequencerControl.
+func (s *fakeSequencer) StartSequencer(ctx context.Context, hash common.Hash) error {
+	if err := s.wait(ctx); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.active {
+		return driver.ErrSequencerAlreadyStarted
+	}
+	if head := s.chain[len(s.chain)-1]; head.ExecutionPayload.BlockHash != hash {
+		return fmt.Errorf("block hash does not match: head %s, received %s", head.ExecutionPayload.BlockHash, hash)
+	}
+	s.active = true
+	return nil
+}
+
+// StopSequencer implements client.SequencerControl.
+func (s *fakeSequencer) StopSequencer(ctx context.Context) (common.Hash, error) {
+	if err := s.wait(ctx); err != nil {
+		return common.Hash{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.active {
+		return common.Hash{}, driver.ErrSequencerAlreadyStopped
+	}
+	s.active = false
+	return s.chain[len(s.chain)-1].ExecutionPayload.BlockHash, nil
+}
+
+// SequencerActive implements client.SequencerControl.
+func (s *fakeSequencer) SequencerActive(ctx context.Context) (bool, error) {
+	if err := s.wait(ctx); err != nil {
+		return false, err
+	}
+	return s.Active(), nil
+}
+
+// PostUnsafePayload implements client.SequencerControl.
+func (s *fakeSequencer) PostUnsafePayload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error {
+	if err := s.wait(ctx); err != nil {
+		return err
+	}
+	if !s.insert(payload) {
+		return fmt.Errorf("payload %d does not extend the head", payload.ExecutionPayload.BlockNumber)
+	}
+	return nil
+}
+
+// Active returns true if the sequencer is active, without hanging.
+func (s *fakeSequencer) Active() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.active
+}
+
+// Head returns the latest unsafe payload.
+func (s *fakeSequencer) Head() *eth.ExecutionPayloadEnvelope {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.chain[len(s.chain)-1]
+}
+
+// crash stops sequencing without going through the conductor, as the host of the sequencer is down.
+func (s *fakeSequencer) crash() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.active = false
+	s.hangUntil = time.Time{}
+}
+
+// insert appends the payload if it extends the head, returns false otherwise.
+func (s *fakeSequencer) insert(payload *eth.ExecutionPayloadEnvelope) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	head := s.chain[len(s.chain)-1]
+	if uint64(payload.ExecutionPayload.BlockNumber) != uint64(head.ExecutionPayload.BlockNumber)+1 ||
+		payload.ExecutionPayload.ParentHash != head.ExecutionPayload.BlockHash {
+		return false
+	}
+	s.chain = append(s.chain, payload)
+	return true
+}
+
+// sync receives a block gossiped by a peer. If there's a gap, the missing blocks are fetched from the peer, and if the
+// chain of the peer has diverged, the chain is reorged to the one of the peer.
+func (s *fakeSequencer) sync(peer *fakeSequencer) {
+	peer.mu.Lock()
+	peerChain := peer.chain
+	peer.mu.Unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(peerChain) <= len(s.chain) {
+		return
+	}
+	n := 0
+	for n < len(s.chain) && s.chain[n].ExecutionPayload.BlockHash == peerChain[n].ExecutionPayload.BlockHash {
+		n++
+	}
+	s.chain = append(s.chain[:n:n], peerChain[n:]...)
+}
+
+// build creates the next block on top of the head.
+func (s *fakeSequencer) build() *eth.ExecutionPayloadEnvelope {
+	head := s.Head()
+	return newPayload(head.ExecutionPayload.BlockHash, uint64(head.ExecutionPayload.BlockNumber)+1, s.id)
+}
@@ -0,0 +1,69 @@
+package chaos
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/raft"
+)
+
+var _ raft.Transport = (*delayTransport)(nil)
+
+// delayTransport wraps raft.InmemTransport to delay the outgoing RPCs of a server.
+// Pipelining is not supported so every AppendEntries RPC is delayed.
+type delayTransport struct {
+	*raft.InmemTransport
+
+	mu    sync.Mutex
+	delay time.Duration
+}
+
+func newDelayTransport(addr raft.ServerAddress) *delayTransport {
+	_, trans := raft.NewInmemTransportWithTimeout(addr, 500*time.Millisecond)
+	return &delayTransport{InmemTransport: trans}
+}
+
+func (d *delayTransport) setDelay(delay time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.delay = delay
+}
+
+func (d *delayTransport) wait() {
+	d.mu.Lock()
+	delay := d.delay
+	d.mu.Unlock()
+	if delay > 0 {
+		time.Sleep(delay)
+	}
+}
+
+// AppendEntriesPipeline implements raft.Transport.
+func (d *delayTransport) AppendEntriesPipeline(_ raft.ServerID, _ raft.ServerAddress) (raft.AppendPipeline, error) {
+	return nil, raft.ErrPipelineReplicationNotSupported
+}
+
+// AppendEntries implements raft.Transport.
+func (d *delayTransport) AppendEntries(id raft.ServerID, target raft.ServerAddress, args *raft.AppendEntriesRequest, resp *raft.AppendEntriesResponse) error {
+	d.wait()
+	return d.InmemTransport.AppendEntries(id, target, args, resp)
+}
+
+// RequestVote implements raft.Transport.
+func (d *delayTransport) RequestVote(id raft.ServerID, target raft.ServerAddress, args *raft.RequestVoteRequest, resp *raft.RequestVoteResponse) error {
+	d.wait()
+	return d.InmemTransport.RequestVote(id, target, args, resp)
+}
+
+// InstallSnapshot implements raft.Transport.
+func (d *delayTransport) InstallSnapshot(id raft.ServerID, target raft.ServerAddress, args *raft.InstallSnapshotRequest, resp *raft.InstallSnapshotResponse, data io.Reader) error {
+	d.wait()
+	return d.InmemTransport.InstallSnapshot(id, target, args, resp, data)
+}
+
+// TimeoutNow implements raft.Transport.
+func (d *delayTransport) TimeoutNow(id raft.ServerID, target raft.ServerAddress, args *raft.TimeoutNowRequest, resp *raft.TimeoutNowResponse) error {
+	d.wait()
+	return d.InmemTransport.TimeoutNow(id, target, args, resp)
+}
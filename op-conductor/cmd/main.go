@@ -10,9 +10,11 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-conductor/conductor"
 	"github.com/ethereum-optimism/optimism/op-conductor/flags"
+	"github.com/ethereum-optimism/optimism/op-conductor/metrics"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	"github.com/ethereum-optimism/optimism/op-service/cliapp"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/metrics/doc"
 	"github.com/ethereum-optimism/optimism/op-service/opio"
 )
 
@@ -32,7 +34,12 @@ func main() {
 	app.Usage = "Optimism Sequencer Conductor Service"
 	app.Description = "op-conductor help sequencer to run in highly available mode"
 	app.Action = cliapp.LifecycleCmd(OpConductorMain)
-	app.Commands = []*cli.Command{}
+	app.Commands = []*cli.Command{
+		{
+			Name:        "doc",
+			Subcommands: doc.NewSubcommands(metrics.NewMetrics("default")),
+		},
+	}
 
 	ctx := opio.WithInterruptBlocker(context.Background())
 	err := app.RunContext(ctx, os.Args)
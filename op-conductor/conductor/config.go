@@ -3,18 +3,22 @@ package conductor
 import (
 	"fmt"
 	"math"
+	"time"
 
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/pkg/errors"
 	"github.com/urfave/cli/v2"
 
+	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
 	"github.com/ethereum-optimism/optimism/op-conductor/flags"
+	"github.com/ethereum-optimism/optimism/op-conductor/health"
 	opnode "github.com/ethereum-optimism/optimism/op-node"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
 )
 
 type Config struct {
@@ -24,6 +28,12 @@ type Config struct {
 	// ConsensusPort is the port to listen for consensus connections.
 	ConsensusPort int
 
+	// ConsensusTLSEnabled is true if the consensus connections are secured with mutual TLS.
+	ConsensusTLSEnabled bool
+
+	// ConsensusTLS is the TLS configuration of the consensus connections.
+	ConsensusTLS optls.CLIConfig
+
 	// RaftServerID is the unique ID for this server used by raft consensus.
 	RaftServerID string
 
@@ -33,6 +43,17 @@ type Config struct {
 	// RaftBootstrap is true if this node should bootstrap a new raft cluster.
 	RaftBootstrap bool
 
+	// RaftUnsafePayloadHistory is the number of recent unsafe payloads kept in the raft FSM, only the latest one is
+	// kept if zero.
+	RaftUnsafePayloadHistory int
+
+	// RaftSnapshotHistory is true if the raft snapshots hold the unsafe payload history, which only the servers
+	// supporting the history can restore.
+	RaftSnapshotHistory bool
+
+	// RaftMembership is the configuration of the automatic raft cluster membership reconciliation.
+	RaftMembership RaftMembershipConfig
+
 	// NodeRPC is the HTTP provider URL for op-node.
 	NodeRPC string
 
@@ -65,12 +86,26 @@ func (c *Config) Check() error {
 	if c.ConsensusPort < 0 || c.ConsensusPort > math.MaxUint16 {
 		return fmt.Errorf("invalid RPC port")
 	}
+	if c.ConsensusTLSEnabled {
+		if !c.ConsensusTLS.TLSEnabled() {
+			return fmt.Errorf("missing consensus tls config")
+		}
+		if err := c.ConsensusTLS.Check(); err != nil {
+			return errors.Wrap(err, "invalid consensus tls config")
+		}
+	}
 	if c.RaftServerID == "" {
 		return fmt.Errorf("missing raft server ID")
 	}
 	if c.RaftStorageDir == "" {
 		return fmt.Errorf("missing raft storage directory")
 	}
+	if c.RaftUnsafePayloadHistory < 0 {
+		return fmt.Errorf("invalid raft unsafe payload history")
+	}
+	if err := c.RaftMembership.Check(); err != nil {
+		return errors.Wrap(err, "invalid raft membership config")
+	}
 	if c.NodeRPC == "" {
 		return fmt.Errorf("missing node RPC")
 	}
@@ -107,19 +142,37 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*Config, error) {
 	}
 
 	return &Config{
-		ConsensusAddr:  ctx.String(flags.ConsensusAddr.Name),
-		ConsensusPort:  ctx.Int(flags.ConsensusPort.Name),
-		RaftBootstrap:  ctx.Bool(flags.RaftBootstrap.Name),
-		RaftServerID:   ctx.String(flags.RaftServerID.Name),
-		RaftStorageDir: ctx.String(flags.RaftStorageDir.Name),
-		NodeRPC:        ctx.String(flags.NodeRPC.Name),
-		ExecutionRPC:   ctx.String(flags.ExecutionRPC.Name),
-		Paused:         ctx.Bool(flags.Paused.Name),
+		ConsensusAddr:            ctx.String(flags.ConsensusAddr.Name),
+		ConsensusPort:            ctx.Int(flags.ConsensusPort.Name),
+		ConsensusTLSEnabled:      ctx.Bool(flags.ConsensusTLSEnabled.Name),
+		ConsensusTLS:             optls.ReadCLIConfigWithPrefix(ctx, flags.ConsensusTLSFlagPrefix),
+		RaftBootstrap:            ctx.Bool(flags.RaftBootstrap.Name),
+		RaftServerID:             ctx.String(flags.RaftServerID.Name),
+		RaftStorageDir:           ctx.String(flags.RaftStorageDir.Name),
+		RaftUnsafePayloadHistory: ctx.Int(flags.RaftUnsafePayloadHistory.Name),
+		RaftSnapshotHistory:      ctx.Bool(flags.RaftSnapshotHistory.Name),
+		NodeRPC:                  ctx.String(flags.NodeRPC.Name),
+		ExecutionRPC:             ctx.String(flags.ExecutionRPC.Name),
+		Paused:                   ctx.Bool(flags.Paused.Name),
+		RaftMembership: RaftMembershipConfig{
+			Voters:                ctx.StringSlice(flags.RaftMembershipVoters.Name),
+			Nonvoters:             ctx.StringSlice(flags.RaftMembershipNonvoters.Name),
+			File:                  ctx.String(flags.RaftMembershipFile.Name),
+			Interval:              ctx.Duration(flags.RaftMembershipInterval.Name),
+			DeadServerGracePeriod: ctx.Duration(flags.RaftMembershipDeadServerGracePeriod.Name),
+			MinVoters:             ctx.Int(flags.RaftMembershipMinVoters.Name),
+		},
 		HealthCheck: HealthCheckConfig{
 			Interval:       ctx.Uint64(flags.HealthCheckInterval.Name),
 			UnsafeInterval: ctx.Uint64(flags.HealthCheckUnsafeInterval.Name),
 			SafeInterval:   ctx.Uint64(flags.HealthCheckSafeInterval.Name),
 			MinPeerCount:   ctx.Uint64(flags.HealthCheckMinPeerCount.Name),
+			Rules:          ctx.StringSlice(flags.HealthCheckRules.Name),
+			L1Interval:     ctx.Uint64(flags.HealthCheckL1Interval.Name),
+			MaxBatcherLag:  ctx.Uint64(flags.HealthCheckMaxBatcherLag.Name),
+			MaxTxPoolSize:  ctx.Uint64(flags.HealthCheckMaxTxPoolSize.Name),
+			MinDiskFree:    ctx.Uint64(flags.HealthCheckMinDiskFree.Name),
+			DiskPath:       ctx.String(flags.HealthCheckDiskPath.Name),
 		},
 		RollupCfg:      *rollupCfg,
 		RPCEnableProxy: ctx.Bool(flags.RPCEnableProxy.Name),
@@ -130,6 +183,57 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*Config, error) {
 	}, nil
 }
 
+// RaftMembershipConfig defines the desired members of the raft cluster, which the leader converges the cluster
+// membership toward. The members are either given statically or read from a file.
+type RaftMembershipConfig struct {
+	// Voters is the desired voting members given as <id>=<addr>.
+	Voters []string
+
+	// Nonvoters is the desired non-voting members given as <id>=<addr>.
+	Nonvoters []string
+
+	// File is the path of the JSON file listing the desired members, which is reloaded on every reconciliation.
+	File string
+
+	// Interval is the interval between membership reconciliations.
+	Interval time.Duration
+
+	// DeadServerGracePeriod is how long a member can be unreachable before it is removed from the cluster.
+	DeadServerGracePeriod time.Duration
+
+	// MinVoters is the number of voters below which no voter is removed or demoted.
+	MinVoters int
+}
+
+// Enabled returns true if the desired members are configured.
+func (c *RaftMembershipConfig) Enabled() bool {
+	return len(c.Voters) > 0 || len(c.Nonvoters) > 0 || c.File != ""
+}
+
+func (c *RaftMembershipConfig) Check() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if c.File != "" && (len(c.Voters) > 0 || len(c.Nonvoters) > 0) {
+		return fmt.Errorf("members must be given either statically or by file, not both")
+	}
+	if c.File == "" {
+		if _, err := consensus.NewStaticMembership(c.Voters, c.Nonvoters); err != nil {
+			return err
+		}
+	}
+	if c.Interval == 0 {
+		return fmt.Errorf("missing membership reconciliation interval")
+	}
+	if c.DeadServerGracePeriod == 0 {
+		return fmt.Errorf("missing dead server grace period")
+	}
+	if c.MinVoters < 1 {
+		return fmt.Errorf("invalid minimum number of voters")
+	}
+	return nil
+}
+
 // HealthCheckConfig defines health check configuration.
 type HealthCheckConfig struct {
 	// Interval is the interval (in seconds) to check the health of the sequencer.
@@ -143,6 +247,24 @@ type HealthCheckConfig struct {
 
 	// MinPeerCount is the minimum number of peers required for the sequencer to be healthy.
 	MinPeerCount uint64
+
+	// Rules is the names of the health check rules to enable, health.DefaultRules are enabled if empty.
+	Rules []string
+
+	// L1Interval is the interval allowed between L1 head seen by the sequencer and now in seconds.
+	L1Interval uint64
+
+	// MaxBatcherLag is the maximum number of blocks the safe head can be behind the unsafe head.
+	MaxBatcherLag uint64
+
+	// MaxTxPoolSize is the maximum number of transactions in the txpool of the execution layer.
+	MaxTxPoolSize uint64
+
+	// MinDiskFree is the minimum free disk space in bytes.
+	MinDiskFree uint64
+
+	// DiskPath is the path on the disk to check the free space of.
+	DiskPath string
 }
 
 func (c *HealthCheckConfig) Check() error {
@@ -155,5 +277,29 @@ func (c *HealthCheckConfig) Check() error {
 	if c.MinPeerCount == 0 {
 		return fmt.Errorf("missing minimum peer count")
 	}
+	for _, rule := range c.Rules {
+		switch rule {
+		case health.RuleUnsafeHeadProgress, health.RuleUnsafeHeadLag, health.RuleSafeHeadLag, health.RulePeerCount,
+			health.RuleExecutionSync:
+		case health.RuleTxPoolSize:
+			if c.MaxTxPoolSize == 0 {
+				return fmt.Errorf("missing maximum txpool size")
+			}
+		case health.RuleL1HeadLag:
+			if c.L1Interval == 0 {
+				return fmt.Errorf("missing L1 interval")
+			}
+		case health.RuleBatcherLag:
+			if c.MaxBatcherLag == 0 {
+				return fmt.Errorf("missing maximum batcher lag")
+			}
+		case health.RuleDiskSpace:
+			if c.MinDiskFree == 0 {
+				return fmt.Errorf("missing minimum free disk space")
+			}
+		default:
+			return fmt.Errorf("unknown health check rule: %s", rule)
+		}
+	}
 	return nil
 }
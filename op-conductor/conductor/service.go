@@ -9,6 +9,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/hashicorp/go-multierror"
@@ -18,6 +19,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-conductor/client"
 	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
 	"github.com/ethereum-optimism/optimism/op-conductor/health"
+	"github.com/ethereum-optimism/optimism/op-conductor/metrics"
 	conductorrpc "github.com/ethereum-optimism/optimism/op-conductor/rpc"
 	opp2p "github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
@@ -25,6 +27,8 @@ import (
 	opclient "github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/httputil"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 )
@@ -38,7 +42,11 @@ var (
 
 // New creates a new OpConductor instance.
 func New(ctx context.Context, cfg *Config, log log.Logger, version string) (*OpConductor, error) {
-	return NewOpConductor(ctx, cfg, log, version, nil, nil, nil)
+	var m metrics.Metricer = metrics.NoopMetrics
+	if cfg.MetricsConfig.Enabled {
+		m = metrics.NewMetrics("default")
+	}
+	return NewOpConductor(ctx, cfg, log, m, version, nil, nil, nil)
 }
 
 // NewOpConductor creates a new OpConductor instance.
@@ -46,6 +54,7 @@ func NewOpConductor(
 	ctx context.Context,
 	cfg *Config,
 	log log.Logger,
+	m metrics.Metricer,
 	version string,
 	ctrl client.SequencerControl,
 	cons consensus.Consensus,
@@ -57,6 +66,7 @@ func NewOpConductor(
 
 	oc := &OpConductor{
 		log:          log,
+		metrics:      m,
 		version:      version,
 		cfg:          cfg,
 		pauseCh:      make(chan struct{}),
@@ -140,11 +150,42 @@ func (c *OpConductor) initSequencerControl(ctx context.Context) error {
 
 func (c *OpConductor) initConsensus(ctx context.Context) error {
 	if c.cons != nil {
+		c.leaderUpdateCh = c.cons.LeaderCh()
 		return nil
 	}
 
 	serverAddr := fmt.Sprintf("%s:%d", c.cfg.ConsensusAddr, c.cfg.ConsensusPort)
-	cons, err := consensus.NewRaftConsensus(c.log, c.cfg.RaftServerID, serverAddr, c.cfg.RaftStorageDir, c.cfg.RaftBootstrap, &c.cfg.RollupCfg)
+	raftCfg := &consensus.RaftConsensusConfig{
+		ServerID:             c.cfg.RaftServerID,
+		ServerAddr:           serverAddr,
+		StorageDir:           c.cfg.RaftStorageDir,
+		Bootstrap:            c.cfg.RaftBootstrap,
+		UnsafePayloadHistory: c.cfg.RaftUnsafePayloadHistory,
+		SnapshotHistory:      c.cfg.RaftSnapshotHistory,
+		RollupCfg:            &c.cfg.RollupCfg,
+	}
+	if c.cfg.ConsensusTLSEnabled {
+		raftCfg.TLS = &c.cfg.ConsensusTLS
+	}
+	if membership := c.cfg.RaftMembership; membership.Enabled() {
+		var source consensus.MembershipSource
+		if membership.File != "" {
+			source = consensus.NewFileMembership(c.log, membership.File)
+		} else {
+			static, err := consensus.NewStaticMembership(membership.Voters, membership.Nonvoters)
+			if err != nil {
+				return errors.Wrap(err, "invalid raft membership")
+			}
+			source = static
+		}
+		raftCfg.Membership = &consensus.MembershipConfig{
+			Source:                source,
+			Interval:              membership.Interval,
+			DeadServerGracePeriod: membership.DeadServerGracePeriod,
+			MinVoters:             membership.MinVoters,
+		}
+	}
+	cons, err := consensus.NewRaftConsensus(c.log, raftCfg)
 	if err != nil {
 		return errors.Wrap(err, "failed to create raft consensus")
 	}
@@ -155,6 +196,7 @@ func (c *OpConductor) initConsensus(ctx context.Context) error {
 
 func (c *OpConductor) initHealthMonitor(ctx context.Context) error {
 	if c.hmon != nil {
+		c.healthUpdateCh = c.hmon.Subscribe()
 		return nil
 	}
 
@@ -164,27 +206,75 @@ func (c *OpConductor) initHealthMonitor(ctx context.Context) error {
 	}
 	node := sources.NewRollupClient(nc)
 
-	pc, err := rpc.DialContext(ctx, c.cfg.NodeRPC)
+	rules, err := c.initHealthRules(ctx)
 	if err != nil {
-		return errors.Wrap(err, "failed to create p2p rpc client")
-	}
-	p2p := opp2p.NewClient(pc)
-
-	c.hmon = health.NewSequencerHealthMonitor(
-		c.log,
-		c.cfg.HealthCheck.Interval,
-		c.cfg.HealthCheck.UnsafeInterval,
-		c.cfg.HealthCheck.SafeInterval,
-		c.cfg.HealthCheck.MinPeerCount,
-		&c.cfg.RollupCfg,
-		node,
-		p2p,
-	)
+		return err
+	}
+
+	c.hmon = health.NewSequencerHealthMonitor(c.log, c.metrics, c.cfg.HealthCheck.Interval, rules, node)
 	c.healthUpdateCh = c.hmon.Subscribe()
 
 	return nil
 }
 
+// initHealthRules creates the health check rules enabled in the config, in the order they are configured.
+func (c *OpConductor) initHealthRules(ctx context.Context) ([]health.Rule, error) {
+	hc := c.cfg.HealthCheck
+	if len(hc.Rules) == 0 {
+		hc.Rules = health.DefaultRules
+	}
+	var (
+		p2p        *opp2p.Client
+		execClient *rpc.Client
+	)
+	rules := make([]health.Rule, 0, len(hc.Rules))
+	for _, name := range hc.Rules {
+		switch name {
+		case health.RuleUnsafeHeadProgress:
+			rules = append(rules, health.NewUnsafeHeadProgressRule(c.log, &c.cfg.RollupCfg))
+		case health.RuleUnsafeHeadLag:
+			rules = append(rules, health.NewUnsafeHeadLagRule(c.log, hc.UnsafeInterval))
+		case health.RuleSafeHeadLag:
+			rules = append(rules, health.NewSafeHeadLagRule(c.log, hc.SafeInterval))
+		case health.RulePeerCount:
+			if p2p == nil {
+				pc, err := rpc.DialContext(ctx, c.cfg.NodeRPC)
+				if err != nil {
+					return nil, errors.Wrap(err, "failed to create p2p rpc client")
+				}
+				p2p = opp2p.NewClient(pc)
+			}
+			rules = append(rules, health.NewPeerCountRule(c.log, p2p, hc.MinPeerCount))
+		case health.RuleExecutionSync, health.RuleTxPoolSize:
+			if execClient == nil {
+				ec, err := rpc.DialContext(ctx, c.cfg.ExecutionRPC)
+				if err != nil {
+					return nil, errors.Wrap(err, "failed to create execution rpc client")
+				}
+				execClient = ec
+			}
+			if name == health.RuleExecutionSync {
+				rules = append(rules, health.NewExecutionSyncRule(c.log, ethclient.NewClient(execClient)))
+			} else {
+				rules = append(rules, health.NewTxPoolSizeRule(c.log, execClient, hc.MaxTxPoolSize))
+			}
+		case health.RuleL1HeadLag:
+			rules = append(rules, health.NewL1HeadLagRule(c.log, hc.L1Interval))
+		case health.RuleBatcherLag:
+			rules = append(rules, health.NewBatcherLagRule(c.log, hc.MaxBatcherLag))
+		case health.RuleDiskSpace:
+			path := hc.DiskPath
+			if path == "" {
+				path = c.cfg.RaftStorageDir
+			}
+			rules = append(rules, health.NewDiskSpaceRule(c.log, path, hc.MinDiskFree))
+		default:
+			return nil, fmt.Errorf("unknown health check rule: %s", name)
+		}
+	}
+	return rules, nil
+}
+
 func (oc *OpConductor) initRPCServer(ctx context.Context) error {
 	server := oprpc.NewServer(
 		oc.cfg.RPC.ListenAddr,
@@ -204,7 +294,7 @@ func (oc *OpConductor) initRPCServer(ctx context.Context) error {
 		if err != nil {
 			return errors.Wrap(err, "failed to create execution rpc client")
 		}
-		executionProxy := conductorrpc.NewExecutionProxyBackend(oc.log, oc, execClient)
+		executionProxy := conductorrpc.NewExecutionProxyBackend(oc.log, oc.metrics, oc, execClient)
 		server.AddAPI(rpc.API{
 			Namespace: conductorrpc.ExecutionRPCNamespace,
 			Service:   executionProxy,
@@ -214,13 +304,13 @@ func (oc *OpConductor) initRPCServer(ctx context.Context) error {
 		if err != nil {
 			return errors.Wrap(err, "failed to create node rpc client")
 		}
-		nodeProxy := conductorrpc.NewNodeProxyBackend(oc.log, oc, nodeClient)
+		nodeProxy := conductorrpc.NewNodeProxyBackend(oc.log, oc.metrics, oc, nodeClient)
 		server.AddAPI(rpc.API{
 			Namespace: conductorrpc.NodeRPCNamespace,
 			Service:   nodeProxy,
 		})
 
-		nodeAdminProxy := conductorrpc.NewNodeAdminProxyBackend(oc.log, oc, nodeClient)
+		nodeAdminProxy := conductorrpc.NewNodeAdminProxyBackend(oc.log, oc.metrics, oc, nodeClient)
 		server.AddAPI(rpc.API{
 			Namespace: conductorrpc.NodeAdminRPCNamespace,
 			Service:   nodeAdminProxy,
@@ -242,6 +332,7 @@ func (oc *OpConductor) initRPCServer(ctx context.Context) error {
 //  3. stopped: it is stopped, which means it is not participating in leader election and control loop. OpConductor cannot be started again from stopped mode.
 type OpConductor struct {
 	log     log.Logger
+	metrics metrics.Metricer
 	version string
 	cfg     *Config
 
@@ -270,7 +361,8 @@ type OpConductor struct {
 	shutdownCtx    context.Context
 	shutdownCancel context.CancelFunc
 
-	rpcServer *oprpc.Server
+	rpcServer     *oprpc.Server
+	metricsServer *httputil.HTTPServer
 }
 
 type state struct {
@@ -305,13 +397,42 @@ func (oc *OpConductor) Start(ctx context.Context) error {
 		return errors.Wrap(err, "failed to start JSON-RPC server")
 	}
 
+	if err := oc.startMetricsServer(); err != nil {
+		return errors.Wrap(err, "failed to start metrics server")
+	}
+
 	oc.wg.Add(1)
 	go oc.loop()
 
+	oc.metrics.RecordInfo(oc.version)
+	oc.metrics.RecordUp()
+	oc.metrics.RecordLeadershipChange(oc.leader.Load())
+	oc.metrics.RecordStateChange(oc.prevState.leader, oc.prevState.healthy, oc.prevState.active)
+	oc.metrics.RecordPaused(oc.Paused())
+
 	oc.log.Info("OpConductor started")
 	return nil
 }
 
+func (oc *OpConductor) startMetricsServer() error {
+	if !oc.cfg.MetricsConfig.Enabled {
+		oc.log.Info("metrics disabled")
+		return nil
+	}
+	m, ok := oc.metrics.(opmetrics.RegistryMetricer)
+	if !ok {
+		return fmt.Errorf("metrics were enabled, but metricer %T does not expose registry for metrics-server", oc.metrics)
+	}
+	oc.log.Debug("starting metrics server", "addr", oc.cfg.MetricsConfig.ListenAddr, "port", oc.cfg.MetricsConfig.ListenPort)
+	metricsServer, err := opmetrics.StartServer(m.Registry(), oc.cfg.MetricsConfig.ListenAddr, oc.cfg.MetricsConfig.ListenPort)
+	if err != nil {
+		return err
+	}
+	oc.log.Info("started metrics server", "addr", metricsServer.Addr())
+	oc.metricsServer = metricsServer
+	return nil
+}
+
 // Stop implements cliapp.Lifecycle.
 func (oc *OpConductor) Stop(ctx context.Context) error {
 	if oc.Stopped() {
@@ -345,6 +466,12 @@ func (oc *OpConductor) Stop(ctx context.Context) error {
 		}
 	}
 
+	if oc.metricsServer != nil {
+		if err := oc.metricsServer.Stop(ctx); err != nil {
+			result = multierror.Append(result, errors.Wrap(err, "failed to stop metrics server"))
+		}
+	}
+
 	if result.ErrorOrNil() != nil {
 		oc.log.Error("failed to stop OpConductor", "err", result.ErrorOrNil())
 		return result.ErrorOrNil()
@@ -436,7 +563,10 @@ func (oc *OpConductor) TransferLeaderToServer(_ context.Context, id string, addr
 
 // CommitUnsafePayload commits a unsafe payload (latest head) to the cluster FSM.
 func (oc *OpConductor) CommitUnsafePayload(_ context.Context, payload *eth.ExecutionPayloadEnvelope) error {
-	return oc.cons.CommitUnsafePayload(payload)
+	start := time.Now()
+	err := oc.cons.CommitUnsafePayload(payload)
+	oc.metrics.RecordCommitUnsafePayload(time.Since(start), err)
+	return err
 }
 
 // SequencerHealthy returns true if sequencer is healthy.
@@ -444,6 +574,11 @@ func (oc *OpConductor) SequencerHealthy(_ context.Context) bool {
 	return oc.healthy.Load()
 }
 
+// SequencerHealthReport returns the per-rule results of the latest sequencer health check.
+func (oc *OpConductor) SequencerHealthReport(_ context.Context) *health.Report {
+	return oc.hmon.Report()
+}
+
 // ClusterMembership returns current cluster's membership information.
 func (oc *OpConductor) ClusterMembership(_ context.Context) ([]*consensus.ServerInfo, error) {
 	return oc.cons.ClusterMembership()
@@ -454,6 +589,11 @@ func (oc *OpConductor) LatestUnsafePayload(_ context.Context) *eth.ExecutionPayl
 	return oc.cons.LatestUnsafePayload()
 }
 
+// UnsafePayloadByNumber returns the recent unsafe payload envelope of the given block number from FSM.
+func (oc *OpConductor) UnsafePayloadByNumber(_ context.Context, number uint64) *eth.ExecutionPayloadEnvelope {
+	return oc.cons.UnsafePayloadByNumber(number)
+}
+
 func (oc *OpConductor) loop() {
 	defer oc.wg.Done()
 
@@ -475,9 +615,11 @@ func (oc *OpConductor) loopAction() {
 		oc.handleLeaderUpdate(leader)
 	case <-oc.pauseCh:
 		oc.paused.Store(true)
+		oc.metrics.RecordPaused(true)
 		oc.pauseDoneCh <- struct{}{}
 	case <-oc.resumeCh:
 		oc.paused.Store(false)
+		oc.metrics.RecordPaused(false)
 		oc.resumeDoneCh <- struct{}{}
 		// queue an action to make sure sequencer is in the desired state after resume.
 		oc.queueAction()
@@ -502,6 +644,7 @@ func (oc *OpConductor) handleLeaderUpdate(leader bool) {
 	oc.log.Info("Leadership status changed", "server", oc.cons.ServerID(), "leader", leader)
 
 	oc.leader.Store(leader)
+	oc.metrics.RecordLeadershipChange(leader)
 	oc.queueAction()
 }
 
@@ -530,6 +673,11 @@ func (oc *OpConductor) action() {
 		return
 	}
 
+	start := time.Now()
+	defer func() {
+		oc.metrics.RecordLoopExecutionTime(time.Since(start))
+	}()
+
 	var err error
 	status := NewState(oc.leader.Load(), oc.healthy.Load(), oc.seqActive.Load())
 	oc.log.Debug("entering action with status", "status", status)
@@ -612,15 +760,20 @@ func (oc *OpConductor) action() {
 
 	if !status.Equal(oc.prevState) {
 		oc.log.Info("state changed", "prev_state", oc.prevState, "new_state", status)
+		oc.metrics.RecordStateChange(status.leader, status.healthy, status.active)
 		oc.prevState = status
 	}
 }
 
 // transferLeader tries to transfer leadership to another server.
-func (oc *OpConductor) transferLeader() error {
+func (oc *OpConductor) transferLeader() (err error) {
+	defer func() {
+		oc.metrics.RecordAction(metrics.ActionTransferLeader, err)
+	}()
+
 	// TransferLeader here will do round robin to try to transfer leadership to the next healthy node.
 	oc.log.Info("transferring leadership", "server", oc.cons.ServerID())
-	err := oc.cons.TransferLeader()
+	err = oc.cons.TransferLeader()
 	if err == nil {
 		oc.leader.Store(false)
 		return nil // success
@@ -637,10 +790,14 @@ func (oc *OpConductor) transferLeader() error {
 	}
 }
 
-func (oc *OpConductor) stopSequencer() error {
+func (oc *OpConductor) stopSequencer() (err error) {
+	defer func() {
+		oc.metrics.RecordAction(metrics.ActionStopSequencer, err)
+	}()
+
 	oc.log.Info("stopping sequencer", "server", oc.cons.ServerID(), "leader", oc.leader.Load(), "healthy", oc.healthy.Load(), "active", oc.seqActive.Load())
 
-	_, err := oc.ctrl.StopSequencer(context.Background())
+	_, err = oc.ctrl.StopSequencer(context.Background())
 	if err != nil {
 		if strings.Contains(err.Error(), driver.ErrSequencerAlreadyStopped.Error()) {
 			oc.log.Warn("sequencer already stopped.", "err", err)
@@ -653,7 +810,10 @@ func (oc *OpConductor) stopSequencer() error {
 	return nil
 }
 
-func (oc *OpConductor) startSequencer() error {
+func (oc *OpConductor) startSequencer() (err error) {
+	defer func() {
+		oc.metrics.RecordAction(metrics.ActionStartSequencer, err)
+	}()
 	ctx := context.Background()
 
 	// When starting sequencer, we need to make sure that the current node has the latest unsafe head from the consensus protocol
@@ -19,6 +19,7 @@ import (
 	consensusmocks "github.com/ethereum-optimism/optimism/op-conductor/consensus/mocks"
 	"github.com/ethereum-optimism/optimism/op-conductor/health"
 	healthmocks "github.com/ethereum-optimism/optimism/op-conductor/health/mocks"
+	"github.com/ethereum-optimism/optimism/op-conductor/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
@@ -41,6 +42,7 @@ func mockConfig(t *testing.T) Config {
 			UnsafeInterval: 3,
 			SafeInterval:   5,
 			MinPeerCount:   1,
+			Rules:          health.DefaultRules,
 		},
 		RollupCfg: rollup.Config{
 			Genesis: rollup.Genesis{
@@ -115,16 +117,16 @@ func (s *OpConductorTestSuite) SetupTest() {
 	s.hmon = &healthmocks.HealthMonitor{}
 	s.cons.EXPECT().ServerID().Return("SequencerA")
 
-	conductor, err := NewOpConductor(s.ctx, &s.cfg, s.log, s.version, s.ctrl, s.cons, s.hmon)
-	s.NoError(err)
-	s.conductor = conductor
-
 	s.healthUpdateCh = make(chan error, 1)
+	s.hmon.EXPECT().Subscribe().Return(s.healthUpdateCh)
 	s.hmon.EXPECT().Start().Return(nil)
-	s.conductor.healthUpdateCh = s.healthUpdateCh
 
 	s.leaderUpdateCh = make(chan bool, 1)
-	s.conductor.leaderUpdateCh = s.leaderUpdateCh
+	s.cons.EXPECT().LeaderCh().Return(s.leaderUpdateCh)
+
+	conductor, err := NewOpConductor(s.ctx, &s.cfg, s.log, metrics.NoopMetrics, s.version, s.ctrl, s.cons, s.hmon)
+	s.NoError(err)
+	s.conductor = conductor
 
 	s.err = errors.New("error")
 	s.syncEnabled = false // default to no sync, turn it on by calling s.enableSynchronization()
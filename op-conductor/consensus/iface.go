@@ -63,6 +63,8 @@ type Consensus interface {
 	CommitUnsafePayload(payload *eth.ExecutionPayloadEnvelope) error
 	// LatestUnsafeBlock returns the latest unsafe payload from FSM.
 	LatestUnsafePayload() *eth.ExecutionPayloadEnvelope
+	// UnsafePayloadByNumber returns the recent unsafe payload of the given block number from FSM, or nil if it is not kept.
+	UnsafePayloadByNumber(number uint64) *eth.ExecutionPayloadEnvelope
 
 	// Shutdown shuts down the consensus protocol client.
 	Shutdown() error
@@ -0,0 +1,410 @@
+package consensus
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+)
+
+// MembershipSource provides the desired members of the cluster.
+type MembershipSource interface {
+	// DesiredMembers returns the members the cluster should converge to.
+	DesiredMembers() ([]*ServerInfo, error)
+}
+
+// StaticMembership is a MembershipSource with a fixed list of members.
+type StaticMembership []*ServerInfo
+
+var _ MembershipSource = StaticMembership(nil)
+
+// NewStaticMembership creates a StaticMembership from the voters and the non-voters given as "<id>=<addr>".
+func NewStaticMembership(voters, nonvoters []string) (StaticMembership, error) {
+	var members StaticMembership
+	for _, entry := range voters {
+		member, err := parseMember(entry, Voter)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	for _, entry := range nonvoters {
+		member, err := parseMember(entry, Nonvoter)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	if err := checkMembers(members); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func parseMember(entry string, suffrage ServerSuffrage) (*ServerInfo, error) {
+	id, addr, ok := strings.Cut(entry, "=")
+	if !ok || id == "" || addr == "" {
+		return nil, fmt.Errorf("invalid member %q, expected <id>=<addr>", entry)
+	}
+	return &ServerInfo{ID: id, Addr: addr, Suffrage: suffrage}, nil
+}
+
+// DesiredMembers implements MembershipSource.
+func (s StaticMembership) DesiredMembers() ([]*ServerInfo, error) {
+	return s, nil
+}
+
+// FileMembership is a MembershipSource reading the members from a JSON file, which is reloaded on every call so the
+// desired members can be changed without restarting the op-conductor. The file contains a list of members, e.g.
+//
+//	[{"id": "sequencer-1", "addr": "10.0.0.1:50050"}, {"id": "sequencer-2", "addr": "10.0.0.2:50050", "nonvoter": true}]
+type FileMembership struct {
+	log  log.Logger
+	path string
+
+	last []*ServerInfo
+}
+
+var _ MembershipSource = (*FileMembership)(nil)
+
+type fileMember struct {
+	ID       string `json:"id"`
+	Addr     string `json:"addr"`
+	Nonvoter bool   `json:"nonvoter"`
+}
+
+// NewFileMembership creates a FileMembership reading the members from path.
+func NewFileMembership(log log.Logger, path string) *FileMembership {
+	return &FileMembership{log: log, path: path}
+}
+
+// DesiredMembers implements MembershipSource.
+func (f *FileMembership) DesiredMembers() ([]*ServerInfo, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read membership file")
+	}
+	var entries []fileMember
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, errors.Wrap(err, "failed to decode membership file")
+	}
+
+	members := make([]*ServerInfo, 0, len(entries))
+	for _, entry := range entries {
+		if entry.ID == "" || entry.Addr == "" {
+			return nil, fmt.Errorf("invalid member %+v in membership file", entry)
+		}
+		suffrage := Voter
+		if entry.Nonvoter {
+			suffrage = Nonvoter
+		}
+		members = append(members, &ServerInfo{ID: entry.ID, Addr: entry.Addr, Suffrage: suffrage})
+	}
+	if err := checkMembers(members); err != nil {
+		return nil, err
+	}
+
+	if !slices.EqualFunc(f.last, members, func(a, b *ServerInfo) bool { return *a == *b }) {
+		f.log.Info("loaded desired cluster membership", "path", f.path, "members", len(members))
+		f.last = members
+	}
+	return members, nil
+}
+
+func checkMembers(members []*ServerInfo) error {
+	seen := make(map[string]struct{}, len(members))
+	for _, member := range members {
+		if _, ok := seen[member.ID]; ok {
+			return fmt.Errorf("duplicate member %s", member.ID)
+		}
+		seen[member.ID] = struct{}{}
+	}
+	return nil
+}
+
+// MembershipConfig is the configuration of the membership reconciliation.
+type MembershipConfig struct {
+	// Source provides the desired members of the cluster.
+	Source MembershipSource
+	// Interval is the interval between reconciliations.
+	Interval time.Duration
+	// DeadServerGracePeriod is how long a member can fail to heartbeat with the leader before it is removed.
+	DeadServerGracePeriod time.Duration
+	// MinVoters is the number of voters the cluster is kept at least, a voter is not removed or demoted if the
+	// cluster would be left with fewer voters.
+	MinVoters int
+}
+
+// membershipBackend is the subset of Consensus used by the membershipReconciler.
+type membershipBackend interface {
+	Leader() bool
+	ServerID() string
+	ClusterMembership() ([]*ServerInfo, error)
+	AddVoter(id, addr string) error
+	AddNonVoter(id, addr string) error
+	DemoteVoter(id string) error
+	RemoveServer(id string) error
+}
+
+// membershipReconciler converges the membership of the cluster toward the desired members while this server is the
+// leader. Members that are not desired are removed, desired members that are missing or have a different address or
+// suffrage are (re)added, and members failing to heartbeat for longer than the grace period are removed. A missing
+// member is added only once its address is reachable, so that a member removed for being dead, possibly by the
+// previous leader, is not added back while it is still down. Voters are not removed below the configured minimum.
+type membershipReconciler struct {
+	log     log.Logger
+	backend membershipBackend
+	cfg     MembershipConfig
+
+	// probe checks if the address of a missing member is reachable.
+	probe func(addr string) error
+
+	mu sync.Mutex
+	// failing tracks the time since members have failed to heartbeat with the leader.
+	failing map[string]time.Time
+
+	closeCh chan struct{}
+	doneCh  chan struct{}
+}
+
+func newMembershipReconciler(log log.Logger, backend membershipBackend, cfg MembershipConfig) *membershipReconciler {
+	return &membershipReconciler{
+		log:     log,
+		backend: backend,
+		cfg:     cfg,
+		probe:   probeAddr,
+		failing: make(map[string]time.Time),
+		closeCh: make(chan struct{}),
+		doneCh:  make(chan struct{}),
+	}
+}
+
+func probeAddr(addr string) error {
+	conn, err := net.DialTimeout("tcp", addr, defaultTimeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func (m *membershipReconciler) start() {
+	go m.loop()
+}
+
+func (m *membershipReconciler) stop() {
+	close(m.closeCh)
+	<-m.doneCh
+}
+
+func (m *membershipReconciler) loop() {
+	defer close(m.doneCh)
+
+	ticker := time.NewTicker(m.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if !m.backend.Leader() {
+				// heartbeats are only observed by the leader, start over when leadership is regained.
+				m.resetHeartbeats()
+				continue
+			}
+			if err := m.reconcile(time.Now()); err != nil {
+				m.log.Error("failed to reconcile cluster membership", "err", err)
+			}
+		case <-m.closeCh:
+			return
+		}
+	}
+}
+
+// heartbeatFailed records that the member failed to heartbeat with the leader, lastContact is the last time the
+// member was reachable.
+func (m *membershipReconciler) heartbeatFailed(id string, lastContact time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.failing[id]; ok {
+		return
+	}
+	if lastContact.IsZero() {
+		lastContact = time.Now()
+	}
+	m.failing[id] = lastContact
+}
+
+// heartbeatResumed records that the member resumed to heartbeat with the leader.
+func (m *membershipReconciler) heartbeatResumed(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.failing, id)
+}
+
+func (m *membershipReconciler) resetHeartbeats() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	clear(m.failing)
+}
+
+// dead returns true if the member has failed to heartbeat for longer than the grace period.
+func (m *membershipReconciler) dead(id string, now time.Time) (bool, time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	since, ok := m.failing[id]
+	return ok && now.Sub(since) > m.cfg.DeadServerGracePeriod, since
+}
+
+func (m *membershipReconciler) forget(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.failing, id)
+}
+
+// reconcile applies the changes needed to converge the cluster membership toward the desired members.
+func (m *membershipReconciler) reconcile(now time.Time) error {
+	desired, err := m.cfg.Source.DesiredMembers()
+	if err != nil {
+		return errors.Wrap(err, "failed to get desired members")
+	}
+	current, err := m.backend.ClusterMembership()
+	if err != nil {
+		return errors.Wrap(err, "failed to get cluster membership")
+	}
+
+	self := m.backend.ServerID()
+	desiredByID := make(map[string]*ServerInfo, len(desired))
+	for _, member := range desired {
+		desiredByID[member.ID] = member
+	}
+	currentByID := make(map[string]*ServerInfo, len(current))
+	voters := 0
+	for _, member := range current {
+		currentByID[member.ID] = member
+		if member.Suffrage == Voter {
+			voters++
+		}
+	}
+
+	var result *multierror.Error
+	for _, member := range current {
+		if member.ID == self {
+			if _, ok := desiredByID[self]; !ok {
+				m.log.Warn("leader is not a desired member, transfer leadership to remove it", "id", self)
+			}
+			continue
+		}
+		if _, ok := desiredByID[member.ID]; !ok {
+			if !m.removable(member, voters) {
+				continue
+			}
+			m.log.Info("removing undesired member", "id", member.ID, "addr", member.Addr)
+			if err := m.remove(member, &voters); err != nil {
+				result = multierror.Append(result, err)
+			}
+			continue
+		}
+		if dead, since := m.dead(member.ID, now); dead {
+			if !m.removable(member, voters) {
+				continue
+			}
+			m.log.Info("removing dead member", "id", member.ID, "addr", member.Addr, "last_contact", since)
+			if err := m.remove(member, &voters); err != nil {
+				result = multierror.Append(result, err)
+				continue
+			}
+			delete(currentByID, member.ID)
+		}
+	}
+
+	for _, member := range desired {
+		if member.ID == self {
+			continue
+		}
+		existing, ok := currentByID[member.ID]
+		if ok && *existing == *member {
+			continue
+		}
+		if !ok {
+			// the member may have been removed for being dead, so it is added only once reachable.
+			if err := m.probe(member.Addr); err != nil {
+				m.log.Debug("missing member is unreachable", "id", member.ID, "addr", member.Addr, "err", err)
+				continue
+			}
+		}
+		if err := m.apply(existing, member, &voters); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// removable returns true if the member can be removed or demoted without leaving the cluster with fewer voters than
+// the minimum. The refused changes are retried on the next reconciliation, after the desired voters are added.
+func (m *membershipReconciler) removable(member *ServerInfo, voters int) bool {
+	if member.Suffrage != Voter || voters > m.cfg.MinVoters {
+		return true
+	}
+	m.log.Warn("not removing voter to keep the minimum number of voters", "id", member.ID, "addr", member.Addr,
+		"voters", voters, "min_voters", m.cfg.MinVoters)
+	return false
+}
+
+// remove removes the member from the cluster, voters is decremented if the member is a voter.
+func (m *membershipReconciler) remove(member *ServerInfo, voters *int) error {
+	if err := m.backend.RemoveServer(member.ID); err != nil {
+		return errors.Wrapf(err, "failed to remove member %s", member.ID)
+	}
+	m.forget(member.ID)
+	if member.Suffrage == Voter {
+		*voters--
+	}
+	return nil
+}
+
+// apply changes the existing member, which is nil if missing, into the desired member. voters is updated by the
+// number of voters changed.
+func (m *membershipReconciler) apply(existing, desired *ServerInfo, voters *int) error {
+	if existing != nil && existing.Suffrage == Voter && desired.Suffrage == Nonvoter && !m.removable(existing, *voters) {
+		return nil
+	}
+
+	switch {
+	case existing != nil && existing.Addr == desired.Addr && desired.Suffrage == Nonvoter:
+		m.log.Info("demoting member to non-voter", "id", desired.ID, "addr", desired.Addr)
+		if err := m.backend.DemoteVoter(desired.ID); err != nil {
+			return errors.Wrapf(err, "failed to demote member %s", desired.ID)
+		}
+		*voters--
+		return nil
+	case existing != nil && existing.Addr != desired.Addr && desired.Suffrage == Nonvoter:
+		// raft keeps a voter as a voter when it is added again as a non-voter, so remove it before updating the address.
+		m.log.Info("removing member to update its address", "id", desired.ID, "addr", existing.Addr, "new_addr", desired.Addr)
+		if err := m.remove(existing, voters); err != nil {
+			return err
+		}
+	}
+
+	if desired.Suffrage == Nonvoter {
+		m.log.Info("adding non-voter", "id", desired.ID, "addr", desired.Addr)
+		if err := m.backend.AddNonVoter(desired.ID, desired.Addr); err != nil {
+			return errors.Wrapf(err, "failed to add non-voter %s", desired.ID)
+		}
+		return nil
+	}
+	m.log.Info("adding voter", "id", desired.ID, "addr", desired.Addr)
+	if err := m.backend.AddVoter(desired.ID, desired.Addr); err != nil {
+		return errors.Wrapf(err, "failed to add voter %s", desired.ID)
+	}
+	if existing == nil || existing.Suffrage != Voter {
+		*voters++
+	}
+	return nil
+}
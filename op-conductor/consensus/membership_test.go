@@ -0,0 +1,258 @@
+package consensus
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// fakeMembershipBackend applies the membership changes to an in-memory cluster configuration.
+type fakeMembershipBackend struct {
+	self    string
+	members []*ServerInfo
+}
+
+func (f *fakeMembershipBackend) Leader() bool     { return true }
+func (f *fakeMembershipBackend) ServerID() string { return f.self }
+
+func (f *fakeMembershipBackend) ClusterMembership() ([]*ServerInfo, error) {
+	members := make([]*ServerInfo, 0, len(f.members))
+	for _, member := range f.members {
+		copied := *member
+		members = append(members, &copied)
+	}
+	return members, nil
+}
+
+func (f *fakeMembershipBackend) upsert(id, addr string, suffrage ServerSuffrage) {
+	for _, member := range f.members {
+		if member.ID == id {
+			member.Addr = addr
+			if suffrage == Voter {
+				member.Suffrage = Voter
+			}
+			return
+		}
+	}
+	f.members = append(f.members, &ServerInfo{ID: id, Addr: addr, Suffrage: suffrage})
+}
+
+func (f *fakeMembershipBackend) AddVoter(id, addr string) error {
+	f.upsert(id, addr, Voter)
+	return nil
+}
+
+func (f *fakeMembershipBackend) AddNonVoter(id, addr string) error {
+	f.upsert(id, addr, Nonvoter)
+	return nil
+}
+
+func (f *fakeMembershipBackend) DemoteVoter(id string) error {
+	for _, member := range f.members {
+		if member.ID == id {
+			member.Suffrage = Nonvoter
+		}
+	}
+	return nil
+}
+
+func (f *fakeMembershipBackend) RemoveServer(id string) error {
+	f.members = slices.DeleteFunc(f.members, func(member *ServerInfo) bool { return member.ID == id })
+	return nil
+}
+
+func newTestReconciler(t *testing.T, backend *fakeMembershipBackend, desired StaticMembership) *membershipReconciler {
+	r := newMembershipReconciler(testlog.Logger(t, log.LevelDebug), backend, MembershipConfig{
+		Source:                desired,
+		Interval:              time.Second,
+		DeadServerGracePeriod: time.Minute,
+		MinVoters:             1,
+	})
+	r.probe = func(addr string) error { return nil }
+	return r
+}
+
+func TestMembershipReconcile(t *testing.T) {
+	backend := &fakeMembershipBackend{
+		self: "a",
+		members: []*ServerInfo{
+			{ID: "a", Addr: "a:1", Suffrage: Voter},
+			{ID: "b", Addr: "b:1", Suffrage: Voter},
+			{ID: "c", Addr: "c:1", Suffrage: Voter},
+			{ID: "stale", Addr: "stale:1", Suffrage: Voter},
+		},
+	}
+	desired, err := NewStaticMembership([]string{"a=a:1", "b=b:2", "d=d:1"}, []string{"c=c:1", "e=e:1"})
+	require.NoError(t, err)
+
+	r := newTestReconciler(t, backend, desired)
+	require.NoError(t, r.reconcile(time.Now()))
+	require.ElementsMatch(t, []*ServerInfo(desired), backend.members)
+
+	// nothing to change once converged
+	before, _ := backend.ClusterMembership()
+	require.NoError(t, r.reconcile(time.Now()))
+	require.Equal(t, before, backend.members)
+}
+
+func TestMembershipReconcileKeepsLeader(t *testing.T) {
+	backend := &fakeMembershipBackend{
+		self:    "a",
+		members: []*ServerInfo{{ID: "a", Addr: "a:1", Suffrage: Voter}},
+	}
+	desired, err := NewStaticMembership([]string{"b=b:1"}, nil)
+	require.NoError(t, err)
+
+	r := newTestReconciler(t, backend, desired)
+	require.NoError(t, r.reconcile(time.Now()))
+	require.ElementsMatch(t, []*ServerInfo{
+		{ID: "a", Addr: "a:1", Suffrage: Voter},
+		{ID: "b", Addr: "b:1", Suffrage: Voter},
+	}, backend.members)
+}
+
+func TestMembershipReconcileDeadServer(t *testing.T) {
+	backend := &fakeMembershipBackend{
+		self: "a",
+		members: []*ServerInfo{
+			{ID: "a", Addr: "a:1", Suffrage: Voter},
+			{ID: "b", Addr: "b:1", Suffrage: Voter},
+		},
+	}
+	desired, err := NewStaticMembership([]string{"a=a:1", "b=b:1"}, nil)
+	require.NoError(t, err)
+
+	r := newTestReconciler(t, backend, desired)
+	reachable := false
+	r.probe = func(addr string) error {
+		if !reachable {
+			return errors.New("unreachable")
+		}
+		return nil
+	}
+
+	now := time.Now()
+	r.heartbeatFailed("b", now)
+
+	// still within the grace period
+	require.NoError(t, r.reconcile(now.Add(30*time.Second)))
+	require.Len(t, backend.members, 2)
+
+	// removed after the grace period, and not added back while unreachable
+	require.NoError(t, r.reconcile(now.Add(2*time.Minute)))
+	require.Equal(t, []*ServerInfo{{ID: "a", Addr: "a:1", Suffrage: Voter}}, backend.members)
+	require.NoError(t, r.reconcile(now.Add(3*time.Minute)))
+	require.Len(t, backend.members, 1)
+
+	// added back once reachable
+	reachable = true
+	require.NoError(t, r.reconcile(now.Add(4*time.Minute)))
+	require.ElementsMatch(t, []*ServerInfo(desired), backend.members)
+
+	// a member resuming heartbeats within the grace period is kept
+	r.heartbeatFailed("b", now)
+	r.heartbeatResumed("b")
+	require.NoError(t, r.reconcile(now.Add(5*time.Minute)))
+	require.Len(t, backend.members, 2)
+}
+
+func TestMembershipReconcileMinVoters(t *testing.T) {
+	backend := &fakeMembershipBackend{
+		self: "a",
+		members: []*ServerInfo{
+			{ID: "a", Addr: "a:1", Suffrage: Voter},
+			{ID: "b", Addr: "b:1", Suffrage: Voter},
+			{ID: "c", Addr: "c:1", Suffrage: Voter},
+		},
+	}
+	voters, _ := backend.ClusterMembership()
+	desired, err := NewStaticMembership([]string{"a=a:1", "c=c:1"}, []string{"b=b:1"})
+	require.NoError(t, err)
+
+	r := newTestReconciler(t, backend, desired)
+	r.cfg.MinVoters = 3
+
+	// b is not demoted below the minimum
+	require.NoError(t, r.reconcile(time.Now()))
+	require.Equal(t, voters, backend.members)
+
+	// a dead voter is not removed below the minimum
+	now := time.Now()
+	r.heartbeatFailed("c", now)
+	require.NoError(t, r.reconcile(now.Add(2*time.Minute)))
+	require.Equal(t, voters, backend.members)
+
+	// b is demoted once another voter is desired
+	r.cfg.Source, err = NewStaticMembership([]string{"a=a:1", "c=c:1", "d=d:1"}, []string{"b=b:1"})
+	require.NoError(t, err)
+	require.NoError(t, r.reconcile(now))
+	require.ElementsMatch(t, []*ServerInfo{
+		{ID: "a", Addr: "a:1", Suffrage: Voter},
+		{ID: "b", Addr: "b:1", Suffrage: Nonvoter},
+		{ID: "c", Addr: "c:1", Suffrage: Voter},
+		{ID: "d", Addr: "d:1", Suffrage: Voter},
+	}, backend.members)
+}
+
+func TestMembershipReconcileProbesMissingMember(t *testing.T) {
+	// the member removed for being dead by the previous leader is missing
+	backend := &fakeMembershipBackend{
+		self:    "a",
+		members: []*ServerInfo{{ID: "a", Addr: "a:1", Suffrage: Voter}},
+	}
+	desired, err := NewStaticMembership([]string{"a=a:1", "b=b:1"}, nil)
+	require.NoError(t, err)
+
+	r := newTestReconciler(t, backend, desired)
+	reachable := false
+	r.probe = func(addr string) error {
+		if !reachable {
+			return errors.New("unreachable")
+		}
+		return nil
+	}
+
+	require.NoError(t, r.reconcile(time.Now()))
+	require.Len(t, backend.members, 1)
+
+	reachable = true
+	require.NoError(t, r.reconcile(time.Now()))
+	require.ElementsMatch(t, []*ServerInfo(desired), backend.members)
+}
+
+func TestStaticMembership(t *testing.T) {
+	_, err := NewStaticMembership([]string{"a"}, nil)
+	require.ErrorContains(t, err, "invalid member")
+	_, err = NewStaticMembership([]string{"a=a:1"}, []string{"a=a:2"})
+	require.ErrorContains(t, err, "duplicate member")
+}
+
+func TestFileMembership(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "members.json")
+	source := NewFileMembership(testlog.Logger(t, log.LevelInfo), path)
+
+	_, err := source.DesiredMembers()
+	require.Error(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte(`[{"id": "a", "addr": "a:1"}, {"id": "b", "addr": "b:1", "nonvoter": true}]`), 0o600))
+	members, err := source.DesiredMembers()
+	require.NoError(t, err)
+	require.Equal(t, []*ServerInfo{
+		{ID: "a", Addr: "a:1", Suffrage: Voter},
+		{ID: "b", Addr: "b:1", Suffrage: Nonvoter},
+	}, members)
+
+	// reloaded when changed
+	require.NoError(t, os.WriteFile(path, []byte(`[{"id": "a", "addr": "a:2"}]`), 0o600))
+	members, err = source.DesiredMembers()
+	require.NoError(t, err)
+	require.Equal(t, []*ServerInfo{{ID: "a", Addr: "a:2", Suffrage: Voter}}, members)
+}
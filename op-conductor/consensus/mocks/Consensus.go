@@ -679,6 +679,54 @@ func (_c *Consensus_TransferLeaderTo_Call) RunAndReturn(run func(string, string)
 	return _c
 }
 
+// UnsafePayloadByNumber provides a mock function with given fields: number
+func (_m *Consensus) UnsafePayloadByNumber(number uint64) *eth.ExecutionPayloadEnvelope {
+	ret := _m.Called(number)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnsafePayloadByNumber")
+	}
+
+	var r0 *eth.ExecutionPayloadEnvelope
+	if rf, ok := ret.Get(0).(func(uint64) *eth.ExecutionPayloadEnvelope); ok {
+		r0 = rf(number)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*eth.ExecutionPayloadEnvelope)
+		}
+	}
+
+	return r0
+}
+
+// Consensus_UnsafePayloadByNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnsafePayloadByNumber'
+type Consensus_UnsafePayloadByNumber_Call struct {
+	*mock.Call
+}
+
+// UnsafePayloadByNumber is a helper method to define mock.On call
+//   - number uint64
+func (_e *Consensus_Expecter) UnsafePayloadByNumber(number interface{}) *Consensus_UnsafePayloadByNumber_Call {
+	return &Consensus_UnsafePayloadByNumber_Call{Call: _e.mock.On("UnsafePayloadByNumber", number)}
+}
+
+func (_c *Consensus_UnsafePayloadByNumber_Call) Run(run func(number uint64)) *Consensus_UnsafePayloadByNumber_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint64))
+	})
+	return _c
+}
+
+func (_c *Consensus_UnsafePayloadByNumber_Call) Return(_a0 *eth.ExecutionPayloadEnvelope) *Consensus_UnsafePayloadByNumber_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Consensus_UnsafePayloadByNumber_Call) RunAndReturn(run func(uint64) *eth.ExecutionPayloadEnvelope) *Consensus_UnsafePayloadByNumber_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewConsensus creates a new instance of Consensus. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewConsensus(t interface {
@@ -15,6 +15,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
 )
 
 const defaultTimeout = 5 * time.Second
@@ -30,14 +31,51 @@ type RaftConsensus struct {
 	r        *raft.Raft
 
 	unsafeTracker *unsafeHeadTracker
+
+	membership *membershipReconciler
+	observer   *raft.Observer
+	observerCh chan raft.Observation
 }
 
-// NewRaftConsensus creates a new RaftConsensus instance.
-func NewRaftConsensus(log log.Logger, serverID, serverAddr, storageDir string, bootstrap bool, rollupCfg *rollup.Config) (*RaftConsensus, error) {
-	rc := raft.DefaultConfig()
-	rc.LocalID = raft.ServerID(serverID)
+// RaftConsensusConfig is the configuration of RaftConsensus.
+type RaftConsensusConfig struct {
+	// ServerID is the unique ID of this server in the cluster.
+	ServerID string
+	// ServerAddr is the address to listen for consensus connections, which is advertised to the other servers.
+	ServerAddr string
+	// StorageDir is the directory to store raft data.
+	StorageDir string
+	// Bootstrap is true if this server should bootstrap a new cluster.
+	Bootstrap bool
+	// UnsafePayloadHistory is the number of recent unsafe payloads kept in the FSM and its snapshots, only the latest
+	// one is kept if zero.
+	UnsafePayloadHistory int
+	// SnapshotHistory is whether the snapshots hold the unsafe payload history. The servers before the history was
+	// introduced cannot restore such snapshots, so it must be enabled only after all the servers are upgraded.
+	SnapshotHistory bool
+	RollupCfg       *rollup.Config
+	// TLS is the TLS configuration of the transport between the servers. If nil, the transport is not encrypted.
+	TLS *optls.CLIConfig
+	// Membership is the configuration of the membership reconciliation. If nil, the membership is managed by hand.
+	Membership *MembershipConfig
+	// HeartbeatTimeout, ElectionTimeout and LeaderLeaseTimeout override the timeouts of raft if non-zero.
+	HeartbeatTimeout   time.Duration
+	ElectionTimeout    time.Duration
+	LeaderLeaseTimeout time.Duration
+}
 
-	baseDir := filepath.Join(storageDir, serverID)
+// RaftStorage is the transport and the stores used by raft.
+type RaftStorage struct {
+	Transport     raft.Transport
+	LogStore      raft.LogStore
+	StableStore   raft.StableStore
+	SnapshotStore raft.SnapshotStore
+}
+
+// NewRaftConsensus creates a new RaftConsensus instance, storing the raft data in the storage directory and
+// listening for consensus connections on the server address.
+func NewRaftConsensus(log log.Logger, cfg *RaftConsensusConfig) (*RaftConsensus, error) {
+	baseDir := filepath.Join(cfg.StorageDir, cfg.ServerID)
 	if _, err := os.Stat(baseDir); os.IsNotExist(err) {
 		if err := os.MkdirAll(baseDir, 0o755); err != nil {
 			return nil, fmt.Errorf("error creating storage dir: %w", err)
@@ -57,12 +95,12 @@ func NewRaftConsensus(log log.Logger, serverID, serverAddr, storageDir string, b
 		return nil, fmt.Errorf(`boltdb.NewBoltStore(%q): %w`, stableStorePath, err)
 	}
 
-	snapshotStore, err := raft.NewFileSnapshotStoreWithLogger(baseDir, 1, rc.Logger)
+	snapshotStore, err := raft.NewFileSnapshotStoreWithLogger(baseDir, 1, nil)
 	if err != nil {
 		return nil, fmt.Errorf(`raft.NewFileSnapshotStore(%q): %w`, baseDir, err)
 	}
 
-	addr, err := net.ResolveTCPAddr("tcp", serverAddr)
+	addr, err := net.ResolveTCPAddr("tcp", cfg.ServerAddr)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to resolve tcp address")
 	}
@@ -70,14 +108,47 @@ func NewRaftConsensus(log log.Logger, serverID, serverAddr, storageDir string, b
 	maxConnPool := 10
 	timeout := 5 * time.Second
 	bindAddr := fmt.Sprintf("0.0.0.0:%d", addr.Port)
-	transport, err := raft.NewTCPTransportWithLogger(bindAddr, addr, maxConnPool, timeout, rc.Logger)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to create raft tcp transport")
+	var transport raft.Transport
+	if cfg.TLS != nil {
+		stream, err := NewTLSStreamLayer(log, bindAddr, addr, *cfg.TLS)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create raft tls stream layer")
+		}
+		transport = raft.NewNetworkTransportWithLogger(stream, maxConnPool, timeout, nil)
+		log.Info("raft transport secured with mutual tls")
+	} else {
+		transport, err = raft.NewTCPTransportWithLogger(bindAddr, addr, maxConnPool, timeout, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create raft tcp transport")
+		}
+	}
+
+	return NewRaftConsensusWithStorage(log, cfg, RaftStorage{
+		Transport:     transport,
+		LogStore:      logStore,
+		StableStore:   stableStore,
+		SnapshotStore: snapshotStore,
+	})
+}
+
+// NewRaftConsensusWithStorage creates a new RaftConsensus instance on the given transport and stores, the storage
+// directory of the config is not used. This allows running the servers in-process, e.g. with raft.InmemTransport.
+func NewRaftConsensusWithStorage(log log.Logger, cfg *RaftConsensusConfig, storage RaftStorage) (*RaftConsensus, error) {
+	rc := raft.DefaultConfig()
+	rc.LocalID = raft.ServerID(cfg.ServerID)
+	if cfg.HeartbeatTimeout != 0 {
+		rc.HeartbeatTimeout = cfg.HeartbeatTimeout
+	}
+	if cfg.ElectionTimeout != 0 {
+		rc.ElectionTimeout = cfg.ElectionTimeout
+	}
+	if cfg.LeaderLeaseTimeout != 0 {
+		rc.LeaderLeaseTimeout = cfg.LeaderLeaseTimeout
 	}
 
-	fsm := &unsafeHeadTracker{}
+	fsm := newUnsafeHeadTracker(cfg.UnsafePayloadHistory, cfg.SnapshotHistory)
 
-	r, err := raft.NewRaft(rc, fsm, logStore, stableStore, snapshotStore, transport)
+	r, err := raft.NewRaft(rc, fsm, storage.LogStore, storage.StableStore, storage.SnapshotStore, storage.Transport)
 	if err != nil {
 		log.Error("failed to create raft", "err", err)
 		return nil, errors.Wrap(err, "failed to create raft")
@@ -85,30 +156,64 @@ func NewRaftConsensus(log log.Logger, serverID, serverAddr, storageDir string, b
 
 	// If bootstrap = true, start raft in bootstrap mode, this will allow the current node to elect itself as leader when there's no other participants
 	// and allow other nodes to join the cluster.
-	if bootstrap {
-		cfg := raft.Configuration{
+	if cfg.Bootstrap {
+		bootstrapCfg := raft.Configuration{
 			Servers: []raft.Server{
 				{
 					ID:       rc.LocalID,
-					Address:  raft.ServerAddress(serverAddr),
+					Address:  raft.ServerAddress(cfg.ServerAddr),
 					Suffrage: raft.Voter,
 				},
 			},
 		}
 
-		f := r.BootstrapCluster(cfg)
+		f := r.BootstrapCluster(bootstrapCfg)
 		if err := f.Error(); err != nil {
 			return nil, errors.Wrap(err, "failed to bootstrap raft cluster")
 		}
 	}
 
-	return &RaftConsensus{
+	cons := &RaftConsensus{
 		log:           log,
 		r:             r,
-		serverID:      raft.ServerID(serverID),
+		serverID:      raft.ServerID(cfg.ServerID),
 		unsafeTracker: fsm,
-		rollupCfg:     rollupCfg,
-	}, nil
+		rollupCfg:     cfg.RollupCfg,
+	}
+	if cfg.Membership != nil {
+		cons.startMembershipReconciler(*cfg.Membership)
+	}
+	return cons, nil
+}
+
+// startMembershipReconciler starts converging the cluster membership toward the desired members while this server is
+// the leader, the heartbeats observed by the leader are used to detect dead members. The observer is blocking, since a
+// dropped resumed heartbeat would get a live member removed.
+func (rc *RaftConsensus) startMembershipReconciler(cfg MembershipConfig) {
+	rc.membership = newMembershipReconciler(rc.log, rc, cfg)
+	rc.observerCh = make(chan raft.Observation, 16)
+	rc.observer = raft.NewObserver(rc.observerCh, true, func(o *raft.Observation) bool {
+		switch o.Data.(type) {
+		case raft.FailedHeartbeatObservation, raft.ResumedHeartbeatObservation:
+			return true
+		}
+		return false
+	})
+	rc.r.RegisterObserver(rc.observer)
+
+	go func() {
+		for o := range rc.observerCh {
+			switch data := o.Data.(type) {
+			case raft.FailedHeartbeatObservation:
+				rc.membership.heartbeatFailed(string(data.PeerID), data.LastContact)
+			case raft.ResumedHeartbeatObservation:
+				rc.membership.heartbeatResumed(string(data.PeerID))
+			}
+		}
+	}()
+	rc.membership.start()
+	rc.log.Info("started cluster membership reconciliation", "interval", cfg.Interval,
+		"dead_server_grace_period", cfg.DeadServerGracePeriod, "min_voters", cfg.MinVoters)
 }
 
 // AddNonVoter implements Consensus, it tries to add a non-voting member into the cluster.
@@ -198,6 +303,11 @@ func (rc *RaftConsensus) TransferLeaderTo(id string, addr string) error {
 
 // Shutdown implements Consensus, it shuts down the consensus protocol client.
 func (rc *RaftConsensus) Shutdown() error {
+	if rc.membership != nil {
+		rc.membership.stop()
+		rc.r.DeregisterObserver(rc.observer)
+		close(rc.observerCh)
+	}
 	if err := rc.r.Shutdown().Error(); err != nil {
 		rc.log.Error("failed to shutdown raft", "err", err)
 		return err
@@ -226,6 +336,11 @@ func (rc *RaftConsensus) LatestUnsafePayload() *eth.ExecutionPayloadEnvelope {
 	return payload
 }
 
+// UnsafePayloadByNumber implements Consensus, it returns the unsafe payload of the given block number from FSM.
+func (rc *RaftConsensus) UnsafePayloadByNumber(number uint64) *eth.ExecutionPayloadEnvelope {
+	return rc.unsafeTracker.PayloadByNumber(number)
+}
+
 // ClusterMembership implements Consensus, it returns the current cluster membership configuration.
 func (rc *RaftConsensus) ClusterMembership() ([]*ServerInfo, error) {
 	var future raft.ConfigurationFuture
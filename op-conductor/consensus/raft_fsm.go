@@ -2,8 +2,10 @@ package consensus
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
 	"io"
+	"sort"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/log"
@@ -14,10 +16,31 @@ import (
 
 var _ raft.FSM = (*unsafeHeadTracker)(nil)
 
+// snapshotMagic prefixes the snapshots holding the history of unsafe payloads. Snapshots without it hold only the
+// latest unsafe payload, which is the only format the servers before the history was introduced can restore. So the
+// history is written to the snapshots only if enabled, once all the servers in the cluster can read it.
+var snapshotMagic = []byte("opcsnap1")
+
 // unsafeHeadTracker implements raft.FSM for storing unsafe head payload into raft consensus layer.
+// It also keeps a bounded history of the recent unsafe payloads, so they can be served to a lagging sequencer.
 type unsafeHeadTracker struct {
 	mtx        sync.RWMutex
 	unsafeHead *eth.ExecutionPayloadEnvelope
+
+	// history is a ring buffer of the recent unsafe payloads in ascending order of block number. The oldest one is
+	// at historyStart, followed by historyLen-1 payloads.
+	history      []*eth.ExecutionPayloadEnvelope
+	historyStart int
+	historyLen   int
+	// snapshotHistory is whether the snapshots hold the history, instead of the latest unsafe payload only.
+	snapshotHistory bool
+}
+
+func newUnsafeHeadTracker(historySize int, snapshotHistory bool) *unsafeHeadTracker {
+	return &unsafeHeadTracker{
+		history:         make([]*eth.ExecutionPayloadEnvelope, max(historySize, 1)),
+		snapshotHistory: snapshotHistory,
+	}
 }
 
 // Apply implements raft.FSM, it applies the latest change (latest unsafe head payload) to FSM.
@@ -35,28 +58,68 @@ func (t *unsafeHeadTracker) Apply(l *raft.Log) interface{} {
 	defer t.mtx.Unlock()
 	if t.unsafeHead == nil || t.unsafeHead.ExecutionPayload.BlockNumber < data.ExecutionPayload.BlockNumber {
 		t.unsafeHead = data
+		t.pushHistory(data)
 	}
 
 	return nil
 }
 
+// pushHistory appends the payload to the history, overwriting the oldest payload if the history is full.
+func (t *unsafeHeadTracker) pushHistory(payload *eth.ExecutionPayloadEnvelope) {
+	if len(t.history) == 0 {
+		t.history = make([]*eth.ExecutionPayloadEnvelope, 1)
+	}
+	t.history[(t.historyStart+t.historyLen)%len(t.history)] = payload
+	if t.historyLen < len(t.history) {
+		t.historyLen++
+	} else {
+		t.historyStart = (t.historyStart + 1) % len(t.history)
+	}
+}
+
+// historyAt returns the i-th oldest payload in the history.
+func (t *unsafeHeadTracker) historyAt(i int) *eth.ExecutionPayloadEnvelope {
+	return t.history[(t.historyStart+i)%len(t.history)]
+}
+
+// resetHistory removes all the payloads from the history.
+func (t *unsafeHeadTracker) resetHistory() {
+	clear(t.history)
+	t.historyStart, t.historyLen = 0, 0
+}
+
 // Restore implements raft.FSM, it restores state from snapshot.
 func (t *unsafeHeadTracker) Restore(snapshot io.ReadCloser) error {
 	var buf bytes.Buffer
-	n, err := io.Copy(&buf, snapshot)
+	_, err := io.Copy(&buf, snapshot)
 	snapshot.Close()
 	if err != nil {
 		return fmt.Errorf("error reading snapshot data: %w", err)
 	}
 
-	data := &eth.ExecutionPayloadEnvelope{}
-	if err := data.UnmarshalSSZ(uint32(n), bytes.NewReader(buf.Bytes())); err != nil {
-		return fmt.Errorf("error unmarshalling snapshot: %w", err)
+	var payloads []*eth.ExecutionPayloadEnvelope
+	if data := buf.Bytes(); bytes.HasPrefix(data, snapshotMagic) {
+		if payloads, err = decodePayloads(data[len(snapshotMagic):]); err != nil {
+			return fmt.Errorf("error unmarshalling snapshot: %w", err)
+		}
+	} else {
+		payload := &eth.ExecutionPayloadEnvelope{}
+		if err := payload.UnmarshalSSZ(uint32(len(data)), bytes.NewReader(data)); err != nil {
+			return fmt.Errorf("error unmarshalling snapshot: %w", err)
+		}
+		payloads = append(payloads, payload)
+	}
+	if len(payloads) == 0 {
+		return fmt.Errorf("snapshot has no unsafe payload")
 	}
 
 	t.mtx.Lock()
 	defer t.mtx.Unlock()
-	t.unsafeHead = data
+	t.resetHistory()
+	for _, payload := range payloads {
+		t.pushHistory(payload)
+	}
+	t.unsafeHead = payloads[len(payloads)-1]
 	return nil
 }
 
@@ -65,8 +128,19 @@ func (t *unsafeHeadTracker) Snapshot() (raft.FSMSnapshot, error) {
 	t.mtx.RLock()
 	defer t.mtx.RUnlock()
 
+	var payloads []*eth.ExecutionPayloadEnvelope
+	if t.snapshotHistory {
+		payloads = make([]*eth.ExecutionPayloadEnvelope, 0, t.historyLen)
+		for i := 0; i < t.historyLen; i++ {
+			payloads = append(payloads, t.historyAt(i))
+		}
+	}
+	if len(payloads) == 0 && t.unsafeHead != nil {
+		payloads = append(payloads, t.unsafeHead)
+	}
 	return &snapshot{
-		unsafeHead: t.unsafeHead,
+		payloads: payloads,
+		legacy:   !t.snapshotHistory,
 	}, nil
 }
 
@@ -78,17 +152,92 @@ func (t *unsafeHeadTracker) UnsafeHead() *eth.ExecutionPayloadEnvelope {
 	return t.unsafeHead
 }
 
+// PayloadByNumber returns the unsafe payload of the given block number from the history, or nil if it is not found.
+func (t *unsafeHeadTracker) PayloadByNumber(number uint64) *eth.ExecutionPayloadEnvelope {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+
+	i := sort.Search(t.historyLen, func(i int) bool {
+		return uint64(t.historyAt(i).ExecutionPayload.BlockNumber) >= number
+	})
+	if i == t.historyLen || uint64(t.historyAt(i).ExecutionPayload.BlockNumber) != number {
+		return nil
+	}
+	return t.historyAt(i)
+}
+
+// encodePayloads encodes the payloads as a count followed by the length-prefixed SSZ encoding of each payload.
+func encodePayloads(w io.Writer, payloads []*eth.ExecutionPayloadEnvelope) error {
+	if err := binary.Write(w, binary.BigEndian, uint32(len(payloads))); err != nil {
+		return err
+	}
+	for _, payload := range payloads {
+		var buf bytes.Buffer
+		if _, err := payload.MarshalSSZ(&buf); err != nil {
+			return err
+		}
+		if err := binary.Write(w, binary.BigEndian, uint32(buf.Len())); err != nil {
+			return err
+		}
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodePayloads(data []byte) ([]*eth.ExecutionPayloadEnvelope, error) {
+	r := bytes.NewReader(data)
+	var count uint32
+	if err := binary.Read(r, binary.BigEndian, &count); err != nil {
+		return nil, err
+	}
+	payloads := make([]*eth.ExecutionPayloadEnvelope, 0, min(int(count), 1024))
+	for i := uint32(0); i < count; i++ {
+		var size uint32
+		if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+			return nil, err
+		}
+		if int64(size) > int64(r.Len()) {
+			return nil, fmt.Errorf("payload %d of size %d exceeds the remaining %d bytes", i, size, r.Len())
+		}
+		payload := &eth.ExecutionPayloadEnvelope{}
+		if err := payload.UnmarshalSSZ(size, io.LimitReader(r, int64(size))); err != nil {
+			return nil, err
+		}
+		payloads = append(payloads, payload)
+	}
+	return payloads, nil
+}
+
 var _ raft.FSMSnapshot = (*snapshot)(nil)
 
 type snapshot struct {
-	log        log.Logger
-	unsafeHead *eth.ExecutionPayloadEnvelope
+	log      log.Logger
+	payloads []*eth.ExecutionPayloadEnvelope
+	// legacy is whether to write the latest payload only, without the snapshot magic.
+	legacy bool
 }
 
 // Persist implements raft.FSMSnapshot, it writes the snapshot to the given sink.
 func (s *snapshot) Persist(sink raft.SnapshotSink) error {
-	if _, err := s.unsafeHead.MarshalSSZ(sink); err != nil {
-		if cerr := sink.Cancel(); cerr != nil {
+	var buf bytes.Buffer
+	var err error
+	if s.legacy {
+		if len(s.payloads) == 0 {
+			err = fmt.Errorf("no unsafe payload")
+		} else {
+			_, err = s.payloads[len(s.payloads)-1].MarshalSSZ(&buf)
+		}
+	} else {
+		buf.Write(snapshotMagic)
+		err = encodePayloads(&buf, s.payloads)
+	}
+	if err == nil {
+		_, err = sink.Write(buf.Bytes())
+	}
+	if err != nil {
+		if cerr := sink.Cancel(); cerr != nil && s.log != nil {
 			s.log.Error("error cancelling snapshot sink", "error", cerr)
 		}
 		return fmt.Errorf("error writing data to sink: %w", err)
@@ -98,3 +98,101 @@ func (m *mockReadCloser) Read(p []byte) (n int, err error) {
 func (m *mockReadCloser) Close() error {
 	return nil
 }
+
+type mockSnapshotSink struct {
+	bytes.Buffer
+}
+
+func (s *mockSnapshotSink) ID() string    { return "mock" }
+func (s *mockSnapshotSink) Cancel() error { return nil }
+func (s *mockSnapshotSink) Close() error  { return nil }
+
+func applyPayload(t *testing.T, tracker *unsafeHeadTracker, number uint64) {
+	data := createPayloadEnvelope()
+	data.ExecutionPayload.BlockNumber = eth.Uint64Quantity(number)
+
+	var buf bytes.Buffer
+	_, err := data.MarshalSSZ(&buf)
+	require.NoError(t, err)
+	require.Nil(t, tracker.Apply(&raft.Log{Data: buf.Bytes()}))
+}
+
+func TestUnsafeHeadTrackerHistory(t *testing.T) {
+	tracker := newUnsafeHeadTracker(3, true)
+	for number := uint64(1); number <= 5; number++ {
+		applyPayload(t, tracker, number)
+	}
+	// stale payloads are not kept
+	applyPayload(t, tracker, 2)
+
+	require.Equal(t, hexutil.Uint64(5), tracker.UnsafeHead().ExecutionPayload.BlockNumber)
+	require.Nil(t, tracker.PayloadByNumber(2))
+	for number := uint64(3); number <= 5; number++ {
+		require.Equal(t, hexutil.Uint64(number), tracker.PayloadByNumber(number).ExecutionPayload.BlockNumber)
+	}
+	require.Nil(t, tracker.PayloadByNumber(6))
+
+	t.Run("SnapshotRestore", func(t *testing.T) {
+		snap, err := tracker.Snapshot()
+		require.NoError(t, err)
+		var sink mockSnapshotSink
+		require.NoError(t, snap.Persist(&sink))
+
+		// restoring into a smaller history keeps the latest payloads
+		restored := newUnsafeHeadTracker(2, true)
+		require.NoError(t, restored.Restore(io.NopCloser(&sink)))
+		require.Equal(t, hexutil.Uint64(5), restored.UnsafeHead().ExecutionPayload.BlockNumber)
+		require.Nil(t, restored.PayloadByNumber(3))
+		require.NotNil(t, restored.PayloadByNumber(4))
+		require.NotNil(t, restored.PayloadByNumber(5))
+	})
+
+	t.Run("RestoreLegacySnapshot", func(t *testing.T) {
+		mrc, err := NewMockReadCloser(createPayloadEnvelope())
+		require.NoError(t, err)
+
+		restored := newUnsafeHeadTracker(3, true)
+		require.NoError(t, restored.Restore(mrc))
+		require.Equal(t, hexutil.Uint64(222), restored.UnsafeHead().ExecutionPayload.BlockNumber)
+		require.NotNil(t, restored.PayloadByNumber(222))
+	})
+
+	t.Run("LegacySnapshot", func(t *testing.T) {
+		legacy := newUnsafeHeadTracker(3, false)
+		for number := uint64(1); number <= 5; number++ {
+			applyPayload(t, legacy, number)
+		}
+		snap, err := legacy.Snapshot()
+		require.NoError(t, err)
+		var sink mockSnapshotSink
+		require.NoError(t, snap.Persist(&sink))
+
+		// the snapshot holds the latest payload only, as the servers without the history write it
+		require.False(t, bytes.HasPrefix(sink.Bytes(), snapshotMagic))
+		payload := &eth.ExecutionPayloadEnvelope{}
+		require.NoError(t, payload.UnmarshalSSZ(uint32(sink.Len()), bytes.NewReader(sink.Bytes())))
+		require.Equal(t, hexutil.Uint64(5), payload.ExecutionPayload.BlockNumber)
+	})
+}
+
+func TestUnsafeHeadTrackerHistoryWrap(t *testing.T) {
+	tracker := newUnsafeHeadTracker(4, true)
+	for number := uint64(1); number <= 10; number++ {
+		applyPayload(t, tracker, number)
+		for n := uint64(1); n <= number; n++ {
+			if n+4 <= number {
+				require.Nil(t, tracker.PayloadByNumber(n))
+			} else {
+				require.Equal(t, hexutil.Uint64(n), tracker.PayloadByNumber(n).ExecutionPayload.BlockNumber)
+			}
+		}
+	}
+
+	snap, err := tracker.Snapshot()
+	require.NoError(t, err)
+	payloads := snap.(*snapshot).payloads
+	require.Len(t, payloads, 4)
+	for i, payload := range payloads {
+		require.Equal(t, hexutil.Uint64(7+i), payload.ExecutionPayload.BlockNumber)
+	}
+}
@@ -30,7 +30,14 @@ func TestCommitAndRead(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	cons, err := NewRaftConsensus(log, serverID, serverAddr, storageDir, bootstrap, rollupCfg)
+	raftCfg := &RaftConsensusConfig{
+		ServerID:   serverID,
+		ServerAddr: serverAddr,
+		StorageDir: storageDir,
+		Bootstrap:  bootstrap,
+		RollupCfg:  rollupCfg,
+	}
+	cons, err := NewRaftConsensus(log, raftCfg)
 	require.NoError(t, err)
 
 	// wait till it became leader
@@ -0,0 +1,115 @@
+package consensus
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/hashicorp/raft"
+	"github.com/pkg/errors"
+
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
+	"github.com/ethereum-optimism/optimism/op-service/tls/certman"
+)
+
+var _ raft.StreamLayer = (*TLSStreamLayer)(nil)
+
+// TLSStreamLayer implements raft.StreamLayer with mutual TLS. Both the incoming and the outgoing connections present
+// the certificate of the server, and the certificate of the peer is verified against the CA. The certificate and the
+// key are reloaded when they are changed on disk.
+type TLSStreamLayer struct {
+	log       log.Logger
+	listener  net.Listener
+	advertise net.Addr
+	certMan   *certman.CertMan
+	dialCfg   *tls.Config
+}
+
+// NewTLSStreamLayer creates a new TLSStreamLayer listening on bindAddr. advertise is the address the other servers
+// use to reach this server, if nil, the address of the listener is used.
+func NewTLSStreamLayer(log log.Logger, bindAddr string, advertise net.Addr, cfg optls.CLIConfig) (*TLSStreamLayer, error) {
+	caCert, err := os.ReadFile(cfg.TLSCaCert)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read tls ca cert")
+	}
+	caCertPool := x509.NewCertPool()
+	if !caCertPool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("no certificate found in %s", cfg.TLSCaCert)
+	}
+
+	cm, err := certman.New(log, cfg.TLSCert, cfg.TLSKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read tls cert or key")
+	}
+	if err := cm.Watch(); err != nil {
+		return nil, errors.Wrap(err, "failed to start certman watcher")
+	}
+	if cert, _ := cm.GetCertificate(nil); cert == nil {
+		cm.Stop()
+		return nil, fmt.Errorf("failed to load tls cert %s and key %s", cfg.TLSCert, cfg.TLSKey)
+	}
+
+	listener, err := tls.Listen("tcp", bindAddr, &tls.Config{
+		MinVersion:     tls.VersionTLS13,
+		ClientAuth:     tls.RequireAndVerifyClientCert,
+		ClientCAs:      caCertPool,
+		GetCertificate: cm.GetCertificate,
+	})
+	if err != nil {
+		cm.Stop()
+		return nil, errors.Wrap(err, "failed to listen for consensus connections")
+	}
+
+	if advertise == nil {
+		advertise = listener.Addr()
+	}
+	tcpAddr, ok := advertise.(*net.TCPAddr)
+	if !ok || tcpAddr.IP == nil || tcpAddr.IP.IsUnspecified() {
+		_ = listener.Close()
+		cm.Stop()
+		return nil, fmt.Errorf("advertise address %s is not advertisable", advertise)
+	}
+	if tcpAddr.Port == 0 {
+		// advertise the port picked by the listener
+		advertise = &net.TCPAddr{IP: tcpAddr.IP, Port: listener.Addr().(*net.TCPAddr).Port, Zone: tcpAddr.Zone}
+	}
+
+	return &TLSStreamLayer{
+		log:       log,
+		listener:  listener,
+		advertise: advertise,
+		certMan:   cm,
+		dialCfg: &tls.Config{
+			MinVersion:           tls.VersionTLS13,
+			RootCAs:              caCertPool,
+			GetClientCertificate: cm.GetClientCertificate,
+		},
+	}, nil
+}
+
+// Accept implements net.Listener.
+func (s *TLSStreamLayer) Accept() (net.Conn, error) {
+	return s.listener.Accept()
+}
+
+// Close implements net.Listener.
+func (s *TLSStreamLayer) Close() error {
+	err := s.listener.Close()
+	s.certMan.Stop()
+	return err
+}
+
+// Addr implements net.Listener.
+func (s *TLSStreamLayer) Addr() net.Addr {
+	return s.advertise
+}
+
+// Dial implements raft.StreamLayer. The certificate of the peer is verified against the host of the address.
+func (s *TLSStreamLayer) Dial(address raft.ServerAddress, timeout time.Duration) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: timeout}
+	return tls.DialWithDialer(dialer, "tcp", string(address), s.dialCfg)
+}
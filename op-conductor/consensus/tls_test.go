@@ -0,0 +1,139 @@
+package consensus
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/hashicorp/raft"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// writeTLSConfig issues a certificate for 127.0.0.1 signed by the ca, and writes the files of the TLS config in dir.
+func (ca *testCA) writeTLSConfig(t *testing.T, dir string) optls.CLIConfig {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "sequencer"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	cfg := optls.CLIConfig{
+		TLSCaCert: filepath.Join(dir, "ca.crt"),
+		TLSCert:   filepath.Join(dir, "tls.crt"),
+		TLSKey:    filepath.Join(dir, "tls.key"),
+	}
+	require.NoError(t, os.WriteFile(cfg.TLSCaCert, ca.pem, 0o600))
+	require.NoError(t, os.WriteFile(cfg.TLSCert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(cfg.TLSKey, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600))
+	return cfg
+}
+
+func newTestStreamLayer(t *testing.T, cfg optls.CLIConfig) *TLSStreamLayer {
+	advertise := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)}
+	stream, err := NewTLSStreamLayer(testlog.Logger(t, log.LevelInfo), "127.0.0.1:0", advertise, cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = stream.Close() })
+	return stream
+}
+
+func TestTLSStreamLayer(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	server := newTestStreamLayer(t, ca.writeTLSConfig(t, filepath.Join(dir, "server")))
+	client := newTestStreamLayer(t, ca.writeTLSConfig(t, filepath.Join(dir, "client")))
+	require.NotZero(t, server.Addr().(*net.TCPAddr).Port)
+
+	accepted := make(chan []byte, 1)
+	go func() {
+		conn, err := server.Accept()
+		if err != nil {
+			close(accepted)
+			return
+		}
+		defer conn.Close()
+		buf := make([]byte, 4)
+		if _, err := conn.Read(buf); err != nil {
+			close(accepted)
+			return
+		}
+		accepted <- buf
+	}()
+
+	conn, err := client.Dial(raft.ServerAddress(server.Addr().String()), time.Second)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte("raft"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("raft"), <-accepted)
+}
+
+func TestTLSStreamLayerRejectsUnknownCA(t *testing.T) {
+	dir := t.TempDir()
+	server := newTestStreamLayer(t, newTestCA(t).writeTLSConfig(t, filepath.Join(dir, "server")))
+	stranger := newTestStreamLayer(t, newTestCA(t).writeTLSConfig(t, filepath.Join(dir, "stranger")))
+
+	go func() {
+		conn, err := server.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		// trigger the handshake, which fails with the certificate of the stranger
+		_, _ = conn.Read(make([]byte, 1))
+	}()
+
+	conn, err := stranger.Dial(raft.ServerAddress(server.Addr().String()), time.Second)
+	if err == nil {
+		defer conn.Close()
+	}
+	require.Error(t, err)
+}
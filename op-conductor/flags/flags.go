@@ -2,6 +2,7 @@ package flags
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/urfave/cli/v2"
 
@@ -11,10 +12,14 @@ import (
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
 )
 
 const EnvVarPrefix = "OP_CONDUCTOR"
 
+// ConsensusTLSFlagPrefix is the prefix of the TLS flags of the consensus transport.
+const ConsensusTLSFlagPrefix = "consensus"
+
 var (
 	ConsensusAddr = &cli.StringFlag{
 		Name:    "consensus.addr",
@@ -28,6 +33,12 @@ var (
 		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "CONSENSUS_PORT"),
 		Value:   50050,
 	}
+	ConsensusTLSEnabled = &cli.BoolFlag{
+		Name:    "consensus.tls.enabled",
+		Usage:   "Enable mutual TLS for consensus connections, with the certificates given by the consensus.tls.* flags",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "CONSENSUS_TLS_ENABLED"),
+		Value:   false,
+	}
 	RaftBootstrap = &cli.BoolFlag{
 		Name:    "raft.bootstrap",
 		Usage:   "If this node should bootstrap a new raft cluster",
@@ -44,6 +55,51 @@ var (
 		Usage:   "Directory to store raft data",
 		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "RAFT_STORAGE_DIR"),
 	}
+	RaftUnsafePayloadHistory = &cli.IntFlag{
+		Name:    "raft.unsafe-payload-history",
+		Usage:   "Number of recent unsafe payloads kept in the raft FSM and its snapshots, to be served to a lagging sequencer",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "RAFT_UNSAFE_PAYLOAD_HISTORY"),
+		Value:   64,
+	}
+	RaftSnapshotHistory = &cli.BoolFlag{
+		Name:    "raft.snapshot-history",
+		Usage:   "Write the unsafe payload history to the raft snapshots. Enable it only after all the servers in the cluster are upgraded to a version supporting the history, since the older ones cannot restore such snapshots",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "RAFT_SNAPSHOT_HISTORY"),
+	}
+	RaftMembershipVoters = &cli.StringSliceFlag{
+		Name:    "raft.membership.voters",
+		Usage:   "Desired voting members of the raft cluster given as <id>=<addr>, which the leader converges the cluster membership toward",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "RAFT_MEMBERSHIP_VOTERS"),
+	}
+	RaftMembershipNonvoters = &cli.StringSliceFlag{
+		Name:    "raft.membership.nonvoters",
+		Usage:   "Desired non-voting members of the raft cluster given as <id>=<addr>",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "RAFT_MEMBERSHIP_NONVOTERS"),
+	}
+	RaftMembershipFile = &cli.StringFlag{
+		Name: "raft.membership.file",
+		Usage: "Path of the JSON file listing the desired members of the raft cluster, reloaded on every reconciliation. " +
+			"Cannot be used with raft.membership.voters and raft.membership.nonvoters",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "RAFT_MEMBERSHIP_FILE"),
+	}
+	RaftMembershipInterval = &cli.DurationFlag{
+		Name:    "raft.membership.interval",
+		Usage:   "Interval between raft cluster membership reconciliations",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "RAFT_MEMBERSHIP_INTERVAL"),
+		Value:   30 * time.Second,
+	}
+	RaftMembershipDeadServerGracePeriod = &cli.DurationFlag{
+		Name:    "raft.membership.dead-server-grace-period",
+		Usage:   "How long a member of the raft cluster can be unreachable before it is removed",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "RAFT_MEMBERSHIP_DEAD_SERVER_GRACE_PERIOD"),
+		Value:   5 * time.Minute,
+	}
+	RaftMembershipMinVoters = &cli.IntFlag{
+		Name:    "raft.membership.min-voters",
+		Usage:   "Minimum number of voters in the raft cluster, no voter is removed or demoted if fewer voters would be left",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "RAFT_MEMBERSHIP_MIN_VOTERS"),
+		Value:   3,
+	}
 	NodeRPC = &cli.StringFlag{
 		Name:    "node.rpc",
 		Usage:   "HTTP provider URL for op-node",
@@ -74,6 +130,38 @@ var (
 		Usage:   "Minimum number of peers required to be considered healthy",
 		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_MIN_PEER_COUNT"),
 	}
+	HealthCheckRules = &cli.StringSliceFlag{
+		Name: "healthcheck.rules",
+		Usage: "Health check rules to enable. Available rules: unsafe_head_progress, unsafe_head_lag, safe_head_lag, " +
+			"peer_count, execution_sync, txpool_size, l1_head_lag, batcher_lag, disk_space",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_RULES"),
+		Value:   cli.NewStringSlice("unsafe_head_progress", "unsafe_head_lag", "safe_head_lag", "peer_count"),
+	}
+	HealthCheckL1Interval = &cli.Uint64Flag{
+		Name:    "healthcheck.l1-interval",
+		Usage:   "Interval allowed between L1 head seen by the sequencer and now measured in seconds, used by the l1_head_lag rule",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_L1_INTERVAL"),
+	}
+	HealthCheckMaxBatcherLag = &cli.Uint64Flag{
+		Name:    "healthcheck.max-batcher-lag",
+		Usage:   "Maximum number of blocks the safe head can be behind the unsafe head, used by the batcher_lag rule",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_MAX_BATCHER_LAG"),
+	}
+	HealthCheckMaxTxPoolSize = &cli.Uint64Flag{
+		Name:    "healthcheck.max-txpool-size",
+		Usage:   "Maximum number of transactions in the txpool of the execution layer, used by the txpool_size rule",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_MAX_TXPOOL_SIZE"),
+	}
+	HealthCheckMinDiskFree = &cli.Uint64Flag{
+		Name:    "healthcheck.min-disk-free",
+		Usage:   "Minimum free disk space in bytes, used by the disk_space rule",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_MIN_DISK_FREE"),
+	}
+	HealthCheckDiskPath = &cli.StringFlag{
+		Name:    "healthcheck.disk-path",
+		Usage:   "Path on the disk to check the free space of, used by the disk_space rule. Defaults to the raft storage directory",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_DISK_PATH"),
+	}
 	Paused = &cli.BoolFlag{
 		Name:    "paused",
 		Usage:   "Whether the conductor is paused",
@@ -105,6 +193,21 @@ var optionalFlags = []cli.Flag{
 	Paused,
 	RPCEnableProxy,
 	RaftBootstrap,
+	ConsensusTLSEnabled,
+	RaftUnsafePayloadHistory,
+	RaftSnapshotHistory,
+	RaftMembershipVoters,
+	RaftMembershipNonvoters,
+	RaftMembershipFile,
+	RaftMembershipInterval,
+	RaftMembershipDeadServerGracePeriod,
+	RaftMembershipMinVoters,
+	HealthCheckRules,
+	HealthCheckL1Interval,
+	HealthCheckMaxBatcherLag,
+	HealthCheckMaxTxPoolSize,
+	HealthCheckMinDiskFree,
+	HealthCheckDiskPath,
 }
 
 func init() {
@@ -113,6 +216,7 @@ func init() {
 	optionalFlags = append(optionalFlags, opmetrics.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, oppprof.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, opflags.CLIFlags(EnvVarPrefix, "")...)
+	optionalFlags = append(optionalFlags, optls.CLIFlagsWithFlagPrefix(EnvVarPrefix+"_CONSENSUS", ConsensusTLSFlagPrefix)...)
 
 	Flags = append(requiredFlags, optionalFlags...)
 }
@@ -0,0 +1,14 @@
+//go:build !windows
+
+package health
+
+import "syscall"
+
+// diskFree returns the free space in bytes of the disk holding the path, available to unprivileged users.
+func diskFree(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return stat.Bavail * uint64(stat.Bsize), nil
+}
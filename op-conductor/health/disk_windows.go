@@ -0,0 +1,9 @@
+//go:build windows
+
+package health
+
+import "errors"
+
+func diskFree(path string) (uint64, error) {
+	return 0, errors.New("disk space check is not supported on windows")
+}
@@ -2,7 +2,10 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	health "github.com/ethereum-optimism/optimism/op-conductor/health"
+	mock "github.com/stretchr/testify/mock"
+)
 
 // HealthMonitor is an autogenerated mock type for the HealthMonitor type
 type HealthMonitor struct {
@@ -17,6 +20,53 @@ func (_m *HealthMonitor) EXPECT() *HealthMonitor_Expecter {
 	return &HealthMonitor_Expecter{mock: &_m.Mock}
 }
 
+// Report provides a mock function with given fields:
+func (_m *HealthMonitor) Report() *health.Report {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Report")
+	}
+
+	var r0 *health.Report
+	if rf, ok := ret.Get(0).(func() *health.Report); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*health.Report)
+		}
+	}
+
+	return r0
+}
+
+// HealthMonitor_Report_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Report'
+type HealthMonitor_Report_Call struct {
+	*mock.Call
+}
+
+// Report is a helper method to define mock.On call
+func (_e *HealthMonitor_Expecter) Report() *HealthMonitor_Report_Call {
+	return &HealthMonitor_Report_Call{Call: _e.mock.On("Report")}
+}
+
+func (_c *HealthMonitor_Report_Call) Run(run func()) *HealthMonitor_Report_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *HealthMonitor_Report_Call) Return(_a0 *health.Report) *HealthMonitor_Report_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HealthMonitor_Report_Call) RunAndReturn(run func() *health.Report) *HealthMonitor_Report_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Start provides a mock function with given fields:
 func (_m *HealthMonitor) Start() error {
 	ret := _m.Called()
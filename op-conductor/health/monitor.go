@@ -8,8 +8,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/log"
 
-	"github.com/ethereum-optimism/optimism/op-node/p2p"
-	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-conductor/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 )
 
@@ -18,57 +17,72 @@ var (
 	ErrSequencerConnectionDown = errors.New("cannot connect to sequencer rpc endpoints")
 )
 
+// ReasonSyncStatusUnavailable is the reason of the health check failure recorded in the metrics, when the sync status
+// of the sequencer cannot be fetched. The other failures are recorded with the names of the failed rules.
+const ReasonSyncStatusUnavailable = "sync_status_unavailable"
+
 // HealthMonitor defines the interface for monitoring the health of the sequencer.
 //
 //go:generate mockery --name HealthMonitor --output mocks/ --with-expecter=true
 type HealthMonitor interface {
 	// Subscribe returns a channel that will be notified for every health check.
 	Subscribe() <-chan error
+	// Report returns the report of the latest health check, or nil if no health check is done yet.
+	Report() *Report
 	// Start starts the health check.
 	Start() error
 	// Stop stops the health check.
 	Stop() error
 }
 
+// RuleResult is the result of a rule in a health check.
+type RuleResult struct {
+	Rule    string `json:"rule"`
+	Healthy bool   `json:"healthy"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Report is the report of a health check.
+type Report struct {
+	// Time is the time of the health check in seconds.
+	Time    uint64       `json:"time"`
+	Healthy bool         `json:"healthy"`
+	Results []RuleResult `json:"results"`
+}
+
 // NewSequencerHealthMonitor creates a new sequencer health monitor.
 // interval is the interval between health checks measured in seconds.
-// safeInterval is the interval between safe head progress measured in seconds.
-// minPeerCount is the minimum number of peers required for the sequencer to be healthy.
-func NewSequencerHealthMonitor(log log.Logger, interval, unsafeInterval, safeInterval, minPeerCount uint64, rollupCfg *rollup.Config, node dial.RollupClientInterface, p2p p2p.API) HealthMonitor {
+// rules are the rules checked on every health check, the sequencer is healthy if all of them pass.
+func NewSequencerHealthMonitor(log log.Logger, m metrics.Metricer, interval uint64, rules []Rule, node dial.RollupClientInterface) HealthMonitor {
 	return &SequencerHealthMonitor{
 		log:            log,
+		metrics:        m,
 		done:           make(chan struct{}),
 		interval:       interval,
 		healthUpdateCh: make(chan error),
-		rollupCfg:      rollupCfg,
-		unsafeInterval: unsafeInterval,
-		safeInterval:   safeInterval,
-		minPeerCount:   minPeerCount,
+		rules:          rules,
 		timeProviderFn: currentTimeProvicer,
 		node:           node,
-		p2p:            p2p,
 	}
 }
 
 // SequencerHealthMonitor monitors sequencer health.
 type SequencerHealthMonitor struct {
-	log  log.Logger
-	done chan struct{}
-	wg   sync.WaitGroup
-
-	rollupCfg          *rollup.Config
-	unsafeInterval     uint64
-	safeInterval       uint64
-	minPeerCount       uint64
-	interval           uint64
-	healthUpdateCh     chan error
-	lastSeenUnsafeNum  uint64
-	lastSeenUnsafeTime uint64
+	log     log.Logger
+	metrics metrics.Metricer
+	done    chan struct{}
+	wg      sync.WaitGroup
+
+	interval       uint64
+	healthUpdateCh chan error
+	rules          []Rule
+
+	reportLock sync.RWMutex
+	report     *Report
 
 	timeProviderFn func() uint64
 
 	node dial.RollupClientInterface
-	p2p  p2p.API
 }
 
 var _ HealthMonitor = (*SequencerHealthMonitor)(nil)
@@ -98,6 +112,13 @@ func (hm *SequencerHealthMonitor) Subscribe() <-chan error {
 	return hm.healthUpdateCh
 }
 
+// Report implements HealthMonitor.
+func (hm *SequencerHealthMonitor) Report() *Report {
+	hm.reportLock.RLock()
+	defer hm.reportLock.RUnlock()
+	return hm.report
+}
+
 func (hm *SequencerHealthMonitor) loop() {
 	defer hm.wg.Done()
 
@@ -115,82 +136,52 @@ func (hm *SequencerHealthMonitor) loop() {
 	}
 }
 
-// healthCheck checks the health of the sequencer by 3 criteria:
-// 1. unsafe head is progressing per block time
-// 2. unsafe head is not too far behind now (measured by unsafeInterval)
-// 3. safe head is progressing every configured batch submission interval
-// 4. peer count is above the configured minimum
+// healthCheck checks the health of the sequencer by all the rules, and reports the result of each rule.
+// It returns ErrSequencerConnectionDown if the sequencer cannot be reached by any of the rules,
+// ErrSequencerNotHealthy if any of the rules failed, and nil otherwise.
 func (hm *SequencerHealthMonitor) healthCheck() error {
 	ctx := context.Background()
+	now := hm.timeProviderFn()
+	report := &Report{Time: now, Healthy: true}
+	defer hm.setReport(report)
+
 	status, err := hm.node.SyncStatus(ctx)
 	if err != nil {
 		hm.log.Error("health monitor failed to get sync status", "err", err)
+		hm.metrics.RecordHealthCheck(ReasonSyncStatusUnavailable)
+		report.Healthy = false
+		report.Results = []RuleResult{{Rule: ReasonSyncStatusUnavailable, Error: err.Error()}}
 		return ErrSequencerConnectionDown
 	}
 
-	now := hm.timeProviderFn()
-
-	var timeDiff, blockDiff, expectedBlocks uint64
-	if hm.lastSeenUnsafeNum != 0 {
-		timeDiff = now - hm.lastSeenUnsafeTime
-		blockDiff = status.UnsafeL2.Number - hm.lastSeenUnsafeNum
-		// how many blocks do we expect to see, minus 1 to account for edge case with respect to time.
-		// for example, if diff = 2.001s and block time = 2s, expecting to see 1 block could potentially cause sequencer to be considered unhealthy.
-		expectedBlocks = timeDiff / hm.rollupCfg.BlockTime
-		if expectedBlocks > 0 {
-			expectedBlocks--
+	var result error
+	for _, rule := range hm.rules {
+		err := rule.Check(ctx, status, now)
+		if err == nil {
+			report.Results = append(report.Results, RuleResult{Rule: rule.Name(), Healthy: true})
+			continue
 		}
-	}
-	if status.UnsafeL2.Number > hm.lastSeenUnsafeNum {
-		hm.lastSeenUnsafeNum = status.UnsafeL2.Number
-		hm.lastSeenUnsafeTime = now
-	}
-
-	if timeDiff > hm.rollupCfg.BlockTime && expectedBlocks > blockDiff {
-		hm.log.Error(
-			"unsafe head is not progressing as expected",
-			"now", now,
-			"unsafe_head_num", status.UnsafeL2.Number,
-			"last_seen_unsafe_num", hm.lastSeenUnsafeNum,
-			"last_seen_unsafe_time", hm.lastSeenUnsafeTime,
-			"unsafe_interval", hm.unsafeInterval,
-		)
-		return ErrSequencerNotHealthy
-	}
-
-	if now-status.UnsafeL2.Time > hm.unsafeInterval {
-		hm.log.Error(
-			"unsafe head is not progressing as expected",
-			"now", now,
-			"unsafe_head_num", status.UnsafeL2.Number,
-			"unsafe_head_time", status.UnsafeL2.Time,
-			"unsafe_interval", hm.unsafeInterval,
-		)
-		return ErrSequencerNotHealthy
-	}
 
-	if now-status.SafeL2.Time > hm.safeInterval {
-		hm.log.Error(
-			"safe head is not progressing as expected",
-			"now", now,
-			"safe_head_num", status.SafeL2.Number,
-			"safe_head_time", status.SafeL2.Time,
-			"safe_interval", hm.safeInterval,
-		)
-		return ErrSequencerNotHealthy
+		hm.metrics.RecordHealthCheck(rule.Name())
+		report.Healthy = false
+		report.Results = append(report.Results, RuleResult{Rule: rule.Name(), Error: err.Error()})
+		if errors.Is(err, ErrSequencerConnectionDown) {
+			result = ErrSequencerConnectionDown
+		} else if result == nil {
+			result = ErrSequencerNotHealthy
+		}
 	}
 
-	stats, err := hm.p2p.PeerStats(ctx)
-	if err != nil {
-		hm.log.Error("health monitor failed to get peer stats", "err", err)
-		return ErrSequencerConnectionDown
-	}
-	if uint64(stats.Connected) < hm.minPeerCount {
-		hm.log.Error("peer count is below minimum", "connected", stats.Connected, "minPeerCount", hm.minPeerCount)
-		return ErrSequencerNotHealthy
+	if result == nil {
+		hm.metrics.RecordHealthCheck(metrics.HealthCheckHealthy)
 	}
+	return result
+}
 
-	return nil
+func (hm *SequencerHealthMonitor) setReport(report *Report) {
+	hm.reportLock.Lock()
+	defer hm.reportLock.Unlock()
+	hm.report = report
 }
 
 func currentTimeProvicer() uint64 {
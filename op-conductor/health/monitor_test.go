@@ -8,6 +8,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/ethereum-optimism/optimism/op-conductor/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	p2pMocks "github.com/ethereum-optimism/optimism/op-node/p2p/mocks"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
@@ -56,16 +57,18 @@ func (s *HealthMonitorTestSuite) SetupMonitor(
 	}
 	monitor := &SequencerHealthMonitor{
 		log:            s.log,
+		metrics:        metrics.NoopMetrics,
 		done:           make(chan struct{}),
 		interval:       s.interval,
 		healthUpdateCh: make(chan error),
-		rollupCfg:      s.rollupCfg,
-		unsafeInterval: unsafeInterval,
-		safeInterval:   safeInterval,
-		minPeerCount:   s.minPeerCount,
+		rules: []Rule{
+			NewUnsafeHeadProgressRule(s.log, s.rollupCfg),
+			NewUnsafeHeadLagRule(s.log, unsafeInterval),
+			NewSafeHeadLagRule(s.log, safeInterval),
+			NewPeerCountRule(s.log, mockP2P, s.minPeerCount),
+		},
 		timeProviderFn: tp.Now,
 		node:           mockRollupClient,
-		p2p:            mockP2P,
 	}
 	err := monitor.Start()
 	s.NoError(err)
@@ -93,6 +96,13 @@ func (s *HealthMonitorTestSuite) TestUnhealthyLowPeerCount() {
 	healthy := <-healthUpdateCh
 	s.NotNil(healthy)
 
+	report := monitor.Report()
+	s.False(report.Healthy)
+	s.Len(report.Results, 4)
+	for _, result := range report.Results {
+		s.Equal(result.Rule != RulePeerCount, result.Healthy, result.Rule)
+	}
+
 	s.NoError(monitor.Stop())
 }
 
@@ -107,14 +117,15 @@ func (s *HealthMonitorTestSuite) TestUnhealthyUnsafeHeadNotProgressing() {
 	}
 
 	monitor := s.SetupMonitor(now, 60, 60, rc, nil)
+	progress := monitor.rules[0].(*UnsafeHeadProgressRule)
 	healthUpdateCh := monitor.Subscribe()
 
 	for i := 0; i < 5; i++ {
 		healthy := <-healthUpdateCh
 		if i < 4 {
 			s.Nil(healthy)
-			s.Equal(now, monitor.lastSeenUnsafeTime)
-			s.Equal(uint64(5), monitor.lastSeenUnsafeNum)
+			s.Equal(now, progress.lastSeenUnsafeTime)
+			s.Equal(uint64(5), progress.lastSeenUnsafeNum)
 		} else {
 			s.NotNil(healthy)
 		}
@@ -163,28 +174,29 @@ func (s *HealthMonitorTestSuite) TestHealthyWithUnsafeLag() {
 	rc.ExpectSyncStatus(mockSyncStatus(now-8, 2, now, 1), nil)
 
 	monitor := s.SetupMonitor(now, 60, 60, rc, nil)
+	progress := monitor.rules[0].(*UnsafeHeadProgressRule)
 	healthUpdateCh := monitor.Subscribe()
 
 	// confirm initial state
-	s.Zero(monitor.lastSeenUnsafeNum)
-	s.Zero(monitor.lastSeenUnsafeTime)
+	s.Zero(progress.lastSeenUnsafeNum)
+	s.Zero(progress.lastSeenUnsafeTime)
 
 	// confirm state after first check
 	healthy := <-healthUpdateCh
 	s.Nil(healthy)
-	lastSeenUnsafeTime := monitor.lastSeenUnsafeTime
-	s.NotZero(monitor.lastSeenUnsafeTime)
-	s.Equal(uint64(1), monitor.lastSeenUnsafeNum)
+	lastSeenUnsafeTime := progress.lastSeenUnsafeTime
+	s.NotZero(progress.lastSeenUnsafeTime)
+	s.Equal(uint64(1), progress.lastSeenUnsafeNum)
 
 	healthy = <-healthUpdateCh
 	s.Nil(healthy)
-	s.Equal(lastSeenUnsafeTime, monitor.lastSeenUnsafeTime)
-	s.Equal(uint64(1), monitor.lastSeenUnsafeNum)
+	s.Equal(lastSeenUnsafeTime, progress.lastSeenUnsafeTime)
+	s.Equal(uint64(1), progress.lastSeenUnsafeNum)
 
 	healthy = <-healthUpdateCh
 	s.Nil(healthy)
-	s.Equal(lastSeenUnsafeTime+2, monitor.lastSeenUnsafeTime)
-	s.Equal(uint64(2), monitor.lastSeenUnsafeNum)
+	s.Equal(lastSeenUnsafeTime+2, progress.lastSeenUnsafeTime)
+	s.Equal(uint64(2), progress.lastSeenUnsafeNum)
 
 	s.NoError(monitor.Stop())
 }
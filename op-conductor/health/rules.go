@@ -0,0 +1,303 @@
+package health
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-node/p2p"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// The names of the built-in rules.
+const (
+	RuleUnsafeHeadProgress = "unsafe_head_progress"
+	RuleUnsafeHeadLag      = "unsafe_head_lag"
+	RuleSafeHeadLag        = "safe_head_lag"
+	RulePeerCount          = "peer_count"
+	RuleExecutionSync      = "execution_sync"
+	RuleTxPoolSize         = "txpool_size"
+	RuleL1HeadLag          = "l1_head_lag"
+	RuleBatcherLag         = "batcher_lag"
+	RuleDiskSpace          = "disk_space"
+)
+
+// DefaultRules is the rules enabled by default.
+var DefaultRules = []string{RuleUnsafeHeadProgress, RuleUnsafeHeadLag, RuleSafeHeadLag, RulePeerCount}
+
+// Rule is a health check of the sequencer.
+type Rule interface {
+	// Name returns the name of the rule, which identifies the rule in the config and the health report.
+	Name() string
+	// Check returns nil if the sequencer is healthy by the rule. status is the sync status of the sequencer and now is
+	// the time of the health check in seconds. The error wraps ErrSequencerConnectionDown if the sequencer cannot be
+	// reached, and ErrSequencerNotHealthy otherwise.
+	Check(ctx context.Context, status *eth.SyncStatus, now uint64) error
+}
+
+func notHealthy(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrSequencerNotHealthy, fmt.Sprintf(format, args...))
+}
+
+func connectionDown(err error) error {
+	return fmt.Errorf("%w: %w", ErrSequencerConnectionDown, err)
+}
+
+// UnsafeHeadProgressRule checks that the unsafe head is progressing per block time.
+type UnsafeHeadProgressRule struct {
+	log       log.Logger
+	rollupCfg *rollup.Config
+
+	lastSeenUnsafeNum  uint64
+	lastSeenUnsafeTime uint64
+}
+
+func NewUnsafeHeadProgressRule(log log.Logger, rollupCfg *rollup.Config) *UnsafeHeadProgressRule {
+	return &UnsafeHeadProgressRule{log: log, rollupCfg: rollupCfg}
+}
+
+func (r *UnsafeHeadProgressRule) Name() string { return RuleUnsafeHeadProgress }
+
+func (r *UnsafeHeadProgressRule) Check(_ context.Context, status *eth.SyncStatus, now uint64) error {
+	var timeDiff, blockDiff, expectedBlocks uint64
+	if r.lastSeenUnsafeNum != 0 {
+		timeDiff = now - r.lastSeenUnsafeTime
+		blockDiff = status.UnsafeL2.Number - r.lastSeenUnsafeNum
+		// how many blocks do we expect to see, minus 1 to account for edge case with respect to time.
+		// for example, if diff = 2.001s and block time = 2s, expecting to see 1 block could potentially cause sequencer to be considered unhealthy.
+		expectedBlocks = timeDiff / r.rollupCfg.BlockTime
+		if expectedBlocks > 0 {
+			expectedBlocks--
+		}
+	}
+	if status.UnsafeL2.Number > r.lastSeenUnsafeNum {
+		r.lastSeenUnsafeNum = status.UnsafeL2.Number
+		r.lastSeenUnsafeTime = now
+	}
+
+	if timeDiff > r.rollupCfg.BlockTime && expectedBlocks > blockDiff {
+		r.log.Error(
+			"unsafe head is not progressing as expected",
+			"now", now,
+			"unsafe_head_num", status.UnsafeL2.Number,
+			"last_seen_unsafe_num", r.lastSeenUnsafeNum,
+			"last_seen_unsafe_time", r.lastSeenUnsafeTime,
+		)
+		return notHealthy("unsafe head %d is not progressing since %d", status.UnsafeL2.Number, r.lastSeenUnsafeTime)
+	}
+	return nil
+}
+
+// UnsafeHeadLagRule checks that the unsafe head is not too far behind now.
+type UnsafeHeadLagRule struct {
+	log            log.Logger
+	unsafeInterval uint64
+}
+
+func NewUnsafeHeadLagRule(log log.Logger, unsafeInterval uint64) *UnsafeHeadLagRule {
+	return &UnsafeHeadLagRule{log: log, unsafeInterval: unsafeInterval}
+}
+
+func (r *UnsafeHeadLagRule) Name() string { return RuleUnsafeHeadLag }
+
+func (r *UnsafeHeadLagRule) Check(_ context.Context, status *eth.SyncStatus, now uint64) error {
+	if now-status.UnsafeL2.Time > r.unsafeInterval {
+		r.log.Error(
+			"unsafe head is not progressing as expected",
+			"now", now,
+			"unsafe_head_num", status.UnsafeL2.Number,
+			"unsafe_head_time", status.UnsafeL2.Time,
+			"unsafe_interval", r.unsafeInterval,
+		)
+		return notHealthy("unsafe head %d is %ds behind", status.UnsafeL2.Number, now-status.UnsafeL2.Time)
+	}
+	return nil
+}
+
+// SafeHeadLagRule checks that the safe head is progressing every configured batch submission interval.
+type SafeHeadLagRule struct {
+	log          log.Logger
+	safeInterval uint64
+}
+
+func NewSafeHeadLagRule(log log.Logger, safeInterval uint64) *SafeHeadLagRule {
+	return &SafeHeadLagRule{log: log, safeInterval: safeInterval}
+}
+
+func (r *SafeHeadLagRule) Name() string { return RuleSafeHeadLag }
+
+func (r *SafeHeadLagRule) Check(_ context.Context, status *eth.SyncStatus, now uint64) error {
+	if now-status.SafeL2.Time > r.safeInterval {
+		r.log.Error(
+			"safe head is not progressing as expected",
+			"now", now,
+			"safe_head_num", status.SafeL2.Number,
+			"safe_head_time", status.SafeL2.Time,
+			"safe_interval", r.safeInterval,
+		)
+		return notHealthy("safe head %d is %ds behind", status.SafeL2.Number, now-status.SafeL2.Time)
+	}
+	return nil
+}
+
+// PeerCountRule checks that the peer count is above the configured minimum.
+type PeerCountRule struct {
+	log          log.Logger
+	p2p          p2p.API
+	minPeerCount uint64
+}
+
+func NewPeerCountRule(log log.Logger, p2p p2p.API, minPeerCount uint64) *PeerCountRule {
+	return &PeerCountRule{log: log, p2p: p2p, minPeerCount: minPeerCount}
+}
+
+func (r *PeerCountRule) Name() string { return RulePeerCount }
+
+func (r *PeerCountRule) Check(ctx context.Context, _ *eth.SyncStatus, _ uint64) error {
+	stats, err := r.p2p.PeerStats(ctx)
+	if err != nil {
+		r.log.Error("health monitor failed to get peer stats", "err", err)
+		return connectionDown(err)
+	}
+	if uint64(stats.Connected) < r.minPeerCount {
+		r.log.Error("peer count is below minimum", "connected", stats.Connected, "minPeerCount", r.minPeerCount)
+		return notHealthy("peer count %d is below %d", stats.Connected, r.minPeerCount)
+	}
+	return nil
+}
+
+// ExecutionSyncRule checks that the execution engine is not syncing.
+type ExecutionSyncRule struct {
+	log    log.Logger
+	client *ethclient.Client
+}
+
+func NewExecutionSyncRule(log log.Logger, client *ethclient.Client) *ExecutionSyncRule {
+	return &ExecutionSyncRule{log: log, client: client}
+}
+
+func (r *ExecutionSyncRule) Name() string { return RuleExecutionSync }
+
+func (r *ExecutionSyncRule) Check(ctx context.Context, _ *eth.SyncStatus, _ uint64) error {
+	progress, err := r.client.SyncProgress(ctx)
+	if err != nil {
+		r.log.Error("health monitor failed to get execution sync progress", "err", err)
+		return connectionDown(err)
+	}
+	if progress != nil {
+		r.log.Error("execution engine is syncing", "current", progress.CurrentBlock, "highest", progress.HighestBlock)
+		return notHealthy("execution engine is syncing at %d of %d", progress.CurrentBlock, progress.HighestBlock)
+	}
+	return nil
+}
+
+// TxPoolSizeRule checks that the transactions in the txpool of the execution engine do not exceed the maximum.
+type TxPoolSizeRule struct {
+	log     log.Logger
+	client  *rpc.Client
+	maxSize uint64
+}
+
+func NewTxPoolSizeRule(log log.Logger, client *rpc.Client, maxSize uint64) *TxPoolSizeRule {
+	return &TxPoolSizeRule{log: log, client: client, maxSize: maxSize}
+}
+
+func (r *TxPoolSizeRule) Name() string { return RuleTxPoolSize }
+
+func (r *TxPoolSizeRule) Check(ctx context.Context, _ *eth.SyncStatus, _ uint64) error {
+	var status struct {
+		Pending hexutil.Uint64 `json:"pending"`
+		Queued  hexutil.Uint64 `json:"queued"`
+	}
+	if err := r.client.CallContext(ctx, &status, "txpool_status"); err != nil {
+		r.log.Error("health monitor failed to get txpool status", "err", err)
+		return connectionDown(err)
+	}
+	if size := uint64(status.Pending) + uint64(status.Queued); size > r.maxSize {
+		r.log.Error("txpool size is above maximum", "pending", status.Pending, "queued", status.Queued, "maxSize", r.maxSize)
+		return notHealthy("txpool size %d is above %d", size, r.maxSize)
+	}
+	return nil
+}
+
+// L1HeadLagRule checks that the L1 head seen by the sequencer is not too far behind now.
+type L1HeadLagRule struct {
+	log        log.Logger
+	l1Interval uint64
+}
+
+func NewL1HeadLagRule(log log.Logger, l1Interval uint64) *L1HeadLagRule {
+	return &L1HeadLagRule{log: log, l1Interval: l1Interval}
+}
+
+func (r *L1HeadLagRule) Name() string { return RuleL1HeadLag }
+
+func (r *L1HeadLagRule) Check(_ context.Context, status *eth.SyncStatus, now uint64) error {
+	if now-status.HeadL1.Time > r.l1Interval {
+		r.log.Error(
+			"L1 head is not progressing as expected",
+			"now", now,
+			"l1_head_num", status.HeadL1.Number,
+			"l1_head_time", status.HeadL1.Time,
+			"l1_interval", r.l1Interval,
+		)
+		return notHealthy("L1 head %d is %ds behind", status.HeadL1.Number, now-status.HeadL1.Time)
+	}
+	return nil
+}
+
+// BatcherLagRule checks that the safe head is not too many blocks behind the unsafe head.
+type BatcherLagRule struct {
+	log       log.Logger
+	maxBlocks uint64
+}
+
+func NewBatcherLagRule(log log.Logger, maxBlocks uint64) *BatcherLagRule {
+	return &BatcherLagRule{log: log, maxBlocks: maxBlocks}
+}
+
+func (r *BatcherLagRule) Name() string { return RuleBatcherLag }
+
+func (r *BatcherLagRule) Check(_ context.Context, status *eth.SyncStatus, _ uint64) error {
+	if status.UnsafeL2.Number > status.SafeL2.Number && status.UnsafeL2.Number-status.SafeL2.Number > r.maxBlocks {
+		r.log.Error(
+			"safe head is too far behind unsafe head",
+			"unsafe_head_num", status.UnsafeL2.Number,
+			"safe_head_num", status.SafeL2.Number,
+			"max_blocks", r.maxBlocks,
+		)
+		return notHealthy("safe head is %d blocks behind unsafe head", status.UnsafeL2.Number-status.SafeL2.Number)
+	}
+	return nil
+}
+
+// DiskSpaceRule checks that the free space of the disk holding the path is above the minimum.
+type DiskSpaceRule struct {
+	log     log.Logger
+	path    string
+	minFree uint64
+}
+
+func NewDiskSpaceRule(log log.Logger, path string, minFree uint64) *DiskSpaceRule {
+	return &DiskSpaceRule{log: log, path: path, minFree: minFree}
+}
+
+func (r *DiskSpaceRule) Name() string { return RuleDiskSpace }
+
+func (r *DiskSpaceRule) Check(_ context.Context, _ *eth.SyncStatus, _ uint64) error {
+	free, err := diskFree(r.path)
+	if err != nil {
+		r.log.Error("health monitor failed to get free disk space", "path", r.path, "err", err)
+		return notHealthy("failed to get free disk space of %s: %v", r.path, err)
+	}
+	if free < r.minFree {
+		r.log.Error("free disk space is below minimum", "path", r.path, "free", free, "minFree", r.minFree)
+		return notHealthy("free disk space %d of %s is below %d", free, r.path, r.minFree)
+	}
+	return nil
+}
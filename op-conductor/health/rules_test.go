@@ -0,0 +1,42 @@
+package health
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestStatusRules(t *testing.T) {
+	lgr := testlog.Logger(t, log.LevelCrit)
+	now := uint64(1000)
+	status := &eth.SyncStatus{
+		HeadL1:   eth.L1BlockRef{Number: 10, Time: now - 30},
+		UnsafeL2: eth.L2BlockRef{Number: 200, Time: now},
+		SafeL2:   eth.L2BlockRef{Number: 100, Time: now - 200},
+	}
+
+	tests := []struct {
+		rule    Rule
+		healthy bool
+	}{
+		{rule: NewL1HeadLagRule(lgr, 60), healthy: true},
+		{rule: NewL1HeadLagRule(lgr, 20), healthy: false},
+		{rule: NewBatcherLagRule(lgr, 100), healthy: true},
+		{rule: NewBatcherLagRule(lgr, 99), healthy: false},
+		{rule: NewDiskSpaceRule(lgr, t.TempDir(), 1), healthy: true},
+		{rule: NewDiskSpaceRule(lgr, t.TempDir(), ^uint64(0)), healthy: false},
+	}
+	for _, tt := range tests {
+		err := tt.rule.Check(context.Background(), status, now)
+		if tt.healthy {
+			require.NoError(t, err, tt.rule.Name())
+		} else {
+			require.ErrorIs(t, err, ErrSequencerNotHealthy, tt.rule.Name())
+		}
+	}
+}
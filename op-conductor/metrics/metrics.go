@@ -0,0 +1,251 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+const Namespace = "op_conductor"
+
+const (
+	ActionStartSequencer = "start_sequencer"
+	ActionStopSequencer  = "stop_sequencer"
+	ActionTransferLeader = "transfer_leader"
+
+	ResultSuccess   = "success"
+	ResultError     = "error"
+	ResultNotLeader = "not_leader"
+
+	HealthCheckHealthy = "healthy"
+)
+
+type Metricer interface {
+	RecordInfo(version string)
+	RecordUp()
+
+	RecordStateChange(leader, healthy, active bool)
+	RecordLeadershipChange(leader bool)
+	RecordPaused(paused bool)
+	RecordAction(action string, err error)
+	RecordLoopExecutionTime(duration time.Duration)
+	RecordHealthCheck(result string)
+	RecordCommitUnsafePayload(duration time.Duration, err error)
+	RecordRPCProxyRequest(method string) func(result string)
+
+	Document() []opmetrics.DocumentedMetric
+}
+
+type Metrics struct {
+	ns       string
+	registry *prometheus.Registry
+	factory  opmetrics.Factory
+
+	info prometheus.GaugeVec
+	up   prometheus.Gauge
+
+	leader          prometheus.Gauge
+	healthy         prometheus.Gauge
+	sequencerActive prometheus.Gauge
+	paused          prometheus.Gauge
+
+	// label by leader, healthy and active of the new state
+	stateChanges opmetrics.EventVec
+	// label by whether the op-conductor became the leader
+	leadershipChanges opmetrics.EventVec
+
+	actionsTotal      *prometheus.CounterVec
+	loopExecutionTime prometheus.Histogram
+
+	// label by healthy or the reason of the failure
+	healthChecksTotal *prometheus.CounterVec
+
+	commitUnsafePayloadTotal    *prometheus.CounterVec
+	commitUnsafePayloadDuration prometheus.Histogram
+
+	rpcProxyRequestsTotal   *prometheus.CounterVec
+	rpcProxyRequestDuration *prometheus.HistogramVec
+}
+
+var _ Metricer = (*Metrics)(nil)
+
+// implements the Registry getter, for metrics HTTP server to hook into
+var _ opmetrics.RegistryMetricer = (*Metrics)(nil)
+
+func NewMetrics(procName string) *Metrics {
+	if procName == "" {
+		procName = "default"
+	}
+	ns := Namespace + "_" + procName
+
+	registry := opmetrics.NewRegistry()
+	factory := opmetrics.With(registry)
+
+	return &Metrics{
+		ns:       ns,
+		registry: registry,
+		factory:  factory,
+
+		info: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "info",
+			Help:      "Pseudo-metric tracking version and config info",
+		}, []string{
+			"version",
+		}),
+		up: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "up",
+			Help:      "1 if the op-conductor has finished starting up",
+		}),
+
+		leader: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "leader",
+			Help:      "1 if the op-conductor is the raft leader",
+		}),
+		healthy: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "healthy",
+			Help:      "1 if the sequencer is healthy",
+		}),
+		sequencerActive: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "sequencer_active",
+			Help:      "1 if the sequencer is active",
+		}),
+		paused: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "paused",
+			Help:      "1 if the control loop of the op-conductor is paused",
+		}),
+
+		stateChanges:      opmetrics.NewEventVec(factory, ns, "", "state_change", "state change", []string{"leader", "healthy", "active"}),
+		leadershipChanges: opmetrics.NewEventVec(factory, ns, "", "leadership_change", "leadership change", []string{"leader"}),
+
+		actionsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "actions_total",
+			Help:      "Count of actions taken to bring the sequencer to the desired state",
+		}, []string{"action", "result"}),
+		loopExecutionTime: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "loop_execution_time_seconds",
+			Help:      "Histogram of the execution time of the control loop actions",
+			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+		}),
+
+		healthChecksTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "health_checks_total",
+			Help:      "Count of sequencer health checks by result",
+		}, []string{"result"}),
+
+		commitUnsafePayloadTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "commit_unsafe_payload_total",
+			Help:      "Count of unsafe payloads committed to the raft cluster",
+		}, []string{"result"}),
+		commitUnsafePayloadDuration: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "commit_unsafe_payload_duration_seconds",
+			Help:      "Histogram of the latency to commit an unsafe payload to the raft cluster",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}),
+
+		rpcProxyRequestsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "rpc_proxy",
+			Name:      "requests_total",
+			Help:      "Count of requests proxied to the sequencer",
+		}, []string{"method", "result"}),
+		rpcProxyRequestDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Subsystem: "rpc_proxy",
+			Name:      "request_duration_seconds",
+			Help:      "Histogram of the durations of the requests proxied to the sequencer",
+			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+		}, []string{"method"}),
+	}
+}
+
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+func (m *Metrics) Document() []opmetrics.DocumentedMetric {
+	return m.factory.Document()
+}
+
+// RecordInfo sets a pseudo-metric that contains versioning and config info for the op-conductor.
+func (m *Metrics) RecordInfo(version string) {
+	m.info.WithLabelValues(version).Set(1)
+}
+
+// RecordUp sets the up metric to 1.
+func (m *Metrics) RecordUp() {
+	m.up.Set(1)
+}
+
+// RecordStateChange records the state the op-conductor has transitioned to.
+func (m *Metrics) RecordStateChange(leader, healthy, active bool) {
+	m.healthy.Set(boolToFloat64(healthy))
+	m.sequencerActive.Set(boolToFloat64(active))
+	m.stateChanges.Record(strconv.FormatBool(leader), strconv.FormatBool(healthy), strconv.FormatBool(active))
+}
+
+// RecordLeadershipChange records the leadership update from the raft consensus.
+func (m *Metrics) RecordLeadershipChange(leader bool) {
+	m.leader.Set(boolToFloat64(leader))
+	m.leadershipChanges.Record(strconv.FormatBool(leader))
+}
+
+func (m *Metrics) RecordPaused(paused bool) {
+	m.paused.Set(boolToFloat64(paused))
+}
+
+func (m *Metrics) RecordAction(action string, err error) {
+	m.actionsTotal.WithLabelValues(action, resultOf(err)).Inc()
+}
+
+func (m *Metrics) RecordLoopExecutionTime(duration time.Duration) {
+	m.loopExecutionTime.Observe(duration.Seconds())
+}
+
+// RecordHealthCheck records the result of a health check, which is either HealthCheckHealthy or the reason of the
+// failure.
+func (m *Metrics) RecordHealthCheck(result string) {
+	m.healthChecksTotal.WithLabelValues(result).Inc()
+}
+
+func (m *Metrics) RecordCommitUnsafePayload(duration time.Duration, err error) {
+	m.commitUnsafePayloadTotal.WithLabelValues(resultOf(err)).Inc()
+	m.commitUnsafePayloadDuration.Observe(duration.Seconds())
+}
+
+// RecordRPCProxyRequest records a request proxied to the sequencer. It returns a function to be called with the
+// result of the request when it is done.
+func (m *Metrics) RecordRPCProxyRequest(method string) func(result string) {
+	timer := prometheus.NewTimer(m.rpcProxyRequestDuration.WithLabelValues(method))
+	return func(result string) {
+		m.rpcProxyRequestsTotal.WithLabelValues(method, result).Inc()
+		timer.ObserveDuration()
+	}
+}
+
+func resultOf(err error) string {
+	if err != nil {
+		return ResultError
+	}
+	return ResultSuccess
+}
+
+func boolToFloat64(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
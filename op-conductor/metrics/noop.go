@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"time"
+
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+type noopMetrics struct{}
+
+var NoopMetrics Metricer = new(noopMetrics)
+
+func (*noopMetrics) Document() []opmetrics.DocumentedMetric { return nil }
+
+func (*noopMetrics) RecordInfo(version string) {}
+func (*noopMetrics) RecordUp()                 {}
+
+func (*noopMetrics) RecordStateChange(leader, healthy, active bool)              {}
+func (*noopMetrics) RecordLeadershipChange(leader bool)                          {}
+func (*noopMetrics) RecordPaused(paused bool)                                    {}
+func (*noopMetrics) RecordAction(action string, err error)                       {}
+func (*noopMetrics) RecordLoopExecutionTime(duration time.Duration)              {}
+func (*noopMetrics) RecordHealthCheck(result string)                             {}
+func (*noopMetrics) RecordCommitUnsafePayload(duration time.Duration, err error) {}
+func (*noopMetrics) RecordRPCProxyRequest(method string) func(result string) {
+	return func(result string) {}
+}
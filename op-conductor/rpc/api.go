@@ -4,15 +4,30 @@ import (
 	"context"
 	"errors"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
+	"github.com/ethereum-optimism/optimism/op-conductor/health"
+	"github.com/ethereum-optimism/optimism/op-conductor/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
 var ErrNotLeader = errors.New("refusing to proxy request to non-leader sequencer")
 
+// proxyResult returns the result of a proxied request to be recorded in the metrics.
+func proxyResult(err error) string {
+	switch {
+	case err == nil:
+		return metrics.ResultSuccess
+	case errors.Is(err, ErrNotLeader):
+		return metrics.ResultNotLeader
+	default:
+		return metrics.ResultError
+	}
+}
+
 // API defines the interface for the op-conductor API.
 type API interface {
 	// Pause pauses op-conductor.
@@ -21,6 +36,8 @@ type API interface {
 	Resume(ctx context.Context) error
 	// SequencerHealthy returns true if the sequencer is healthy.
 	SequencerHealthy(ctx context.Context) (bool, error)
+	// SequencerHealthReport returns the per-rule results of the latest sequencer health check.
+	SequencerHealthReport(ctx context.Context) (*health.Report, error)
 
 	// Consensus related APIs
 	// Leader returns true if the server is the leader.
@@ -39,6 +56,9 @@ type API interface {
 	TransferLeaderToServer(ctx context.Context, id string, addr string) error
 	// ClusterMembership returns the current cluster membership configuration.
 	ClusterMembership(ctx context.Context) ([]*consensus.ServerInfo, error)
+	// UnsafePayloadByNumber returns the recent unsafe payload of the given block number kept in the consensus layer,
+	// or nil if it is not kept.
+	UnsafePayloadByNumber(ctx context.Context, number hexutil.Uint64) (*eth.ExecutionPayloadEnvelope, error)
 
 	// APIs called by op-node
 	// Active returns true if op-conductor is active.
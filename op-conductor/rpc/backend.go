@@ -3,9 +3,11 @@ package rpc
 import (
 	"context"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
+	"github.com/ethereum-optimism/optimism/op-conductor/health"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
@@ -15,6 +17,7 @@ type conductor interface {
 	Paused() bool
 	Stopped() bool
 	SequencerHealthy(ctx context.Context) bool
+	SequencerHealthReport(ctx context.Context) *health.Report
 
 	Leader(ctx context.Context) bool
 	LeaderWithID(ctx context.Context) *consensus.ServerInfo
@@ -25,6 +28,7 @@ type conductor interface {
 	TransferLeaderToServer(ctx context.Context, id string, addr string) error
 	CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error
 	ClusterMembership(ctx context.Context) ([]*consensus.ServerInfo, error)
+	UnsafePayloadByNumber(ctx context.Context, number uint64) *eth.ExecutionPayloadEnvelope
 }
 
 // APIBackend is the backend implementation of the API.
@@ -107,7 +111,17 @@ func (api *APIBackend) SequencerHealthy(ctx context.Context) (bool, error) {
 	return api.con.SequencerHealthy(ctx), nil
 }
 
+// SequencerHealthReport implements API.
+func (api *APIBackend) SequencerHealthReport(ctx context.Context) (*health.Report, error) {
+	return api.con.SequencerHealthReport(ctx), nil
+}
+
 // ClusterMembership implements API.
 func (api *APIBackend) ClusterMembership(ctx context.Context) ([]*consensus.ServerInfo, error) {
 	return api.con.ClusterMembership(ctx)
 }
+
+// UnsafePayloadByNumber implements API.
+func (api *APIBackend) UnsafePayloadByNumber(ctx context.Context, number hexutil.Uint64) (*eth.ExecutionPayloadEnvelope, error) {
+	return api.con.UnsafePayloadByNumber(ctx, uint64(number)), nil
+}
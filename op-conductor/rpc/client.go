@@ -3,9 +3,11 @@ package rpc
 import (
 	"context"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
+	"github.com/ethereum-optimism/optimism/op-conductor/health"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
@@ -100,9 +102,23 @@ func (c *APIClient) SequencerHealthy(ctx context.Context) (bool, error) {
 	return healthy, err
 }
 
+// SequencerHealthReport implements API.
+func (c *APIClient) SequencerHealthReport(ctx context.Context) (*health.Report, error) {
+	var report *health.Report
+	err := c.c.CallContext(ctx, &report, prefixRPC("sequencerHealthReport"))
+	return report, err
+}
+
 // ClusterMembership implements API.
 func (c *APIClient) ClusterMembership(ctx context.Context) ([]*consensus.ServerInfo, error) {
 	var info []*consensus.ServerInfo
 	err := c.c.CallContext(ctx, &info, prefixRPC("clusterMembership"))
 	return info, err
 }
+
+// UnsafePayloadByNumber implements API.
+func (c *APIClient) UnsafePayloadByNumber(ctx context.Context, number hexutil.Uint64) (*eth.ExecutionPayloadEnvelope, error) {
+	var payload *eth.ExecutionPayloadEnvelope
+	err := c.c.CallContext(ctx, &payload, prefixRPC("unsafePayloadByNumber"), number)
+	return payload, err
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ethereum-optimism/optimism/op-conductor/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 )
 
@@ -12,24 +13,29 @@ var NodeAdminRPCNamespace = "admin"
 
 // NodeAdminProxyAPI implements a node admin rpc proxy with a leadership check to make sure only leader returns the result.
 type NodeAdminProxyBackend struct {
-	log    log.Logger
-	con    conductor
-	client *sources.RollupClient
+	log     log.Logger
+	metrics metrics.Metricer
+	con     conductor
+	client  *sources.RollupClient
 }
 
 var _ NodeAdminProxyAPI = (*NodeAdminProxyBackend)(nil)
 
 // NewNodeAdminProxyBackend creates a new NodeAdminProxyBackend instance.
-func NewNodeAdminProxyBackend(log log.Logger, con conductor, client *sources.RollupClient) NodeAdminProxyAPI {
+func NewNodeAdminProxyBackend(log log.Logger, m metrics.Metricer, con conductor, client *sources.RollupClient) NodeAdminProxyAPI {
 	return &NodeAdminProxyBackend{
-		log:    log,
-		con:    con,
-		client: client,
+		log:     log,
+		metrics: m,
+		con:     con,
+		client:  client,
 	}
 }
 
-func (api *NodeAdminProxyBackend) SequencerActive(ctx context.Context) (bool, error) {
-	active, err := api.client.SequencerActive(ctx)
+func (api *NodeAdminProxyBackend) SequencerActive(ctx context.Context) (active bool, err error) {
+	done := api.metrics.RecordRPCProxyRequest("admin_sequencerActive")
+	defer func() { done(proxyResult(err)) }()
+
+	active, err = api.client.SequencerActive(ctx)
 	if err != nil {
 		return false, err
 	}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ethereum-optimism/optimism/op-conductor/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
@@ -14,23 +15,28 @@ var NodeRPCNamespace = "optimism"
 
 // NodeProxyBackend implements a node rpc proxy with a leadership check before each call.
 type NodeProxyBackend struct {
-	log    log.Logger
-	con    conductor
-	client *sources.RollupClient
+	log     log.Logger
+	metrics metrics.Metricer
+	con     conductor
+	client  *sources.RollupClient
 }
 
 var _ NodeProxyAPI = (*NodeProxyBackend)(nil)
 
-func NewNodeProxyBackend(log log.Logger, con conductor, client *sources.RollupClient) *NodeProxyBackend {
+func NewNodeProxyBackend(log log.Logger, m metrics.Metricer, con conductor, client *sources.RollupClient) *NodeProxyBackend {
 	return &NodeProxyBackend{
-		log:    log,
-		con:    con,
-		client: client,
+		log:     log,
+		metrics: m,
+		con:     con,
+		client:  client,
 	}
 }
 
-func (api *NodeProxyBackend) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
-	status, err := api.client.SyncStatus(ctx)
+func (api *NodeProxyBackend) SyncStatus(ctx context.Context) (status *eth.SyncStatus, err error) {
+	done := api.metrics.RecordRPCProxyRequest("optimism_syncStatus")
+	defer func() { done(proxyResult(err)) }()
+
+	status, err = api.client.SyncStatus(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -40,8 +46,11 @@ func (api *NodeProxyBackend) SyncStatus(ctx context.Context) (*eth.SyncStatus, e
 	return status, err
 }
 
-func (api *NodeProxyBackend) OutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error) {
-	output, err := api.client.OutputAtBlock(ctx, blockNum)
+func (api *NodeProxyBackend) OutputAtBlock(ctx context.Context, blockNum uint64) (output *eth.OutputResponse, err error) {
+	done := api.metrics.RecordRPCProxyRequest("optimism_outputAtBlock")
+	defer func() { done(proxyResult(err)) }()
+
+	output, err = api.client.OutputAtBlock(ctx, blockNum)
 	if err != nil {
 		return nil, err
 	}
@@ -51,8 +60,11 @@ func (api *NodeProxyBackend) OutputAtBlock(ctx context.Context, blockNum uint64)
 	return output, nil
 }
 
-func (api *NodeProxyBackend) RollupConfig(ctx context.Context) (*rollup.Config, error) {
-	config, err := api.client.RollupConfig(ctx)
+func (api *NodeProxyBackend) RollupConfig(ctx context.Context) (config *rollup.Config, err error) {
+	done := api.metrics.RecordRPCProxyRequest("optimism_rollupConfig")
+	defer func() { done(proxyResult(err)) }()
+
+	config, err = api.client.RollupConfig(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -110,7 +110,7 @@ func NewL2Validator(t Testing, log log.Logger, cfg *ValidatorCfg, l1 *ethclient.
 	err = challenger.InitConfig(t.Ctx())
 	require.NoError(t, err)
 
-	guardian, err := validator.NewGuardian(validatorCfg, log)
+	guardian, err := validator.NewGuardian(validatorCfg, log, validatormetrics.NoopMetrics)
 	require.NoError(t, err)
 	err = guardian.InitConfig(t.Ctx())
 	require.NoError(t, err)
@@ -159,6 +159,10 @@ func (s *l2VerifierBackend) OnUnsafeL2Payload(ctx context.Context, envelope *eth
 	return nil
 }
 
+func (s *l2VerifierBackend) DerivationPipelineDump(ctx context.Context) (*derive.PipelineDump, error) {
+	return s.verifier.derivation.Dump(), nil
+}
+
 func (s *L2Verifier) L2Finalized() eth.L2BlockRef {
 	return s.engine.Finalized()
 }
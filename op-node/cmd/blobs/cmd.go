@@ -0,0 +1,196 @@
+package blobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+
+	opnode "github.com/ethereum-optimism/optimism/op-node"
+	"github.com/ethereum-optimism/optimism/op-node/flags"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+)
+
+var (
+	l1RPCFlag = &cli.StringFlag{
+		Name:     "l1",
+		Usage:    "Address of the L1 RPC endpoint of a synced node",
+		Required: true,
+	}
+	beaconFlag = &cli.StringFlag{
+		Name:     "l1.beacon",
+		Usage:    "Address of the L1 Beacon-node HTTP endpoint of a synced node, which still has the blobs to export",
+		Required: true,
+	}
+	archiveFlag = &cli.PathFlag{
+		Name:     "archive",
+		Usage:    "Directory of the blob archive to write to",
+		Required: true,
+	}
+	fromFlag = &cli.StringFlag{
+		Name:     "from",
+		Usage:    "Directory, or HTTP(S) URL of an object store, of the blob archive to import",
+		Required: true,
+	}
+	startFlag = &cli.Uint64Flag{
+		Name:     "start",
+		Usage:    "First L1 block number of the range",
+		Required: true,
+	}
+	endFlag = &cli.Uint64Flag{
+		Name:     "end",
+		Usage:    "L1 block number after the last block of the range",
+		Required: true,
+	}
+)
+
+var Subcommands = cli.Commands{
+	{
+		Name:  "export",
+		Usage: "Exports the batch blobs of a range of L1 blocks into a blob archive",
+		Description: "Exports the blobs of the transactions to the batch inbox of the rollup, " +
+			"from the L1 blocks in the range [start, end), into a blob archive that can be read with --l1.blob-archive. " +
+			"Blocks that are already archived are skipped.",
+		Flags: []cli.Flag{
+			l1RPCFlag, beaconFlag, archiveFlag, startFlag, endFlag,
+			opflags.CLINetworkFlag(flags.EnvVarPrefix, ""),
+			opflags.CLIRollupConfigFlag(flags.EnvVarPrefix, ""),
+		},
+		Action: func(ctx *cli.Context) error {
+			rollupCfg, err := opnode.NewRollupConfig(log.Root(), ctx.String(opflags.NetworkFlagName), ctx.String(opflags.RollupConfigFlagName))
+			if err != nil {
+				return err
+			}
+			l1, err := ethclient.DialContext(ctx.Context, ctx.String(l1RPCFlag.Name))
+			if err != nil {
+				return fmt.Errorf("failed to dial L1 RPC: %w", err)
+			}
+			defer l1.Close()
+			beacon := sources.NewL1BeaconClient(
+				sources.NewBeaconHTTPClient(client.NewBasicHTTPClient(ctx.String(beaconFlag.Name), log.Root())),
+				sources.L1BeaconClientConfig{})
+			archive := sources.NewDirBlobArchive(ctx.Path(archiveFlag.Name))
+			return Export(ctx.Context, log.Root(), l1, beacon, rollupCfg.BatchInboxAddress, archive, ctx.Uint64(startFlag.Name), ctx.Uint64(endFlag.Name))
+		},
+	},
+	{
+		Name:  "import",
+		Usage: "Imports a range of L1 blocks of a blob archive into another blob archive",
+		Description: "Imports the archived blobs of the L1 blocks in the range [start, end) into a local blob archive, " +
+			"e.g. from an archive that was exported by another node. Every blob is checked against its versioned hash. " +
+			"Blocks that are already archived are skipped.",
+		Flags: []cli.Flag{fromFlag, archiveFlag, startFlag, endFlag},
+		Action: func(ctx *cli.Context) error {
+			from := sources.NewBlobArchive(sources.NewBlobArchiveStore(ctx.String(fromFlag.Name), log.Root()))
+			archive := sources.NewDirBlobArchive(ctx.Path(archiveFlag.Name))
+			return Import(ctx.Context, log.Root(), from, archive, ctx.Uint64(startFlag.Name), ctx.Uint64(endFlag.Name))
+		},
+	},
+}
+
+type L1Blocks interface {
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+}
+
+type BlobsFetcher interface {
+	GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error)
+}
+
+// Export archives the blobs of the transactions to the batch inbox in the L1 blocks in the range [start, end).
+func Export(ctx context.Context, log log.Logger, l1 L1Blocks, blobs BlobsFetcher, batchInbox common.Address, archive sources.WritableBlobArchiveStore, start, end uint64) error {
+	existing := sources.NewBlobArchive(archive)
+	for num := start; num < end; num++ {
+		if archived, err := isArchived(ctx, existing, num); err != nil {
+			return err
+		} else if archived {
+			continue
+		}
+		block, err := l1.BlockByNumber(ctx, new(big.Int).SetUint64(num))
+		if err != nil {
+			return fmt.Errorf("failed to fetch L1 block %d: %w", num, err)
+		}
+		hashes := batchBlobHashes(block.Transactions(), batchInbox)
+		if len(hashes) == 0 {
+			continue
+		}
+		ref := eth.L1BlockRef{Hash: block.Hash(), Number: num, ParentHash: block.ParentHash(), Time: block.Time()}
+		bs, err := blobs.GetBlobs(ctx, ref, hashes)
+		if err != nil {
+			return fmt.Errorf("failed to fetch blobs of L1 block %s: %w", ref, err)
+		}
+		if err := sources.WriteBlobs(ctx, archive, ref.ID(), hashes, bs); err != nil {
+			return fmt.Errorf("failed to archive blobs of L1 block %s: %w", ref, err)
+		}
+		log.Info("Exported blobs", "block", ref, "blobs", len(hashes))
+	}
+	return nil
+}
+
+// Import copies the archived blobs of the L1 blocks in the range [start, end) into the archive.
+func Import(ctx context.Context, log log.Logger, from *sources.BlobArchive, archive sources.WritableBlobArchiveStore, start, end uint64) error {
+	existing := sources.NewBlobArchive(archive)
+	for num := start; num < end; num++ {
+		if archived, err := isArchived(ctx, existing, num); err != nil {
+			return err
+		} else if archived {
+			continue
+		}
+		manifest, err := from.Manifest(ctx, num)
+		if errors.Is(err, sources.ErrNotArchived) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to read manifest of L1 block %d: %w", num, err)
+		}
+		hashes := make([]eth.IndexedBlobHash, 0, len(manifest.Blobs))
+		bs := make([]*eth.Blob, 0, len(manifest.Blobs))
+		for _, entry := range manifest.Blobs {
+			blob, err := from.Blob(ctx, entry.Hash)
+			if err != nil {
+				return fmt.Errorf("failed to read blob %s of L1 block %s: %w", entry.Hash, manifest.Block, err)
+			}
+			hashes = append(hashes, eth.IndexedBlobHash{Index: entry.Index, Hash: entry.Hash})
+			bs = append(bs, blob)
+		}
+		if err := sources.WriteBlobs(ctx, archive, manifest.Block, hashes, bs); err != nil {
+			return fmt.Errorf("failed to archive blobs of L1 block %s: %w", manifest.Block, err)
+		}
+		log.Info("Imported blobs", "block", manifest.Block, "blobs", len(hashes))
+	}
+	return nil
+}
+
+func isArchived(ctx context.Context, archive *sources.BlobArchive, num uint64) (bool, error) {
+	_, err := archive.Manifest(ctx, num)
+	if errors.Is(err, sources.ErrNotArchived) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to check if L1 block %d is archived: %w", num, err)
+	}
+	return true, nil
+}
+
+// batchBlobHashes returns the indexed hashes of the blobs of the transactions to the batch inbox.
+// The batch sender is not checked, so the blobs are archived across changes of the batcher.
+func batchBlobHashes(txs types.Transactions, batchInbox common.Address) []eth.IndexedBlobHash {
+	var hashes []eth.IndexedBlobHash
+	blobIndex := uint64(0) // index of each blob in the block's blob sidecar
+	for _, tx := range txs {
+		for _, h := range tx.BlobHashes() {
+			if to := tx.To(); to != nil && *to == batchInbox {
+				hashes = append(hashes, eth.IndexedBlobHash{Index: blobIndex, Hash: h})
+			}
+			blobIndex++
+		}
+	}
+	return hashes
+}
@@ -0,0 +1,104 @@
+package blobs
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type testL1 map[uint64]*types.Block
+
+func (l testL1) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	block, ok := l[number.Uint64()]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return block, nil
+}
+
+type testBlobs map[common.Hash]*eth.Blob
+
+func (b testBlobs) GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error) {
+	blobs := make([]*eth.Blob, 0, len(hashes))
+	for _, h := range hashes {
+		blob, ok := b[h.Hash]
+		if !ok {
+			return nil, errors.New("blob expired")
+		}
+		blobs = append(blobs, blob)
+	}
+	return blobs, nil
+}
+
+func blobTx(to common.Address, hashes ...common.Hash) *types.Transaction {
+	return types.NewTx(&types.BlobTx{To: to, BlobHashes: hashes})
+}
+
+func TestExportImport(t *testing.T) {
+	ctx := context.Background()
+	logger := testlog.Logger(t, log.LevelInfo)
+	inbox, other := common.Address{0x11}, common.Address{0x22}
+
+	blobs := make(testBlobs)
+	var hashes []common.Hash
+	for _, data := range []string{"a", "b", "c"} {
+		var blob eth.Blob
+		require.NoError(t, blob.FromData(eth.Data(data)))
+		commitment, err := blob.ComputeKZGCommitment()
+		require.NoError(t, err)
+		hash := eth.KZGToVersionedHash(commitment)
+		blobs[hash] = &blob
+		hashes = append(hashes, hash)
+	}
+	l1 := testL1{
+		// the blob of another rollup comes first, and is not exported but counted in the blob indices
+		10: types.NewBlockWithHeader(&types.Header{Number: big.NewInt(10)}).WithBody(types.Transactions{
+			blobTx(other, common.Hash{0x01}),
+			blobTx(inbox, hashes[0], hashes[1]),
+		}, nil),
+		11: types.NewBlockWithHeader(&types.Header{Number: big.NewInt(11)}).WithBody(types.Transactions{
+			blobTx(other, common.Hash{0x02}),
+		}, nil),
+		12: types.NewBlockWithHeader(&types.Header{Number: big.NewInt(12)}).WithBody(types.Transactions{
+			blobTx(inbox, hashes[2]),
+		}, nil),
+	}
+
+	exported := sources.NewDirBlobArchive(t.TempDir())
+	require.NoError(t, Export(ctx, logger, l1, blobs, inbox, exported, 10, 13))
+
+	imported := sources.NewDirBlobArchive(t.TempDir())
+	require.NoError(t, Import(ctx, logger, sources.NewBlobArchive(exported), imported, 10, 13))
+
+	archive := sources.NewBlobArchive(imported)
+	manifest, err := archive.Manifest(ctx, 10)
+	require.NoError(t, err)
+	require.Equal(t, []sources.BlobArchiveEntry{{Index: 1, Hash: hashes[0]}, {Index: 2, Hash: hashes[1]}}, manifest.Blobs)
+	ref := eth.L1BlockRef{Hash: l1[10].Hash(), Number: 10}
+	got, err := archive.GetBlobs(ctx, ref, []eth.IndexedBlobHash{{Index: 1, Hash: hashes[0]}, {Index: 2, Hash: hashes[1]}})
+	require.NoError(t, err)
+	require.Equal(t, []*eth.Blob{blobs[hashes[0]], blobs[hashes[1]]}, got)
+
+	_, err = archive.Manifest(ctx, 11)
+	require.ErrorIs(t, err, sources.ErrNotArchived)
+	manifest, err = archive.Manifest(ctx, 12)
+	require.NoError(t, err)
+	require.Equal(t, []sources.BlobArchiveEntry{{Index: 0, Hash: hashes[2]}}, manifest.Blobs)
+
+	// archived blocks are skipped, even once their blobs expired
+	clear(blobs)
+	require.NoError(t, Export(ctx, logger, l1, blobs, inbox, exported, 10, 13))
+	require.Error(t, Export(ctx, logger, l1, blobs, inbox, sources.NewDirBlobArchive(t.TempDir()), 10, 13))
+}
@@ -11,9 +11,11 @@ import (
 
 	opnode "github.com/ethereum-optimism/optimism/op-node"
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-node/cmd/blobs"
 	"github.com/ethereum-optimism/optimism/op-node/cmd/genesis"
 	"github.com/ethereum-optimism/optimism/op-node/cmd/networks"
 	"github.com/ethereum-optimism/optimism/op-node/cmd/p2p"
+	"github.com/ethereum-optimism/optimism/op-node/cmd/safedb"
 	"github.com/ethereum-optimism/optimism/op-node/flags"
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/node"
@@ -62,6 +64,14 @@ func main() {
 			Name:        "networks",
 			Subcommands: networks.Subcommands,
 		},
+		{
+			Name:        "blobs",
+			Subcommands: blobs.Subcommands,
+		},
+		{
+			Name:        "safedb",
+			Subcommands: safedb.Subcommands,
+		},
 	}
 
 	ctx := opio.WithInterruptBlocker(context.Background())
@@ -0,0 +1,286 @@
+package safedb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	opnode "github.com/ethereum-optimism/optimism/op-node"
+	"github.com/ethereum-optimism/optimism/op-node/flags"
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	nodesafedb "github.com/ethereum-optimism/optimism/op-node/node/safedb"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	plasma "github.com/ethereum-optimism/optimism/op-plasma"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+)
+
+var (
+	l1RPCFlag = &cli.StringFlag{
+		Name:     "l1",
+		Usage:    "Address of the L1 RPC endpoint of a synced node",
+		Required: true,
+	}
+	beaconFlag = &cli.StringFlag{
+		Name:  "l1.beacon",
+		Usage: "Address of the L1 Beacon-node HTTP endpoint, to read the batches posted as blobs",
+	}
+	blobArchiveFlag = &cli.StringFlag{
+		Name:  "l1.blob-archive",
+		Usage: "Directory, or HTTP(S) URL of an object store, of a blob archive to read blobs from before the beacon node",
+	}
+	l2RPCFlag = &cli.StringFlag{
+		Name:     "l2",
+		Usage:    "Address of the L2 RPC endpoint of a synced execution engine, it is only read from",
+		Required: true,
+	}
+	safeDBPathFlag = &cli.PathFlag{
+		Name:     "safedb.path",
+		Usage:    "File path of the SafeDB to rebuild. The node using it must be stopped",
+		Required: true,
+	}
+	startFlag = &cli.Uint64Flag{
+		Name:     "start",
+		Usage:    "L2 block number of a safe block to start deriving from",
+		Required: true,
+	}
+	endFlag = &cli.Uint64Flag{
+		Name:     "end",
+		Usage:    "L2 block number to derive the safe head up to",
+		Required: true,
+	}
+)
+
+var Subcommands = cli.Commands{
+	{
+		Name:  "rebuild",
+		Usage: "Rebuilds the safe head history of a SafeDB by deriving a range of L2 blocks from L1",
+		Description: "Re-derives the L2 chain from the safe block at start up to the block at end, " +
+			"and records the L1 block at which each L2 block became safe in the SafeDB. " +
+			"The entries of the SafeDB after start are replaced, so end must be at or after the last safe head recorded in the SafeDB. " +
+			"The execution engine is not modified: " +
+			"the derived blocks are checked against the blocks it already has, and the rebuild fails on any mismatch.",
+		Flags: []cli.Flag{
+			l1RPCFlag, beaconFlag, blobArchiveFlag, l2RPCFlag, safeDBPathFlag, startFlag, endFlag,
+			opflags.CLINetworkFlag(flags.EnvVarPrefix, ""),
+			opflags.CLIRollupConfigFlag(flags.EnvVarPrefix, ""),
+		},
+		Action: func(ctx *cli.Context) error {
+			logger := log.Root()
+			rollupCfg, err := opnode.NewRollupConfig(logger, ctx.String(opflags.NetworkFlagName), ctx.String(opflags.RollupConfigFlagName))
+			if err != nil {
+				return err
+			}
+			l1RPC, err := client.NewRPC(ctx.Context, logger, ctx.String(l1RPCFlag.Name))
+			if err != nil {
+				return fmt.Errorf("failed to dial L1 RPC: %w", err)
+			}
+			defer l1RPC.Close()
+			l1, err := sources.NewL1Client(l1RPC, logger, nil, sources.L1ClientDefaultConfig(rollupCfg, false, sources.RPCKindStandard))
+			if err != nil {
+				return fmt.Errorf("failed to create L1 client: %w", err)
+			}
+			l2RPC, err := client.NewRPC(ctx.Context, logger, ctx.String(l2RPCFlag.Name))
+			if err != nil {
+				return fmt.Errorf("failed to dial L2 RPC: %w", err)
+			}
+			defer l2RPC.Close()
+			l2, err := sources.NewL2Client(l2RPC, logger, nil, sources.L2ClientDefaultConfig(rollupCfg, false))
+			if err != nil {
+				return fmt.Errorf("failed to create L2 client: %w", err)
+			}
+			var l1Blobs derive.L1BlobsFetcher
+			if ctx.IsSet(beaconFlag.Name) {
+				l1Blobs = sources.NewL1BeaconClient(
+					sources.NewBeaconHTTPClient(client.NewBasicHTTPClient(ctx.String(beaconFlag.Name), logger)),
+					sources.L1BeaconClientConfig{})
+			}
+			if ctx.IsSet(blobArchiveFlag.Name) {
+				archive := sources.NewBlobArchive(sources.NewBlobArchiveStore(ctx.String(blobArchiveFlag.Name), logger))
+				l1Blobs = sources.NewArchivedBlobsFetcher(archive, l1Blobs, logger)
+			}
+			db, err := nodesafedb.NewSafeDB(logger, ctx.Path(safeDBPathFlag.Name))
+			if err != nil {
+				return fmt.Errorf("failed to open safe db: %w", err)
+			}
+			defer db.Close()
+			return Rebuild(ctx.Context, logger, rollupCfg, l1, l1Blobs, l2, db, ctx.Uint64(startFlag.Name), ctx.Uint64(endFlag.Name))
+		},
+	},
+}
+
+// maxTemporaryErrors is the number of consecutive temporary derivation errors after which the rebuild fails.
+const maxTemporaryErrors = 10
+
+// SafeDB is the SafeDB to rebuild, of which the last entry is read to not truncate the entries after end.
+type SafeDB interface {
+	derive.SafeHeadListener
+	SafeHeadAtL1(ctx context.Context, l1BlockNum uint64) (l1Block eth.BlockID, safeHead eth.BlockID, err error)
+}
+
+// rebuildPipeline is the part of the derivation pipeline that the rebuild is driven with.
+type rebuildPipeline interface {
+	Reset()
+	Step(ctx context.Context) error
+	Origin() eth.L1BlockRef
+}
+
+// safeHeadSource provides the safe head that the derivation progressed to.
+type safeHeadSource interface {
+	SafeL2Head() eth.L2BlockRef
+}
+
+// Rebuild derives the L2 chain from the safe block at start up to the block at end,
+// and records the safe head updates in the SafeDB.
+// The L2 source must already have the blocks up to end, they are checked against the derived blocks, but never modified.
+// The derivation resets the SafeDB to start, so end must be at or after the last safe head of the SafeDB,
+// for the deleted entries to be recorded again.
+func Rebuild(ctx context.Context, log log.Logger, rollupCfg *rollup.Config, l1 derive.L1Fetcher, l1Blobs derive.L1BlobsFetcher, l2 derive.L2Source, db SafeDB, start, end uint64) error {
+	if start >= end {
+		return fmt.Errorf("start %d must be before end %d", start, end)
+	}
+	startRef, err := l2.L2BlockRefByNumber(ctx, start)
+	if err != nil {
+		return fmt.Errorf("failed to fetch L2 block %d: %w", start, err)
+	}
+	unsafeHead, err := l2.L2BlockRefByLabel(ctx, eth.Unsafe)
+	if err != nil {
+		return fmt.Errorf("failed to fetch L2 unsafe head: %w", err)
+	}
+	if unsafeHead.Number < end {
+		return fmt.Errorf("end %d is after the L2 unsafe head %s, the blocks to derive must already be in the engine", end, unsafeHead)
+	}
+	if err := checkLastSafeHead(ctx, db, end); err != nil {
+		return err
+	}
+
+	eng := &readOnlyEngine{L2Source: l2, safe: startRef}
+	ec := derive.NewEngineController(eng, log, metrics.NoopMetrics, rollupCfg, sync.CLSync)
+	syncCfg := &sync.Config{SyncMode: sync.CLSync, SkipSyncStartCheck: true}
+	pipeline := derive.NewDerivationPipeline(log, rollupCfg, l1, l1Blobs, plasma.Disabled, eng, ec, metrics.NoopMetrics, syncCfg, db)
+
+	log.Info("Rebuilding safe db", "start", startRef, "end", end)
+	if err := rebuild(ctx, log, pipeline, ec, eng, end); err != nil {
+		return err
+	}
+	log.Info("Rebuilt safe db", "start", startRef, "safe", ec.SafeL2Head(), "l1", pipeline.Origin())
+	return nil
+}
+
+// checkLastSafeHead checks that the last safe head recorded in the SafeDB is not after end,
+// as the entries after end would be deleted by the reset without being recorded again.
+func checkLastSafeHead(ctx context.Context, db SafeDB, end uint64) error {
+	// there are no entries at the max L1 block number, the last entry is the one before it.
+	l1Block, lastSafeHead, err := db.SafeHeadAtL1(ctx, math.MaxUint64-1)
+	if errors.Is(err, nodesafedb.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to read the last entry of the safe db: %w", err)
+	}
+	if lastSafeHead.Number > end {
+		return fmt.Errorf("end %d is before the last safe head %s recorded at L1 block %s in the safe db, its entries after end would be lost", end, lastSafeHead, l1Block)
+	}
+	return nil
+}
+
+// rebuild resets the pipeline, and steps it until the safe head reaches end.
+func rebuild(ctx context.Context, log log.Logger, pipeline rebuildPipeline, ec safeHeadSource, eng *readOnlyEngine, end uint64) error {
+	pipeline.Reset()
+	lastLog := time.Now()
+	temporaryErrors := 0
+	for ec.SafeL2Head().Number < end {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		err := pipeline.Step(ctx)
+		if eng.err != nil {
+			return fmt.Errorf("derived L2 chain does not match the engine at safe head %s: %w", ec.SafeL2Head(), eng.err)
+		}
+		if err != nil && errors.Is(err, derive.ErrTemporary) {
+			temporaryErrors++
+			if temporaryErrors > maxTemporaryErrors {
+				return fmt.Errorf("too many temporary errors: %w", err)
+			}
+			log.Warn("Temporary derivation error, retrying", "err", err)
+			time.Sleep(time.Second)
+			continue
+		}
+		temporaryErrors = 0
+		if errors.Is(err, io.EOF) {
+			return fmt.Errorf("reached the L1 head %s before end %d, safe head %s", pipeline.Origin(), end, ec.SafeL2Head())
+		} else if err != nil && errors.Is(err, derive.ErrReset) {
+			log.Warn("Derivation pipeline is reset", "err", err)
+			pipeline.Reset()
+		} else if err != nil && errors.Is(err, derive.NotEnoughData) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("derivation failed: %w", err)
+		}
+		if time.Since(lastLog) > 10*time.Second {
+			log.Info("Rebuilding safe db", "safe", ec.SafeL2Head(), "l1", pipeline.Origin(), "end", end)
+			lastLog = time.Now()
+		}
+	}
+	return nil
+}
+
+// readOnlyEngine presents the blocks of an L2 source as an execution engine,
+// to run the derivation pipeline against blocks that were already processed, without modifying them.
+// Forkchoice updates only move the safe head that is tracked locally, and any attempt to build
+// or insert a block is rejected and recorded in err, as the derived chain diverged from the existing one.
+type readOnlyEngine struct {
+	derive.L2Source
+
+	safe eth.L2BlockRef
+	err  error
+}
+
+var _ derive.ExecEngine = (*readOnlyEngine)(nil)
+
+// L2BlockRefByLabel reports the locally tracked safe head as the safe and finalized head,
+// so the derivation starts and continues from it.
+func (e *readOnlyEngine) L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L2BlockRef, error) {
+	switch label {
+	case eth.Safe, eth.Finalized:
+		return e.safe, nil
+	default:
+		return e.L2Source.L2BlockRefByLabel(ctx, label)
+	}
+}
+
+func (e *readOnlyEngine) ForkchoiceUpdate(ctx context.Context, state *eth.ForkchoiceState, attr *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
+	if attr != nil {
+		e.err = errors.New("derived a block that differs from the engine")
+		return nil, e.err
+	}
+	if state.SafeBlockHash != e.safe.Hash {
+		safe, err := e.L2Source.L2BlockRefByHash(ctx, state.SafeBlockHash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch safe block %s: %w", state.SafeBlockHash, err)
+		}
+		e.safe = safe
+	}
+	return &eth.ForkchoiceUpdatedResult{PayloadStatus: eth.PayloadStatusV1{Status: eth.ExecutionValid}}, nil
+}
+
+func (e *readOnlyEngine) NewPayload(ctx context.Context, payload *eth.ExecutionPayload, parentBeaconBlockRoot *common.Hash) (*eth.PayloadStatusV1, error) {
+	e.err = fmt.Errorf("derived block %s that is not in the engine", payload.ID())
+	return nil, e.err
+}
+
+func (e *readOnlyEngine) GetPayload(ctx context.Context, payloadInfo eth.PayloadInfo) (*eth.ExecutionPayloadEnvelope, error) {
+	e.err = errors.New("derived a block that is not in the engine")
+	return nil, e.err
+}
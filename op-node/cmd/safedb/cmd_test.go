@@ -0,0 +1,171 @@
+package safedb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	nodesafedb "github.com/ethereum-optimism/optimism/op-node/node/safedb"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type testL2 struct {
+	derive.L2Source
+	blocks []eth.L2BlockRef
+}
+
+func (l *testL2) L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L2BlockRef, error) {
+	return l.blocks[len(l.blocks)-1], nil
+}
+
+func (l *testL2) L2BlockRefByNumber(ctx context.Context, num uint64) (eth.L2BlockRef, error) {
+	if num >= uint64(len(l.blocks)) {
+		return eth.L2BlockRef{}, ethereum.NotFound
+	}
+	return l.blocks[num], nil
+}
+
+func (l *testL2) L2BlockRefByHash(ctx context.Context, hash common.Hash) (eth.L2BlockRef, error) {
+	for _, b := range l.blocks {
+		if b.Hash == hash {
+			return b, nil
+		}
+	}
+	return eth.L2BlockRef{}, ethereum.NotFound
+}
+
+func newTestL2(n int) *testL2 {
+	l2 := &testL2{}
+	for i := 0; i < n; i++ {
+		l2.blocks = append(l2.blocks, eth.L2BlockRef{Hash: common.Hash{byte(i + 1)}, Number: uint64(i)})
+	}
+	return l2
+}
+
+func TestReadOnlyEngine(t *testing.T) {
+	ctx := context.Background()
+	l2 := newTestL2(5)
+	eng := &readOnlyEngine{L2Source: l2, safe: l2.blocks[1]}
+
+	safe, err := eng.L2BlockRefByLabel(ctx, eth.Safe)
+	require.NoError(t, err)
+	require.Equal(t, l2.blocks[1], safe)
+	finalized, err := eng.L2BlockRefByLabel(ctx, eth.Finalized)
+	require.NoError(t, err)
+	require.Equal(t, l2.blocks[1], finalized)
+	unsafe, err := eng.L2BlockRefByLabel(ctx, eth.Unsafe)
+	require.NoError(t, err)
+	require.Equal(t, l2.blocks[4], unsafe)
+
+	// a forkchoice update only moves the local safe head
+	res, err := eng.ForkchoiceUpdate(ctx, &eth.ForkchoiceState{HeadBlockHash: l2.blocks[4].Hash, SafeBlockHash: l2.blocks[3].Hash}, nil)
+	require.NoError(t, err)
+	require.Equal(t, eth.ExecutionValid, res.PayloadStatus.Status)
+	require.NoError(t, eng.err)
+	safe, err = eng.L2BlockRefByLabel(ctx, eth.Safe)
+	require.NoError(t, err)
+	require.Equal(t, l2.blocks[3], safe)
+
+	// building a block is rejected
+	_, err = eng.ForkchoiceUpdate(ctx, &eth.ForkchoiceState{HeadBlockHash: l2.blocks[3].Hash}, &eth.PayloadAttributes{})
+	require.Error(t, err)
+	require.Error(t, eng.err)
+}
+
+func TestRebuild_InvalidRange(t *testing.T) {
+	ctx := context.Background()
+	logger := testlog.Logger(t, log.LevelInfo)
+	l2 := newTestL2(5)
+	cfg := &rollup.Config{}
+
+	require.ErrorContains(t, Rebuild(ctx, logger, cfg, nil, nil, l2, nil, 3, 3), "must be before end")
+	require.ErrorContains(t, Rebuild(ctx, logger, cfg, nil, nil, l2, nil, 1, 5), "after the L2 unsafe head")
+}
+
+// testPipeline resets and advances the safe head of the SafeDB like the derivation pipeline,
+// each L2 block becoming safe at the L1 block of the same number plus 100.
+type testPipeline struct {
+	l2    *testL2
+	db    derive.SafeHeadListener
+	start eth.L2BlockRef
+	safe  eth.L2BlockRef
+	err   error
+}
+
+func (p *testPipeline) Reset() {
+	p.safe = p.start
+	p.err = p.db.SafeHeadReset(p.start)
+}
+
+func (p *testPipeline) Step(ctx context.Context) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.safe = p.l2.blocks[p.safe.Number+1]
+	return p.db.SafeHeadUpdated(p.safe, rebuiltL1(p.safe.Number), nil)
+}
+
+func (p *testPipeline) Origin() eth.L1BlockRef {
+	return eth.L1BlockRef{Hash: rebuiltL1(p.safe.Number).Hash, Number: rebuiltL1(p.safe.Number).Number}
+}
+
+func (p *testPipeline) SafeL2Head() eth.L2BlockRef {
+	return p.safe
+}
+
+func rebuiltL1(l2Num uint64) eth.BlockID {
+	return eth.BlockID{Hash: common.Hash{0xbb, byte(l2Num)}, Number: 100 + l2Num}
+}
+
+func recordedL1(l2Num uint64) eth.BlockID {
+	return eth.BlockID{Hash: common.Hash{0xaa, byte(l2Num)}, Number: 100 + l2Num}
+}
+
+func TestRebuild_SafeDBEntries(t *testing.T) {
+	ctx := context.Background()
+	logger := testlog.Logger(t, log.LevelInfo)
+	l2 := newTestL2(10)
+	for i := range l2.blocks {
+		l2.blocks[i].L1Origin = eth.BlockID{Number: 100 + uint64(i) - 1}
+	}
+	db, err := nodesafedb.NewSafeDB(logger, t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+	for i := uint64(0); i <= 6; i++ {
+		require.NoError(t, db.SafeHeadUpdated(l2.blocks[i], recordedL1(i), nil))
+	}
+
+	// the entries after end would be deleted by the reset, without being recorded again
+	require.ErrorContains(t, checkLastSafeHead(ctx, db, 5), "end 5 is before the last safe head")
+	require.NoError(t, checkLastSafeHead(ctx, db, 6))
+
+	pipeline := &testPipeline{l2: l2, db: db, start: l2.blocks[2]}
+	require.NoError(t, rebuild(ctx, logger, pipeline, pipeline, &readOnlyEngine{L2Source: l2}, 6))
+	require.Equal(t, l2.blocks[6], pipeline.SafeL2Head())
+
+	// the entries up to start are kept, and the entries after it are recorded again
+	for i := uint64(0); i <= 6; i++ {
+		expectedL1 := recordedL1(i)
+		if i > 2 {
+			expectedL1 = rebuiltL1(i)
+		}
+		l1Block, safeHead, err := db.SafeHeadAtL1(ctx, 100+i)
+		require.NoError(t, err)
+		require.Equal(t, expectedL1, l1Block, "l2 block %d", i)
+		require.Equal(t, l2.blocks[i].ID(), safeHead, "l2 block %d", i)
+		l1Block, safeHead, _, err = db.L1InclusionAtL2(ctx, i)
+		require.NoError(t, err)
+		require.Equal(t, expectedL1, l1Block, "l2 block %d", i)
+		require.Equal(t, l2.blocks[i].ID(), safeHead, "l2 block %d", i)
+	}
+	_, _, _, err = db.L1InclusionAtL2(ctx, 7)
+	require.ErrorIs(t, err, nodesafedb.ErrNotFound)
+}
@@ -82,6 +82,15 @@ var (
 		EnvVars:  prefixEnvVars("L1_BEACON_ARCHIVER"),
 		Category: L1RPCCategory,
 	}
+	L1BlobArchive = &cli.StringFlag{
+		Name: "l1.blob-archive",
+		Usage: "Local directory, or HTTP(S) URL of an object store, with an archive of L1 blobs as exported by 'op-node blobs export'. " +
+			"Blobs are read from the archive first, and from the L1 Beacon endpoint if they are not archived. " +
+			"Use with --l1.beacon.ignore to sync from the archive only.",
+		Required: false,
+		EnvVars:  prefixEnvVars("L1_BLOB_ARCHIVE"),
+		Category: L1RPCCategory,
+	}
 	BeaconCheckIgnore = &cli.BoolFlag{
 		Name:     "l1.beacon.ignore",
 		Usage:    "When false, halts op-node startup if the healthcheck to the Beacon-node endpoint fails.",
@@ -302,6 +311,20 @@ var (
 		EnvVars:  prefixEnvVars("SAFEDB_PATH"),
 		Category: OperationsCategory,
 	}
+	SafeDBRetention = &cli.Uint64Flag{
+		Name:     "safedb.retention",
+		Usage:    "Number of L1 blocks of safe head history to keep in the safe head database, older history is pruned. Keeps all history if 0.",
+		EnvVars:  prefixEnvVars("SAFEDB_RETENTION"),
+		Value:    0,
+		Category: OperationsCategory,
+	}
+	SafeDBCompactionInterval = &cli.DurationFlag{
+		Name:     "safedb.compaction-interval",
+		Usage:    "Interval between prunings and compactions of the safe head database. Disabled if 0.",
+		EnvVars:  prefixEnvVars("SAFEDB_COMPACTION_INTERVAL"),
+		Value:    time.Hour,
+		Category: OperationsCategory,
+	}
 	/* Deprecated Flags */
 	L2EngineSyncEnabled = &cli.BoolFlag{
 		Name:    "l2.engine-sync",
@@ -379,6 +402,7 @@ var optionalFlags = []cli.Flag{
 	BeaconAddr,
 	BeaconHeader,
 	BeaconArchiverAddr,
+	L1BlobArchive,
 	BeaconCheckIgnore,
 	BeaconFetchAllSidecars,
 	SyncModeFlag,
@@ -413,6 +437,8 @@ var optionalFlags = []cli.Flag{
 	ConductorRpcFlag,
 	ConductorRpcTimeoutFlag,
 	SafeDBPath,
+	SafeDBRetention,
+	SafeDBCompactionInterval,
 }
 
 var DeprecatedFlags = []cli.Flag{
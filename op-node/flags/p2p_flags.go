@@ -47,6 +47,9 @@ var (
 	PeerstorePathName      = "p2p.peerstore.path"
 	DiscoveryPathName      = "p2p.discovery.path"
 	SequencerP2PKeyName    = "p2p.sequencer.key"
+	SignerHandoversName    = "p2p.sequencer.signer-handovers"
+	SignerOverlapName      = "p2p.sequencer.signer-overlap"
+	ValidatorManagerName   = "p2p.output-attestations.validator-manager"
 	GossipMeshDName        = "p2p.gossip.mesh.d"
 	GossipMeshDloName      = "p2p.gossip.mesh.lo"
 	GossipMeshDhiName      = "p2p.gossip.mesh.dhi"
@@ -341,6 +344,30 @@ func P2PFlags(envPrefix string) []cli.Flag {
 			EnvVars:  p2pEnv(envPrefix, "SEQUENCER_KEY"),
 			Category: P2PCategory,
 		},
+		&cli.StringSliceFlag{
+			Name: SignerHandoversName,
+			Usage: "Scheduled handovers of the p2p block signer, as comma-separated <address>@<L2 timestamp> entries. " +
+				"Gossiped blocks from the L2 timestamp onwards are accepted from the new signer, on top of the signer loaded from the L1 SystemConfig.",
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "SEQUENCER_SIGNER_HANDOVERS"),
+			Category: P2PCategory,
+		},
+		&cli.DurationFlag{
+			Name:     SignerOverlapName,
+			Usage:    "Duration around every p2p block signer handover in which gossiped blocks of both the old and the new signer are accepted.",
+			Required: false,
+			Value:    0,
+			EnvVars:  p2pEnv(envPrefix, "SEQUENCER_SIGNER_OVERLAP"),
+			Category: P2PCategory,
+		},
+		&cli.StringFlag{
+			Name: ValidatorManagerName,
+			Usage: "Address of the ValidatorManager contract on L1. If set, the node joins the output attestations gossip topic, " +
+				"and accepts output root attestations signed by the active validators.",
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "OUTPUT_ATTESTATIONS_VALIDATOR_MANAGER"),
+			Category: P2PCategory,
+		},
 		&cli.UintFlag{
 			Name:     GossipMeshDName,
 			Usage:    "Configure GossipSub topic stable mesh target count, a.k.a. desired outbound degree, number of peers to gossip to",
@@ -47,11 +47,17 @@ type Metricer interface {
 	RecordL2Ref(name string, ref eth.L2BlockRef)
 	RecordUnsafePayloadsBuffer(length uint64, memSize uint64, next eth.BlockID)
 	RecordDerivedBatches(batchType string)
+	RecordDerivationStageTime(stage string, d time.Duration)
+	RecordChannelLifecycle(event string, sinceFirstFrame time.Duration)
+	RecordBatchValidity(validity string, reason string)
 	CountSequencedTxs(count int)
 	RecordL1ReorgDepth(d uint64)
 	RecordSequencerInconsistentL1Origin(from eth.BlockID, to eth.BlockID)
 	RecordSequencerReset()
 	RecordGossipEvent(evType int32)
+	RecordGossipBlockSigner(signer common.Address)
+	RecordOutputAttestation(validator common.Address, l2BlockNumber uint64)
+	RecordOutputAttestationDivergence()
 	IncPeerCount()
 	DecPeerCount()
 	IncStreamCount()
@@ -68,6 +74,8 @@ type Metricer interface {
 	SetPeerScores(allScores []store.PeerScores)
 	ClientPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration)
 	ServerPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration)
+	ClientPayloadByRangeEvent(start uint64, count uint64, resultCode byte, duration time.Duration)
+	ServerPayloadByRangeEvent(start uint64, count uint64, resultCode byte, duration time.Duration)
 	PayloadsQuarantineSize(n int)
 	RecordPeerUnban()
 	RecordIPUnban()
@@ -95,9 +103,14 @@ type Metrics struct {
 
 	DerivedBatches metrics.EventVec
 
+	DerivationStageSeconds  *prometheus.HistogramVec
+	ChannelLifecycleSeconds *prometheus.HistogramVec
+	BatchValidity           metrics.EventVec
+
 	P2PReqDurationSeconds *prometheus.HistogramVec
 	P2PReqTotal           *prometheus.CounterVec
 	P2PPayloadByNumber    *prometheus.GaugeVec
+	P2PPayloadByRange     *prometheus.GaugeVec
 
 	PayloadsQuarantineTotal prometheus.Gauge
 
@@ -129,15 +142,21 @@ type Metrics struct {
 	frameAddedEvent        *metrics.Event
 
 	// P2P Metrics
-	PeerCount         prometheus.Gauge
-	StreamCount       prometheus.Gauge
-	GossipEventsTotal *prometheus.CounterVec
-	BandwidthTotal    *prometheus.GaugeVec
-	PeerUnbans        prometheus.Counter
-	IPUnbans          prometheus.Counter
-	Dials             *prometheus.CounterVec
-	Accepts           *prometheus.CounterVec
-	PeerScores        *prometheus.HistogramVec
+	PeerCount              prometheus.Gauge
+	StreamCount            prometheus.Gauge
+	GossipEventsTotal      *prometheus.CounterVec
+	GossipBlockSignerTotal *prometheus.CounterVec
+	BandwidthTotal         *prometheus.GaugeVec
+
+	OutputAttestationsTotal      *prometheus.CounterVec
+	OutputAttestationBlock       *prometheus.GaugeVec
+	OutputAttestationDivergences prometheus.Counter
+
+	PeerUnbans prometheus.Counter
+	IPUnbans   prometheus.Counter
+	Dials      *prometheus.CounterVec
+	Accepts    *prometheus.CounterVec
+	PeerScores *prometheus.HistogramVec
 
 	ChannelInputBytes prometheus.Counter
 
@@ -200,6 +219,20 @@ func NewMetrics(procName string) *Metrics {
 
 		DerivedBatches: metrics.NewEventVec(factory, ns, "", "derived_batches", "derived batches", []string{"type"}),
 
+		DerivationStageSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "derivation_stage_seconds",
+			Buckets:   []float64{.0001, .0005, .001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+			Help:      "Histogram of the time spent in a derivation pipeline stage per call, excluding the time spent in the stages it pulls from",
+		}, []string{"stage"}),
+		ChannelLifecycleSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "channel_lifecycle_seconds",
+			Buckets:   []float64{.1, .5, 1, 5, 15, 30, 60, 120, 300, 600, 1200, 3600},
+			Help:      "Histogram of the time since the first frame of a channel was seen, when the channel becomes ready, is read, pruned or timed out",
+		}, []string{"event"}),
+		BatchValidity: metrics.NewEventVec(factory, ns, "", "batch_validity", "batches accepted or dropped by the batch queue, by result and drop reason", []string{"validity", "reason"}),
+
 		SequencerInconsistentL1Origin: metrics.NewEvent(factory, ns, "", "sequencer_inconsistent_l1_origin", "events when the sequencer selects an inconsistent L1 origin"),
 		SequencerResets:               metrics.NewEvent(factory, ns, "", "sequencer_resets", "sequencer resets"),
 
@@ -255,6 +288,36 @@ func NewMetrics(procName string) *Metrics {
 		}, []string{
 			"type",
 		}),
+		GossipBlockSignerTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "gossip_block_signer_total",
+			Help:      "Count of gossiped blocks accepted by signer",
+		}, []string{
+			"signer",
+		}),
+		OutputAttestationsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "output_attestations_total",
+			Help:      "Count of gossiped output attestations accepted by validator",
+		}, []string{
+			"validator",
+		}),
+		OutputAttestationBlock: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "output_attestation_block",
+			Help:      "Latest L2 block number of the gossiped output attestations by validator",
+		}, []string{
+			"validator",
+		}),
+		OutputAttestationDivergences: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "output_attestation_divergences_total",
+			Help:      "Count of gossiped output attestations diverging from the output root attested by another validator",
+		}),
 		BandwidthTotal: factory.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: ns,
 			Subsystem: "p2p",
@@ -329,6 +392,14 @@ func NewMetrics(procName string) *Metrics {
 		}, []string{
 			"p2p_role", // "client" or "server"
 		}),
+		P2PPayloadByRange: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "payload_by_range",
+			Help:      "Payload count of payload by range requests",
+		}, []string{
+			"p2p_role", // "client" or "server"
+		}),
 		PayloadsQuarantineTotal: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: ns,
 			Subsystem: "p2p",
@@ -443,6 +514,18 @@ func (m *Metrics) RecordDerivedBatches(batchType string) {
 	m.DerivedBatches.Record(batchType)
 }
 
+func (m *Metrics) RecordDerivationStageTime(stage string, d time.Duration) {
+	m.DerivationStageSeconds.WithLabelValues(stage).Observe(d.Seconds())
+}
+
+func (m *Metrics) RecordChannelLifecycle(event string, sinceFirstFrame time.Duration) {
+	m.ChannelLifecycleSeconds.WithLabelValues(event).Observe(sinceFirstFrame.Seconds())
+}
+
+func (m *Metrics) RecordBatchValidity(validity string, reason string) {
+	m.BatchValidity.Record(validity, reason)
+}
+
 func (m *Metrics) CountSequencedTxs(count int) {
 	m.TransactionsSequencedTotal.Add(float64(count))
 }
@@ -465,6 +548,19 @@ func (m *Metrics) RecordGossipEvent(evType int32) {
 	m.GossipEventsTotal.WithLabelValues(pb.TraceEvent_Type_name[evType]).Inc()
 }
 
+func (m *Metrics) RecordGossipBlockSigner(signer common.Address) {
+	m.GossipBlockSignerTotal.WithLabelValues(signer.Hex()).Inc()
+}
+
+func (m *Metrics) RecordOutputAttestation(validator common.Address, l2BlockNumber uint64) {
+	m.OutputAttestationsTotal.WithLabelValues(validator.Hex()).Inc()
+	m.OutputAttestationBlock.WithLabelValues(validator.Hex()).Set(float64(l2BlockNumber))
+}
+
+func (m *Metrics) RecordOutputAttestationDivergence() {
+	m.OutputAttestationDivergences.Inc()
+}
+
 func (m *Metrics) IncPeerCount() {
 	m.PeerCount.Inc()
 }
@@ -547,6 +643,25 @@ func (m *Metrics) ServerPayloadByNumberEvent(num uint64, resultCode byte, durati
 	m.P2PPayloadByNumber.WithLabelValues("server").Set(float64(num))
 }
 
+func (m *Metrics) ClientPayloadByRangeEvent(start uint64, count uint64, resultCode byte, duration time.Duration) {
+	if resultCode > 4 { // summarize all high codes to reduce metrics overhead
+		resultCode = 5
+	}
+	code := strconv.FormatUint(uint64(resultCode), 10)
+	m.P2PReqTotal.WithLabelValues("client", "payload_by_range", code).Inc()
+	m.P2PReqDurationSeconds.WithLabelValues("client", "payload_by_range", code).Observe(float64(duration) / float64(time.Second))
+	m.P2PPayloadByNumber.WithLabelValues("client").Set(float64(start + count - 1))
+	m.P2PPayloadByRange.WithLabelValues("client").Set(float64(count))
+}
+
+func (m *Metrics) ServerPayloadByRangeEvent(start uint64, count uint64, resultCode byte, duration time.Duration) {
+	code := strconv.FormatUint(uint64(resultCode), 10)
+	m.P2PReqTotal.WithLabelValues("server", "payload_by_range", code).Inc()
+	m.P2PReqDurationSeconds.WithLabelValues("server", "payload_by_range", code).Observe(float64(duration) / float64(time.Second))
+	m.P2PPayloadByNumber.WithLabelValues("server").Set(float64(start + count - 1))
+	m.P2PPayloadByRange.WithLabelValues("server").Set(float64(count))
+}
+
 func (m *Metrics) PayloadsQuarantineSize(n int) {
 	m.PayloadsQuarantineTotal.Set(float64(n))
 }
@@ -636,6 +751,15 @@ func (n *noopMetricer) RecordUnsafePayloadsBuffer(length uint64, memSize uint64,
 func (n *noopMetricer) RecordDerivedBatches(batchType string) {
 }
 
+func (n *noopMetricer) RecordDerivationStageTime(stage string, d time.Duration) {
+}
+
+func (n *noopMetricer) RecordChannelLifecycle(event string, sinceFirstFrame time.Duration) {
+}
+
+func (n *noopMetricer) RecordBatchValidity(validity string, reason string) {
+}
+
 func (n *noopMetricer) CountSequencedTxs(count int) {
 }
 
@@ -651,6 +775,15 @@ func (n *noopMetricer) RecordSequencerReset() {
 func (n *noopMetricer) RecordGossipEvent(evType int32) {
 }
 
+func (n *noopMetricer) RecordGossipBlockSigner(signer common.Address) {
+}
+
+func (n *noopMetricer) RecordOutputAttestation(validator common.Address, l2BlockNumber uint64) {
+}
+
+func (n *noopMetricer) RecordOutputAttestationDivergence() {
+}
+
 func (n *noopMetricer) SetPeerScores(allScores []store.PeerScores) {
 }
 
@@ -685,6 +818,12 @@ func (n *noopMetricer) ClientPayloadByNumberEvent(num uint64, resultCode byte, d
 func (n *noopMetricer) ServerPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration) {
 }
 
+func (n *noopMetricer) ClientPayloadByRangeEvent(start uint64, count uint64, resultCode byte, duration time.Duration) {
+}
+
+func (n *noopMetricer) ServerPayloadByRangeEvent(start uint64, count uint64, resultCode byte, duration time.Duration) {
+}
+
 func (n *noopMetricer) PayloadsQuarantineSize(int) {
 }
 
@@ -13,6 +13,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/version"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -42,6 +43,7 @@ type driverClient interface {
 	StopSequencer(context.Context) (common.Hash, error)
 	SequencerActive(context.Context) (bool, error)
 	OnUnsafeL2Payload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error
+	DerivationPipelineDump(ctx context.Context) (*derive.PipelineDump, error)
 
 	// [Kroma: START]
 	BlockRefsWithStatus(ctx context.Context, num uint64) (eth.L2BlockRef, eth.L2BlockRef, *eth.SyncStatus, error)
@@ -50,6 +52,7 @@ type driverClient interface {
 
 type SafeDBReader interface {
 	SafeHeadAtL1(ctx context.Context, l1BlockNum uint64) (l1 eth.BlockID, l2 eth.BlockID, err error)
+	L1InclusionAtL2(ctx context.Context, l2BlockNum uint64) (l1 eth.BlockID, l2 eth.BlockID, provenance *eth.BatchProvenance, err error)
 }
 
 type adminAPI struct {
@@ -104,6 +107,44 @@ func (n *adminAPI) PostUnsafePayload(ctx context.Context, envelope *eth.Executio
 	return n.dr.OnUnsafeL2Payload(ctx, envelope)
 }
 
+// DerivationPipelineDump returns the data buffered in each stage of the derivation pipeline,
+// to inspect which stage holds up the derivation.
+func (n *adminAPI) DerivationPipelineDump(ctx context.Context) (*derive.PipelineDump, error) {
+	recordDur := n.M.RecordRPCServerRequest("admin_derivationPipelineDump")
+	defer recordDur()
+	return n.dr.DerivationPipelineDump(ctx)
+}
+
+type outputAttestationsPublisher interface {
+	Publish(ctx context.Context, att *eth.OutputAttestation) error
+}
+
+// outputAttestationsAPI serves the output attestations gossiped by the validators,
+// and lets validators publish their signed attestations.
+type outputAttestationsAPI struct {
+	attestations *outputAttestations
+	publisher    outputAttestationsPublisher
+	m            metrics.RPCMetricer
+}
+
+func (a *outputAttestationsAPI) OutputAttestations(_ context.Context, number hexutil.Uint64) (*eth.OutputAttestationsResponse, error) {
+	recordDur := a.m.RecordRPCServerRequest("kroma_outputAttestations")
+	defer recordDur()
+	return a.attestations.at(uint64(number)), nil
+}
+
+// PublishOutputAttestation publishes an attestation signed by a validator.
+// It is validated like any gossiped attestation before it is published, so it is not published
+// while the status of the validator is still being checked on L1, and may be retried shortly after.
+func (a *outputAttestationsAPI) PublishOutputAttestation(ctx context.Context, att *eth.OutputAttestation) error {
+	recordDur := a.m.RecordRPCServerRequest("kroma_publishOutputAttestation")
+	defer recordDur()
+	if err := a.publisher.Publish(ctx, att); err != nil {
+		return fmt.Errorf("failed to publish output attestation: %w", err)
+	}
+	return nil
+}
+
 type nodeAPI struct {
 	config *rollup.Config
 	client l2EthClient
@@ -173,6 +214,41 @@ func (n *nodeAPI) SafeHeadAtL1Block(ctx context.Context, number hexutil.Uint64)
 	}, nil
 }
 
+// L1InclusionAtL2Block returns the first L1 block at which the L2 block was safe,
+// with the safe head of the update that made the L2 block safe.
+func (n *nodeAPI) L1InclusionAtL2Block(ctx context.Context, number hexutil.Uint64) (*eth.SafeHeadResponse, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_l1InclusionAtL2Block")
+	defer recordDur()
+	l1Block, safeHead, _, err := n.safeDB.L1InclusionAtL2(ctx, uint64(number))
+	if errors.Is(err, safedb.ErrNotFound) {
+		return nil, err
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get l1 inclusion of l2 block %s: %w", number, err)
+	}
+	return &eth.SafeHeadResponse{
+		L1Block:  l1Block,
+		SafeHead: safeHead,
+	}, nil
+}
+
+// BatchProvenanceAtL2Block returns the L1 batcher transactions, and blobs, of the batch that made the L2 block safe.
+// The provenance is nil for the safe heads that were recorded before the provenance was kept.
+func (n *nodeAPI) BatchProvenanceAtL2Block(ctx context.Context, number hexutil.Uint64) (*eth.BatchProvenanceResponse, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_batchProvenanceAtL2Block")
+	defer recordDur()
+	l1Block, safeHead, provenance, err := n.safeDB.L1InclusionAtL2(ctx, uint64(number))
+	if errors.Is(err, safedb.ErrNotFound) {
+		return nil, err
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get batch provenance of l2 block %s: %w", number, err)
+	}
+	return &eth.BatchProvenanceResponse{
+		L1Block:    l1Block,
+		SafeHead:   safeHead,
+		Provenance: provenance,
+	}, nil
+}
+
 func (n *nodeAPI) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
 	recordDur := n.m.RecordRPCServerRequest("optimism_syncStatus")
 	defer recordDur()
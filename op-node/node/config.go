@@ -14,6 +14,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	plasma "github.com/ethereum-optimism/optimism/op-plasma"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -23,6 +24,9 @@ type Config struct {
 
 	Beacon L1BeaconEndpointSetup
 
+	// L1BlobArchive is the location of an archive of L1 blobs, read before the L1 Beacon API. Disabled if empty.
+	L1BlobArchive string
+
 	Driver driver.Config
 
 	Rollup rollup.Config
@@ -31,6 +35,13 @@ type Config struct {
 	// if the node is sequencing and if the p2p stack is enabled
 	P2PSigner p2p.SignerSetup
 
+	// P2PSignerSchedule schedules the rotation of the p2p block signer of gossiped blocks
+	P2PSignerSchedule SignerScheduleConfig
+
+	// ValidatorManagerAddr is the address of the ValidatorManager contract on L1, to check the validators of
+	// gossiped output attestations against. The output attestations topic is not joined if it is not set.
+	ValidatorManagerAddr common.Address
+
 	RPC RPCConfig
 
 	P2P p2p.SetupP2P
@@ -47,6 +58,13 @@ type Config struct {
 	// Path to store safe head database. Disabled when set to empty string
 	SafeDBPath string
 
+	// SafeDBRetention is the number of L1 blocks of safe head history to keep. All history is kept if 0.
+	SafeDBRetention uint64
+
+	// SafeDBCompactionInterval is the interval between prunings and compactions of the safe head database.
+	// Disabled if <= 0.
+	SafeDBCompactionInterval time.Duration
+
 	// RuntimeConfigReloadInterval defines the interval between runtime config reloads.
 	// Disabled if <= 0.
 	// Runtime config changes should be picked up from log-events,
@@ -162,6 +180,9 @@ func (cfg *Config) Check() error {
 			return fmt.Errorf("p2p config error: %w", err)
 		}
 	}
+	if err := cfg.P2PSignerSchedule.Check(); err != nil {
+		return fmt.Errorf("p2p signer schedule error: %w", err)
+	}
 	/* [Kroma: START]
 	if !(cfg.RollupHalt == "" || cfg.RollupHalt == "major" || cfg.RollupHalt == "minor" || cfg.RollupHalt == "patch") {
 		return fmt.Errorf("invalid rollup halting option: %q", cfg.RollupHalt)
@@ -17,6 +17,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 
@@ -51,6 +52,7 @@ type OpNode struct {
 	l1SafeSub      ethereum.Subscription // Subscription to get L1 safe blocks, a.k.a. justified data (polling)
 	l1FinalizedSub ethereum.Subscription // Subscription to get L1 safe blocks, a.k.a. justified data (polling)
 
+	l1RPC     client.RPC            // L1 RPC, to make contract calls with
 	l1Source  *sources.L1Client     // L1 Client to fetch data from
 	l2Driver  *driver.Driver        // L2 Engine to Sync
 	l2Source  *sources.EngineClient // L2 Execution Engine RPC bindings
@@ -60,6 +62,9 @@ type OpNode struct {
 	tracer    Tracer                // tracer to get events for testing/debugging
 	runCfg    *RuntimeConfig        // runtime configurables
 
+	outputAttestations      *outputAttestations          // output attestations gossiped by the validators
+	outputAttestationsTopic *p2p.OutputAttestationsTopic // output attestations gossip topic, nil if not enabled
+
 	safeDB closableSafeDB
 
 	/* [Kroma: START]
@@ -71,6 +76,8 @@ type OpNode struct {
 
 	beacon *sources.L1BeaconClient
 
+	blobArchive *sources.ArchivedBlobsFetcher // L1 blobs fetcher reading from the blob archive first, nil if disabled
+
 	// some resources cannot be stopped directly, like the p2p gossipsub router (not our design),
 	// and depend on this ctx to be closed.
 	resourcesCtx   context.Context
@@ -180,8 +187,8 @@ func (n *OpNode) initL1(ctx context.Context, cfg *Config) error {
 	// Set the RethDB path in the EthClientConfig, if there is one configured.
 	rpcCfg.EthClientConfig.RethDBPath = cfg.RethDBPath
 
-	n.l1Source, err = sources.NewL1Client(
-		client.NewInstrumentedRPC(l1Node, n.metrics), n.log, n.metrics.L1SourceCache, rpcCfg)
+	n.l1RPC = client.NewInstrumentedRPC(l1Node, n.metrics)
+	n.l1Source, err = sources.NewL1Client(n.l1RPC, n.log, n.metrics.L1SourceCache, rpcCfg)
 	if err != nil {
 		return fmt.Errorf("failed to create L1 source: %w", err)
 	}
@@ -216,7 +223,7 @@ func (n *OpNode) initL1(ctx context.Context, cfg *Config) error {
 
 func (n *OpNode) initRuntimeConfig(ctx context.Context, cfg *Config) error {
 	// attempt to load runtime config, repeat N times
-	n.runCfg = NewRuntimeConfig(n.log, n.l1Source, &cfg.Rollup)
+	n.runCfg = NewRuntimeConfig(n.log, n.l1Source, &cfg.Rollup, cfg.P2PSignerSchedule)
 
 	confDepth := cfg.Driver.VerifierConfDepth
 	reload := func(ctx context.Context) (eth.L1BlockRef, error) {
@@ -335,6 +342,11 @@ func (n *OpNode) initL1BeaconAPI(ctx context.Context, cfg *Config) error {
 		FetchAllSidecars: cfg.Beacon.ShouldFetchAllSidecars(),
 	}
 	n.beacon = sources.NewL1BeaconClient(beaconClient, beaconCfg, fallbacks...)
+	if cfg.L1BlobArchive != "" {
+		archive := sources.NewBlobArchive(sources.NewBlobArchiveStore(cfg.L1BlobArchive, n.log))
+		n.blobArchive = sources.NewArchivedBlobsFetcher(archive, n.beacon, n.log)
+		n.log.Info("Reading L1 blobs from the blob archive, before the L1 Beacon API", "archive", cfg.L1BlobArchive)
+	}
 
 	// Retry retrieval of the Beacon API version, to be more robust on startup against Beacon API connection issues.
 	beaconVersion, missingEndpoint, err := retry.Do2[string, bool](ctx, 5, retry.Exponential(), func() (string, bool, error) {
@@ -411,11 +423,20 @@ func (n *OpNode) initL2(ctx context.Context, cfg *Config, snapshotLog log.Logger
 		if err != nil {
 			return fmt.Errorf("failed to create safe head database at %v: %w", cfg.SafeDBPath, err)
 		}
+		if cfg.SafeDBCompactionInterval > 0 {
+			safeDB.StartCompaction(cfg.SafeDBCompactionInterval, cfg.SafeDBRetention)
+		} else if cfg.SafeDBRetention != 0 {
+			n.log.Warn("Safe head database retention is not applied, the compaction is disabled", "retention", cfg.SafeDBRetention)
+		}
 		n.safeDB = safeDB
 	} else {
 		n.safeDB = safedb.Disabled
 	}
-	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, n.beacon, n, n, n.log, snapshotLog, n.metrics, cfg.ConfigPersistence, n.safeDB, &cfg.Sync, sequencerConductor, plasmaDA)
+	var l1Blobs derive.L1BlobsFetcher = n.beacon
+	if n.blobArchive != nil {
+		l1Blobs = n.blobArchive
+	}
+	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, l1Blobs, n, n, n.log, snapshotLog, n.metrics, cfg.ConfigPersistence, n.safeDB, &cfg.Sync, sequencerConductor, plasmaDA)
 	return nil
 }
 
@@ -427,6 +448,13 @@ func (n *OpNode) initRPCServer(cfg *Config) error {
 	if n.p2pNode != nil {
 		server.EnableP2P(p2p.NewP2PAPIBackend(n.p2pNode, n.log, n.metrics))
 	}
+	if n.outputAttestationsTopic != nil {
+		server.EnableOutputAttestations(&outputAttestationsAPI{
+			attestations: n.outputAttestations,
+			publisher:    n.outputAttestationsTopic,
+			m:            n.metrics,
+		})
+	}
 	if cfg.RPC.EnableAdmin {
 		server.EnableAdminAPI(NewAdminAPI(n.l2Driver, n.metrics, n.log))
 		n.log.Info("Admin RPC enabled")
@@ -509,6 +537,31 @@ func (n *OpNode) initP2P(ctx context.Context, cfg *Config) error {
 			go n.p2pNode.DiscoveryProcess(n.resourcesCtx, n.log, &cfg.Rollup, cfg.P2P.TargetPeers())
 		}
 	}
+	return n.initOutputAttestations(cfg)
+}
+
+func (n *OpNode) initOutputAttestations(cfg *Config) error {
+	if cfg.ValidatorManagerAddr == (common.Address{}) {
+		return nil
+	}
+	if n.p2pNode == nil {
+		n.log.Warn("Output attestations require the p2p stack, which is disabled")
+		return nil
+	}
+	valSet, err := newL1ValidatorSet(n.log, n.l1RPC, cfg.ValidatorManagerAddr)
+	if err != nil {
+		return err
+	}
+	heads := newL2HeadsTracker(n.log, n.l2Driver)
+	// both keep running in the background until the node resources are closed
+	go valSet.run(n.resourcesCtx)
+	go heads.run(n.resourcesCtx, time.Duration(cfg.Rollup.BlockTime)*time.Second)
+	n.outputAttestations = newOutputAttestations()
+	n.outputAttestationsTopic, err = p2p.JoinOutputAttestationsGossip(n.p2pNode.Host().ID(), n.p2pNode.GossipSub(), n.log, &cfg.Rollup, valSet, heads, n.OnOutputAttestation)
+	if err != nil {
+		return fmt.Errorf("failed to join output attestations gossip topic: %w", err)
+	}
+	n.log.Info("Joined output attestations gossip topic", "validator_manager", cfg.ValidatorManagerAddr)
 	return nil
 }
 
@@ -588,6 +641,18 @@ func (n *OpNode) PublishL2Payload(ctx context.Context, envelope *eth.ExecutionPa
 	return nil
 }
 
+// OnOutputAttestation keeps an output attestation of an active validator,
+// and reports it if it diverges from the output root attested by another validator.
+func (n *OpNode) OnOutputAttestation(ctx context.Context, from peer.ID, att *eth.OutputAttestation) error {
+	n.metrics.RecordOutputAttestation(att.Validator, uint64(att.L2BlockNumber))
+	if n.outputAttestations.add(att) {
+		n.metrics.RecordOutputAttestationDivergence()
+		n.log.Warn("Received output attestation diverging from other validators", "block", uint64(att.L2BlockNumber),
+			"output_root", att.OutputRoot, "validator", att.Validator, "peer", from)
+	}
+	return nil
+}
+
 func (n *OpNode) OnUnsafeL2Payload(ctx context.Context, from peer.ID, envelope *eth.ExecutionPayloadEnvelope) error {
 	// ignore if it's from ourselves
 	if n.p2pNode != nil && from == n.p2pNode.Host().ID() {
@@ -655,6 +720,11 @@ func (n *OpNode) Stop(ctx context.Context) error {
 			result = multierror.Append(result, fmt.Errorf("failed to close RPC server: %w", err))
 		}
 	}
+	if n.outputAttestationsTopic != nil {
+		if err := n.outputAttestationsTopic.Close(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to close output attestations topic: %w", err))
+		}
+	}
 	if n.p2pNode != nil {
 		if err := n.p2pNode.Close(); err != nil {
 			result = multierror.Append(result, fmt.Errorf("failed to close p2p node: %w", err))
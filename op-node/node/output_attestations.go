@@ -0,0 +1,273 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/p2p"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/kroma-network/kroma/kroma-bindings/bindings"
+)
+
+const (
+	// validatorSetRefreshInterval is how often the statuses of the known active validators are refreshed from L1.
+	validatorSetRefreshInterval = time.Minute
+	// validatorSetMinRefreshDelay rate-limits the refreshes that are requested for validators of unknown status.
+	validatorSetMinRefreshDelay = 5 * time.Second
+	// activeValidatorsCacheSize limits the number of known active validators.
+	activeValidatorsCacheSize = 1000
+	// inactiveValidatorsCacheSize limits the number of known inactive signers, kept apart from the active validators
+	// so that they cannot evict them.
+	inactiveValidatorsCacheSize = 1000
+	// pendingValidatorsLimit limits the number of validators of unknown status checked at each requested refresh.
+	pendingValidatorsLimit = 64
+	// outputAttestationsBlocks is the number of most recent attested L2 blocks that attestations are kept for.
+	outputAttestationsBlocks = 256
+)
+
+var errUnknownValidatorStatus = errors.New("validator status is not known yet")
+
+// l1ValidatorSet checks validators against the active validator set of the ValidatorManager contract on L1.
+// The statuses are refreshed in the background, so gossiped attestations are checked without an L1 call.
+// The status of a validator that is not known yet is checked at the next refresh. The known active validators are
+// refreshed periodically, while the known inactive signers are forgotten at each periodic refresh to be checked again
+// on demand, so that a random signer costs at most a single check per period.
+type l1ValidatorSet struct {
+	log      log.Logger
+	caller   *batching.MultiCaller
+	contract *batching.BoundContract
+
+	mu       sync.Mutex
+	active   *simplelru.LRU[common.Address, struct{}]
+	inactive *simplelru.LRU[common.Address, struct{}]
+	pending  map[common.Address]struct{}
+	// refreshReq signals the background loop that there are pending validators.
+	refreshReq chan struct{}
+}
+
+var _ p2p.ValidatorSet = (*l1ValidatorSet)(nil)
+
+func newL1ValidatorSet(log log.Logger, rpc batching.EthRpc, validatorManagerAddr common.Address) (*l1ValidatorSet, error) {
+	validatorManagerAbi, err := bindings.ValidatorManagerMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ValidatorManager ABI: %w", err)
+	}
+	active, _ := simplelru.NewLRU[common.Address, struct{}](activeValidatorsCacheSize, nil)
+	inactive, _ := simplelru.NewLRU[common.Address, struct{}](inactiveValidatorsCacheSize, nil)
+	return &l1ValidatorSet{
+		log:        log,
+		caller:     batching.NewMultiCaller(rpc, batching.DefaultBatchSize),
+		contract:   batching.NewBoundContract(validatorManagerAbi, validatorManagerAddr),
+		active:     active,
+		inactive:   inactive,
+		pending:    make(map[common.Address]struct{}),
+		refreshReq: make(chan struct{}, 1),
+	}, nil
+}
+
+// IsActiveValidator returns the last known status of the validator.
+// If it is not known yet, the validator is checked at the next refresh and errUnknownValidatorStatus is returned.
+func (s *l1ValidatorSet) IsActiveValidator(_ context.Context, addr common.Address) (bool, error) {
+	s.mu.Lock()
+	if _, ok := s.active.Get(addr); ok {
+		s.mu.Unlock()
+		return true, nil
+	}
+	if s.inactive.Contains(addr) {
+		s.mu.Unlock()
+		return false, nil
+	}
+	if len(s.pending) < pendingValidatorsLimit {
+		s.pending[addr] = struct{}{}
+	}
+	s.mu.Unlock()
+	select {
+	case s.refreshReq <- struct{}{}:
+	default:
+	}
+	return false, fmt.Errorf("%w: %s", errUnknownValidatorStatus, addr)
+}
+
+// refresh checks the statuses of the pending validators, and of the known active validators if periodic, in a single
+// batch of L1 calls. The known inactive signers are forgotten at the periodic refresh.
+func (s *l1ValidatorSet) refresh(ctx context.Context, periodic bool) error {
+	s.mu.Lock()
+	var addrs []common.Address
+	if periodic {
+		addrs = s.active.Keys()
+		s.inactive.Purge()
+	}
+	for addr := range s.pending {
+		addrs = append(addrs, addr)
+	}
+	s.mu.Unlock()
+	if len(addrs) == 0 {
+		return nil
+	}
+
+	calls := make([]batching.Call, len(addrs))
+	for i, addr := range addrs {
+		calls[i] = s.contract.Call("isActive", addr)
+	}
+	results, err := s.caller.Call(ctx, rpcblock.Latest, calls...)
+	if err != nil {
+		return fmt.Errorf("failed to check the active validators: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, addr := range addrs {
+		if results[i].GetBool(0) {
+			s.active.Add(addr, struct{}{})
+			s.inactive.Remove(addr)
+		} else {
+			s.active.Remove(addr)
+			s.inactive.Add(addr, struct{}{})
+		}
+		delete(s.pending, addr)
+	}
+	return nil
+}
+
+// run refreshes the validator statuses periodically, and when validators of unknown status are checked.
+func (s *l1ValidatorSet) run(ctx context.Context) {
+	ticker := time.NewTicker(validatorSetRefreshInterval)
+	defer ticker.Stop()
+	for {
+		periodic := false
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			periodic = true
+		case <-s.refreshReq:
+		}
+		if err := s.refresh(ctx, periodic); err != nil {
+			s.log.Warn("Failed to refresh the active validator set", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(validatorSetMinRefreshDelay):
+		}
+	}
+}
+
+// syncStatusSource is the part of the driver that the L2 heads are tracked from.
+type syncStatusSource interface {
+	SyncStatus(ctx context.Context) (*eth.SyncStatus, error)
+}
+
+type l2HeadNumbers struct {
+	safe, unsafe uint64
+}
+
+// l2HeadsTracker keeps the recent safe and unsafe L2 heads of the driver,
+// to bound the gossiped attestations without waiting on the driver event loop.
+type l2HeadsTracker struct {
+	log    log.Logger
+	status syncStatusSource
+	heads  atomic.Pointer[l2HeadNumbers]
+}
+
+var _ p2p.L2Heads = (*l2HeadsTracker)(nil)
+
+func newL2HeadsTracker(log log.Logger, status syncStatusSource) *l2HeadsTracker {
+	return &l2HeadsTracker{log: log, status: status}
+}
+
+func (t *l2HeadsTracker) L2Heads() (uint64, uint64, bool) {
+	heads := t.heads.Load()
+	if heads == nil {
+		return 0, 0, false
+	}
+	return heads.safe, heads.unsafe, true
+}
+
+func (t *l2HeadsTracker) update(ctx context.Context) error {
+	status, err := t.status.SyncStatus(ctx)
+	if err != nil {
+		return err
+	}
+	t.heads.Store(&l2HeadNumbers{safe: status.SafeL2.Number, unsafe: status.UnsafeL2.Number})
+	return nil
+}
+
+// run updates the L2 heads at the interval until the context is done.
+func (t *l2HeadsTracker) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := t.update(ctx); err != nil && ctx.Err() == nil {
+			t.log.Warn("Failed to update the L2 heads of output attestations", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// outputAttestations keeps the output attestations of the most recent attested L2 blocks, by validator.
+type outputAttestations struct {
+	mu     sync.RWMutex
+	blocks *simplelru.LRU[uint64, map[common.Address]*eth.OutputAttestation]
+}
+
+func newOutputAttestations() *outputAttestations {
+	blocks, _ := simplelru.NewLRU[uint64, map[common.Address]*eth.OutputAttestation](outputAttestationsBlocks, nil)
+	return &outputAttestations{blocks: blocks}
+}
+
+// add adds the attestation, replacing any previous attestation of the validator at the same L2 block.
+// It returns true if the attested output root diverges from the output root attested by another validator.
+func (a *outputAttestations) add(att *eth.OutputAttestation) (diverged bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	num := uint64(att.L2BlockNumber)
+	attestations, ok := a.blocks.Get(num)
+	if !ok {
+		attestations = make(map[common.Address]*eth.OutputAttestation)
+		a.blocks.Add(num, attestations)
+	}
+	for validator, other := range attestations {
+		if validator != att.Validator && other.OutputRoot != att.OutputRoot {
+			diverged = true
+		}
+	}
+	attestations[att.Validator] = att
+	return diverged
+}
+
+// at returns the attestations at the L2 block ordered by validator.
+func (a *outputAttestations) at(num uint64) *eth.OutputAttestationsResponse {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	attestations, _ := a.blocks.Peek(num)
+	res := &eth.OutputAttestationsResponse{
+		L2BlockNumber: hexutil.Uint64(num),
+		Attestations:  make([]*eth.OutputAttestation, 0, len(attestations)),
+	}
+	for _, att := range attestations {
+		res.Attestations = append(res.Attestations, att)
+		res.Diverged = res.Diverged || att.OutputRoot != res.Attestations[0].OutputRoot
+	}
+	slices.SortFunc(res.Attestations, func(x, y *eth.OutputAttestation) int {
+		return x.Validator.Cmp(y.Validator)
+	})
+	return res
+}
@@ -0,0 +1,161 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	batchingTest "github.com/ethereum-optimism/optimism/op-service/sources/batching/test"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/kroma-network/kroma/kroma-bindings/bindings"
+)
+
+type countingRpc struct {
+	*batchingTest.AbiBasedRpc
+	calls int
+}
+
+func (r *countingRpc) CallContext(ctx context.Context, out interface{}, method string, args ...interface{}) error {
+	r.calls++
+	return r.AbiBasedRpc.CallContext(ctx, out, method, args...)
+}
+
+func (r *countingRpc) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	r.calls++
+	return r.AbiBasedRpc.BatchCallContext(ctx, b)
+}
+
+func TestL1ValidatorSet(t *testing.T) {
+	validatorManagerAddr := common.Address{0x11}
+	validator, other := common.Address{0xaa}, common.Address{0xbb}
+	validatorManagerAbi, err := bindings.ValidatorManagerMetaData.GetAbi()
+	require.NoError(t, err)
+	stub := &countingRpc{AbiBasedRpc: batchingTest.NewAbiBasedRpc(t, validatorManagerAddr, validatorManagerAbi)}
+	stub.SetResponse(validatorManagerAddr, "isActive", rpcblock.Latest, []interface{}{validator}, []interface{}{true})
+	stub.SetResponse(validatorManagerAddr, "isActive", rpcblock.Latest, []interface{}{other}, []interface{}{false})
+
+	valSet, err := newL1ValidatorSet(testlog.Logger(t, log.LevelCrit), stub, validatorManagerAddr)
+	require.NoError(t, err)
+
+	// unknown validators are not checked on L1 synchronously, but at the next refresh
+	_, err = valSet.IsActiveValidator(context.Background(), validator)
+	require.ErrorIs(t, err, errUnknownValidatorStatus)
+	require.Equal(t, 0, stub.calls)
+	require.Len(t, valSet.refreshReq, 1)
+	require.NoError(t, valSet.refresh(context.Background(), false))
+	require.Equal(t, 1, stub.calls)
+	active, err := valSet.IsActiveValidator(context.Background(), validator)
+	require.NoError(t, err)
+	require.True(t, active)
+	require.Equal(t, 1, stub.calls)
+
+	// a requested refresh checks only the pending validators
+	_, err = valSet.IsActiveValidator(context.Background(), other)
+	require.ErrorIs(t, err, errUnknownValidatorStatus)
+	require.NoError(t, valSet.refresh(context.Background(), false))
+	require.Equal(t, 2, stub.calls)
+	active, err = valSet.IsActiveValidator(context.Background(), other)
+	require.NoError(t, err)
+	require.False(t, active)
+
+	// inactive signers do not evict the active validators
+	for i := 0; i < inactiveValidatorsCacheSize+1; i++ {
+		signer := common.BigToAddress(big.NewInt(int64(0x1000 + i)))
+		stub.SetResponse(validatorManagerAddr, "isActive", rpcblock.Latest, []interface{}{signer}, []interface{}{false})
+		_, err = valSet.IsActiveValidator(context.Background(), signer)
+		require.ErrorIs(t, err, errUnknownValidatorStatus)
+		if len(valSet.pending) == pendingValidatorsLimit {
+			require.NoError(t, valSet.refresh(context.Background(), false))
+			stub.ClearResponses()
+		}
+	}
+	require.NoError(t, valSet.refresh(context.Background(), false))
+	active, err = valSet.IsActiveValidator(context.Background(), validator)
+	require.NoError(t, err)
+	require.True(t, active)
+
+	// the periodic refresh checks the active validators again, and forgets the inactive signers
+	stub.ClearResponses()
+	stub.SetResponse(validatorManagerAddr, "isActive", rpcblock.Latest, []interface{}{validator}, []interface{}{false})
+	require.NoError(t, valSet.refresh(context.Background(), true))
+	active, err = valSet.IsActiveValidator(context.Background(), validator)
+	require.NoError(t, err)
+	require.False(t, active)
+	_, err = valSet.IsActiveValidator(context.Background(), other)
+	require.ErrorIs(t, err, errUnknownValidatorStatus)
+}
+
+type stubSyncStatus struct {
+	status *eth.SyncStatus
+	err    error
+}
+
+func (s *stubSyncStatus) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
+	return s.status, s.err
+}
+
+func TestL2HeadsTracker(t *testing.T) {
+	src := &stubSyncStatus{err: errors.New("driver stopped")}
+	tracker := newL2HeadsTracker(testlog.Logger(t, log.LevelCrit), src)
+	require.Error(t, tracker.update(context.Background()))
+	_, _, ok := tracker.L2Heads()
+	require.False(t, ok)
+
+	src.status, src.err = &eth.SyncStatus{SafeL2: eth.L2BlockRef{Number: 10}, UnsafeL2: eth.L2BlockRef{Number: 20}}, nil
+	require.NoError(t, tracker.update(context.Background()))
+	safe, unsafe, ok := tracker.L2Heads()
+	require.True(t, ok)
+	require.EqualValues(t, 10, safe)
+	require.EqualValues(t, 20, unsafe)
+
+	// the last known heads are kept if the driver cannot be reached
+	src.err = errors.New("driver stopped")
+	require.Error(t, tracker.update(context.Background()))
+	safe, _, ok = tracker.L2Heads()
+	require.True(t, ok)
+	require.EqualValues(t, 10, safe)
+}
+
+func TestOutputAttestations(t *testing.T) {
+	attestation := func(num uint64, root eth.Bytes32, validator common.Address) *eth.OutputAttestation {
+		return &eth.OutputAttestation{L2BlockNumber: eth.Uint64Quantity(num), OutputRoot: root, Validator: validator}
+	}
+	a, b, c := common.Address{0xa}, common.Address{0xb}, common.Address{0xc}
+	store := newOutputAttestations()
+
+	require.False(t, store.add(attestation(100, eth.Bytes32{1}, b)))
+	require.False(t, store.add(attestation(100, eth.Bytes32{1}, a)))
+	res := store.at(100)
+	require.EqualValues(t, 100, res.L2BlockNumber)
+	require.Equal(t, []*eth.OutputAttestation{attestation(100, eth.Bytes32{1}, a), attestation(100, eth.Bytes32{1}, b)}, res.Attestations)
+	require.False(t, res.Diverged)
+
+	require.True(t, store.add(attestation(100, eth.Bytes32{2}, c)))
+	require.True(t, store.at(100).Diverged)
+	require.Len(t, store.at(100).Attestations, 3)
+
+	// a validator may replace its own attestation, which is not compared against its previous one
+	require.False(t, store.add(attestation(100, eth.Bytes32{1}, c)))
+	require.False(t, store.at(100).Diverged)
+	require.Len(t, store.at(100).Attestations, 3)
+
+	// attestations of other blocks are kept apart
+	require.False(t, store.add(attestation(200, eth.Bytes32{2}, c)))
+	require.False(t, store.at(200).Diverged)
+	require.Empty(t, store.at(300).Attestations)
+
+	// only the most recent attested blocks are kept
+	for i := uint64(0); i < outputAttestationsBlocks; i++ {
+		store.add(attestation(1000+i, eth.Bytes32{1}, a))
+	}
+	require.Empty(t, store.at(100).Attestations)
+}
@@ -1,9 +1,13 @@
 package node
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -19,6 +23,38 @@ var (
 	UnsafeBlockSignerAddressSystemConfigStorageSlot = common.HexToHash("0x65a7ed542fb37fe237fdfbdd70b31598523fe5b32879e307bae27a0bd9581c08")
 )
 
+// signerRetention is how long a p2p block signer is kept in the schedule after it was handed over,
+// far beyond the age of any unsafe block that is still accepted from gossip.
+const signerRetention = uint64(time.Hour / time.Second)
+
+// SignerHandover hands the signing of unsafe blocks over to Signer, for the blocks from the L2 time ValidFrom onwards.
+type SignerHandover struct {
+	Signer    common.Address
+	ValidFrom uint64
+}
+
+// SignerScheduleConfig configures the rotation of the p2p block signer.
+type SignerScheduleConfig struct {
+	// Handovers are scheduled on top of the signer loaded from the L1 SystemConfig,
+	// so blocks signed by a new signer are accepted as soon as the sequencer rotates its key.
+	Handovers []SignerHandover
+	// Overlap is how long both the old and the new signer are accepted around every handover,
+	// including the handovers to a new signer loaded from the L1 SystemConfig.
+	Overlap time.Duration
+}
+
+func (c *SignerScheduleConfig) Check() error {
+	for _, h := range c.Handovers {
+		if h.Signer == (common.Address{}) {
+			return errors.New("signer handover to the zero address")
+		}
+	}
+	if c.Overlap < 0 {
+		return errors.New("signer overlap must not be negative")
+	}
+	return nil
+}
+
 type RuntimeCfgL1Source interface {
 	ReadStorageAt(ctx context.Context, address common.Address, storageSlot common.Hash, blockHash common.Hash) (common.Hash, error)
 }
@@ -47,6 +83,12 @@ type RuntimeConfig struct {
 	// if this is invalidated with a reorg the data will have to be reloaded.
 	l1Ref eth.L1BlockRef
 
+	// signers is the schedule of p2p block signers, ordered by the L2 time they are valid from.
+	// It holds both the configured handovers and the changes of the signer loaded from L1.
+	signers []SignerHandover
+	// signerOverlap is the time in seconds around every handover, in which both signers are accepted.
+	signerOverlap uint64
+
 	runtimeConfigData
 }
 
@@ -63,20 +105,61 @@ type runtimeConfigData struct {
 
 var _ p2p.GossipRuntimeConfig = (*RuntimeConfig)(nil)
 
-func NewRuntimeConfig(log log.Logger, l1Client RuntimeCfgL1Source, rollupCfg *rollup.Config) *RuntimeConfig {
-	return &RuntimeConfig{
-		log:       log,
-		l1Client:  l1Client,
-		rollupCfg: rollupCfg,
+func NewRuntimeConfig(log log.Logger, l1Client RuntimeCfgL1Source, rollupCfg *rollup.Config, signerCfg SignerScheduleConfig) *RuntimeConfig {
+	r := &RuntimeConfig{
+		log:           log,
+		l1Client:      l1Client,
+		rollupCfg:     rollupCfg,
+		signerOverlap: uint64(signerCfg.Overlap / time.Second),
 	}
+	for _, h := range signerCfg.Handovers {
+		r.scheduleSigner(h)
+	}
+	return r
 }
 
+// P2PSequencerAddress returns the p2p block signer loaded from the L1 SystemConfig.
 func (r *RuntimeConfig) P2PSequencerAddress() common.Address {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	return r.p2pBlockSignerAddr
 }
 
+// P2PSequencerSigners returns the p2p block signers that are accepted for unsafe blocks at the given L2 time:
+// the signer scheduled at that time, and the signers scheduled within the overlap around it.
+func (r *RuntimeConfig) P2PSequencerSigners(l2Time uint64) []common.Address {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	from, to := l2Time-min(l2Time, r.signerOverlap), l2Time+r.signerOverlap
+	var signers []common.Address
+	for i, h := range r.signers {
+		if h.ValidFrom > to {
+			break
+		}
+		// the signer is valid until the next handover
+		if i+1 < len(r.signers) && r.signers[i+1].ValidFrom <= from {
+			continue
+		}
+		if h.Signer != (common.Address{}) && !slices.Contains(signers, h.Signer) {
+			signers = append(signers, h.Signer)
+		}
+	}
+	return signers
+}
+
+// scheduleSigner adds the handover to the signer schedule, replacing any handover at the same time.
+// The caller must hold the lock, if the runtime config is in use already.
+func (r *RuntimeConfig) scheduleSigner(h SignerHandover) {
+	i, found := slices.BinarySearchFunc(r.signers, h.ValidFrom, func(e SignerHandover, t uint64) int {
+		return cmp.Compare(e.ValidFrom, t)
+	})
+	if found {
+		r.signers[i] = h
+	} else {
+		r.signers = slices.Insert(r.signers, i, h)
+	}
+}
+
 // Load resets the runtime configuration by fetching the latest config data from L1 at the given L1 block.
 // Load is safe to call concurrently, but will lock the runtime configuration modifications only,
 // and will thus not block other Load calls with possibly alternative L1 block views.
@@ -87,8 +170,20 @@ func (r *RuntimeConfig) Load(ctx context.Context, l1Ref eth.L1BlockRef) error {
 	}
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	signer := common.BytesToAddress(p2pSignerVal[:])
+	if r.l1Ref == (eth.L1BlockRef{}) {
+		// the initially loaded signer is valid from the start, until any scheduled handover
+		r.scheduleSigner(SignerHandover{Signer: signer, ValidFrom: 0})
+	} else if signer != r.p2pBlockSignerAddr {
+		r.log.Info("p2p block signer changed", "old", r.p2pBlockSignerAddr, "new", signer, "valid_from", l1Ref.Time)
+		r.scheduleSigner(SignerHandover{Signer: signer, ValidFrom: l1Ref.Time})
+	}
+	// drop the signers that were handed over long ago
+	for len(r.signers) > 1 && r.signers[1].ValidFrom+r.signerOverlap+signerRetention < l1Ref.Time {
+		r.signers = r.signers[1:]
+	}
 	r.l1Ref = l1Ref
-	r.p2pBlockSignerAddr = common.BytesToAddress(p2pSignerVal[:])
+	r.p2pBlockSignerAddr = signer
 	r.log.Info("loaded new runtime config values!", "p2p_seq_address", r.p2pBlockSignerAddr)
 	return nil
 }
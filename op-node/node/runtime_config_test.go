@@ -0,0 +1,73 @@
+package node
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type mockRuntimeCfgL1Source struct {
+	signer common.Address
+}
+
+func (m *mockRuntimeCfgL1Source) ReadStorageAt(ctx context.Context, address common.Address, storageSlot common.Hash, blockHash common.Hash) (common.Hash, error) {
+	return common.BytesToHash(m.signer[:]), nil
+}
+
+func TestRuntimeConfigSignerSchedule(t *testing.T) {
+	signerA, signerB, signerC := common.Address{0xa}, common.Address{0xb}, common.Address{0xc}
+	l1 := &mockRuntimeCfgL1Source{signer: signerA}
+	r := NewRuntimeConfig(testlog.Logger(t, log.LevelError), l1, &rollup.Config{}, SignerScheduleConfig{
+		Handovers: []SignerHandover{{Signer: signerB, ValidFrom: 1000}},
+		Overlap:   10 * time.Second,
+	})
+
+	// nothing is accepted before the handover, until the signer is loaded from L1
+	require.Empty(t, r.P2PSequencerSigners(500))
+	require.Equal(t, []common.Address{signerB}, r.P2PSequencerSigners(1000))
+
+	require.NoError(t, r.Load(context.Background(), eth.L1BlockRef{Hash: common.Hash{1}, Time: 500}))
+	require.Equal(t, signerA, r.P2PSequencerAddress())
+	require.Equal(t, []common.Address{signerA}, r.P2PSequencerSigners(500))
+	require.Equal(t, []common.Address{signerA}, r.P2PSequencerSigners(989))
+	// both signers are accepted within the overlap around the scheduled handover
+	require.Equal(t, []common.Address{signerA, signerB}, r.P2PSequencerSigners(990))
+	require.Equal(t, []common.Address{signerA, signerB}, r.P2PSequencerSigners(1009))
+	require.Equal(t, []common.Address{signerB}, r.P2PSequencerSigners(1010))
+
+	// a new signer loaded from L1 is handed over to at the time of the L1 block
+	l1.signer = signerC
+	require.NoError(t, r.Load(context.Background(), eth.L1BlockRef{Hash: common.Hash{2}, Time: 2000}))
+	require.Equal(t, signerC, r.P2PSequencerAddress())
+	require.Equal(t, []common.Address{signerB}, r.P2PSequencerSigners(1989))
+	require.Equal(t, []common.Address{signerB, signerC}, r.P2PSequencerSigners(2000))
+	require.Equal(t, []common.Address{signerC}, r.P2PSequencerSigners(2010))
+
+	// old signers are eventually dropped from the schedule
+	require.NoError(t, r.Load(context.Background(), eth.L1BlockRef{Hash: common.Hash{3}, Time: 2000 + 10 + signerRetention + 1}))
+	require.Empty(t, r.P2PSequencerSigners(1500))
+	require.Equal(t, []common.Address{signerC}, r.P2PSequencerSigners(2010))
+}
+
+func TestRuntimeConfigNoOverlap(t *testing.T) {
+	signerA, signerB := common.Address{0xa}, common.Address{0xb}
+	l1 := &mockRuntimeCfgL1Source{signer: signerA}
+	r := NewRuntimeConfig(testlog.Logger(t, log.LevelError), l1, &rollup.Config{}, SignerScheduleConfig{})
+	require.NoError(t, r.Load(context.Background(), eth.L1BlockRef{Hash: common.Hash{1}, Time: 500}))
+	require.Equal(t, []common.Address{signerA}, r.P2PSequencerSigners(0))
+	require.Equal(t, []common.Address{signerA}, r.P2PSequencerSigners(1000))
+
+	l1.signer = signerB
+	require.NoError(t, r.Load(context.Background(), eth.L1BlockRef{Hash: common.Hash{2}, Time: 1000}))
+	require.Equal(t, []common.Address{signerA}, r.P2PSequencerSigners(999))
+	require.Equal(t, []common.Address{signerB}, r.P2PSequencerSigners(1000))
+}
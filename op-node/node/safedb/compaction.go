@@ -0,0 +1,111 @@
+package safedb
+
+import (
+	"fmt"
+	"time"
+)
+
+// Prune deletes the safe head history of L1 blocks that are more than retention blocks older than
+// the last recorded L1 block. The last update at or before the retention window is kept,
+// so the safe head is still known at every L1 block of the window.
+func (d *SafeDB) Prune(retention uint64) error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	iter, err := d.db.NewIter(safeByL1BlockNumKey.IterRange())
+	if err != nil {
+		return fmt.Errorf("prune failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+	if valid := iter.Last(); !valid {
+		// No entries to prune
+		return nil
+	}
+	val, err := iter.ValueAndErr()
+	if err != nil {
+		return fmt.Errorf("prune failed to read entry: %w", err)
+	}
+	last, _, err := decodeSafeByL1BlockNum(iter.Key(), val)
+	if err != nil {
+		return fmt.Errorf("prune encountered invalid entry: %w", err)
+	}
+	if last.Number <= retention {
+		return nil
+	}
+	if valid := iter.SeekLT(safeByL1BlockNumKey.Of(last.Number - retention + 1)); !valid {
+		return nil
+	}
+	val, err = iter.ValueAndErr()
+	if err != nil {
+		return fmt.Errorf("prune failed to read entry: %w", err)
+	}
+	l1Block, l2Block, err := decodeSafeByL1BlockNum(iter.Key(), val)
+	if err != nil {
+		return fmt.Errorf("prune encountered invalid entry: %w", err)
+	}
+	batch := d.db.NewBatch()
+	defer batch.Close()
+	if err := batch.DeleteRange(safeByL1BlockNumKey.Of(0), safeByL1BlockNumKey.Of(l1Block.Number), d.writeOpts); err != nil {
+		return fmt.Errorf("prune failed to delete entries before L1 block %v: %w", l1Block, err)
+	}
+	// The L2 block indexed entries are pruned up to the safe head of the kept update,
+	// so the L2 blocks of the pruned updates are unknown instead of being attributed to the kept update.
+	if err := batch.DeleteRange(safeByL2BlockNumKey.Of(0), safeByL2BlockNumKey.Of(l2Block.Number), d.writeOpts); err != nil {
+		return fmt.Errorf("prune failed to delete entries before L2 block %v: %w", l2Block, err)
+	}
+	if err := batch.Commit(d.writeOpts); err != nil {
+		return fmt.Errorf("prune failed to commit batch: %w", err)
+	}
+	d.log.Debug("Pruned safe head history", "before_l1", l1Block, "before_l2", l2Block)
+	return nil
+}
+
+// Compact compacts the whole database, to reclaim the disk space of deleted entries.
+func (d *SafeDB) Compact() error {
+	start := safeByL1BlockNumKey.Of(0)
+	end := safeByL2BlockNumKey.Max()
+	if err := d.db.Compact(start, end, true); err != nil {
+		return fmt.Errorf("failed to compact safe head database: %w", err)
+	}
+	return nil
+}
+
+// StartCompaction starts a background job that prunes the database to the retention window, if retention is not 0,
+// and compacts it, every interval. The job is stopped when the database is closed.
+func (d *SafeDB) StartCompaction(interval time.Duration, retention uint64) {
+	d.compactionStop = make(chan struct{})
+	d.compactionDone = make(chan struct{})
+	go func() {
+		defer close(d.compactionDone)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if retention != 0 {
+					if err := d.Prune(retention); err != nil {
+						d.log.Warn("Failed to prune safe head database", "err", err)
+						continue
+					}
+				}
+				start := time.Now()
+				if err := d.Compact(); err != nil {
+					d.log.Warn("Failed to compact safe head database", "err", err)
+				} else {
+					d.log.Debug("Compacted safe head database", "duration", time.Since(start))
+				}
+			case <-d.compactionStop:
+				return
+			}
+		}
+	}()
+}
+
+// stopCompaction stops the background compaction job, if it was started, and waits for it to exit.
+func (d *SafeDB) stopCompaction() {
+	d.stopCompactionOnce.Do(func() {
+		if d.compactionStop != nil {
+			close(d.compactionStop)
+			<-d.compactionDone
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package safedb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrune(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	dir := t.TempDir()
+	db, err := NewSafeDB(logger, dir)
+	require.NoError(t, err)
+	defer db.Close()
+
+	l2a := eth.L2BlockRef{Hash: common.Hash{0x02, 0xaa}, Number: 20}
+	l2b := eth.L2BlockRef{Hash: common.Hash{0x02, 0xbb}, Number: 25}
+	l2c := eth.L2BlockRef{Hash: common.Hash{0x02, 0xcc}, Number: 30}
+	l1a := eth.BlockID{Hash: common.Hash{0x01, 0xaa}, Number: 100}
+	l1b := eth.BlockID{Hash: common.Hash{0x01, 0xbb}, Number: 150}
+	l1c := eth.BlockID{Hash: common.Hash{0x01, 0xcc}, Number: 160}
+	require.NoError(t, db.SafeHeadUpdated(l2a, l1a, nil))
+	require.NoError(t, db.SafeHeadUpdated(l2b, l1b, nil))
+	require.NoError(t, db.SafeHeadUpdated(l2c, l1c, nil))
+
+	// The retention window covers all entries
+	require.NoError(t, db.Prune(100))
+	actualL1, actualL2, err := db.SafeHeadAtL1(context.Background(), l1a.Number)
+	require.NoError(t, err)
+	require.Equal(t, l1a, actualL1)
+	require.Equal(t, l2a.ID(), actualL2)
+
+	// The retention window starts at L1 block 155, so the entry of L1 block 150 is kept as the safe head at 155
+	require.NoError(t, db.Prune(5))
+	_, _, err = db.SafeHeadAtL1(context.Background(), l1a.Number)
+	require.ErrorIs(t, err, ErrNotFound)
+	_, _, err = db.SafeHeadAtL1(context.Background(), l1b.Number-1)
+	require.ErrorIs(t, err, ErrNotFound)
+	actualL1, actualL2, err = db.SafeHeadAtL1(context.Background(), l1c.Number-5)
+	require.NoError(t, err)
+	require.Equal(t, l1b, actualL1)
+	require.Equal(t, l2b.ID(), actualL2)
+	actualL1, actualL2, err = db.SafeHeadAtL1(context.Background(), l1c.Number)
+	require.NoError(t, err)
+	require.Equal(t, l1c, actualL1)
+	require.Equal(t, l2c.ID(), actualL2)
+
+	// The L2 blocks of the pruned updates are unknown
+	_, _, _, err = db.L1InclusionAtL2(context.Background(), l2a.Number)
+	require.ErrorIs(t, err, ErrNotFound)
+	_, _, _, err = db.L1InclusionAtL2(context.Background(), l2b.Number-1)
+	require.ErrorIs(t, err, ErrNotFound)
+	actualL1, actualL2, _, err = db.L1InclusionAtL2(context.Background(), l2b.Number)
+	require.NoError(t, err)
+	require.Equal(t, l1b, actualL1)
+	require.Equal(t, l2b.ID(), actualL2)
+	actualL1, actualL2, _, err = db.L1InclusionAtL2(context.Background(), l2c.Number-1)
+	require.NoError(t, err)
+	require.Equal(t, l1c, actualL1)
+	require.Equal(t, l2c.ID(), actualL2)
+
+	require.NoError(t, db.Compact())
+}
+
+func TestPrune_EmptyDatabase(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewSafeDB(logger, t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, db.Prune(10))
+	require.NoError(t, db.Compact())
+}
+
+func TestStartCompaction(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewSafeDB(logger, t.TempDir())
+	require.NoError(t, err)
+
+	l1a := eth.BlockID{Hash: common.Hash{0x01, 0xaa}, Number: 100}
+	l1b := eth.BlockID{Hash: common.Hash{0x01, 0xbb}, Number: 150}
+	l1c := eth.BlockID{Hash: common.Hash{0x01, 0xcc}, Number: 160}
+	require.NoError(t, db.SafeHeadUpdated(eth.L2BlockRef{Hash: common.Hash{0x02, 0xaa}, Number: 20}, l1a, nil))
+	require.NoError(t, db.SafeHeadUpdated(eth.L2BlockRef{Hash: common.Hash{0x02, 0xbb}, Number: 25}, l1b, nil))
+	require.NoError(t, db.SafeHeadUpdated(eth.L2BlockRef{Hash: common.Hash{0x02, 0xcc}, Number: 30}, l1c, nil))
+
+	db.StartCompaction(time.Millisecond, 5)
+	require.Eventually(t, func() bool {
+		_, _, err := db.SafeHeadAtL1(context.Background(), l1a.Number)
+		return errors.Is(err, ErrNotFound)
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// Closing stops the background job
+	require.NoError(t, db.Close())
+	require.NoError(t, db.Close())
+}
@@ -18,7 +18,7 @@ func (d *DisabledDB) Enabled() bool {
 	return false
 }
 
-func (d *DisabledDB) SafeHeadUpdated(_ eth.L2BlockRef, _ eth.BlockID) error {
+func (d *DisabledDB) SafeHeadUpdated(_ eth.L2BlockRef, _ eth.BlockID, _ *eth.BatchProvenance) error {
 	return nil
 }
 
@@ -27,6 +27,11 @@ func (d *DisabledDB) SafeHeadAtL1(_ context.Context, _ uint64) (l1 eth.BlockID,
 	return
 }
 
+func (d *DisabledDB) L1InclusionAtL2(_ context.Context, _ uint64) (l1 eth.BlockID, safeHead eth.BlockID, provenance *eth.BatchProvenance, err error) {
+	err = ErrNotEnabled
+	return
+}
+
 func (d *DisabledDB) SafeHeadReset(_ eth.L2BlockRef) error {
 	return nil
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/cockroachdb/pebble"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -22,10 +23,13 @@ var (
 const (
 	// Keys are prefixed with a constant byte to allow us to differentiate different "columns" within the data
 	keyPrefixSafeByL1BlockNum byte = 0
+	// keyPrefixSafeByL2BlockNum is the reverse index, from each recorded safe head to the L1 block that made it safe
+	keyPrefixSafeByL2BlockNum byte = 1
 )
 
 var (
 	safeByL1BlockNumKey = uint64Key{prefix: keyPrefixSafeByL1BlockNum}
+	safeByL2BlockNumKey = uint64Key{prefix: keyPrefixSafeByL2BlockNum}
 )
 
 type uint64Key struct {
@@ -58,6 +62,11 @@ type SafeDB struct {
 
 	writeOpts *pebble.WriteOptions
 
+	// compactionStop and compactionDone control the background compaction job, if it was started
+	compactionStop     chan struct{}
+	compactionDone     chan struct{}
+	stopCompactionOnce sync.Once
+
 	closed bool
 }
 
@@ -81,23 +90,166 @@ func decodeSafeByL1BlockNum(key []byte, val []byte) (l1 eth.BlockID, l2 eth.Bloc
 	return
 }
 
+func safeByL2BlockNumValue(l1 eth.BlockID, l2 eth.BlockID, provenance *eth.BatchProvenance) []byte {
+	val := make([]byte, 0, 72)
+	val = append(val, l2.Hash.Bytes()...)
+	val = append(val, l1.Hash.Bytes()...)
+	val = binary.BigEndian.AppendUint64(val, l1.Number)
+	if provenance != nil {
+		val = appendProvenance(val, provenance)
+	}
+	return val
+}
+
+func decodeSafeByL2BlockNum(key []byte, val []byte) (l1 eth.BlockID, l2 eth.BlockID, provenance *eth.BatchProvenance, err error) {
+	if len(key) != 9 || len(val) < 72 || key[0] != keyPrefixSafeByL2BlockNum {
+		err = ErrInvalidEntry
+		return
+	}
+	copy(l2.Hash[:], val[:32])
+	l2.Number = binary.BigEndian.Uint64(key[1:])
+	copy(l1.Hash[:], val[32:64])
+	l1.Number = binary.BigEndian.Uint64(val[64:72])
+	if len(val) > 72 {
+		provenance, err = decodeProvenance(val[72:])
+	}
+	return
+}
+
+// appendProvenance encodes the provenance as:
+//
+//	channel_id_length(uint8) ++ channel_id ++ frames_count(uint16) ++ frames
+//	frame = frame_number(uint16) ++ tx_hash ++ has_blob(uint8) ++ [blob_hash]
+func appendProvenance(val []byte, provenance *eth.BatchProvenance) []byte {
+	val = append(val, byte(len(provenance.ChannelID)))
+	val = append(val, provenance.ChannelID...)
+	val = binary.BigEndian.AppendUint16(val, uint16(len(provenance.Frames)))
+	for _, f := range provenance.Frames {
+		val = binary.BigEndian.AppendUint16(val, f.FrameNumber)
+		val = append(val, f.TxHash.Bytes()...)
+		if f.BlobHash != nil {
+			val = append(val, 1)
+			val = append(val, f.BlobHash.Bytes()...)
+		} else {
+			val = append(val, 0)
+		}
+	}
+	return val
+}
+
+func decodeProvenance(data []byte) (*eth.BatchProvenance, error) {
+	if len(data) < 1 || len(data) < 1+int(data[0])+2 {
+		return nil, ErrInvalidEntry
+	}
+	var provenance eth.BatchProvenance
+	provenance.ChannelID = slices.Clone(data[1 : 1+data[0]])
+	data = data[1+data[0]:]
+	count := binary.BigEndian.Uint16(data)
+	data = data[2:]
+	provenance.Frames = make([]eth.FrameProvenance, 0, count)
+	for i := uint16(0); i < count; i++ {
+		if len(data) < 35 {
+			return nil, ErrInvalidEntry
+		}
+		var f eth.FrameProvenance
+		f.FrameNumber = binary.BigEndian.Uint16(data)
+		copy(f.TxHash[:], data[2:34])
+		hasBlob := data[34]
+		data = data[35:]
+		if hasBlob != 0 {
+			if len(data) < 32 {
+				return nil, ErrInvalidEntry
+			}
+			var blobHash common.Hash
+			copy(blobHash[:], data[:32])
+			f.BlobHash = &blobHash
+			data = data[32:]
+		}
+		provenance.Frames = append(provenance.Frames, f)
+	}
+	if len(data) != 0 {
+		return nil, ErrInvalidEntry
+	}
+	return &provenance, nil
+}
+
 func NewSafeDB(logger log.Logger, path string) (*SafeDB, error) {
 	db, err := pebble.Open(path, &pebble.Options{})
 	if err != nil {
 		return nil, err
 	}
-	return &SafeDB{
+	safeDB := &SafeDB{
 		log:       logger,
 		db:        db,
 		writeOpts: &pebble.WriteOptions{Sync: true},
-	}, nil
+	}
+	if err := safeDB.migrateSafeByL2BlockNum(); err != nil {
+		return nil, errors.Join(err, db.Close())
+	}
+	return safeDB, nil
+}
+
+// migrateSafeByL2BlockNum builds the L2 block indexed entries from the L1 block indexed entries,
+// if the database was created before the L2 block index was introduced.
+// The provenance of the batches is not known for the migrated entries.
+func (d *SafeDB) migrateSafeByL2BlockNum() error {
+	l2Iter, err := d.db.NewIter(safeByL2BlockNumKey.IterRange())
+	if err != nil {
+		return fmt.Errorf("migration failed to create iterator: %w", err)
+	}
+	indexed := l2Iter.First()
+	if err := l2Iter.Close(); err != nil {
+		return fmt.Errorf("migration failed to close iterator: %w", err)
+	}
+	if indexed {
+		return nil
+	}
+
+	iter, err := d.db.NewIter(safeByL1BlockNumKey.IterRange())
+	if err != nil {
+		return fmt.Errorf("migration failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+	batch := d.db.NewBatch()
+	defer batch.Close()
+	count := 0
+	var lastSafeHead eth.BlockID
+	for valid := iter.First(); valid; valid = iter.Next() {
+		val, err := iter.ValueAndErr()
+		if err != nil {
+			return fmt.Errorf("migration failed to read entry: %w", err)
+		}
+		l1Block, l2Block, err := decodeSafeByL1BlockNum(iter.Key(), val)
+		if err != nil {
+			return fmt.Errorf("migration encountered invalid entry: %w", err)
+		}
+		// Only the first L1 block at which the safe head was recorded made it safe.
+		if count > 0 && l2Block.Number <= lastSafeHead.Number {
+			continue
+		}
+		if err := batch.Set(safeByL2BlockNumKey.Of(l2Block.Number), safeByL2BlockNumValue(l1Block, l2Block, nil), d.writeOpts); err != nil {
+			return fmt.Errorf("migration failed to record safe head: %w", err)
+		}
+		lastSafeHead = l2Block
+		count++
+	}
+	if count == 0 {
+		return nil
+	}
+	if err := batch.Commit(d.writeOpts); err != nil {
+		return fmt.Errorf("migration failed to commit batch: %w", err)
+	}
+	d.log.Info("Migrated safe heads to the L2 block index", "entries", count, "last_safe_head", lastSafeHead)
+	return nil
 }
 
 func (d *SafeDB) Enabled() bool {
 	return true
 }
 
-func (d *SafeDB) SafeHeadUpdated(safeHead eth.L2BlockRef, l1Head eth.BlockID) error {
+// SafeHeadUpdated records that the safe head became safe at the L1 block, together with the provenance
+// of the batch of the safe head, if known.
+func (d *SafeDB) SafeHeadUpdated(safeHead eth.L2BlockRef, l1Head eth.BlockID, provenance *eth.BatchProvenance) error {
 	d.m.Lock()
 	defer d.m.Unlock()
 	d.log.Info("Record safe head", "l2", safeHead.ID(), "l1", l1Head)
@@ -106,6 +258,9 @@ func (d *SafeDB) SafeHeadUpdated(safeHead eth.L2BlockRef, l1Head eth.BlockID) er
 	if err := batch.Set(safeByL1BlockNumKey.Of(l1Head.Number), safeByL1BlockNumValue(l1Head, safeHead.ID()), d.writeOpts); err != nil {
 		return fmt.Errorf("failed to record safe head update: %w", err)
 	}
+	if err := batch.Set(safeByL2BlockNumKey.Of(safeHead.Number), safeByL2BlockNumValue(l1Head, safeHead.ID(), provenance), d.writeOpts); err != nil {
+		return fmt.Errorf("failed to record safe head update: %w", err)
+	}
 	if err := batch.Commit(d.writeOpts); err != nil {
 		return fmt.Errorf("failed to commit safe head update: %w", err)
 	}
@@ -115,6 +270,23 @@ func (d *SafeDB) SafeHeadUpdated(safeHead eth.L2BlockRef, l1Head eth.BlockID) er
 func (d *SafeDB) SafeHeadReset(safeHead eth.L2BlockRef) error {
 	d.m.Lock()
 	defer d.m.Unlock()
+	batch := d.db.NewBatch()
+	defer batch.Close()
+	if err := d.resetSafeByL1BlockNum(batch, safeHead); err != nil {
+		return err
+	}
+	if err := d.resetSafeByL2BlockNum(batch, safeHead); err != nil {
+		return err
+	}
+	if err := batch.Commit(d.writeOpts); err != nil {
+		return fmt.Errorf("reset failed to commit batch: %w", err)
+	}
+	return nil
+}
+
+// resetSafeByL1BlockNum adds the changes to the batch to truncate the L1 block indexed entries
+// so the reset safe head is the last recorded safe head.
+func (d *SafeDB) resetSafeByL1BlockNum(batch *pebble.Batch, safeHead eth.L2BlockRef) error {
 	iter, err := d.db.NewIter(safeByL1BlockNumKey.IterRange())
 	if err != nil {
 		return fmt.Errorf("reset failed to create iterator: %w", err)
@@ -138,7 +310,6 @@ func (d *SafeDB) SafeHeadReset(safeHead eth.L2BlockRef) error {
 			l1HeadKey := slices.Clone(iter.Key())
 			hasPrevEntry := iter.Prev()
 			// Found the first entry that made the new safe head safe.
-			batch := d.db.NewBatch()
 			if err := batch.DeleteRange(l1HeadKey, safeByL1BlockNumKey.Max(), d.writeOpts); err != nil {
 				return fmt.Errorf("reset failed to delete entries after %v: %w", l1HeadKey, err)
 			}
@@ -151,9 +322,6 @@ func (d *SafeDB) SafeHeadReset(safeHead eth.L2BlockRef) error {
 					return fmt.Errorf("reset failed to record safe head update: %w", err)
 				}
 			}
-			if err := batch.Commit(d.writeOpts); err != nil {
-				return fmt.Errorf("reset failed to commit batch: %w", err)
-			}
 			return nil
 		}
 		if valid := iter.Next(); !valid {
@@ -163,6 +331,48 @@ func (d *SafeDB) SafeHeadReset(safeHead eth.L2BlockRef) error {
 	}
 }
 
+// resetSafeByL2BlockNum adds the changes to the batch to truncate the L2 block indexed entries
+// so the reset safe head is the last recorded safe head.
+func (d *SafeDB) resetSafeByL2BlockNum(batch *pebble.Batch, safeHead eth.L2BlockRef) error {
+	iter, err := d.db.NewIter(safeByL2BlockNumKey.IterRange())
+	if err != nil {
+		return fmt.Errorf("reset failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+	if valid := iter.SeekGE(safeByL2BlockNumKey.Of(safeHead.Number)); !valid {
+		// Reached end of column without finding any entries to delete
+		return nil
+	}
+	val, err := iter.ValueAndErr()
+	if err != nil {
+		return fmt.Errorf("reset failed to read entry: %w", err)
+	}
+	l1Block, l2Block, provenance, err := decodeSafeByL2BlockNum(iter.Key(), val)
+	if err != nil {
+		return fmt.Errorf("reset encountered invalid entry: %w", err)
+	}
+	if l2Block.Number == safeHead.Number {
+		// The reset safe head was recorded itself, only the entries after it are deleted.
+		if valid := iter.Next(); !valid {
+			return nil
+		}
+	}
+	// Keep a copy of this key - it may be modified when calling Prev()
+	firstKey := slices.Clone(iter.Key())
+	hasPrevEntry := iter.Prev()
+	if err := batch.DeleteRange(firstKey, safeByL2BlockNumKey.Max(), d.writeOpts); err != nil {
+		return fmt.Errorf("reset failed to delete entries after %v: %w", firstKey, err)
+	}
+	// The first deleted update made the reset safe head safe, so it is kept for the reset safe head instead.
+	// As above, this is unknown if the reset safe head is before the first entry.
+	if l2Block.Number > safeHead.Number && hasPrevEntry {
+		if err := batch.Set(safeByL2BlockNumKey.Of(safeHead.Number), safeByL2BlockNumValue(l1Block, safeHead.ID(), provenance), d.writeOpts); err != nil {
+			return fmt.Errorf("reset failed to record safe head update: %w", err)
+		}
+	}
+	return nil
+}
+
 func (d *SafeDB) SafeHeadAtL1(ctx context.Context, l1BlockNum uint64) (l1Block eth.BlockID, safeHead eth.BlockID, err error) {
 	d.m.RLock()
 	defer d.m.RUnlock()
@@ -184,7 +394,45 @@ func (d *SafeDB) SafeHeadAtL1(ctx context.Context, l1BlockNum uint64) (l1Block e
 	return
 }
 
+// L1InclusionAtL2 returns the recorded safe head update that made the L2 block safe: the first L1 block at which
+// the L2 block was safe, the safe head of the update, which is at or after the L2 block, and the provenance of
+// the batch of the safe head, if known.
+// It returns ErrNotFound if the L2 block is not safe yet, or if it became safe before the records start.
+// The provenance is not known for the safe heads recorded before the L2 block index was introduced.
+func (d *SafeDB) L1InclusionAtL2(ctx context.Context, l2BlockNum uint64) (l1Block eth.BlockID, safeHead eth.BlockID, provenance *eth.BatchProvenance, err error) {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	iter, err := d.db.NewIterWithContext(ctx, safeByL2BlockNumKey.IterRange())
+	if err != nil {
+		return
+	}
+	defer iter.Close()
+	if valid := iter.SeekGE(safeByL2BlockNumKey.Of(l2BlockNum)); !valid {
+		err = ErrNotFound
+		return
+	}
+	// Found the first update with a safe head at or after the requested L2 block
+	val, err := iter.ValueAndErr()
+	if err != nil {
+		return
+	}
+	l1Block, safeHead, provenance, err = decodeSafeByL2BlockNum(iter.Key(), val)
+	if err != nil {
+		return
+	}
+	if safeHead.Number != l2BlockNum {
+		// Without a previous update, the L2 block may have been safe before the records start.
+		if hasPrevEntry := iter.Prev(); !hasPrevEntry {
+			err = ErrNotFound
+			return
+		}
+	}
+	return
+}
+
 func (d *SafeDB) Close() error {
+	// Stop the compaction job before closing, it runs without holding the lock while compacting.
+	d.stopCompaction()
 	d.m.Lock()
 	defer d.m.Unlock()
 	if d.closed {
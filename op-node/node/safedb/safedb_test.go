@@ -6,6 +6,7 @@ import (
 	"slices"
 	"testing"
 
+	"github.com/cockroachdb/pebble"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/common"
@@ -35,8 +36,8 @@ func TestStoreSafeHeads(t *testing.T) {
 		Hash:   common.Hash{0x01, 0xbb},
 		Number: 150,
 	}
-	require.NoError(t, db.SafeHeadUpdated(l2a, l1a))
-	require.NoError(t, db.SafeHeadUpdated(l2b, l1b))
+	require.NoError(t, db.SafeHeadUpdated(l2a, l1a, nil))
+	require.NoError(t, db.SafeHeadUpdated(l2b, l1b, nil))
 
 	verifySafeHeads := func(db *SafeDB) {
 		_, _, err = db.SafeHeadAtL1(context.Background(), l1a.Number-1)
@@ -132,9 +133,9 @@ func TestTruncateOnSafeHeadReset(t *testing.T) {
 	}
 
 	// Add some entries
-	require.NoError(t, db.SafeHeadUpdated(l2a, l1a))
-	require.NoError(t, db.SafeHeadUpdated(l2c, l1b))
-	require.NoError(t, db.SafeHeadUpdated(l2d, l1c))
+	require.NoError(t, db.SafeHeadUpdated(l2a, l1a, nil))
+	require.NoError(t, db.SafeHeadUpdated(l2c, l1b, nil))
+	require.NoError(t, db.SafeHeadUpdated(l2d, l1c, nil))
 
 	// Then reset to between the two existing entries
 	require.NoError(t, db.SafeHeadReset(l2b))
@@ -199,8 +200,8 @@ func TestTruncateOnSafeHeadReset_BeforeFirstEntry(t *testing.T) {
 	}
 
 	// Add some entries
-	require.NoError(t, db.SafeHeadUpdated(l2c, l1b))
-	require.NoError(t, db.SafeHeadUpdated(l2d, l1c))
+	require.NoError(t, db.SafeHeadUpdated(l2c, l1b, nil))
+	require.NoError(t, db.SafeHeadUpdated(l2d, l1c, nil))
 
 	// Then reset to between the two existing entries
 	require.NoError(t, db.SafeHeadReset(l2b))
@@ -256,9 +257,9 @@ func TestTruncateOnSafeHeadReset_AfterLastEntry(t *testing.T) {
 	}
 
 	// Add some entries
-	require.NoError(t, db.SafeHeadUpdated(l2a, l1a))
-	require.NoError(t, db.SafeHeadUpdated(l2b, l1b))
-	require.NoError(t, db.SafeHeadUpdated(l2c, l1c))
+	require.NoError(t, db.SafeHeadUpdated(l2a, l1a, nil))
+	require.NoError(t, db.SafeHeadUpdated(l2b, l1b, nil))
+	require.NoError(t, db.SafeHeadUpdated(l2c, l1c, nil))
 
 	verifySafeHeads := func() {
 		// Everything is still safe
@@ -367,3 +368,214 @@ func TestDecodeSafeByL1BlockNum(t *testing.T) {
 		require.ErrorIs(t, err, ErrInvalidEntry)
 	})
 }
+
+func TestL1InclusionAtL2(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	dir := t.TempDir()
+	db, err := NewSafeDB(logger, dir)
+	require.NoError(t, err)
+	defer db.Close()
+	l2a := eth.L2BlockRef{
+		Hash:   common.Hash{0x02, 0xaa},
+		Number: 20,
+	}
+	l2b := eth.L2BlockRef{
+		Hash:   common.Hash{0x02, 0xbb},
+		Number: 25,
+	}
+	l1a := eth.BlockID{
+		Hash:   common.Hash{0x01, 0xaa},
+		Number: 100,
+	}
+	l1b := eth.BlockID{
+		Hash:   common.Hash{0x01, 0xbb},
+		Number: 150,
+	}
+	blobHash := common.Hash{0x04, 0xbb}
+	provenance := &eth.BatchProvenance{
+		ChannelID: []byte{0x03, 0xbb},
+		Frames: []eth.FrameProvenance{
+			{FrameNumber: 0, TxHash: common.Hash{0x05, 0x01}},
+			{FrameNumber: 1, TxHash: common.Hash{0x05, 0x02}, BlobHash: &blobHash},
+		},
+	}
+	require.NoError(t, db.SafeHeadUpdated(l2a, l1a, nil))
+	require.NoError(t, db.SafeHeadUpdated(l2b, l1b, provenance))
+
+	verifyInclusions := func(db *SafeDB) {
+		// The first recorded safe head may have been safe before the records start
+		_, _, _, err := db.L1InclusionAtL2(context.Background(), l2a.Number-1)
+		require.ErrorIs(t, err, ErrNotFound)
+
+		actualL1, actualL2, actualProvenance, err := db.L1InclusionAtL2(context.Background(), l2a.Number)
+		require.NoError(t, err)
+		require.Equal(t, l1a, actualL1)
+		require.Equal(t, l2a.ID(), actualL2)
+		require.Nil(t, actualProvenance)
+
+		// L2 blocks between the safe heads became safe with the next safe head
+		actualL1, actualL2, actualProvenance, err = db.L1InclusionAtL2(context.Background(), l2a.Number+1)
+		require.NoError(t, err)
+		require.Equal(t, l1b, actualL1)
+		require.Equal(t, l2b.ID(), actualL2)
+		require.Equal(t, provenance, actualProvenance)
+
+		actualL1, actualL2, actualProvenance, err = db.L1InclusionAtL2(context.Background(), l2b.Number)
+		require.NoError(t, err)
+		require.Equal(t, l1b, actualL1)
+		require.Equal(t, l2b.ID(), actualL2)
+		require.Equal(t, provenance, actualProvenance)
+
+		// Not safe yet
+		_, _, _, err = db.L1InclusionAtL2(context.Background(), l2b.Number+1)
+		require.ErrorIs(t, err, ErrNotFound)
+	}
+	verifyInclusions(db)
+
+	// Close the DB and open a new instance
+	require.NoError(t, db.Close())
+	newDB, err := NewSafeDB(logger, dir)
+	require.NoError(t, err)
+	defer newDB.Close()
+	verifyInclusions(newDB)
+}
+
+func TestL1InclusionAtL2_MigrateSafeByL1BlockNum(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	dir := t.TempDir()
+	l1a := eth.BlockID{Hash: common.Hash{0x01, 0xaa}, Number: 100}
+	l1b := eth.BlockID{Hash: common.Hash{0x01, 0xbb}, Number: 150}
+	l1c := eth.BlockID{Hash: common.Hash{0x01, 0xcc}, Number: 160}
+	l2a := eth.BlockID{Hash: common.Hash{0x02, 0xaa}, Number: 20}
+	l2b := eth.BlockID{Hash: common.Hash{0x02, 0xbb}, Number: 25}
+
+	// A database written before the L2 block index was introduced
+	db, err := pebble.Open(dir, &pebble.Options{})
+	require.NoError(t, err)
+	require.NoError(t, db.Set(safeByL1BlockNumKey.Of(l1a.Number), safeByL1BlockNumValue(l1a, l2a), pebble.Sync))
+	require.NoError(t, db.Set(safeByL1BlockNumKey.Of(l1b.Number), safeByL1BlockNumValue(l1b, l2b), pebble.Sync))
+	// The safe head may be recorded again at a later L1 block, e.g. after a reset
+	require.NoError(t, db.Set(safeByL1BlockNumKey.Of(l1c.Number), safeByL1BlockNumValue(l1c, l2b), pebble.Sync))
+	require.NoError(t, db.Close())
+
+	safeDB, err := NewSafeDB(logger, dir)
+	require.NoError(t, err)
+	verifyInclusions := func(db *SafeDB) {
+		_, _, _, err := db.L1InclusionAtL2(context.Background(), l2a.Number-1)
+		require.ErrorIs(t, err, ErrNotFound)
+
+		actualL1, actualL2, actualProvenance, err := db.L1InclusionAtL2(context.Background(), l2a.Number)
+		require.NoError(t, err)
+		require.Equal(t, l1a, actualL1)
+		require.Equal(t, l2a, actualL2)
+		require.Nil(t, actualProvenance)
+
+		actualL1, actualL2, actualProvenance, err = db.L1InclusionAtL2(context.Background(), l2a.Number+1)
+		require.NoError(t, err)
+		require.Equal(t, l1b, actualL1)
+		require.Equal(t, l2b, actualL2)
+		require.Nil(t, actualProvenance)
+	}
+	verifyInclusions(safeDB)
+	_, _, _, err = safeDB.L1InclusionAtL2(context.Background(), l2b.Number+1)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// The migration runs only once, and keeps the entries recorded since
+	l2c := eth.L2BlockRef{Hash: common.Hash{0x02, 0xcc}, Number: 30}
+	l1d := eth.BlockID{Hash: common.Hash{0x01, 0xdd}, Number: 170}
+	require.NoError(t, safeDB.SafeHeadUpdated(l2c, l1d, nil))
+	require.NoError(t, safeDB.Close())
+	safeDB, err = NewSafeDB(logger, dir)
+	require.NoError(t, err)
+	defer safeDB.Close()
+	verifyInclusions(safeDB)
+	actualL1, actualL2, _, err := safeDB.L1InclusionAtL2(context.Background(), l2c.Number)
+	require.NoError(t, err)
+	require.Equal(t, l1d, actualL1)
+	require.Equal(t, l2c.ID(), actualL2)
+}
+
+func TestL1InclusionAtL2_SafeHeadReset(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	dir := t.TempDir()
+	db, err := NewSafeDB(logger, dir)
+	require.NoError(t, err)
+	defer db.Close()
+
+	l2a := eth.L2BlockRef{Hash: common.Hash{0x02, 0xaa}, Number: 20, L1Origin: eth.BlockID{Number: 60}}
+	l2b := eth.L2BlockRef{Hash: common.Hash{0x02, 0xbb}, Number: 22, L1Origin: eth.BlockID{Number: 90}}
+	l2c := eth.L2BlockRef{Hash: common.Hash{0x02, 0xcc}, Number: 25, L1Origin: eth.BlockID{Number: 110}}
+	l2d := eth.L2BlockRef{Hash: common.Hash{0x02, 0xdd}, Number: 30, L1Origin: eth.BlockID{Number: 120}}
+	l1a := eth.BlockID{Hash: common.Hash{0x01, 0xaa}, Number: 100}
+	l1b := eth.BlockID{Hash: common.Hash{0x01, 0xbb}, Number: 150}
+	l1c := eth.BlockID{Hash: common.Hash{0x01, 0xcc}, Number: 160}
+	provenance := &eth.BatchProvenance{
+		ChannelID: []byte{0x03, 0xcc},
+		Frames:    []eth.FrameProvenance{{FrameNumber: 0, TxHash: common.Hash{0x05, 0xcc}}},
+	}
+
+	require.NoError(t, db.SafeHeadUpdated(l2a, l1a, nil))
+	require.NoError(t, db.SafeHeadUpdated(l2c, l1b, provenance))
+	require.NoError(t, db.SafeHeadUpdated(l2d, l1c, nil))
+
+	// Reset to between the entries of l2a and l2c
+	require.NoError(t, db.SafeHeadReset(l2b))
+
+	// The reset safe head keeps the L1 block and provenance of the update that made it safe
+	actualL1, actualL2, actualProvenance, err := db.L1InclusionAtL2(context.Background(), l2b.Number)
+	require.NoError(t, err)
+	require.Equal(t, l1b, actualL1)
+	require.Equal(t, l2b.ID(), actualL2)
+	require.Equal(t, provenance, actualProvenance)
+
+	actualL1, actualL2, _, err = db.L1InclusionAtL2(context.Background(), l2a.Number)
+	require.NoError(t, err)
+	require.Equal(t, l1a, actualL1)
+	require.Equal(t, l2a.ID(), actualL2)
+
+	// Later blocks are not safe anymore
+	_, _, _, err = db.L1InclusionAtL2(context.Background(), l2b.Number+1)
+	require.ErrorIs(t, err, ErrNotFound)
+	_, _, _, err = db.L1InclusionAtL2(context.Background(), l2d.Number)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// Reset to before the first entry removes all entries
+	require.NoError(t, db.SafeHeadReset(eth.L2BlockRef{Hash: common.Hash{0x02, 0x01}, Number: 10, L1Origin: eth.BlockID{Number: 50}}))
+	_, _, _, err = db.L1InclusionAtL2(context.Background(), l2a.Number)
+	require.ErrorIs(t, err, ErrNotFound)
+	_, _, _, err = db.L1InclusionAtL2(context.Background(), 10)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestDecodeSafeByL2BlockNum(t *testing.T) {
+	l1 := eth.BlockID{Hash: common.Hash{0x01}, Number: 100}
+	l2 := eth.BlockID{Hash: common.Hash{0x02}, Number: 20}
+	blobHash := common.Hash{0x04}
+	provenance := &eth.BatchProvenance{
+		ChannelID: []byte{0x03, 0x03},
+		Frames: []eth.FrameProvenance{
+			{FrameNumber: 2, TxHash: common.Hash{0x05}, BlobHash: &blobHash},
+			{FrameNumber: 3, TxHash: common.Hash{0x06}},
+		},
+	}
+	key := safeByL2BlockNumKey.Of(l2.Number)
+
+	for _, expected := range []*eth.BatchProvenance{nil, provenance} {
+		actualL1, actualL2, actualProvenance, err := decodeSafeByL2BlockNum(key, safeByL2BlockNumValue(l1, l2, expected))
+		require.NoError(t, err)
+		require.Equal(t, l1, actualL1)
+		require.Equal(t, l2, actualL2)
+		require.Equal(t, expected, actualProvenance)
+	}
+
+	t.Run("ErrorOnWrongKeyPrefix", func(t *testing.T) {
+		_, _, _, err := decodeSafeByL2BlockNum(safeByL1BlockNumKey.Of(l2.Number), safeByL2BlockNumValue(l1, l2, nil))
+		require.ErrorIs(t, err, ErrInvalidEntry)
+	})
+
+	t.Run("ErrorOnTruncatedProvenance", func(t *testing.T) {
+		val := safeByL2BlockNumValue(l1, l2, provenance)
+		_, _, _, err := decodeSafeByL2BlockNum(key, val[:len(val)-1])
+		require.ErrorIs(t, err, ErrInvalidEntry)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/version"
 	rpcclient "github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -262,6 +264,52 @@ func TestSyncStatus(t *testing.T) {
 	assert.Equal(t, status, out)
 }
 
+func TestDerivationPipelineDump(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	safeReader := &mockSafeDBReader{}
+	dump := &derive.PipelineDump{
+		Origin: eth.L1BlockRef{Hash: common.Hash{0xaa}, Number: 100},
+		ChannelBank: &derive.ChannelBankDump{Channels: []derive.ChannelDump{{
+			ID:        derive.ChannelID{0x01},
+			OpenBlock: eth.BlockID{Hash: common.Hash{0xbb}, Number: 99},
+			Frames:    2,
+			Size:      1000,
+			FirstSeen: time.Unix(1700000000, 0).UTC(),
+		}}},
+		BatchQueue: &derive.BatchQueueDump{
+			L1Blocks: []eth.BlockID{{Hash: common.Hash{0xbb}, Number: 99}},
+			Batches:  []derive.BatchDump{{Type: "span", Timestamp: 1234, L1InclusionBlock: eth.BlockID{Hash: common.Hash{0xaa}, Number: 100}}},
+			NextSpan: []derive.SingularBatchDump{},
+		},
+	}
+	drClient.On("DerivationPipelineDump").Return(dump)
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(rpcCfg, rollupCfg, l2Client, drClient, safeReader, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	server.EnableAdminAPI(NewAdminAPI(drClient, metrics.NoopMetrics, log))
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+
+	var out *derive.PipelineDump
+	err = client.CallContext(context.Background(), &out, "admin_derivationPipelineDump")
+	require.NoError(t, err)
+	require.Equal(t, dump, out)
+}
+
 func TestSafeHeadAtL1Block(t *testing.T) {
 	log := testlog.Logger(t, log.LevelError)
 	l2Client := &testutils.MockL2Client{}
@@ -308,6 +356,61 @@ func TestSafeHeadAtL1Block(t *testing.T) {
 	safeReader.Mock.AssertExpectations(t)
 }
 
+func TestL1InclusionAtL2Block(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	safeReader := &mockSafeDBReader{}
+	l2BlockNum := uint64(220)
+	expectedL1 := eth.BlockID{
+		Hash:   common.Hash{0xdd},
+		Number: 5221,
+	}
+	expectedSafeHead := eth.BlockID{
+		Hash:   common.Hash{0xee},
+		Number: 223,
+	}
+	blobHash := common.Hash{0xbb}
+	provenance := &eth.BatchProvenance{
+		ChannelID: []byte{0xcc, 0x01},
+		Frames: []eth.FrameProvenance{
+			{FrameNumber: 0, TxHash: common.Hash{0xaa, 0x01}},
+			{FrameNumber: 1, TxHash: common.Hash{0xaa, 0x02}, BlobHash: &blobHash},
+		},
+	}
+	safeReader.ExpectL1InclusionAtL2(l2BlockNum, expectedL1, expectedSafeHead, provenance, nil)
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(rpcCfg, rollupCfg, l2Client, drClient, safeReader, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+
+	var inclusion *eth.SafeHeadResponse
+	err = client.CallContext(context.Background(), &inclusion, "optimism_l1InclusionAtL2Block", hexutil.Uint64(l2BlockNum).String())
+	require.NoError(t, err)
+	require.Equal(t, &eth.SafeHeadResponse{L1Block: expectedL1, SafeHead: expectedSafeHead}, inclusion)
+
+	var out *eth.BatchProvenanceResponse
+	err = client.CallContext(context.Background(), &out, "optimism_batchProvenanceAtL2Block", hexutil.Uint64(l2BlockNum).String())
+	require.NoError(t, err)
+	require.Equal(t, &eth.BatchProvenanceResponse{L1Block: expectedL1, SafeHead: expectedSafeHead, Provenance: provenance}, out)
+	l2Client.Mock.AssertExpectations(t)
+	drClient.Mock.AssertExpectations(t)
+	safeReader.Mock.AssertExpectations(t)
+}
+
 type mockDriverClient struct {
 	mock.Mock
 }
@@ -357,6 +460,10 @@ func (c *mockDriverClient) OnUnsafeL2Payload(ctx context.Context, payload *eth.E
 	return c.Mock.MethodCalled("OnUnsafeL2Payload").Get(0).(error)
 }
 
+func (c *mockDriverClient) DerivationPipelineDump(ctx context.Context) (*derive.PipelineDump, error) {
+	return c.Mock.MethodCalled("DerivationPipelineDump").Get(0).(*derive.PipelineDump), nil
+}
+
 type mockSafeDBReader struct {
 	mock.Mock
 }
@@ -369,3 +476,12 @@ func (m *mockSafeDBReader) SafeHeadAtL1(ctx context.Context, l1BlockNum uint64)
 func (m *mockSafeDBReader) ExpectSafeHeadAtL1(l1BlockNum uint64, l1 eth.BlockID, safeHead eth.BlockID, err error) {
 	m.Mock.On("SafeHeadAtL1", l1BlockNum).Return(l1, safeHead, &err)
 }
+
+func (m *mockSafeDBReader) L1InclusionAtL2(ctx context.Context, l2BlockNum uint64) (l1 eth.BlockID, l2 eth.BlockID, provenance *eth.BatchProvenance, err error) {
+	r := m.Mock.MethodCalled("L1InclusionAtL2", l2BlockNum)
+	return r[0].(eth.BlockID), r[1].(eth.BlockID), r[2].(*eth.BatchProvenance), *r[3].(*error)
+}
+
+func (m *mockSafeDBReader) ExpectL1InclusionAtL2(l2BlockNum uint64, l1 eth.BlockID, safeHead eth.BlockID, provenance *eth.BatchProvenance, err error) {
+	m.Mock.On("L1InclusionAtL2", l2BlockNum).Return(l1, safeHead, provenance, &err)
+}
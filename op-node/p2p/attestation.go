@@ -0,0 +1,221 @@
+package p2p
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/golang/snappy"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var SigningDomainOutputAttestationsV1 = [32]byte{31: 1}
+
+const (
+	// outputAttestationPayloadSize is the size of the signed attestation payload:
+	// the L2 block number as big-endian uint64, followed by the output root.
+	outputAttestationPayloadSize = 8 + 32
+	// outputAttestationSize is the size of an uncompressed attestation message, the signature followed by the payload.
+	outputAttestationSize = 65 + outputAttestationPayloadSize
+	// maxOutputAttestationLag is how far behind the safe head an attested L2 block may be.
+	maxOutputAttestationLag = time.Hour
+	// maxOutputAttestationLead is how far ahead of the unsafe head an attested L2 block may be,
+	// to accept the attestations of peers that are slightly ahead of this node.
+	maxOutputAttestationLead = time.Minute
+)
+
+// ValidatorSet checks the signers of output attestations against the active validator set.
+// It must not block on L1, an error is returned if the status of the validator is not known yet.
+type ValidatorSet interface {
+	IsActiveValidator(ctx context.Context, addr common.Address) (bool, error)
+}
+
+// L2Heads provides the recent safe and unsafe L2 heads of the node, to bound the attested L2 blocks.
+type L2Heads interface {
+	// L2Heads returns the block numbers of the safe and unsafe L2 heads, and false if they are not known yet.
+	L2Heads() (safe uint64, unsafe uint64, ok bool)
+}
+
+func outputAttestationsTopicV1(cfg *rollup.Config) string {
+	return fmt.Sprintf("/kroma/%s/0/output_attestations", cfg.L2ChainID.String())
+}
+
+func OutputAttestationSigningHash(cfg *rollup.Config, payloadBytes []byte) (common.Hash, error) {
+	return SigningHash(SigningDomainOutputAttestationsV1, cfg.L2ChainID, payloadBytes)
+}
+
+func outputAttestationPayload(l2BlockNumber uint64, outputRoot eth.Bytes32) []byte {
+	payload := make([]byte, outputAttestationPayloadSize)
+	binary.BigEndian.PutUint64(payload[:8], l2BlockNumber)
+	copy(payload[8:], outputRoot[:])
+	return payload
+}
+
+// SignOutputAttestation signs the output root at the L2 block on behalf of a validator.
+func SignOutputAttestation(ctx context.Context, cfg *rollup.Config, signer Signer, l2BlockNumber uint64, outputRoot eth.Bytes32) (*eth.OutputAttestation, error) {
+	payload := outputAttestationPayload(l2BlockNumber, outputRoot)
+	sig, err := signer.Sign(ctx, SigningDomainOutputAttestationsV1, cfg.L2ChainID, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign output attestation: %w", err)
+	}
+	signingHash, err := OutputAttestationSigningHash(cfg, payload)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := crypto.SigToPub(signingHash[:], sig[:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid output attestation signature: %w", err)
+	}
+	return &eth.OutputAttestation{
+		L2BlockNumber: hexutil.Uint64(l2BlockNumber),
+		OutputRoot:    outputRoot,
+		Validator:     crypto.PubkeyToAddress(*pub),
+		Signature:     sig[:],
+	}, nil
+}
+
+// encodeOutputAttestation encodes the attestation into an uncompressed gossip message.
+func encodeOutputAttestation(att *eth.OutputAttestation) ([]byte, error) {
+	if len(att.Signature) != 65 {
+		return nil, fmt.Errorf("invalid output attestation signature length %d", len(att.Signature))
+	}
+	data := make([]byte, 0, outputAttestationSize)
+	data = append(data, att.Signature...)
+	data = append(data, outputAttestationPayload(uint64(att.L2BlockNumber), att.OutputRoot)...)
+	return data, nil
+}
+
+// decodeOutputAttestation decodes an uncompressed gossip message, and recovers the validator from the signature.
+func decodeOutputAttestation(cfg *rollup.Config, data []byte) (*eth.OutputAttestation, error) {
+	if len(data) != outputAttestationSize {
+		return nil, fmt.Errorf("invalid output attestation size %d", len(data))
+	}
+	signature, payload := data[:65], data[65:]
+	signingHash, err := OutputAttestationSigningHash(cfg, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute output attestation signing hash: %w", err)
+	}
+	pub, err := crypto.SigToPub(signingHash[:], signature)
+	if err != nil {
+		return nil, fmt.Errorf("invalid output attestation signature: %w", err)
+	}
+	return &eth.OutputAttestation{
+		L2BlockNumber: hexutil.Uint64(binary.BigEndian.Uint64(payload[:8])),
+		OutputRoot:    eth.Bytes32(payload[8:]),
+		Validator:     crypto.PubkeyToAddress(*pub),
+		Signature:     common.CopyBytes(signature),
+	}, nil
+}
+
+// BuildOutputAttestationsValidator builds a validator that accepts output attestations
+// that are signed by a validator of the active validator set, for an L2 block around the current L2 heads.
+func BuildOutputAttestationsValidator(log log.Logger, cfg *rollup.Config, valSet ValidatorSet, heads L2Heads) pubsub.ValidatorEx {
+	maxLag := uint64(maxOutputAttestationLag/time.Second) / cfg.BlockTime
+	maxLead := uint64(maxOutputAttestationLead/time.Second) / cfg.BlockTime
+	return func(ctx context.Context, id peer.ID, message *pubsub.Message) pubsub.ValidationResult {
+		// [REJECT] if the compression is not valid
+		if dLen, err := snappy.DecodedLen(message.Data); err != nil || dLen != outputAttestationSize {
+			log.Warn("invalid output attestation compression", "peer", id, "err", err)
+			return pubsub.ValidationReject
+		}
+		data, err := snappy.Decode(nil, message.Data)
+		if err != nil {
+			log.Warn("invalid output attestation compression", "peer", id, "err", err)
+			return pubsub.ValidationReject
+		}
+
+		// [REJECT] if the signature is not valid
+		att, err := decodeOutputAttestation(cfg, data)
+		if err != nil {
+			log.Warn("invalid output attestation", "peer", id, "err", err)
+			return pubsub.ValidationReject
+		}
+
+		// [IGNORE] if the attested L2 block is too far from the L2 heads, to not flush the recent attestations
+		safe, unsafe, ok := heads.L2Heads()
+		if !ok {
+			log.Debug("ignoring output attestation, L2 heads are not known yet", "peer", id)
+			return pubsub.ValidationIgnore
+		}
+		num := uint64(att.L2BlockNumber)
+		if (safe > maxLag && num < safe-maxLag) || num > unsafe+maxLead {
+			log.Warn("output attestation is too far from the L2 heads", "peer", id, "number", num, "safe", safe, "unsafe", unsafe)
+			return pubsub.ValidationIgnore
+		}
+
+		// [IGNORE] if the active validator set cannot be checked, the attestation may still be valid
+		active, err := valSet.IsActiveValidator(ctx, att.Validator)
+		if err != nil {
+			log.Warn("failed to check output attestation validator", "peer", id, "validator", att.Validator, "err", err)
+			return pubsub.ValidationIgnore
+		}
+		// [REJECT] if the signer is not an active validator
+		if !active {
+			log.Warn("output attestation from inactive validator", "peer", id, "validator", att.Validator)
+			return pubsub.ValidationReject
+		}
+
+		message.ValidatorData = att
+		return pubsub.ValidationAccept
+	}
+}
+
+func OutputAttestationsHandler(onAttestation func(ctx context.Context, from peer.ID, att *eth.OutputAttestation) error) MessageHandler {
+	return func(ctx context.Context, from peer.ID, msg any) error {
+		att, ok := msg.(*eth.OutputAttestation)
+		if !ok {
+			return fmt.Errorf("expected topic validator to parse and validate data into output attestation, but got %T", msg)
+		}
+		return onAttestation(ctx, from, att)
+	}
+}
+
+// OutputAttestationsTopic is the gossip topic of output root attestations of the validators.
+type OutputAttestationsTopic struct {
+	topic *blockTopic
+	// cancel stops the topic event-handling functions.
+	cancel context.CancelFunc
+}
+
+// JoinOutputAttestationsGossip joins the output attestations topic,
+// and passes the attestations of active validators to onAttestation.
+func JoinOutputAttestationsGossip(self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Config, valSet ValidatorSet, heads L2Heads, onAttestation func(ctx context.Context, from peer.ID, att *eth.OutputAttestation) error) (*OutputAttestationsTopic, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	topicLogger := log.New("topic", "outputAttestationsV1")
+	validator := guardGossipValidator(log, logValidationResult(self, "validated output attestation", topicLogger, BuildOutputAttestationsValidator(topicLogger, cfg, valSet, heads)))
+	topic, err := newTopic(ctx, outputAttestationsTopicV1(cfg), ps, topicLogger, validator, OutputAttestationsHandler(onAttestation))
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to setup output attestations p2p: %w", err)
+	}
+	return &OutputAttestationsTopic{topic: topic, cancel: cancel}, nil
+}
+
+// Publish publishes a signed output attestation.
+// The attestation is validated like any gossiped attestation, and an error is returned if it is not accepted.
+func (t *OutputAttestationsTopic) Publish(ctx context.Context, att *eth.OutputAttestation) error {
+	data, err := encodeOutputAttestation(att)
+	if err != nil {
+		return err
+	}
+	return t.topic.topic.Publish(ctx, snappy.Encode(nil, data))
+}
+
+func (t *OutputAttestationsTopic) Peers() []peer.ID {
+	return t.topic.topic.ListPeers()
+}
+
+func (t *OutputAttestationsTopic) Close() error {
+	t.cancel()
+	return t.topic.Close()
+}
@@ -0,0 +1,145 @@
+package p2p
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/golang/snappy"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsub_pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type mockValidatorSet struct {
+	active map[common.Address]bool
+	err    error
+}
+
+func (m *mockValidatorSet) IsActiveValidator(ctx context.Context, addr common.Address) (bool, error) {
+	return m.active[addr], m.err
+}
+
+type mockL2Heads struct {
+	safe, unsafe uint64
+	ok           bool
+}
+
+func (m *mockL2Heads) L2Heads() (uint64, uint64, bool) {
+	return m.safe, m.unsafe, m.ok
+}
+
+func TestOutputAttestationEncoding(t *testing.T) {
+	cfg := &rollup.Config{L2ChainID: big.NewInt(100)}
+	priv, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	att, err := SignOutputAttestation(context.Background(), cfg, NewLocalSigner(priv), 1800, eth.Bytes32{0xaa})
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(priv.PublicKey), att.Validator)
+
+	data, err := encodeOutputAttestation(att)
+	require.NoError(t, err)
+	require.Len(t, data, outputAttestationSize)
+	decoded, err := decodeOutputAttestation(cfg, data)
+	require.NoError(t, err)
+	require.Equal(t, att, decoded)
+
+	// the attestation is signed for the chain ID of the rollup
+	decoded, err = decodeOutputAttestation(&rollup.Config{L2ChainID: big.NewInt(101)}, data)
+	require.NoError(t, err)
+	require.NotEqual(t, att.Validator, decoded.Validator)
+
+	_, err = decodeOutputAttestation(cfg, data[1:])
+	require.ErrorContains(t, err, "invalid output attestation size")
+}
+
+func TestOutputAttestationsValidator(t *testing.T) {
+	cfg := &rollup.Config{L2ChainID: big.NewInt(100), BlockTime: 2}
+	activePriv, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	inactivePriv, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	valSet := &mockValidatorSet{active: map[common.Address]bool{crypto.PubkeyToAddress(activePriv.PublicKey): true}}
+	heads := &mockL2Heads{safe: 5000, unsafe: 5100, ok: true}
+	validator := BuildOutputAttestationsValidator(testlog.Logger(t, log.LevelCrit), cfg, valSet, heads)
+
+	signAt := func(t *testing.T, priv *ecdsa.PrivateKey, num uint64) []byte {
+		att, err := SignOutputAttestation(context.Background(), cfg, NewLocalSigner(priv), num, eth.Bytes32{0xaa})
+		require.NoError(t, err)
+		data, err := encodeOutputAttestation(att)
+		require.NoError(t, err)
+		return data
+	}
+	sign := func(t *testing.T, priv *ecdsa.PrivateKey) []byte {
+		return signAt(t, priv, 5000)
+	}
+	validate := func(data []byte) (pubsub.ValidationResult, *pubsub.Message) {
+		message := &pubsub.Message{Message: &pubsub_pb.Message{Data: data}}
+		return validator(context.Background(), peer.ID("foo"), message), message
+	}
+
+	t.Run("Accept", func(t *testing.T) {
+		res, message := validate(snappy.Encode(nil, sign(t, activePriv)))
+		require.Equal(t, pubsub.ValidationAccept, res)
+		att, ok := message.ValidatorData.(*eth.OutputAttestation)
+		require.True(t, ok)
+		require.Equal(t, crypto.PubkeyToAddress(activePriv.PublicKey), att.Validator)
+		require.EqualValues(t, 5000, att.L2BlockNumber)
+		require.Equal(t, eth.Bytes32{0xaa}, att.OutputRoot)
+	})
+	t.Run("RejectInactiveValidator", func(t *testing.T) {
+		res, _ := validate(snappy.Encode(nil, sign(t, inactivePriv)))
+		require.Equal(t, pubsub.ValidationReject, res)
+	})
+	t.Run("RejectUncompressed", func(t *testing.T) {
+		res, _ := validate(sign(t, activePriv))
+		require.Equal(t, pubsub.ValidationReject, res)
+	})
+	t.Run("RejectInvalidSize", func(t *testing.T) {
+		res, _ := validate(snappy.Encode(nil, sign(t, activePriv)[1:]))
+		require.Equal(t, pubsub.ValidationReject, res)
+	})
+	t.Run("RejectInvalidSignature", func(t *testing.T) {
+		data := sign(t, activePriv)
+		data[64] = 0xff // invalid recovery id
+		res, _ := validate(snappy.Encode(nil, data))
+		require.Equal(t, pubsub.ValidationReject, res)
+	})
+	t.Run("L2HeadsBounds", func(t *testing.T) {
+		// an hour behind the safe head and a minute ahead of the unsafe head are accepted, at 2s blocks
+		for num, expected := range map[uint64]pubsub.ValidationResult{
+			5000 - 1800 - 1: pubsub.ValidationIgnore,
+			5000 - 1800:     pubsub.ValidationAccept,
+			5100 + 30:       pubsub.ValidationAccept,
+			5100 + 31:       pubsub.ValidationIgnore,
+			1 << 60:         pubsub.ValidationIgnore,
+		} {
+			res, _ := validate(snappy.Encode(nil, signAt(t, activePriv, num)))
+			require.Equal(t, expected, res, "block %d", num)
+		}
+	})
+	t.Run("IgnoreUnknownL2Heads", func(t *testing.T) {
+		heads.ok = false
+		defer func() { heads.ok = true }()
+		res, _ := validate(snappy.Encode(nil, sign(t, activePriv)))
+		require.Equal(t, pubsub.ValidationIgnore, res)
+	})
+	t.Run("IgnoreUnknownValidatorSet", func(t *testing.T) {
+		valSet.err = errors.New("l1 unavailable")
+		defer func() { valSet.err = nil }()
+		res, _ := validate(snappy.Encode(nil, sign(t, activePriv)))
+		require.Equal(t, pubsub.ValidationIgnore, res)
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
@@ -58,12 +59,14 @@ type GossipSetupConfigurables interface {
 }
 
 type GossipRuntimeConfig interface {
-	P2PSequencerAddress() common.Address
+	// P2PSequencerSigners returns the signers that are accepted for unsafe blocks at the given L2 time.
+	P2PSequencerSigners(l2Time uint64) []common.Address
 }
 
 //go:generate mockery --name GossipMetricer
 type GossipMetricer interface {
 	RecordGossipEvent(evType int32)
+	RecordGossipBlockSigner(signer common.Address)
 }
 
 func blocksTopicV1(cfg *rollup.Config) string {
@@ -81,7 +84,7 @@ func blocksTopicV3(cfg *rollup.Config) string {
 // BuildSubscriptionFilter builds a simple subscription filter,
 // to help protect against peers spamming useless subscriptions.
 func BuildSubscriptionFilter(cfg *rollup.Config) pubsub.SubscriptionFilter {
-	return pubsub.NewAllowlistSubscriptionFilter(blocksTopicV1(cfg), blocksTopicV2(cfg), blocksTopicV3(cfg)) // add more topics here in the future, if any.
+	return pubsub.NewAllowlistSubscriptionFilter(blocksTopicV1(cfg), blocksTopicV2(cfg), blocksTopicV3(cfg), outputAttestationsTopicV1(cfg)) // add more topics here in the future, if any.
 }
 
 var msgBufPool = sync.Pool{New: func() any {
@@ -247,7 +250,7 @@ func (sb *seenBlocks) markSeen(h common.Hash) {
 	sb.blockHashes = append(sb.blockHashes, h)
 }
 
-func BuildBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, blockVersion eth.BlockVersion) pubsub.ValidatorEx {
+func BuildBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, m GossipMetricer, blockVersion eth.BlockVersion) pubsub.ValidatorEx {
 
 	// Seen block hashes per block height
 	// uint64 -> *seenBlocks
@@ -284,12 +287,6 @@ func BuildBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRunti
 		// message starts with compact-encoding secp256k1 encoded signature
 		signatureBytes, payloadBytes := data[:65], data[65:]
 
-		// [REJECT] if the signature by the sequencer is not valid
-		result := verifyBlockSignature(log, cfg, runCfg, id, signatureBytes, payloadBytes)
-		if result != pubsub.ValidationAccept {
-			return result
-		}
-
 		var envelope eth.ExecutionPayloadEnvelope
 
 		// [REJECT] if the block encoding is not valid
@@ -324,6 +321,12 @@ func BuildBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRunti
 			return pubsub.ValidationReject
 		}
 
+		// [REJECT] if the signature by the sequencer is not valid
+		signer, result := verifyBlockSignature(log, cfg, runCfg, id, signatureBytes, payloadBytes, uint64(payload.Timestamp))
+		if result != pubsub.ValidationAccept {
+			return result
+		}
+
 		// [REJECT] if the `block_hash` in the `payload` is not valid
 		if actual, ok := envelope.CheckBlockHash(); !ok {
 			log.Warn("payload has bad block hash", "bad_hash", payload.BlockHash.String(), "actual", actual.String())
@@ -402,37 +405,39 @@ func BuildBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRunti
 
 		// remember the decoded payload for later usage in topic subscriber.
 		message.ValidatorData = &envelope
+		m.RecordGossipBlockSigner(signer)
 		return pubsub.ValidationAccept
 	}
 }
 
-func verifyBlockSignature(log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, id peer.ID, signatureBytes []byte, payloadBytes []byte) pubsub.ValidationResult {
+// verifyBlockSignature verifies the payload is signed by one of the signers accepted at the L2 time of the payload,
+// and returns the signer.
+func verifyBlockSignature(log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, id peer.ID, signatureBytes []byte, payloadBytes []byte, l2Time uint64) (common.Address, pubsub.ValidationResult) {
 	signingHash, err := BlockSigningHash(cfg, payloadBytes)
 	if err != nil {
 		log.Warn("failed to compute block signing hash", "err", err, "peer", id)
-		return pubsub.ValidationReject
+		return common.Address{}, pubsub.ValidationReject
 	}
 
 	pub, err := crypto.SigToPub(signingHash[:], signatureBytes)
 	if err != nil {
 		log.Warn("invalid block signature", "err", err, "peer", id)
-		return pubsub.ValidationReject
+		return common.Address{}, pubsub.ValidationReject
 	}
 	addr := crypto.PubkeyToAddress(*pub)
 
-	// In the future we may load & validate block metadata before checking the signature.
-	// And then check the signer based on the metadata, to support e.g. multiple p2p signers at the same time.
-	// For now we only have one signer at a time and thus check the address directly.
-	// This means we may drop old payloads upon key rotation,
+	// The accepted signers depend on the time of the block, so a signer handover can be scheduled,
+	// and blocks signed by both the old and the new signer can be accepted around the handover.
+	// Payloads signed by a signer that is unknown yet are still dropped,
 	// but this can be recovered from like any other missed unsafe payload.
-	if expected := runCfg.P2PSequencerAddress(); expected == (common.Address{}) {
+	if expected := runCfg.P2PSequencerSigners(l2Time); len(expected) == 0 {
 		log.Warn("no configured p2p sequencer address, ignoring gossiped block", "peer", id, "addr", addr)
-		return pubsub.ValidationIgnore
-	} else if addr != expected {
-		log.Warn("unexpected block author", "err", err, "peer", id, "addr", addr, "expected", expected)
-		return pubsub.ValidationReject
+		return addr, pubsub.ValidationIgnore
+	} else if !slices.Contains(expected, addr) {
+		log.Warn("unexpected block author", "peer", id, "addr", addr, "expected", expected, "time", l2Time)
+		return addr, pubsub.ValidationReject
 	}
-	return pubsub.ValidationAccept
+	return addr, pubsub.ValidationAccept
 }
 
 type GossipIn interface {
@@ -564,11 +569,11 @@ func (p *publisher) Close() error {
 	return errors.Join(e1, e2)
 }
 
-func JoinGossip(self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, gossipIn GossipIn) (GossipOut, error) {
+func JoinGossip(self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, m GossipMetricer, gossipIn GossipIn) (GossipOut, error) {
 	p2pCtx, p2pCancel := context.WithCancel(context.Background())
 
 	v1Logger := log.New("topic", "blocksV1")
-	blocksV1Validator := guardGossipValidator(log, logValidationResult(self, "validated blockv1", v1Logger, BuildBlocksValidator(v1Logger, cfg, runCfg, eth.BlockV1)))
+	blocksV1Validator := guardGossipValidator(log, logValidationResult(self, "validated blockv1", v1Logger, BuildBlocksValidator(v1Logger, cfg, runCfg, m, eth.BlockV1)))
 	blocksV1, err := newBlockTopic(p2pCtx, blocksTopicV1(cfg), ps, v1Logger, gossipIn, blocksV1Validator)
 	if err != nil {
 		p2pCancel()
@@ -576,7 +581,7 @@ func JoinGossip(self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Con
 	}
 
 	v2Logger := log.New("topic", "blocksV2")
-	blocksV2Validator := guardGossipValidator(log, logValidationResult(self, "validated blockv2", v2Logger, BuildBlocksValidator(v2Logger, cfg, runCfg, eth.BlockV2)))
+	blocksV2Validator := guardGossipValidator(log, logValidationResult(self, "validated blockv2", v2Logger, BuildBlocksValidator(v2Logger, cfg, runCfg, m, eth.BlockV2)))
 	blocksV2, err := newBlockTopic(p2pCtx, blocksTopicV2(cfg), ps, v2Logger, gossipIn, blocksV2Validator)
 	if err != nil {
 		p2pCancel()
@@ -584,7 +589,7 @@ func JoinGossip(self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Con
 	}
 
 	v3Logger := log.New("topic", "blocksV3")
-	blocksV3Validator := guardGossipValidator(log, logValidationResult(self, "validated blockv3", v3Logger, BuildBlocksValidator(v3Logger, cfg, runCfg, eth.BlockV3)))
+	blocksV3Validator := guardGossipValidator(log, logValidationResult(self, "validated blockv3", v3Logger, BuildBlocksValidator(v3Logger, cfg, runCfg, m, eth.BlockV3)))
 	blocksV3, err := newBlockTopic(p2pCtx, blocksTopicV3(cfg), ps, v3Logger, gossipIn, blocksV3Validator)
 	if err != nil {
 		p2pCancel()
@@ -603,6 +608,12 @@ func JoinGossip(self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Con
 }
 
 func newBlockTopic(ctx context.Context, topicId string, ps *pubsub.PubSub, log log.Logger, gossipIn GossipIn, validator pubsub.ValidatorEx) (*blockTopic, error) {
+	return newTopic(ctx, topicId, ps, log, validator, BlocksHandler(gossipIn.OnUnsafeL2Payload))
+}
+
+// newTopic registers the validator of the topic, joins and subscribes to it,
+// and passes the validated messages to the handler.
+func newTopic(ctx context.Context, topicId string, ps *pubsub.PubSub, log log.Logger, validator pubsub.ValidatorEx, msgHandler MessageHandler) (*blockTopic, error) {
 	err := ps.RegisterTopicValidator(topicId,
 		validator,
 		pubsub.WithValidatorTimeout(3*time.Second),
@@ -630,7 +641,7 @@ func newBlockTopic(ctx context.Context, topicId string, ps *pubsub.PubSub, log l
 		return nil, fmt.Errorf("failed to subscribe to blocks gossip topic: %w", err)
 	}
 
-	subscriber := MakeSubscriber(log, BlocksHandler(gossipIn.OnUnsafeL2Payload))
+	subscriber := MakeSubscriber(log, msgHandler)
 	go subscriber(ctx, subscription)
 
 	return &blockTopic{
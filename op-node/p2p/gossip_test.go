@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/golang/snappy"
 
@@ -71,8 +72,9 @@ func TestVerifyBlockSignature(t *testing.T) {
 		signer := &PreparedSigner{Signer: NewLocalSigner(secrets.SequencerP2P)}
 		sig, err := signer.Sign(context.Background(), SigningDomainBlocksV1, cfg.L2ChainID, msg)
 		require.NoError(t, err)
-		result := verifyBlockSignature(logger, cfg, runCfg, peerId, sig[:65], msg)
+		addr, result := verifyBlockSignature(logger, cfg, runCfg, peerId, sig[:65], msg, 0)
 		require.Equal(t, pubsub.ValidationAccept, result)
+		require.Equal(t, runCfg.P2PSeqAddress, addr)
 	})
 
 	t.Run("WrongSigner", func(t *testing.T) {
@@ -80,14 +82,14 @@ func TestVerifyBlockSignature(t *testing.T) {
 		signer := &PreparedSigner{Signer: NewLocalSigner(secrets.SequencerP2P)}
 		sig, err := signer.Sign(context.Background(), SigningDomainBlocksV1, cfg.L2ChainID, msg)
 		require.NoError(t, err)
-		result := verifyBlockSignature(logger, cfg, runCfg, peerId, sig[:65], msg)
+		_, result := verifyBlockSignature(logger, cfg, runCfg, peerId, sig[:65], msg, 0)
 		require.Equal(t, pubsub.ValidationReject, result)
 	})
 
 	t.Run("InvalidSignature", func(t *testing.T) {
 		runCfg := &testutils.MockRuntimeConfig{P2PSeqAddress: crypto.PubkeyToAddress(secrets.SequencerP2P.PublicKey)}
 		sig := make([]byte, 65)
-		result := verifyBlockSignature(logger, cfg, runCfg, peerId, sig, msg)
+		_, result := verifyBlockSignature(logger, cfg, runCfg, peerId, sig, msg, 0)
 		require.Equal(t, pubsub.ValidationReject, result)
 	})
 
@@ -96,7 +98,7 @@ func TestVerifyBlockSignature(t *testing.T) {
 		signer := &PreparedSigner{Signer: NewLocalSigner(secrets.SequencerP2P)}
 		sig, err := signer.Sign(context.Background(), SigningDomainBlocksV1, cfg.L2ChainID, msg)
 		require.NoError(t, err)
-		result := verifyBlockSignature(logger, cfg, runCfg, peerId, sig[:65], msg)
+		_, result := verifyBlockSignature(logger, cfg, runCfg, peerId, sig[:65], msg, 0)
 		require.Equal(t, pubsub.ValidationIgnore, result)
 	})
 }
@@ -153,8 +155,8 @@ func TestBlockValidator(t *testing.T) {
 	// Params Set 2: Call the validation function
 	peerID := peer.ID("foo")
 
-	v2Validator := BuildBlocksValidator(testlog.Logger(t, log.LevelCrit), cfg, runCfg, eth.BlockV2)
-	v3Validator := BuildBlocksValidator(testlog.Logger(t, log.LevelCrit), cfg, runCfg, eth.BlockV3)
+	v2Validator := BuildBlocksValidator(testlog.Logger(t, log.LevelCrit), cfg, runCfg, metrics.NoopMetrics, eth.BlockV2)
+	v3Validator := BuildBlocksValidator(testlog.Logger(t, log.LevelCrit), cfg, runCfg, metrics.NoopMetrics, eth.BlockV3)
 
 	zero, one := uint64(0), uint64(1)
 	beaconHash := common.HexToHash("0x1234")
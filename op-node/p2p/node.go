@@ -128,6 +128,8 @@ func (n *NodeP2P) init(resourcesCtx context.Context, rollupCfg *rollup.Config, l
 				// register the sync protocol with libp2p host
 				payloadByNumber := MakeStreamHandler(resourcesCtx, log.New("serve", "payloads_by_number"), n.syncSrv.HandleSyncRequest)
 				n.host.SetStreamHandler(PayloadByNumberProtocolID(rollupCfg.L2ChainID), payloadByNumber)
+				payloadByRange := MakeStreamHandler(resourcesCtx, log.New("serve", "payloads_by_range"), n.syncSrv.HandleRangeSyncRequest)
+				n.host.SetStreamHandler(PayloadByRangeProtocolID(rollupCfg.L2ChainID), payloadByRange)
 			}
 		}
 		n.scorer = NewScorer(rollupCfg, eps, metrics, n.appScorer, log)
@@ -138,7 +140,7 @@ func (n *NodeP2P) init(resourcesCtx context.Context, rollupCfg *rollup.Config, l
 		if err != nil {
 			return fmt.Errorf("failed to start gossipsub router: %w", err)
 		}
-		n.gsOut, err = JoinGossip(n.host.ID(), n.gs, log, rollupCfg, runCfg, gossipIn)
+		n.gsOut, err = JoinGossip(n.host.ID(), n.gs, log, rollupCfg, runCfg, metrics, gossipIn)
 		if err != nil {
 			return fmt.Errorf("failed to join blocks gossip topic: %w", err)
 		}
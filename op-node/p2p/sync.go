@@ -17,6 +17,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
+	msmux "github.com/multiformats/go-multistream"
 	"golang.org/x/time/rate"
 
 	"github.com/ethereum/go-ethereum"
@@ -59,12 +60,20 @@ const (
 	// and eventually kick the peer based on degraded scoring if it's really not serving us well.
 	// TODO(CLI-4009): Use a backoff rather than this mechanism.
 	clientErrRateCost = peerServerBlocksBurst
+	// Do not serve more than 32 payloads in a single payload_by_range response
+	maxRangeRequestCount = 32
+	// Stop adding payloads to a payload_by_range response once it holds this much compressed payload data
+	maxRangeResponseSize = maxGossipSize
 )
 
 func PayloadByNumberProtocolID(l2ChainID *big.Int) protocol.ID {
 	return protocol.ID(fmt.Sprintf("/opstack/req/payload_by_number/%d/0", l2ChainID))
 }
 
+func PayloadByRangeProtocolID(l2ChainID *big.Int) protocol.ID {
+	return protocol.ID(fmt.Sprintf("/opstack/req/payload_by_range/%d/0", l2ChainID))
+}
+
 type requestHandlerFn func(ctx context.Context, log log.Logger, stream network.Stream)
 
 func MakeStreamHandler(resourcesCtx context.Context, log log.Logger, fn requestHandlerFn) network.StreamHandler {
@@ -94,8 +103,10 @@ type syncResult struct {
 	peer    peer.ID
 }
 
+// peerRequest requests the blocks [start, start+count) from a peer.
 type peerRequest struct {
-	num uint64
+	start uint64
+	count uint64
 
 	complete *atomic.Bool
 }
@@ -108,6 +119,7 @@ type inFlightCheck struct {
 
 type SyncClientMetrics interface {
 	ClientPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration)
+	ClientPayloadByRangeEvent(start uint64, count uint64, resultCode byte, duration time.Duration)
 	PayloadsQuarantineSize(n int)
 }
 
@@ -129,7 +141,8 @@ type SyncPeerScorer interface {
 //
 // The sync mechanism is implemented as following:
 // - User sends range request: blocks on sync main loop (with ctx timeout)
-// - Main loop processes range request (from high to low), dividing block requests by number between parallel peers.
+// - Main loop processes range request (from high to low), dividing block requests between parallel peers.
+//   - Contiguous block numbers are batched into a single request, of up to maxRangeRequestCount blocks.
 //   - The high part of the range has a known block-hash, and is marked as trusted.
 //   - Once there are no more peers available for buffering requests, we stop the range request processing.
 //   - Every request buffered for a peer is tracked as in-flight, by block number.
@@ -138,7 +151,9 @@ type SyncPeerScorer interface {
 //   - Data already in the quarantine that is trusted is attempted to be promoted.
 //
 // - Peers each have their own routine for processing requests.
-//   - They fetch the requested block by number, parse and validate it, and then send it back to the main loop
+//   - They fetch the requested blocks with the payload_by_range protocol, or fall back to fetching the blocks
+//     one by one with the payload_by_number protocol if the peer does not support it.
+//   - They parse and validate the blocks, verify that the parent hashes link, and then send them back to the main loop
 //   - If peers fail to fetch or process it, or fail to send it back to the main loop within timeout,
 //     then the doRequest returns an error. It then marks the in-flight request as completed.
 //
@@ -191,6 +206,7 @@ type SyncClient struct {
 
 	newStreamFn     newStreamFn
 	payloadByNumber protocol.ID
+	payloadByRange  protocol.ID
 
 	peersLock sync.Mutex
 	// syncing worker per peer
@@ -244,6 +260,7 @@ func NewSyncClient(log log.Logger, cfg *rollup.Config, newStream newStreamFn, rc
 		appScorer:       appScorer,
 		newStreamFn:     newStream,
 		payloadByNumber: PayloadByNumberProtocolID(cfg.L2ChainID),
+		payloadByRange:  PayloadByRangeProtocolID(cfg.L2ChainID),
 		peers:           make(map[peer.ID]context.CancelFunc),
 		quarantineByNum: make(map[uint64]common.Hash),
 		inFlight:        make(map[uint64]*atomic.Bool),
@@ -394,10 +411,34 @@ func (s *SyncClient) onRangeRequest(ctx context.Context, req rangeRequest) {
 		}
 	}
 
+	// Contiguous numbers to fetch are batched into a single peer request.
+	var pr peerRequest
+	schedule := func() bool {
+		if pr.count == 0 {
+			return true
+		}
+		log.Debug("Scheduling P2P block request", "start", pr.start, "count", pr.count)
+		select {
+		case s.peerRequests <- pr:
+			for num := pr.start; num < pr.start+pr.count; num++ {
+				s.inFlight[num] = pr.complete
+			}
+			pr = peerRequest{}
+			return true
+		case <-ctx.Done():
+			log.Info("did not schedule full P2P sync range", "current", pr.start+pr.count-1, "err", ctx.Err())
+			return false
+		default: // peers may all be busy processing requests already
+			log.Info("no peers ready to handle block requests for more P2P requests for L2 block history", "current", pr.start+pr.count-1)
+			return false
+		}
+	}
+
 	// Now try to fetch lower numbers than current end, to traverse back towards the updated start.
 	for i := uint64(0); ; i++ {
 		num := req.end.Number - 1 - i
 		if num <= req.start {
+			schedule()
 			return
 		}
 		// check if we have something in quarantine already
@@ -407,25 +448,27 @@ func (s *SyncClient) onRangeRequest(ctx context.Context, req rangeRequest) {
 			}
 			// Don't fetch things that we have a candidate for already.
 			// We'll evict it from quarantine by finding a conflict, or if we sync enough other blocks
+			if !schedule() {
+				return
+			}
 			continue
 		}
 
 		if _, ok := s.inFlight[num]; ok {
 			log.Debug("request still in-flight, not rescheduling sync request", "num", num)
-			continue // request still in flight
+			// request still in flight
+			if !schedule() {
+				return
+			}
+			continue
 		}
-		pr := peerRequest{num: num, complete: new(atomic.Bool)}
 
-		log.Debug("Scheduling P2P block request", "num", num)
-		// schedule number
-		select {
-		case s.peerRequests <- pr:
-			s.inFlight[num] = pr.complete
-		case <-ctx.Done():
-			log.Info("did not schedule full P2P sync range", "current", num, "err", ctx.Err())
-			return
-		default: // peers may all be busy processing requests already
-			log.Info("no peers ready to handle block requests for more P2P requests for L2 block history", "current", num)
+		if pr.count == 0 {
+			pr.complete = new(atomic.Bool)
+		}
+		pr.start = num
+		pr.count++
+		if pr.count >= maxRangeRequestCount && !schedule() {
 			return
 		}
 	}
@@ -519,6 +562,9 @@ func (s *SyncClient) peerLoop(ctx context.Context, id peer.ID) {
 	// so we don't be too aggressive to the server.
 	rl := rate.NewLimiter(peerServerBlocksRateLimit, peerServerBlocksBurst)
 
+	// Assume the peer supports the payload_by_range protocol, until the stream negotiation tells otherwise.
+	rangeSupported := true
+
 	for {
 		// wait for a global allocation to be available
 		if err := s.globalRL.Wait(ctx); err != nil {
@@ -534,12 +580,24 @@ func (s *SyncClient) peerLoop(ctx context.Context, id peer.ID) {
 		case pr := <-s.peerRequests:
 			// We already established the peer is available w.r.t. rate-limiting,
 			// and this is the only loop over this peer, so we can request now.
-			start := time.Now()
-			err := s.doRequest(ctx, id, pr.num)
+			var err error
+			if pr.count > 1 && rangeSupported {
+				start := time.Now()
+				err = s.doRangeRequest(ctx, id, pr.start, pr.count)
+				s.metrics.ClientPayloadByRangeEvent(pr.start, pr.count, requestResultCode(err), time.Since(start))
+				if errors.Is(err, errRangeNotSupported) {
+					log.Info("Peer does not support payload_by_range, falling back to payload_by_number")
+					rangeSupported = false
+				}
+			}
+			if pr.count == 1 || !rangeSupported {
+				err = s.doRequests(ctx, id, rl, pr)
+			}
+			// Mark as complete: the results we received are tracked by the quarantine,
+			// and the blocks we did not receive have to be rescheduled.
+			pr.complete.Store(true)
 			if err != nil {
-				// mark as complete if there's an error: we are not sending any result and can complete immediately.
-				pr.complete.Store(true)
-				log.Warn("failed p2p sync request", "num", pr.num, "err", err)
+				log.Warn("failed p2p sync request", "start", pr.start, "count", pr.count, "err", err)
 				s.appScorer.onResponseError(id)
 				// If we hit an error, then count it as many requests.
 				// We'd like to avoid making more requests for a while, to back off.
@@ -547,20 +605,9 @@ func (s *SyncClient) peerLoop(ctx context.Context, id peer.ID) {
 					return
 				}
 			} else {
-				log.Debug("completed p2p sync request", "num", pr.num)
+				log.Debug("completed p2p sync request", "start", pr.start, "count", pr.count)
 				s.appScorer.onValidResponse(id)
 			}
-			took := time.Since(start)
-
-			resultCode := byte(0)
-			if err != nil {
-				if re, ok := err.(requestResultErr); ok {
-					resultCode = re.ResultCode()
-				} else {
-					resultCode = 1
-				}
-			}
-			s.metrics.ClientPayloadByNumberEvent(pr.num, resultCode, took)
 		case <-ctx.Done():
 			return
 		}
@@ -577,6 +624,39 @@ func (r requestResultErr) ResultCode() byte {
 	return byte(r)
 }
 
+func requestResultCode(err error) byte {
+	if err == nil {
+		return 0
+	}
+	if re, ok := err.(requestResultErr); ok {
+		return re.ResultCode()
+	}
+	return 1
+}
+
+// doRequests fetches the blocks of the peer request one by one with the payload_by_number protocol,
+// from the highest number down. The first block is fetched immediately, the others wait for the rate-limits.
+func (s *SyncClient) doRequests(ctx context.Context, id peer.ID, rl *rate.Limiter, pr peerRequest) error {
+	for i := uint64(0); i < pr.count; i++ {
+		num := pr.start + pr.count - 1 - i
+		if i > 0 {
+			if err := s.globalRL.Wait(ctx); err != nil {
+				return err
+			}
+			if err := rl.Wait(ctx); err != nil {
+				return err
+			}
+		}
+		start := time.Now()
+		err := s.doRequest(ctx, id, num)
+		s.metrics.ClientPayloadByNumberEvent(num, requestResultCode(err), time.Since(start))
+		if err != nil {
+			return fmt.Errorf("failed to fetch block %d: %w", num, err)
+		}
+	}
+	return nil
+}
+
 func (s *SyncClient) doRequest(ctx context.Context, id peer.ID, expectedBlockNum uint64) error {
 	// open stream to peer
 	reqCtx, reqCancel := context.WithTimeout(ctx, streamTimeout)
@@ -627,20 +707,9 @@ func (s *SyncClient) doRequest(ctx context.Context, id peer.ID, expectedBlockNum
 		return fmt.Errorf("failed to read response: %w", err)
 	}
 
-	envelope := &eth.ExecutionPayloadEnvelope{}
-
-	if version == 0 {
-		expectedBlockTime := s.cfg.TimestampForBlock(expectedBlockNum)
-		envelope, err = s.readExecutionPayload(data, expectedBlockTime)
-		if err != nil {
-			return err
-		}
-	} else if version == 1 {
-		if err := envelope.UnmarshalSSZ(uint32(len(data)), bytes.NewReader(data)); err != nil {
-			return fmt.Errorf("failed to decode execution payload envelope response: %w", err)
-		}
-	} else {
-		panic(fmt.Errorf("should have already filtered by version, but got: %d", version))
+	envelope, err := s.decodePayload(version, data, expectedBlockNum)
+	if err != nil {
+		return err
 	}
 
 	if err := str.CloseRead(); err != nil {
@@ -657,6 +726,143 @@ func (s *SyncClient) doRequest(ctx context.Context, id peer.ID, expectedBlockNum
 	return nil
 }
 
+var errRangeNotSupported = errors.New("peer does not support payload_by_range")
+
+// payloadByRangeRequest is the request of the payload_by_range protocol.
+// The payloads of the blocks [Start, Start+Count) are served from the highest number down,
+// so every payload is the parent of the previous one.
+type payloadByRangeRequest struct {
+	Start uint64
+	Count uint64
+}
+
+// doRangeRequest fetches the blocks [start, start+count) with the payload_by_range protocol.
+// The server may end the response early, in which case only the highest blocks are received.
+func (s *SyncClient) doRangeRequest(ctx context.Context, id peer.ID, start uint64, count uint64) error {
+	// open stream to peer
+	reqCtx, reqCancel := context.WithTimeout(ctx, streamTimeout)
+	defer reqCancel()
+	str, err := s.newStreamFn(reqCtx, id, s.payloadByRange)
+	if err != nil {
+		if errors.Is(err, msmux.ErrNotSupported[protocol.ID]{}) {
+			return errRangeNotSupported
+		}
+		return fmt.Errorf("failed to open stream: %w", err)
+	}
+	defer str.Close()
+	// set write timeout (if available)
+	_ = str.SetWriteDeadline(time.Now().Add(clientWriteRequestTimeout))
+	if err := binary.Write(str, binary.LittleEndian, payloadByRangeRequest{Start: start, Count: count}); err != nil {
+		return fmt.Errorf("failed to write request (%d, %d): %w", start, count, err)
+	}
+	if err := str.CloseWrite(); err != nil {
+		return fmt.Errorf("failed to close writer side while making request: %w", err)
+	}
+
+	var parentHash common.Hash
+	size := 0
+	for i := uint64(0); i < count; i++ {
+		expectedBlockNum := start + count - 1 - i
+		// set read timeout (if available), per payload
+		_ = str.SetReadDeadline(time.Now().Add(clientReadResponsetimeout))
+		envelope, n, err := s.readRangeChunk(str, expectedBlockNum)
+		if err != nil {
+			var re requestResultErr
+			if i > 0 && (errors.Is(err, io.EOF) || errors.As(err, &re)) {
+				// the server ended the response early, e.g. when hitting the size cap or a missing payload
+				break
+			}
+			if errors.Is(err, io.EOF) {
+				return errors.New("empty payload_by_range response")
+			}
+			return err
+		}
+		size += n
+		if i > 0 && size > maxRangeResponseSize {
+			return fmt.Errorf("payload_by_range response exceeds the size cap of %d bytes", maxRangeResponseSize)
+		}
+		if i > 0 && envelope.ExecutionPayload.BlockHash != parentHash {
+			return fmt.Errorf("received execution payload %s does not match the parent hash %s of the previous payload", envelope.ExecutionPayload.ID(), parentHash)
+		}
+		parentHash = envelope.ExecutionPayload.ParentHash
+		select {
+		case s.results <- syncResult{payload: envelope, peer: id}:
+		case <-ctx.Done():
+			return fmt.Errorf("failed to process response, sync client is too busy: %w", ctx.Err())
+		}
+	}
+	if err := str.CloseRead(); err != nil {
+		return fmt.Errorf("failed to close reading side")
+	}
+	return nil
+}
+
+// readRangeChunk reads and verifies a single payload of a payload_by_range response,
+// and returns it along with its compressed size. It returns io.EOF if the response has ended.
+func (s *SyncClient) readRangeChunk(r io.Reader, expectedBlockNum uint64) (*eth.ExecutionPayloadEnvelope, int, error) {
+	var header [9]byte
+	if _, err := io.ReadFull(r, header[:1]); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, 0, io.EOF
+		}
+		return nil, 0, fmt.Errorf("failed to read result part of response: %w", err)
+	}
+	if res := header[0]; res != 0 {
+		return nil, 0, requestResultErr(res)
+	}
+	if _, err := io.ReadFull(r, header[1:]); err != nil {
+		return nil, 0, fmt.Errorf("failed to read version and length part of response: %w", err)
+	}
+	version := binary.LittleEndian.Uint32(header[1:5])
+	if version != 0 && version != 1 {
+		return nil, 0, fmt.Errorf("unrecognized version: %d", version)
+	}
+	length := binary.LittleEndian.Uint32(header[5:9])
+	if length > uint32(snappy.MaxEncodedLen(maxGossipSize)) {
+		return nil, 0, fmt.Errorf("payload of %d bytes is too large", length)
+	}
+	compressed := make([]byte, length)
+	if _, err := io.ReadFull(r, compressed); err != nil {
+		return nil, 0, fmt.Errorf("failed to read payload part of response: %w", err)
+	}
+	// payload is SSZ encoded with Snappy block compression
+	if n, err := snappy.DecodedLen(compressed); err != nil {
+		return nil, 0, fmt.Errorf("failed to read decoded length of payload: %w", err)
+	} else if n > maxGossipSize {
+		return nil, 0, fmt.Errorf("decoded payload of %d bytes is too large", n)
+	}
+	data, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to decompress payload: %w", err)
+	}
+	envelope, err := s.decodePayload(version, data, expectedBlockNum)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := verifyBlock(envelope, expectedBlockNum); err != nil {
+		return nil, 0, fmt.Errorf("received execution payload is invalid: %w", err)
+	}
+	return envelope, len(compressed), nil
+}
+
+// decodePayload decodes a SSZ encoded payload of a sync response: version 0 is a bare execution payload,
+// version 1 is an execution payload envelope.
+func (s *SyncClient) decodePayload(version uint32, data []byte, expectedBlockNum uint64) (*eth.ExecutionPayloadEnvelope, error) {
+	switch version {
+	case 0:
+		expectedBlockTime := s.cfg.TimestampForBlock(expectedBlockNum)
+		return s.readExecutionPayload(data, expectedBlockTime)
+	case 1:
+		envelope := &eth.ExecutionPayloadEnvelope{}
+		if err := envelope.UnmarshalSSZ(uint32(len(data)), bytes.NewReader(data)); err != nil {
+			return nil, fmt.Errorf("failed to decode execution payload envelope response: %w", err)
+		}
+		return envelope, nil
+	default:
+		panic(fmt.Errorf("should have already filtered by version, but got: %d", version))
+	}
+}
+
 func (s *SyncClient) readExecutionPayload(data []byte, expectedTime uint64) (*eth.ExecutionPayloadEnvelope, error) {
 	blockVersion := eth.BlockV1
 	if s.cfg.IsCanyon(expectedTime) {
@@ -697,6 +903,7 @@ type L2Chain interface {
 
 type ReqRespServerMetrics interface {
 	ServerPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration)
+	ServerPayloadByRangeEvent(start uint64, count uint64, resultCode byte, duration time.Duration)
 }
 
 type ReqRespServer struct {
@@ -747,13 +954,7 @@ func (srv *ReqRespServer) HandleSyncRequest(ctx context.Context, log log.Logger,
 	resultCode := byte(0)
 	if err != nil {
 		log.Warn("failed to serve p2p sync request", "req", req, "err", err)
-		if errors.Is(err, ethereum.NotFound) {
-			resultCode = 1
-		} else if errors.Is(err, invalidRequestErr) {
-			resultCode = 2
-		} else {
-			resultCode = 3
-		}
+		resultCode = serveResultCode(err)
 		// try to write error code, so the other peer can understand the reason for failure.
 		_, _ = stream.Write([]byte{resultCode})
 	} else {
@@ -764,17 +965,27 @@ func (srv *ReqRespServer) HandleSyncRequest(ctx context.Context, log log.Logger,
 
 var invalidRequestErr = errors.New("invalid request")
 
-func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.Stream) (uint64, error) {
-	peerId := stream.Conn().RemotePeer()
+func serveResultCode(err error) byte {
+	if errors.Is(err, ethereum.NotFound) {
+		return 1
+	} else if errors.Is(err, invalidRequestErr) {
+		return 2
+	} else {
+		return 3
+	}
+}
 
+// waitRateLimits waits until the server is ready to serve another request of the peer.
+func (srv *ReqRespServer) waitRateLimits(ctx context.Context, peerId peer.ID) error {
 	// take a token from the global rate-limiter,
 	// to make sure there's not too much concurrent server work between different peers.
 	if err := srv.globalRequestsRL.Wait(ctx); err != nil {
-		return 0, fmt.Errorf("timed out waiting for global sync rate limit: %w", err)
+		return fmt.Errorf("timed out waiting for global sync rate limit: %w", err)
 	}
 
 	// find rate limiting data of peer, or add otherwise
 	srv.peerStatsLock.Lock()
+	defer srv.peerStatsLock.Unlock()
 	ps, _ := srv.peerRateLimits.Get(peerId)
 	if ps == nil {
 		ps = &peerStat{
@@ -789,10 +1000,16 @@ func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.
 		// We'll disconnect ourselves only when failing to read/write,
 		// if the work is invalid (range validation), or when individual sub tasks timeout.
 		if err := ps.Requests.Wait(ctx); err != nil {
-			return 0, fmt.Errorf("timed out waiting for global sync rate limit: %w", err)
+			return fmt.Errorf("timed out waiting for global sync rate limit: %w", err)
 		}
 	}
-	srv.peerStatsLock.Unlock()
+	return nil
+}
+
+func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.Stream) (uint64, error) {
+	if err := srv.waitRateLimits(ctx, stream.Conn().RemotePeer()); err != nil {
+		return 0, err
+	}
 
 	// Set read deadline, if available
 	_ = stream.SetReadDeadline(time.Now().Add(serverReadRequestTimeout))
@@ -860,3 +1077,124 @@ func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.
 
 	return req, nil
 }
+
+// HandleRangeSyncRequest is a stream handler function to register the L2 unsafe payloads range alt-sync protocol.
+// See MakeStreamHandler to transform this into a LibP2P handler function.
+//
+// The response consists of a chunk per payload, from the highest requested number down,
+// and ends early when hitting the response size cap or a payload that cannot be served.
+//
+// The caller must Close the stream.
+func (srv *ReqRespServer) HandleRangeSyncRequest(ctx context.Context, log log.Logger, stream network.Stream) {
+	start := time.Now()
+
+	// We wait as long as necessary; we throttle the peer instead of disconnecting,
+	// unless the delay reaches a threshold that is unreasonable to wait for.
+	ctx, cancel := context.WithTimeout(ctx, maxThrottleDelay)
+	defer cancel()
+	req, served, err := srv.handleRangeSyncRequest(ctx, stream)
+
+	resultCode := byte(0)
+	if err != nil {
+		log.Warn("failed to serve p2p range sync request", "start", req.Start, "count", req.Count, "served", served, "err", err)
+		resultCode = serveResultCode(err)
+		// try to write error code, so the other peer can understand the reason for failure,
+		// or why the response ended early.
+		_, _ = stream.Write([]byte{resultCode})
+	} else {
+		log.Debug("successfully served range sync response", "start", req.Start, "count", req.Count, "served", served)
+	}
+	srv.metrics.ServerPayloadByRangeEvent(req.Start, req.Count, resultCode, time.Since(start))
+}
+
+func (srv *ReqRespServer) handleRangeSyncRequest(ctx context.Context, stream network.Stream) (payloadByRangeRequest, uint64, error) {
+	var req payloadByRangeRequest
+	if err := srv.waitRateLimits(ctx, stream.Conn().RemotePeer()); err != nil {
+		return req, 0, err
+	}
+
+	// Set read deadline, if available
+	_ = stream.SetReadDeadline(time.Now().Add(serverReadRequestTimeout))
+
+	// Read the request
+	if err := binary.Read(stream, binary.LittleEndian, &req); err != nil {
+		return req, 0, fmt.Errorf("failed to read requested block range: %w", err)
+	}
+	if err := stream.CloseRead(); err != nil {
+		return req, 0, fmt.Errorf("failed to close reading-side of a P2P range sync request call: %w", err)
+	}
+
+	// Check the request is within the expected range of blocks
+	if req.Count == 0 {
+		return req, 0, fmt.Errorf("cannot serve request for empty range: %w", invalidRequestErr)
+	}
+	end := req.Start + req.Count - 1
+	if end < req.Start {
+		return req, 0, fmt.Errorf("cannot serve request for range (%d, %d) that overflows: %w", req.Start, req.Count, invalidRequestErr)
+	}
+	if req.Start < srv.cfg.Genesis.L2.Number {
+		return req, 0, fmt.Errorf("cannot serve request for L2 block %d before genesis %d: %w", req.Start, srv.cfg.Genesis.L2.Number, invalidRequestErr)
+	}
+	max, err := srv.cfg.TargetBlockNumber(uint64(time.Now().Unix()))
+	if err != nil {
+		return req, 0, fmt.Errorf("cannot determine max target block number to verify request: %w", invalidRequestErr)
+	}
+	if end > max {
+		return req, 0, fmt.Errorf("cannot serve request for L2 block %d after max expected block (%v): %w", end, max, invalidRequestErr)
+	}
+
+	// Only serve the highest blocks of the range if it exceeds the count cap
+	count := min(req.Count, maxRangeRequestCount)
+	size := 0
+	for i := uint64(0); i < count; i++ {
+		// the rate limits are per block, so each payload after the first one takes another token
+		if i > 0 {
+			if err := srv.waitRateLimits(ctx, stream.Conn().RemotePeer()); err != nil {
+				return req, i, err
+			}
+		}
+		num := end - i
+		envelope, err := srv.l2.PayloadByNumber(ctx, num)
+		if err != nil {
+			if errors.Is(err, ethereum.NotFound) {
+				return req, i, fmt.Errorf("peer requested unknown block by number: %w", err)
+			} else {
+				return req, i, fmt.Errorf("failed to retrieve payload to serve to peer: %w", err)
+			}
+		}
+
+		var buf bytes.Buffer
+		var version uint32
+		if srv.cfg.IsEcotone(uint64(envelope.ExecutionPayload.Timestamp)) {
+			version = 1
+			_, err = envelope.MarshalSSZ(&buf)
+		} else {
+			_, err = envelope.ExecutionPayload.MarshalSSZ(&buf)
+		}
+		if err != nil {
+			return req, i, fmt.Errorf("failed to encode payload of block %d: %w", num, err)
+		}
+		data := snappy.Encode(nil, buf.Bytes())
+		if i > 0 && size+len(data) > maxRangeResponseSize {
+			return req, i, nil
+		}
+		size += len(data)
+
+		// We set write deadline, if available, to safely write without blocking on a throttling peer connection
+		_ = stream.SetWriteDeadline(time.Now().Add(serverWriteChunkTimeout))
+
+		// 0 - resultCode: success = 0
+		// 1:5 - version (little endian)
+		// 5:9 - length of the compressed payload (little endian)
+		var header [9]byte
+		binary.LittleEndian.PutUint32(header[1:5], version)
+		binary.LittleEndian.PutUint32(header[5:9], uint32(len(data)))
+		if _, err := stream.Write(header[:]); err != nil {
+			return req, i, fmt.Errorf("failed to write response header data: %w", err)
+		}
+		if _, err := stream.Write(data); err != nil {
+			return req, i, fmt.Errorf("failed to write payload to range sync response: %w", err)
+		}
+	}
+	return req, count, nil
+}
@@ -379,3 +379,196 @@ func TestNetworkNotifyAddPeerAndRemovePeer(t *testing.T) {
 	_, peerBExist3 := syncCl.peers[hostB.ID()]
 	require.True(t, !peerBExist3, "peerB should not exist in syncClient")
 }
+
+func TestRangeSync(t *testing.T) {
+	t.Parallel()
+
+	for _, byRange := range []bool{true, false} {
+		byRange := byRange
+		name := "payload_by_number"
+		if byRange {
+			name = "payload_by_range"
+		}
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			log := testlog.Logger(t, log.LevelError)
+
+			cfg, payloads := setupSyncTestData(50)
+
+			servePayload := mockPayloadFn(func(n uint64) (*eth.ExecutionPayloadEnvelope, error) {
+				p, ok := payloads.getPayload(n)
+				if !ok {
+					return nil, ethereum.NotFound
+				}
+				return p, nil
+			})
+
+			received := make(chan *eth.ExecutionPayloadEnvelope, 100)
+			receivePayload := receivePayloadFn(func(ctx context.Context, from peer.ID, payload *eth.ExecutionPayloadEnvelope) error {
+				received <- payload
+				return nil
+			})
+
+			mnet, err := mocknet.FullMeshConnected(2)
+			require.NoError(t, err, "failed to setup mocknet")
+			defer mnet.Close()
+			hosts := mnet.Hosts()
+			hostA, hostB := hosts[0], hosts[1]
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// Setup host A as the server, serving only one of the protocols,
+			// so the client has to fall back to payload_by_number if payload_by_range is not supported.
+			srv := NewReqRespServer(cfg, servePayload, metrics.NoopMetrics)
+			if byRange {
+				hostA.SetStreamHandler(PayloadByRangeProtocolID(cfg.L2ChainID), MakeStreamHandler(ctx, log.New("role", "server"), srv.HandleRangeSyncRequest))
+			} else {
+				hostA.SetStreamHandler(PayloadByNumberProtocolID(cfg.L2ChainID), MakeStreamHandler(ctx, log.New("role", "server"), srv.HandleSyncRequest))
+			}
+
+			cl := NewSyncClient(log.New("role", "client"), cfg, hostB.NewStream, receivePayload, metrics.NoopMetrics, &NoopApplicationScorer{})
+			cl.AddPeer(hostA.ID())
+			cl.Start()
+			defer cl.Close()
+
+			// request a range exceeding the count cap of a single request
+			require.NoError(t, cl.RequestL2Range(ctx, payloads.getBlockRef(5), payloads.getBlockRef(45)))
+
+			for i := uint64(44); i > 5; i-- {
+				p := <-received
+				require.Equal(t, i, uint64(p.ExecutionPayload.BlockNumber), "expecting payloads in order")
+				exp, ok := payloads.getPayload(i)
+				require.True(t, ok, "expecting known payload")
+				require.Equal(t, exp.ExecutionPayload.BlockHash, p.ExecutionPayload.BlockHash, "expecting the correct payload")
+				require.Equal(t, exp.ParentBeaconBlockRoot, p.ParentBeaconBlockRoot)
+			}
+		})
+	}
+}
+
+func TestRangeRequest(t *testing.T) {
+	t.Parallel()
+	log := testlog.Logger(t, log.LevelError)
+
+	cfg, payloads := setupSyncTestData(100)
+
+	// forge a payload of block 30 that does not link to block 31, but has a valid block hash itself
+	forged := *payloads.payloads[30]
+	forgedPayload := *forged.ExecutionPayload
+	forgedPayload.ParentHash = common.Hash{0x42}
+	forged.ExecutionPayload = &forgedPayload
+	forged.ExecutionPayload.BlockHash, _ = forged.CheckBlockHash()
+
+	// block 80 is missing
+	payloads.deletePayload(80)
+
+	servePayload := mockPayloadFn(func(n uint64) (*eth.ExecutionPayloadEnvelope, error) {
+		if n == 30 {
+			return &forged, nil
+		}
+		p, ok := payloads.getPayload(n)
+		if !ok {
+			return nil, ethereum.NotFound
+		}
+		return p, nil
+	})
+
+	// host C does not serve any sync protocol
+	mnet, err := mocknet.FullMeshConnected(3)
+	require.NoError(t, err, "failed to setup mocknet")
+	defer mnet.Close()
+	hosts := mnet.Hosts()
+	hostA, hostB, hostC := hosts[0], hosts[1], hosts[2]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	srv := NewReqRespServer(cfg, servePayload, metrics.NoopMetrics)
+	hostA.SetStreamHandler(PayloadByRangeProtocolID(cfg.L2ChainID), MakeStreamHandler(ctx, log.New("role", "server"), srv.HandleRangeSyncRequest))
+
+	// The client is not started, the results are read directly from the results channel.
+	cl := NewSyncClient(log.New("role", "client"), cfg, hostB.NewStream, nil, metrics.NoopMetrics, &NoopApplicationScorer{})
+
+	expectResults := func(t *testing.T, from, to uint64) {
+		for i := from; i >= to; i-- {
+			select {
+			case res := <-cl.results:
+				exp, _ := payloads.getPayload(i)
+				require.Equal(t, exp.ExecutionPayload.BlockHash, res.payload.ExecutionPayload.BlockHash)
+			default:
+				t.Fatalf("missing result for block %d", i)
+			}
+		}
+		require.Empty(t, cl.results)
+	}
+
+	t.Run("count cap", func(t *testing.T) {
+		// only the highest blocks are served when exceeding the count cap
+		require.NoError(t, cl.doRangeRequest(ctx, hostA.ID(), 0, 70))
+		expectResults(t, 69, 70-maxRangeRequestCount)
+	})
+
+	t.Run("missing payload", func(t *testing.T) {
+		// the response ends early at the first missing payload
+		require.NoError(t, cl.doRangeRequest(ctx, hostA.ID(), 75, 10))
+		expectResults(t, 84, 81)
+	})
+
+	t.Run("unknown range", func(t *testing.T) {
+		err := cl.doRangeRequest(ctx, hostA.ID(), 101, 5)
+		require.ErrorIs(t, err, requestResultErr(1))
+		require.Empty(t, cl.results)
+	})
+
+	t.Run("broken parent hash link", func(t *testing.T) {
+		err := cl.doRangeRequest(ctx, hostA.ID(), 28, 4)
+		require.ErrorContains(t, err, "does not match the parent hash")
+		expectResults(t, 31, 31)
+	})
+
+	t.Run("unsupported protocol", func(t *testing.T) {
+		err := cl.doRangeRequest(ctx, hostC.ID(), 10, 5)
+		require.ErrorIs(t, err, errRangeNotSupported)
+	})
+}
+
+func TestRangeRequestRateLimits(t *testing.T) {
+	t.Parallel()
+	log := testlog.Logger(t, log.LevelError)
+
+	cfg, payloads := setupSyncTestData(20)
+	servePayload := mockPayloadFn(func(n uint64) (*eth.ExecutionPayloadEnvelope, error) {
+		p, ok := payloads.getPayload(n)
+		if !ok {
+			return nil, ethereum.NotFound
+		}
+		return p, nil
+	})
+
+	mnet, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err, "failed to setup mocknet")
+	defer mnet.Close()
+	hosts := mnet.Hosts()
+	hostA, hostB := hosts[0], hosts[1]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	srv := NewReqRespServer(cfg, servePayload, metrics.NoopMetrics)
+	hostA.SetStreamHandler(PayloadByRangeProtocolID(cfg.L2ChainID), MakeStreamHandler(ctx, log.New("role", "server"), srv.HandleRangeSyncRequest))
+	cl := NewSyncClient(log.New("role", "client"), cfg, hostB.NewStream, nil, metrics.NoopMetrics, &NoopApplicationScorer{})
+
+	start := time.Now()
+	require.NoError(t, cl.doRangeRequest(ctx, hostA.ID(), 5, 10))
+	require.Len(t, cl.results, 10)
+	elapsed := time.Since(start).Seconds()
+
+	// a token is taken for each served payload, rather than for the request
+	srv.peerStatsLock.Lock()
+	ps, ok := srv.peerRateLimits.Get(hostB.ID())
+	srv.peerStatsLock.Unlock()
+	require.True(t, ok)
+	require.LessOrEqual(t, ps.Requests.Tokens(), float64(peerServerBlocksBurst-10)+elapsed*float64(peerServerBlocksRateLimit)+1)
+	require.LessOrEqual(t, srv.globalRequestsRL.Tokens(), float64(globalServerBlocksBurst-10)+elapsed*float64(globalServerBlocksRateLimit)+1)
+}
@@ -34,6 +34,8 @@ type AttributesQueue struct {
 	prev         *BatchQueue
 	batch        *SingularBatch
 	isLastInSpan bool
+	provenance   *eth.BatchProvenance
+	timer        *stageTimer
 }
 
 func NewAttributesQueue(log log.Logger, cfg *rollup.Config, builder AttributesBuilder, prev *BatchQueue) *AttributesQueue {
@@ -50,6 +52,8 @@ func (aq *AttributesQueue) Origin() eth.L1BlockRef {
 }
 
 func (aq *AttributesQueue) NextAttributes(ctx context.Context, parent eth.L2BlockRef) (*AttributesWithParent, error) {
+	defer aq.timer.start(StageAttributesQueue)()
+
 	// Get a batch if we need it
 	if aq.batch == nil {
 		batch, isLastInSpan, err := aq.prev.NextBatch(ctx, parent)
@@ -58,6 +62,7 @@ func (aq *AttributesQueue) NextAttributes(ctx context.Context, parent eth.L2Bloc
 		}
 		aq.batch = batch
 		aq.isLastInSpan = isLastInSpan
+		aq.provenance = aq.prev.LastBatchProvenance()
 	}
 
 	// Actually generate the next attributes
@@ -65,9 +70,10 @@ func (aq *AttributesQueue) NextAttributes(ctx context.Context, parent eth.L2Bloc
 		return nil, err
 	} else {
 		// Clear out the local state once we will succeed
-		attr := AttributesWithParent{attrs, parent, aq.isLastInSpan}
+		attr := AttributesWithParent{attributes: attrs, parent: parent, isLastInSpan: aq.isLastInSpan, provenance: aq.provenance}
 		aq.batch = nil
 		aq.isLastInSpan = false
+		aq.provenance = nil
 		return &attr, nil
 	}
 
@@ -104,5 +110,6 @@ func (aq *AttributesQueue) createNextAttributes(ctx context.Context, batch *Sing
 func (aq *AttributesQueue) Reset(ctx context.Context, _ eth.L1BlockRef, _ eth.SystemConfig) error {
 	aq.batch = nil
 	aq.isLastInSpan = false // overwritten later, but set for consistency
+	aq.provenance = nil
 	return io.EOF
 }
@@ -32,6 +32,11 @@ type NextBatchProvider interface {
 	NextBatch(ctx context.Context) (Batch, error)
 }
 
+// BatchProvenanceProvider is implemented by stages that can tell where the last batch they returned came from.
+type BatchProvenanceProvider interface {
+	LastBatchProvenance() *eth.BatchProvenance
+}
+
 type SafeBlockFetcher interface {
 	L2BlockRefByNumber(context.Context, uint64) (eth.L2BlockRef, error)
 	PayloadByNumber(context.Context, uint64) (*eth.ExecutionPayloadEnvelope, error)
@@ -59,16 +64,23 @@ type BatchQueue struct {
 	// nextSpan is cached SingularBatches derived from SpanBatch
 	nextSpan []*SingularBatch
 
+	// provenance is the provenance of the batch that was last derived, nil if it was generated
+	provenance *eth.BatchProvenance
+
 	l2 SafeBlockFetcher
+
+	metrics Metrics
+	timer   *stageTimer
 }
 
 // NewBatchQueue creates a BatchQueue, which should be Reset(origin) before use.
-func NewBatchQueue(log log.Logger, cfg *rollup.Config, prev NextBatchProvider, l2 SafeBlockFetcher) *BatchQueue {
+func NewBatchQueue(log log.Logger, cfg *rollup.Config, prev NextBatchProvider, l2 SafeBlockFetcher, metrics Metrics) *BatchQueue {
 	return &BatchQueue{
-		log:    log,
-		config: cfg,
-		prev:   prev,
-		l2:     l2,
+		log:     log,
+		config:  cfg,
+		prev:    prev,
+		l2:      l2,
+		metrics: metrics,
 	}
 }
 
@@ -93,6 +105,8 @@ func (bq *BatchQueue) popNextBatch(parent eth.L2BlockRef) *SingularBatch {
 // NextBatch return next valid batch upon the given safe head.
 // It also returns the boolean that indicates if the batch is the last block in the batch.
 func (bq *BatchQueue) NextBatch(ctx context.Context, parent eth.L2BlockRef) (*SingularBatch, bool, error) {
+	defer bq.timer.start(StageBatchQueue)()
+
 	if len(bq.nextSpan) > 0 {
 		// There are cached singular batches derived from the span batch.
 		// Check if the next cached batch matches the given parent block.
@@ -217,9 +231,16 @@ func (bq *BatchQueue) Reset(ctx context.Context, base eth.L1BlockRef, _ eth.Syst
 	bq.l1Blocks = bq.l1Blocks[:0]
 	bq.l1Blocks = append(bq.l1Blocks, base)
 	bq.nextSpan = bq.nextSpan[:0]
+	bq.provenance = nil
 	return io.EOF
 }
 
+// LastBatchProvenance returns the L1 batcher transactions of the batch that was last returned by NextBatch.
+// It is nil if the batch was generated because the sequencing window expired.
+func (bq *BatchQueue) LastBatchProvenance() *eth.BatchProvenance {
+	return bq.provenance
+}
+
 func (bq *BatchQueue) AddBatch(ctx context.Context, batch Batch, parent eth.L2BlockRef) {
 	if len(bq.l1Blocks) == 0 {
 		panic(fmt.Errorf("cannot add batch with timestamp %d, no origin was prepared", batch.GetTimestamp()))
@@ -228,8 +249,12 @@ func (bq *BatchQueue) AddBatch(ctx context.Context, batch Batch, parent eth.L2Bl
 		L1InclusionBlock: bq.origin,
 		Batch:            batch,
 	}
-	validity := CheckBatch(ctx, bq.config, bq.log, bq.l1Blocks, parent, &data, bq.l2)
+	if src, ok := bq.prev.(BatchProvenanceProvider); ok {
+		data.Provenance = src.LastBatchProvenance()
+	}
+	validity, reason := checkBatch(ctx, bq.config, bq.log, bq.l1Blocks, parent, &data, bq.l2)
 	if validity == BatchDrop {
+		bq.metrics.RecordBatchValidity(validity.String(), reason)
 		return // if we do drop the batch, CheckBatch will log the drop reason with WARN level.
 	}
 	batch.LogContext(bq.log).Debug("Adding batch")
@@ -265,7 +290,7 @@ func (bq *BatchQueue) deriveNextBatch(ctx context.Context, outOfData bool, paren
 	var remaining []*BatchWithL1InclusionBlock
 batchLoop:
 	for i, batch := range bq.batches {
-		validity := CheckBatch(ctx, bq.config, bq.log.New("batch_index", i), bq.l1Blocks, parent, batch, bq.l2)
+		validity, reason := checkBatch(ctx, bq.config, bq.log.New("batch_index", i), bq.l1Blocks, parent, batch, bq.l2)
 		switch validity {
 		case BatchFuture:
 			remaining = append(remaining, batch)
@@ -274,9 +299,13 @@ batchLoop:
 			batch.Batch.LogContext(bq.log).Warn("Dropping batch",
 				"parent", parent.ID(),
 				"parent_time", parent.Time,
+				"reason", reason,
 			)
+			bq.metrics.RecordBatchValidity(validity.String(), reason)
 			continue
 		case BatchAccept:
+			// batches are recorded once they are accepted or dropped, not at every check while they are pending.
+			bq.metrics.RecordBatchValidity(validity.String(), reason)
 			nextBatch = batch
 			// don't keep the current batch in the remaining items since we are processing it now,
 			// but retain every batch we didn't get to yet.
@@ -294,6 +323,7 @@ batchLoop:
 
 	if nextBatch != nil {
 		nextBatch.Batch.LogContext(bq.log).Info("Found next batch")
+		bq.provenance = nextBatch.Provenance
 		return nextBatch.Batch, nil
 	}
 
@@ -323,6 +353,7 @@ batchLoop:
 	// batch to ensure that we at least have one batch per epoch.
 	if nextTimestamp < nextEpoch.Time || firstOfEpoch {
 		bq.log.Info("Generating next batch", "epoch", epoch, "timestamp", nextTimestamp)
+		bq.provenance = nil
 		return &SingularBatch{
 			ParentHash:   parent.Hash,
 			EpochNum:     rollup.Epoch(epoch.Number),
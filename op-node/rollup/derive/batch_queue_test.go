@@ -15,6 +15,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
@@ -193,7 +194,7 @@ func BatchQueueNewOrigin(t *testing.T, batchType int) {
 		origin:  l1[0],
 	}
 
-	bq := NewBatchQueue(log, cfg, input, nil)
+	bq := NewBatchQueue(log, cfg, input, nil, metrics.NoopMetrics)
 	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
 	require.Equal(t, []eth.L1BlockRef{l1[0]}, bq.l1Blocks)
 
@@ -282,7 +283,7 @@ func BatchQueueEager(t *testing.T, batchType int) {
 		origin:  l1[0],
 	}
 
-	bq := NewBatchQueue(log, cfg, input, nil)
+	bq := NewBatchQueue(log, cfg, input, nil, metrics.NoopMetrics)
 	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
 	// Advance the origin
 	input.origin = l1[1]
@@ -360,7 +361,7 @@ func BatchQueueInvalidInternalAdvance(t *testing.T, batchType int) {
 		origin:  l1[0],
 	}
 
-	bq := NewBatchQueue(log, cfg, input, nil)
+	bq := NewBatchQueue(log, cfg, input, nil, metrics.NoopMetrics)
 	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
 
 	// Load continuous batches for epoch 0
@@ -475,7 +476,7 @@ func BatchQueueMissing(t *testing.T, batchType int) {
 		origin:  l1[0],
 	}
 
-	bq := NewBatchQueue(log, cfg, input, nil)
+	bq := NewBatchQueue(log, cfg, input, nil, metrics.NoopMetrics)
 	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
 
 	for i := 0; i < len(expectedOutputBatches); i++ {
@@ -602,7 +603,7 @@ func BatchQueueAdvancedEpoch(t *testing.T, batchType int) {
 		origin:  l1[inputOriginNumber],
 	}
 
-	bq := NewBatchQueue(log, cfg, input, nil)
+	bq := NewBatchQueue(log, cfg, input, nil, metrics.NoopMetrics)
 	_ = bq.Reset(context.Background(), l1[1], eth.SystemConfig{})
 
 	for i := 0; i < len(expectedOutputBatches); i++ {
@@ -693,7 +694,7 @@ func BatchQueueShuffle(t *testing.T, batchType int) {
 		origin:  l1[inputOriginNumber],
 	}
 
-	bq := NewBatchQueue(log, cfg, input, nil)
+	bq := NewBatchQueue(log, cfg, input, nil, metrics.NoopMetrics)
 	_ = bq.Reset(context.Background(), l1[1], eth.SystemConfig{})
 
 	for i := 0; i < len(expectedOutputBatches); i++ {
@@ -808,7 +809,7 @@ func TestBatchQueueOverlappingSpanBatch(t *testing.T) {
 		}
 	}
 
-	bq := NewBatchQueue(log, cfg, input, &l2Client)
+	bq := NewBatchQueue(log, cfg, input, &l2Client, metrics.NoopMetrics)
 	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
 	// Advance the origin
 	input.origin = l1[1]
@@ -914,7 +915,7 @@ func TestBatchQueueComplex(t *testing.T) {
 		}
 	}
 
-	bq := NewBatchQueue(log, cfg, input, &l2Client)
+	bq := NewBatchQueue(log, cfg, input, &l2Client, metrics.NoopMetrics)
 	_ = bq.Reset(context.Background(), l1[1], eth.SystemConfig{})
 
 	for i := 0; i < len(expectedOutputBatches); i++ {
@@ -984,7 +985,7 @@ func TestBatchQueueResetSpan(t *testing.T) {
 		origin:  l1[2],
 	}
 	l2Client := testutils.MockL2Client{}
-	bq := NewBatchQueue(log, cfg, input, &l2Client)
+	bq := NewBatchQueue(log, cfg, input, &l2Client, metrics.NoopMetrics)
 	bq.l1Blocks = l1 // Set enough l1 blocks to derive span batch
 
 	// This NextBatch() will derive the span batch, return the first singular batch and save rest of batches in span.
@@ -1014,3 +1015,56 @@ func TestBatchQueueResetSpan(t *testing.T) {
 	require.ErrorIs(t, err, io.EOF)
 	require.Equal(t, len(bq.nextSpan), 0)
 }
+
+func TestBatchQueueValidityMetrics(t *testing.T) {
+	log := testlog.Logger(t, log.LevelCrit)
+	l1 := L1Chain([]uint64{10, 20, 30})
+	chainId := big.NewInt(1234)
+	safeHead := eth.L2BlockRef{
+		Hash:     mockHash(10, 2),
+		Time:     10,
+		L1Origin: l1[0].ID(),
+	}
+	cfg := &rollup.Config{
+		Genesis: rollup.Genesis{
+			L2Time: 10,
+		},
+		BlockTime:         2,
+		MaxSequencerDrift: 600,
+		SeqWindowSize:     30,
+		L2ChainID:         chainId,
+	}
+
+	input := &fakeBatchQueueInput{
+		// the second batch is a copy of the first one, and is dropped once the first one is applied.
+		// the third batch skips a block, and is kept for the future without being recorded.
+		batches: []Batch{b(chainId, 12, l1[0]), b(chainId, 12, l1[0]), b(chainId, 16, l1[0]), nil},
+		errors:  []error{nil, nil, nil, io.EOF},
+		origin:  l1[0],
+	}
+	var validities []string
+	m := &testutils.TestDerivationMetrics{
+		FnRecordBatchValidity: func(validity string, reason string) {
+			validities = append(validities, validity+":"+reason)
+		},
+	}
+
+	bq := NewBatchQueue(log, cfg, input, nil, m)
+	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
+	input.origin = l1[1]
+
+	batch, _, err := bq.NextBatch(context.Background(), safeHead)
+	require.NoError(t, err)
+	require.Equal(t, uint64(12), batch.Timestamp)
+	require.Equal(t, []string{"accept:"}, validities)
+	safeHead.Number += 1
+	safeHead.Time += cfg.BlockTime
+	safeHead.Hash = mockHash(batch.Timestamp, 2)
+
+	for i := 0; i < 2; i++ {
+		_, _, err = bq.NextBatch(context.Background(), safeHead)
+		require.ErrorIs(t, err, NotEnoughData)
+	}
+	// the future batch is not recorded while it is pending, only the dropped copy of the applied batch is
+	require.Equal(t, []string{"accept:", "drop:" + dropReasonOldTimestamp}, validities)
+}
@@ -18,6 +18,8 @@ type blobOrCalldata struct {
 	// union type. exactly one of calldata or blob should be non-nil
 	blob     *eth.Blob
 	calldata *eth.Data
+	// ref is the batcher transaction, and blob if any, of the data
+	ref L1DataRef
 }
 
 // BlobDataSource fetches blobs or calldata as appropriate and transforms them into usable rollup
@@ -30,6 +32,7 @@ type BlobDataSource struct {
 	fetcher      L1TransactionFetcher
 	blobsFetcher L1BlobsFetcher
 	log          log.Logger
+	last         L1DataRef
 }
 
 // NewBlobDataSource creates a new blob data source.
@@ -61,6 +64,7 @@ func (ds *BlobDataSource) Next(ctx context.Context) (eth.Data, error) {
 
 	next := ds.data[0]
 	ds.data = ds.data[1:]
+	ds.last = next.ref
 	if next.calldata != nil {
 		return *next.calldata, nil
 	}
@@ -73,6 +77,11 @@ func (ds *BlobDataSource) Next(ctx context.Context) (eth.Data, error) {
 	return data, nil
 }
 
+// LastL1DataRef returns the batcher transaction and blob of the data that was last returned by Next.
+func (ds *BlobDataSource) LastL1DataRef() L1DataRef {
+	return ds.last
+}
+
 // open fetches and returns the blob or calldata (as appropriate) from all valid batcher
 // transactions in the referenced block. Returns an empty (non-nil) array if no batcher
 // transactions are found. It returns ResetError if it cannot find the referenced block or a
@@ -128,7 +137,7 @@ func dataAndHashesFromTxs(txs types.Transactions, config *DataSourceConfig, batc
 		// handle non-blob batcher transactions by extracting their calldata
 		if tx.Type() != types.BlobTxType {
 			calldata := eth.Data(tx.Data())
-			data = append(data, blobOrCalldata{nil, &calldata})
+			data = append(data, blobOrCalldata{calldata: &calldata, ref: L1DataRef{TxHash: tx.Hash()}})
 			continue
 		}
 		// handle blob batcher transactions by extracting their blob hashes, ignoring any calldata.
@@ -141,7 +150,9 @@ func dataAndHashesFromTxs(txs types.Transactions, config *DataSourceConfig, batc
 				Hash:  h,
 			}
 			hashes = append(hashes, idh)
-			data = append(data, blobOrCalldata{nil, nil}) // will fill in blob pointers after we download them below
+			blobHash := h
+			// will fill in blob pointers after we download them below
+			data = append(data, blobOrCalldata{ref: L1DataRef{TxHash: tx.Hash(), BlobHash: &blobHash}})
 			blobIndex += 1
 		}
 	}
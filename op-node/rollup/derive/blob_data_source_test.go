@@ -45,6 +45,7 @@ func TestDataAndHashesFromTxs(t *testing.T) {
 	data, blobHashes := dataAndHashesFromTxs(txs, &config, batcherAddr)
 	require.Equal(t, 1, len(data))
 	require.Equal(t, 0, len(blobHashes))
+	require.Equal(t, L1DataRef{TxHash: calldataTx.Hash()}, data[0].ref)
 
 	// create a valid blob batcher tx and make sure it's picked up
 	blobHash := testutils.RandomHash(rng)
@@ -61,6 +62,7 @@ func TestDataAndHashesFromTxs(t *testing.T) {
 	require.Equal(t, 1, len(data))
 	require.Equal(t, 1, len(blobHashes))
 	require.Nil(t, data[0].calldata)
+	require.Equal(t, L1DataRef{TxHash: blobTx.Hash(), BlobHash: &blobHash}, data[0].ref)
 
 	// try again with both the blob & calldata transactions and make sure both are picked up
 	txs = types.Transactions{blobTx, calldataTx}
@@ -19,8 +19,10 @@ import (
 // at a later point.
 type CalldataSource struct {
 	// Internal state + data
-	open bool
-	data []eth.Data
+	open     bool
+	data     []eth.Data
+	txHashes []common.Hash
+	last     L1DataRef
 	// Required to re-attempt fetching
 	ref     eth.L1BlockRef
 	dsCfg   DataSourceConfig
@@ -44,9 +46,11 @@ func NewCalldataSource(ctx context.Context, log log.Logger, dsCfg DataSourceConf
 			batcherAddr: batcherAddr,
 		}
 	}
+	data, txHashes := batcherDataFromEVMTransactions(dsCfg, batcherAddr, txs)
 	return &CalldataSource{
-		open: true,
-		data: DataFromEVMTransactions(dsCfg, batcherAddr, txs, log.New("origin", ref)),
+		open:     true,
+		data:     data,
+		txHashes: txHashes,
 	}
 }
 
@@ -57,7 +61,7 @@ func (ds *CalldataSource) Next(ctx context.Context) (eth.Data, error) {
 	if !ds.open {
 		if _, txs, err := ds.fetcher.InfoAndTxsByHash(ctx, ds.ref.Hash); err == nil {
 			ds.open = true
-			ds.data = DataFromEVMTransactions(ds.dsCfg, ds.batcherAddr, txs, ds.log)
+			ds.data, ds.txHashes = batcherDataFromEVMTransactions(ds.dsCfg, ds.batcherAddr, txs)
 		} else if errors.Is(err, ethereum.NotFound) {
 			return nil, NewResetError(fmt.Errorf("failed to open calldata source: %w", err))
 		} else {
@@ -68,20 +72,34 @@ func (ds *CalldataSource) Next(ctx context.Context) (eth.Data, error) {
 		return nil, io.EOF
 	} else {
 		data := ds.data[0]
-		ds.data = ds.data[1:]
+		ds.last = L1DataRef{TxHash: ds.txHashes[0]}
+		ds.data, ds.txHashes = ds.data[1:], ds.txHashes[1:]
 		return data, nil
 	}
 }
 
+// LastL1DataRef returns the batcher transaction of the data that was last returned by Next.
+func (ds *CalldataSource) LastL1DataRef() L1DataRef {
+	return ds.last
+}
+
 // DataFromEVMTransactions filters all of the transactions and returns the calldata from transactions
 // that are sent to the batch inbox address from the batch sender address.
 // This will return an empty array if no valid transactions are found.
 func DataFromEVMTransactions(dsCfg DataSourceConfig, batcherAddr common.Address, txs types.Transactions, log log.Logger) []eth.Data {
+	out, _ := batcherDataFromEVMTransactions(dsCfg, batcherAddr, txs)
+	return out
+}
+
+// batcherDataFromEVMTransactions is like DataFromEVMTransactions, but also returns the hash of the transaction of each data.
+func batcherDataFromEVMTransactions(dsCfg DataSourceConfig, batcherAddr common.Address, txs types.Transactions) ([]eth.Data, []common.Hash) {
 	out := []eth.Data{}
+	var txHashes []common.Hash
 	for _, tx := range txs {
 		if isValidBatchTx(tx, dsCfg.l1Signer, dsCfg.batchInboxAddress, batcherAddr) {
 			out = append(out, tx.Data())
+			txHashes = append(txHashes, tx.Hash())
 		}
 	}
-	return out
+	return out, txHashes
 }
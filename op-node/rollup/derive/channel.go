@@ -5,6 +5,7 @@ import (
 	"compress/zlib"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum/go-ethereum/rlp"
@@ -36,6 +37,9 @@ type Channel struct {
 	inputs map[uint64]Frame
 
 	highestL1InclusionBlock eth.L1BlockRef
+
+	// firstSeen is the local time the first frame of the channel was seen, to time the lifecycle of the channel
+	firstSeen time.Time
 }
 
 func NewChannel(id ChannelID, openBlock eth.L1BlockRef) *Channel {
@@ -43,6 +47,7 @@ func NewChannel(id ChannelID, openBlock eth.L1BlockRef) *Channel {
 		id:        id,
 		inputs:    make(map[uint64]Frame),
 		openBlock: openBlock,
+		firstSeen: time.Now(),
 	}
 }
 
@@ -142,6 +147,24 @@ func (ch *Channel) Reader() io.Reader {
 	return io.MultiReader(readers...)
 }
 
+// Provenance returns the L1 batcher transactions of the frames of the channel, in frame order.
+// This panics if it is called while `IsReady` is not true.
+func (ch *Channel) Provenance() *eth.BatchProvenance {
+	frames := make([]eth.FrameProvenance, 0, len(ch.inputs))
+	for i := uint64(0); i <= uint64(ch.endFrameNumber); i++ {
+		frame, ok := ch.inputs[i]
+		if !ok {
+			panic("dev error in channel.Provenance. Must be called after the channel is ready.")
+		}
+		frames = append(frames, eth.FrameProvenance{
+			FrameNumber: frame.FrameNumber,
+			TxHash:      frame.L1Data.TxHash,
+			BlobHash:    frame.L1Data.BlobHash,
+		})
+	}
+	return &eth.BatchProvenance{ChannelID: append([]byte(nil), ch.id[:]...), Frames: frames}
+}
+
 // BatchReader provides a function that iteratively consumes batches from the reader.
 // The L1Inclusion block is also provided at creation time.
 // Warning: the batch reader can read every batch-type.
@@ -3,6 +3,7 @@ package derive
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -12,6 +13,15 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
+// Events in the lifecycle of a channel in the channel bank, as recorded in the metrics
+// with the time since the first frame of the channel was seen.
+const (
+	ChannelEventReady    = "ready"
+	ChannelEventRead     = "read"
+	ChannelEventPruned   = "pruned"
+	ChannelEventTimedOut = "timed_out"
+)
+
 type NextFrameProvider interface {
 	NextFrame(ctx context.Context) (Frame, error)
 	Origin() eth.L1BlockRef
@@ -39,6 +49,11 @@ type ChannelBank struct {
 
 	prev    NextFrameProvider
 	fetcher L1Fetcher
+
+	// lastReadProvenance is the provenance of the channel that was last read
+	lastReadProvenance *eth.BatchProvenance
+
+	timer *stageTimer
 }
 
 var _ ResettableStage = (*ChannelBank)(nil)
@@ -73,6 +88,7 @@ func (cb *ChannelBank) prune() {
 		cb.channelQueue = cb.channelQueue[1:]
 		delete(cb.channels, id)
 		cb.log.Info("pruning channel", "channel", id, "totalSize", totalSize, "channel_size", ch.size, "remaining_channel_count", len(cb.channels))
+		cb.metrics.RecordChannelLifecycle(ChannelEventPruned, time.Since(ch.firstSeen))
 		totalSize -= ch.size
 	}
 }
@@ -109,6 +125,9 @@ func (cb *ChannelBank) IngestFrame(f Frame) {
 		return
 	}
 	cb.metrics.RecordFrame()
+	if currentCh.IsReady() {
+		cb.metrics.RecordChannelLifecycle(ChannelEventReady, time.Since(currentCh.firstSeen))
+	}
 
 	// Prune after the frame is loaded.
 	cb.prune()
@@ -129,6 +148,7 @@ func (cb *ChannelBank) Read() (data []byte, err error) {
 	if timedOut {
 		cb.log.Info("channel timed out", "channel", first, "frames", len(ch.inputs))
 		cb.metrics.RecordChannelTimedOut()
+		cb.metrics.RecordChannelLifecycle(ChannelEventTimedOut, time.Since(ch.firstSeen))
 		delete(cb.channels, first)
 		cb.channelQueue = cb.channelQueue[1:]
 		return nil, nil // multiple different channels may all be timed out
@@ -166,6 +186,8 @@ func (cb *ChannelBank) tryReadChannelAtIndex(i int) (data []byte, err error) {
 	delete(cb.channels, chanID)
 	cb.channelQueue = slices.Delete(cb.channelQueue, i, i+1)
 	cb.metrics.RecordHeadChannelOpened()
+	cb.metrics.RecordChannelLifecycle(ChannelEventRead, time.Since(ch.firstSeen))
+	cb.lastReadProvenance = ch.Provenance()
 	r := ch.Reader()
 	// Suppress error here. io.ReadAll does return nil instead of io.EOF though.
 	data, _ = io.ReadAll(r)
@@ -178,6 +200,8 @@ func (cb *ChannelBank) tryReadChannelAtIndex(i int) (data []byte, err error) {
 // consistency around channel bank pruning which depends upon the order
 // of operations.
 func (cb *ChannelBank) NextData(ctx context.Context) ([]byte, error) {
+	defer cb.timer.start(StageChannelBank)()
+
 	// Do the read from the channel bank first
 	data, err := cb.Read()
 	if err == io.EOF {
@@ -199,9 +223,15 @@ func (cb *ChannelBank) NextData(ctx context.Context) ([]byte, error) {
 	}
 }
 
+// LastReadProvenance returns the L1 batcher transactions of the frames of the channel that was last read.
+func (cb *ChannelBank) LastReadProvenance() *eth.BatchProvenance {
+	return cb.lastReadProvenance
+}
+
 func (cb *ChannelBank) Reset(ctx context.Context, base eth.L1BlockRef, _ eth.SystemConfig) error {
 	cb.channels = make(map[ChannelID]*Channel)
 	cb.channelQueue = make([]ChannelID, 0, 10)
+	cb.lastReadProvenance = nil
 	return io.EOF
 }
 
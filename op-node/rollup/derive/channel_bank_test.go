@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
@@ -130,6 +131,61 @@ func TestChannelBankSimple(t *testing.T) {
 	require.Equal(t, io.EOF, err)
 }
 
+func TestChannelBankLifecycle(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	a := testutils.RandomBlockRef(rng)
+
+	input := &fakeChannelBankInput{origin: a}
+	input.AddFrames("a:0:first", "b:0:deux!", "a:1:second!", "c:0:never")
+
+	var events []string
+	m := &testutils.TestDerivationMetrics{
+		FnRecordChannelLifecycle: func(event string, sinceFirstFrame time.Duration) {
+			events = append(events, event)
+		},
+	}
+	cfg := &rollup.Config{ChannelTimeout: 10, CanyonTime: nil}
+	cb := NewChannelBank(testlog.Logger(t, log.LevelCrit), cfg, input, nil, m)
+
+	// Load a:0, b:0 which completes channel b, and a:1 which completes channel a
+	for i := 0; i < 3; i++ {
+		_, err := cb.NextData(context.Background())
+		require.ErrorIs(t, err, NotEnoughData)
+	}
+	require.Equal(t, []string{ChannelEventReady, ChannelEventReady}, events)
+
+	// Read both channels
+	out, err := cb.NextData(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "firstsecond", string(out))
+	out, err = cb.NextData(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "deux", string(out))
+	require.Equal(t, []string{ChannelEventReady, ChannelEventReady, ChannelEventRead, ChannelEventRead}, events)
+
+	// Load c:0, which is dumped as the only buffered channel
+	_, err = cb.NextData(context.Background())
+	require.ErrorIs(t, err, NotEnoughData)
+	var dump PipelineDump
+	cb.dump(&dump)
+	require.Len(t, dump.ChannelBank.Channels, 1)
+	ch := dump.ChannelBank.Channels[0]
+	require.Equal(t, testFrame("c:0:never").ChannelID(), ch.ID)
+	require.Equal(t, a.ID(), ch.OpenBlock)
+	require.Equal(t, 1, ch.Frames)
+	require.False(t, ch.Closed)
+	require.False(t, ch.Ready)
+
+	// Channel c times out
+	input.origin.Number += cfg.ChannelTimeout + 1
+	out, err = cb.NextData(context.Background())
+	require.NoError(t, err)
+	require.Nil(t, out)
+	require.Equal(t, ChannelEventTimedOut, events[len(events)-1])
+	cb.dump(&dump)
+	require.Empty(t, dump.ChannelBank.Channels)
+}
+
 // TestChannelBankInterleavedPreCanyon ensure that the channel bank can handle frames from multiple channels
 // that arrive out of order. Per the specs, the first channel to arrive (not the first to be completed)
 // is returned first prior to the Canyon network upgrade
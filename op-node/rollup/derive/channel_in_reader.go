@@ -22,10 +22,14 @@ type ChannelInReader struct {
 	cfg *rollup.Config
 
 	nextBatchFn func() (*BatchData, error)
+	// provenance is the provenance of the channel that batches are read from
+	provenance *eth.BatchProvenance
 
 	prev *ChannelBank
 
 	metrics Metrics
+
+	timer *stageTimer
 }
 
 var _ ResettableStage = (*ChannelInReader)(nil)
@@ -66,6 +70,8 @@ func (cr *ChannelInReader) NextChannel() {
 // It returns io.EOF when it cannot make any more progress.
 // It will return a temporary error if it needs to be called again to advance some internal state.
 func (cr *ChannelInReader) NextBatch(ctx context.Context) (Batch, error) {
+	defer cr.timer.start(StageChannelInReader)()
+
 	if cr.nextBatchFn == nil {
 		if data, err := cr.prev.NextData(ctx); err == io.EOF {
 			return nil, io.EOF
@@ -75,6 +81,7 @@ func (cr *ChannelInReader) NextBatch(ctx context.Context) (Batch, error) {
 			if err := cr.WriteChannel(data); err != nil {
 				return nil, NewTemporaryError(err)
 			}
+			cr.provenance = cr.prev.LastReadProvenance()
 		}
 	}
 
@@ -118,7 +125,13 @@ func (cr *ChannelInReader) NextBatch(ctx context.Context) (Batch, error) {
 	}
 }
 
+// LastBatchProvenance returns the L1 batcher transactions of the channel of the batch that was last read.
+func (cr *ChannelInReader) LastBatchProvenance() *eth.BatchProvenance {
+	return cr.provenance
+}
+
 func (cr *ChannelInReader) Reset(ctx context.Context, _ eth.L1BlockRef, _ eth.SystemConfig) error {
 	cr.nextBatchFn = nil
+	cr.provenance = nil
 	return io.EOF
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 )
 
@@ -99,3 +100,23 @@ func TestFrameValidity(t *testing.T) {
 		t.Run(tc.name, tc.Run)
 	}
 }
+
+// TestChannelProvenance checks that the provenance of a channel lists the batcher transactions of its frames in frame order,
+// regardless of the order the frames were added in.
+func TestChannelProvenance(t *testing.T) {
+	id := ChannelID{0xff}
+	block := eth.L1BlockRef{}
+	ch := NewChannel(id, block)
+	blobHash := common.Hash{0xbb}
+	require.NoError(t, ch.AddFrame(Frame{ID: id, FrameNumber: 1, IsLast: true, L1Data: L1DataRef{TxHash: common.Hash{0x02}, BlobHash: &blobHash}}, block))
+	require.NoError(t, ch.AddFrame(Frame{ID: id, FrameNumber: 0, L1Data: L1DataRef{TxHash: common.Hash{0x01}}}, block))
+	require.True(t, ch.IsReady())
+
+	require.Equal(t, &eth.BatchProvenance{
+		ChannelID: id[:],
+		Frames: []eth.FrameProvenance{
+			{FrameNumber: 0, TxHash: common.Hash{0x01}},
+			{FrameNumber: 1, TxHash: common.Hash{0x02}, BlobHash: &blobHash},
+		},
+	}, ch.Provenance())
+}
@@ -17,6 +17,18 @@ type DataIter interface {
 	Next(ctx context.Context) (eth.Data, error)
 }
 
+// L1DataRef identifies the L1 batcher transaction, and blob if any, that carried a piece of data.
+type L1DataRef struct {
+	TxHash common.Hash
+	// BlobHash is the versioned hash of the blob, nil if the data was carried in calldata.
+	BlobHash *common.Hash
+}
+
+// L1DataRefProvider is implemented by data iterators that can tell where the last data they returned came from.
+type L1DataRefProvider interface {
+	LastL1DataRef() L1DataRef
+}
+
 type L1TransactionFetcher interface {
 	InfoAndTxsByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, types.Transactions, error)
 }
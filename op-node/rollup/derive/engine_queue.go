@@ -23,10 +23,12 @@ type AttributesWithParent struct {
 	attributes   *eth.PayloadAttributes
 	parent       eth.L2BlockRef
 	isLastInSpan bool
+	// provenance is the L1 batcher transactions of the batch of the attributes, nil if unknown
+	provenance *eth.BatchProvenance
 }
 
 func NewAttributesWithParent(attributes *eth.PayloadAttributes, parent eth.L2BlockRef, isLastInSpan bool) *AttributesWithParent {
-	return &AttributesWithParent{attributes, parent, isLastInSpan}
+	return &AttributesWithParent{attributes: attributes, parent: parent, isLastInSpan: isLastInSpan}
 }
 
 func (a *AttributesWithParent) Attributes() *eth.PayloadAttributes {
@@ -106,7 +108,9 @@ type SafeHeadListener interface {
 
 	// SafeHeadUpdated indicates that the safe head has been updated in response to processing batch data
 	// The l1Block specified is the first L1 block containing all required batch data to derive newSafeHead
-	SafeHeadUpdated(newSafeHead eth.L2BlockRef, l1Block eth.BlockID) error
+	// The provenance is the L1 batcher transactions of the batch of newSafeHead, nil if unknown,
+	// e.g. if the batch was generated because the sequencing window expired.
+	SafeHeadUpdated(newSafeHead eth.L2BlockRef, l1Block eth.BlockID, provenance *eth.BatchProvenance) error
 
 	// SafeHeadReset indicates that the derivation pipeline reset back to the specified safe head
 	// The L1 block that made the new safe head safe is unknown.
@@ -152,10 +156,10 @@ func calcFinalityLookback(cfg *rollup.Config) uint64 {
 
 type FinalityData struct {
 	// The last L2 block that was fully derived and inserted into the L2 engine while processing this L1 block.
-	L2Block eth.L2BlockRef
+	L2Block eth.L2BlockRef `json:"l2_block"`
 	// The L1 block this stage was at when inserting the L2 block.
 	// When this L1 block is finalized, the L2 chain up to this block can be fully reproduced from finalized L1 data.
-	L1Block eth.BlockID
+	L1Block eth.BlockID `json:"l1_block"`
 }
 
 // EngineQueue queues up payload attributes to consolidate or process with the provided Engine
@@ -192,6 +196,10 @@ type EngineQueue struct {
 
 	safeHeadNotifs       SafeHeadListener // notified when safe head is updated
 	lastNotifiedSafeHead eth.L2BlockRef
+	// safeHeadProvenance is the provenance of the batch of the safe head, passed on to the safe head listener
+	safeHeadProvenance *eth.BatchProvenance
+
+	timer *stageTimer
 }
 
 // NewEngineQueue creates a new EngineQueue, which should be Reset(origin) before use.
@@ -289,6 +297,8 @@ func (eq *EngineQueue) isEngineSyncing() bool {
 }
 
 func (eq *EngineQueue) Step(ctx context.Context) error {
+	defer eq.timer.start(StageEngineQueue)()
+
 	// If we don't need to call FCU to restore unsafeHead using backupUnsafe, keep going b/c
 	// this was a no-op(except correcting invalid state when backupUnsafe is empty but TryBackupUnsafeReorg called).
 	if fcuCalled, err := eq.ec.TryBackupUnsafeReorg(ctx); fcuCalled {
@@ -467,7 +477,7 @@ func (eq *EngineQueue) notifyNewSafeHead(safeHead eth.L2BlockRef) error {
 		// No change, no need to notify
 		return nil
 	}
-	if err := eq.safeHeadNotifs.SafeHeadUpdated(safeHead, eq.origin.ID()); err != nil {
+	if err := eq.safeHeadNotifs.SafeHeadUpdated(safeHead, eq.origin.ID(), eq.safeHeadProvenance); err != nil {
 		// At this point our state is in a potentially inconsistent state as we've updated the safe head
 		// in the execution client but failed to post process it. Reset the pipeline so the safe head rolls back
 		// a little (it always rolls back at least 1 block) and then it will retry storing the entry
@@ -595,6 +605,7 @@ func (eq *EngineQueue) consolidateNextSafeAttributes(ctx context.Context) error
 	eq.ec.SetPendingSafeL2Head(ref)
 	if eq.safeAttributes.isLastInSpan {
 		eq.ec.SetSafeHead(ref)
+		eq.safeHeadProvenance = eq.safeAttributes.provenance
 		if err := eq.postProcessSafeL2(); err != nil {
 			return err
 		}
@@ -613,6 +624,7 @@ func (eq *EngineQueue) forceNextSafeAttributes(ctx context.Context) error {
 	}
 	attrs := eq.safeAttributes.attributes
 	lastInSpan := eq.safeAttributes.isLastInSpan
+	provenance := eq.safeAttributes.provenance
 	errType, err := eq.StartPayload(ctx, eq.ec.PendingSafeL2Head(), eq.safeAttributes, true)
 	if err == nil {
 		_, errType, err = eq.ec.ConfirmPayload(ctx, async.NoOpGossiper{}, &conductor.NoOpConductor{})
@@ -661,6 +673,7 @@ func (eq *EngineQueue) forceNextSafeAttributes(ctx context.Context) error {
 	eq.safeAttributes = nil
 	eq.logSyncProgress("processed safe block derived from L1")
 	if lastInSpan {
+		eq.safeHeadProvenance = provenance
 		if err := eq.postProcessSafeL2(); err != nil {
 			return err
 		}
@@ -740,6 +753,7 @@ func (eq *EngineQueue) Reset(ctx context.Context, _ eth.L1BlockRef, _ eth.System
 	eq.origin = pipelineOrigin
 	eq.sysCfg = l1Cfg
 	eq.lastNotifiedSafeHead = safe
+	eq.safeHeadProvenance = nil
 	if err := eq.safeHeadNotifs.SafeHeadReset(safe); err != nil {
 		return err
 	}
@@ -753,7 +767,7 @@ func (eq *EngineQueue) Reset(ctx context.Context, _ eth.L1BlockRef, _ eth.System
 		if err != nil {
 			return fmt.Errorf("failed to retrieve L1 genesis: %w", err)
 		}
-		if err := eq.safeHeadNotifs.SafeHeadUpdated(safe, l1Genesis.ID()); err != nil {
+		if err := eq.safeHeadNotifs.SafeHeadUpdated(safe, l1Genesis.ID(), nil); err != nil {
 			return err
 		}
 	}
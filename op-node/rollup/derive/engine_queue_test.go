@@ -40,7 +40,7 @@ func (f *fakeAttributesQueue) NextAttributes(_ context.Context, safeHead eth.L2B
 	if f.attrs == nil {
 		return nil, io.EOF
 	}
-	return &AttributesWithParent{f.attrs, safeHead, f.islastInSpan}, nil
+	return &AttributesWithParent{attributes: f.attrs, parent: safeHead, isLastInSpan: f.islastInSpan}, nil
 }
 
 var _ NextAttributesProvider = (*fakeAttributesQueue)(nil)
@@ -28,6 +28,8 @@ type Frame struct {
 	FrameNumber uint16    `json:"frame_number"`
 	Data        []byte    `json:"data"`
 	IsLast      bool      `json:"is_last"`
+	// L1Data is the L1 batcher transaction the frame was read from, it is not part of the frame encoding.
+	L1Data L1DataRef `json:"-"`
 }
 
 // MarshalBinary writes the frame to `w`.
@@ -20,6 +20,7 @@ type FrameQueue struct {
 	log    log.Logger
 	frames []Frame
 	prev   NextDataProvider
+	timer  *stageTimer
 }
 
 func NewFrameQueue(log log.Logger, prev NextDataProvider) *FrameQueue {
@@ -34,12 +35,20 @@ func (fq *FrameQueue) Origin() eth.L1BlockRef {
 }
 
 func (fq *FrameQueue) NextFrame(ctx context.Context) (Frame, error) {
+	defer fq.timer.start(StageFrameQueue)()
+
 	// Find more frames if we need to
 	if len(fq.frames) == 0 {
 		if data, err := fq.prev.NextData(ctx); err != nil {
 			return Frame{}, err
 		} else {
 			if new, err := ParseFrames(data); err == nil {
+				if src, ok := fq.prev.(L1DataRefProvider); ok {
+					ref := src.LastL1DataRef()
+					for i := range new {
+						new[i].L1Data = ref
+					}
+				}
 				fq.frames = append(fq.frames, new...)
 			} else {
 				fq.log.Warn("Failed to parse frames", "origin", fq.prev.Origin(), "err", err)
@@ -27,6 +27,10 @@ type L1Retrieval struct {
 	prev    NextBlockProvider
 
 	datas DataIter
+	// last is the batcher transaction of the data that was last returned
+	last L1DataRef
+
+	timer *stageTimer
 }
 
 var _ ResettableStage = (*L1Retrieval)(nil)
@@ -47,6 +51,8 @@ func (l1r *L1Retrieval) Origin() eth.L1BlockRef {
 // If there is data, it pushes it to the next stage.
 // If there is no more data open ourselves if we are closed or close ourselves if we are open
 func (l1r *L1Retrieval) NextData(ctx context.Context) ([]byte, error) {
+	defer l1r.timer.start(StageL1Retrieval)()
+
 	if l1r.datas == nil {
 		next, err := l1r.prev.NextL1Block(ctx)
 		if err == io.EOF {
@@ -68,10 +74,19 @@ func (l1r *L1Retrieval) NextData(ctx context.Context) ([]byte, error) {
 		// CalldataSource appropriately wraps the error so avoid double wrapping errors here.
 		return nil, err
 	} else {
+		l1r.last = L1DataRef{}
+		if src, ok := l1r.datas.(L1DataRefProvider); ok {
+			l1r.last = src.LastL1DataRef()
+		}
 		return data, nil
 	}
 }
 
+// LastL1DataRef returns the batcher transaction of the data that was last returned by NextData.
+func (l1r *L1Retrieval) LastL1DataRef() L1DataRef {
+	return l1r.last
+}
+
 // Reset re-initializes the L1 Retrieval stage to block of it's `next` progress.
 // Note that we open up the `l1r.datas` here because it is required to maintain the
 // internal invariants that later propagate up the derivation pipeline.
@@ -29,6 +29,7 @@ type L1Traversal struct {
 	log      log.Logger
 	sysCfg   eth.SystemConfig
 	cfg      *rollup.Config
+	timer    *stageTimer
 }
 
 var _ ResettableStage = (*L1Traversal)(nil)
@@ -48,6 +49,7 @@ func (l1t *L1Traversal) Origin() eth.L1BlockRef {
 // NextL1Block returns the next block. It does not advance, but it can only be
 // called once before returning io.EOF
 func (l1t *L1Traversal) NextL1Block(_ context.Context) (eth.L1BlockRef, error) {
+	defer l1t.timer.start(StageL1Traversal)()
 	if !l1t.done {
 		l1t.done = true
 		return l1t.block, nil
@@ -58,6 +60,7 @@ func (l1t *L1Traversal) NextL1Block(_ context.Context) (eth.L1BlockRef, error) {
 
 // AdvanceL1Block advances the internal state of L1 Traversal
 func (l1t *L1Traversal) AdvanceL1Block(ctx context.Context) error {
+	defer l1t.timer.start(StageL1Traversal)()
 	origin := l1t.block
 	nextL1Origin, err := l1t.l1Blocks.L1BlockRefByNumber(ctx, origin.Number+1)
 	if errors.Is(err, ethereum.NotFound) {
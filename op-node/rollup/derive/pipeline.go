@@ -5,11 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
@@ -22,6 +24,9 @@ type Metrics interface {
 	RecordChannelTimedOut()
 	RecordFrame()
 	RecordDerivedBatches(batchType string)
+	RecordDerivationStageTime(stage string, d time.Duration)
+	RecordChannelLifecycle(event string, sinceFirstFrame time.Duration)
+	RecordBatchValidity(validity string, reason string)
 }
 
 type L1Fetcher interface {
@@ -78,13 +83,24 @@ func NewDerivationPipeline(log log.Logger, rollupCfg *rollup.Config, l1Fetcher L
 	frameQueue := NewFrameQueue(log, l1Src)
 	bank := NewChannelBank(log, rollupCfg, frameQueue, l1Fetcher, metrics)
 	chInReader := NewChannelInReader(rollupCfg, log, bank, metrics)
-	batchQueue := NewBatchQueue(log, rollupCfg, chInReader, l2Source)
+	batchQueue := NewBatchQueue(log, rollupCfg, chInReader, l2Source, metrics)
 	attrBuilder := NewFetchingAttributesBuilder(rollupCfg, l1Fetcher, l2Source)
 	attributesQueue := NewAttributesQueue(log, rollupCfg, attrBuilder, batchQueue)
 
 	// Step stages
 	eng := NewEngineQueue(log, rollupCfg, l2Source, engine, metrics, attributesQueue, l1Fetcher, syncCfg, safeHeadListener)
 
+	// Time the stages, excluding the time spent in the stages they pull from.
+	timer := newStageTimer(metrics, clock.SystemClock)
+	l1Traversal.timer = timer
+	l1Src.timer = timer
+	frameQueue.timer = timer
+	bank.timer = timer
+	chInReader.timer = timer
+	batchQueue.timer = timer
+	attributesQueue.timer = timer
+	eng.timer = timer
+
 	// Plasma takes control of the engine finalization signal only when usePlasma is enabled.
 	plasma.OnFinalizedHeadSignal(func(ref eth.L1BlockRef) {
 		eng.Finalize(ref)
@@ -151,6 +167,21 @@ func (dp *DerivationPipeline) LowestQueuedUnsafeBlock() eth.L2BlockRef {
 	return dp.eng.LowestQueuedUnsafeBlock()
 }
 
+// Dump returns a snapshot of the data buffered in each stage.
+// It must not be called concurrently with Step.
+func (dp *DerivationPipeline) Dump() *PipelineDump {
+	d := &PipelineDump{
+		Origin:    dp.Origin(),
+		Resetting: dp.resetting < len(dp.stages),
+	}
+	for _, stage := range dp.stages {
+		if s, ok := stage.(dumpableStage); ok {
+			s.dump(d)
+		}
+	}
+	return d
+}
+
 // Step tries to progress the buffer.
 // An EOF is returned if the pipeline is blocked by waiting for new L1 data.
 // If ctx errors no error is returned, but the step may exit early in a state that can still be continued.
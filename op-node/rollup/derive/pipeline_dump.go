@@ -0,0 +1,224 @@
+package derive
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// PipelineDump is a snapshot of the data buffered in each stage of the derivation pipeline,
+// to inspect where the derivation is held up.
+type PipelineDump struct {
+	Origin eth.L1BlockRef `json:"origin"`
+	// Resetting is true while the stages are being reset, the buffered data is inconsistent until then.
+	Resetting bool `json:"resetting"`
+
+	L1Traversal     *L1TraversalDump     `json:"l1_traversal,omitempty"`
+	L1Retrieval     *L1RetrievalDump     `json:"l1_retrieval,omitempty"`
+	FrameQueue      *FrameQueueDump      `json:"frame_queue,omitempty"`
+	ChannelBank     *ChannelBankDump     `json:"channel_bank,omitempty"`
+	ChannelInReader *ChannelInReaderDump `json:"channel_in_reader,omitempty"`
+	BatchQueue      *BatchQueueDump      `json:"batch_queue,omitempty"`
+	AttributesQueue *AttributesQueueDump `json:"attributes_queue,omitempty"`
+	EngineQueue     *EngineQueueDump     `json:"engine_queue,omitempty"`
+}
+
+type L1TraversalDump struct {
+	Block eth.L1BlockRef `json:"block"`
+	// Done is true once the block was passed to the next stage.
+	Done bool `json:"done"`
+}
+
+type L1RetrievalDump struct {
+	// Open is true while the data of an L1 block is being read.
+	Open bool `json:"open"`
+}
+
+type FrameQueueDump struct {
+	Frames []FrameDump `json:"frames"`
+}
+
+type FrameDump struct {
+	Channel     ChannelID `json:"channel"`
+	FrameNumber uint16    `json:"frame_number"`
+	Size        int       `json:"size"`
+	IsLast      bool      `json:"is_last"`
+}
+
+type ChannelBankDump struct {
+	// Channels are the buffered channels, in the order they are read.
+	Channels []ChannelDump `json:"channels"`
+}
+
+type ChannelDump struct {
+	ID                      ChannelID   `json:"id"`
+	OpenBlock               eth.BlockID `json:"open_block"`
+	HighestL1InclusionBlock eth.BlockID `json:"highest_l1_inclusion_block"`
+	Frames                  int         `json:"frames"`
+	Size                    uint64      `json:"size"`
+	Closed                  bool        `json:"closed"`
+	Ready                   bool        `json:"ready"`
+	FirstSeen               time.Time   `json:"first_seen"`
+}
+
+type ChannelInReaderDump struct {
+	// Reading is true while batches are being read from a channel.
+	Reading bool `json:"reading"`
+}
+
+type BatchQueueDump struct {
+	Origin   eth.L1BlockRef `json:"origin"`
+	L1Blocks []eth.BlockID  `json:"l1_blocks"`
+	// Batches are the buffered batches, in the order they were seen.
+	Batches []BatchDump `json:"batches"`
+	// NextSpan are the remaining singular batches of the span batch that is being applied.
+	NextSpan []SingularBatchDump `json:"next_span"`
+}
+
+type BatchDump struct {
+	Type             string      `json:"type"`
+	Timestamp        uint64      `json:"timestamp"`
+	L1InclusionBlock eth.BlockID `json:"l1_inclusion_block"`
+}
+
+type SingularBatchDump struct {
+	ParentHash   common.Hash `json:"parent_hash"`
+	Epoch        eth.BlockID `json:"epoch"`
+	Timestamp    uint64      `json:"timestamp"`
+	Transactions int         `json:"transactions"`
+}
+
+type AttributesQueueDump struct {
+	Batch        *SingularBatchDump `json:"batch,omitempty"`
+	IsLastInSpan bool               `json:"is_last_in_span"`
+}
+
+type EngineQueueDump struct {
+	SafeAttributes        *SafeAttributesDump `json:"safe_attributes,omitempty"`
+	UnsafePayloads        int                 `json:"unsafe_payloads"`
+	UnsafePayloadsMemSize uint64              `json:"unsafe_payloads_mem_size"`
+	LowestUnsafePayload   *eth.BlockID        `json:"lowest_unsafe_payload,omitempty"`
+	FinalityData          []FinalityData      `json:"finality_data"`
+}
+
+type SafeAttributesDump struct {
+	Parent       eth.L2BlockRef `json:"parent"`
+	Timestamp    uint64         `json:"timestamp"`
+	Transactions int            `json:"transactions"`
+	IsLastInSpan bool           `json:"is_last_in_span"`
+}
+
+// dumpableStage is a stage that can add the data it buffers to a PipelineDump.
+type dumpableStage interface {
+	dump(d *PipelineDump)
+}
+
+func batchTypeName(batchType int) string {
+	switch batchType {
+	case SingularBatchType:
+		return "singular"
+	case SpanBatchType:
+		return "span"
+	default:
+		return "unknown"
+	}
+}
+
+func dumpSingularBatch(b *SingularBatch) SingularBatchDump {
+	return SingularBatchDump{
+		ParentHash:   b.ParentHash,
+		Epoch:        b.Epoch(),
+		Timestamp:    b.Timestamp,
+		Transactions: len(b.Transactions),
+	}
+}
+
+func (l1t *L1Traversal) dump(d *PipelineDump) {
+	d.L1Traversal = &L1TraversalDump{Block: l1t.block, Done: l1t.done}
+}
+
+func (l1r *L1Retrieval) dump(d *PipelineDump) {
+	d.L1Retrieval = &L1RetrievalDump{Open: l1r.datas != nil}
+}
+
+func (fq *FrameQueue) dump(d *PipelineDump) {
+	frames := make([]FrameDump, 0, len(fq.frames))
+	for _, f := range fq.frames {
+		frames = append(frames, FrameDump{Channel: f.ID, FrameNumber: f.FrameNumber, Size: len(f.Data), IsLast: f.IsLast})
+	}
+	d.FrameQueue = &FrameQueueDump{Frames: frames}
+}
+
+func (cb *ChannelBank) dump(d *PipelineDump) {
+	channels := make([]ChannelDump, 0, len(cb.channelQueue))
+	for _, id := range cb.channelQueue {
+		ch := cb.channels[id]
+		channels = append(channels, ChannelDump{
+			ID:                      id,
+			OpenBlock:               ch.openBlock.ID(),
+			HighestL1InclusionBlock: ch.highestL1InclusionBlock.ID(),
+			Frames:                  len(ch.inputs),
+			Size:                    ch.size,
+			Closed:                  ch.closed,
+			Ready:                   ch.IsReady(),
+			FirstSeen:               ch.firstSeen,
+		})
+	}
+	d.ChannelBank = &ChannelBankDump{Channels: channels}
+}
+
+func (cr *ChannelInReader) dump(d *PipelineDump) {
+	d.ChannelInReader = &ChannelInReaderDump{Reading: cr.nextBatchFn != nil}
+}
+
+func (bq *BatchQueue) dump(d *PipelineDump) {
+	l1Blocks := make([]eth.BlockID, 0, len(bq.l1Blocks))
+	for _, b := range bq.l1Blocks {
+		l1Blocks = append(l1Blocks, b.ID())
+	}
+	batches := make([]BatchDump, 0, len(bq.batches))
+	for _, b := range bq.batches {
+		batches = append(batches, BatchDump{
+			Type:             batchTypeName(b.Batch.GetBatchType()),
+			Timestamp:        b.Batch.GetTimestamp(),
+			L1InclusionBlock: b.L1InclusionBlock.ID(),
+		})
+	}
+	nextSpan := make([]SingularBatchDump, 0, len(bq.nextSpan))
+	for _, b := range bq.nextSpan {
+		nextSpan = append(nextSpan, dumpSingularBatch(b))
+	}
+	d.BatchQueue = &BatchQueueDump{Origin: bq.origin, L1Blocks: l1Blocks, Batches: batches, NextSpan: nextSpan}
+}
+
+func (aq *AttributesQueue) dump(d *PipelineDump) {
+	dump := &AttributesQueueDump{IsLastInSpan: aq.isLastInSpan}
+	if aq.batch != nil {
+		batch := dumpSingularBatch(aq.batch)
+		dump.Batch = &batch
+	}
+	d.AttributesQueue = dump
+}
+
+func (eq *EngineQueue) dump(d *PipelineDump) {
+	dump := &EngineQueueDump{
+		UnsafePayloads:        eq.unsafePayloads.Len(),
+		UnsafePayloadsMemSize: eq.unsafePayloads.MemSize(),
+		FinalityData:          append([]FinalityData(nil), eq.finalityData...),
+	}
+	if attrs := eq.safeAttributes; attrs != nil {
+		dump.SafeAttributes = &SafeAttributesDump{
+			Parent:       attrs.parent,
+			Timestamp:    uint64(attrs.attributes.Timestamp),
+			Transactions: len(attrs.attributes.Transactions),
+			IsLastInSpan: attrs.isLastInSpan,
+		}
+	}
+	if first := eq.unsafePayloads.Peek(); first != nil {
+		id := first.ExecutionPayload.ID()
+		dump.LowestUnsafePayload = &id
+	}
+	d.EngineQueue = dump
+}
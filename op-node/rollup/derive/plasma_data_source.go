@@ -100,3 +100,11 @@ func (s *PlasmaDataSource) Next(ctx context.Context) (eth.Data, error) {
 	s.comm = nil
 	return data, nil
 }
+
+// LastL1DataRef returns the batcher transaction of the commitment of the data that was last returned by Next.
+func (s *PlasmaDataSource) LastL1DataRef() L1DataRef {
+	if src, ok := s.src.(L1DataRefProvider); ok {
+		return src.LastL1DataRef()
+	}
+	return L1DataRef{}
+}
@@ -0,0 +1,58 @@
+package derive
+
+import (
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+// Names of the stages of the derivation pipeline, as recorded in the metrics.
+const (
+	StageL1Traversal     = "l1_traversal"
+	StageL1Retrieval     = "l1_retrieval"
+	StageFrameQueue      = "frame_queue"
+	StageChannelBank     = "channel_bank"
+	StageChannelInReader = "channel_in_reader"
+	StageBatchQueue      = "batch_queue"
+	StageAttributesQueue = "attributes_queue"
+	StageEngineQueue     = "engine_queue"
+)
+
+// stageTimer measures the time spent in each stage of the derivation pipeline.
+// The stages pull data from the previous stage, so the time recorded for a stage excludes
+// the time spent in the stages it pulled from. This makes the slowest stage stand out.
+// A nil stageTimer records nothing, for stages that are used outside of a pipeline.
+type stageTimer struct {
+	metrics Metrics
+	clock   clock.Clock
+	// spans of the stages that are being stepped, the innermost stage last.
+	spans []stageSpan
+}
+
+type stageSpan struct {
+	stage  string
+	start  time.Time
+	pulled time.Duration // time spent in the previous stages
+}
+
+func newStageTimer(m Metrics, clk clock.Clock) *stageTimer {
+	return &stageTimer{metrics: m, clock: clk}
+}
+
+// start starts timing the stage, and returns the function to call when the stage returns.
+// It must only be used by stages that are stepped from a single goroutine.
+func (t *stageTimer) start(stage string) (end func()) {
+	if t == nil {
+		return func() {}
+	}
+	t.spans = append(t.spans, stageSpan{stage: stage, start: t.clock.Now()})
+	return func() {
+		span := t.spans[len(t.spans)-1]
+		t.spans = t.spans[:len(t.spans)-1]
+		total := t.clock.Since(span.start)
+		if n := len(t.spans); n > 0 {
+			t.spans[n-1].pulled += total
+		}
+		t.metrics.RecordDerivationStageTime(span.stage, total-span.pulled)
+	}
+}
@@ -0,0 +1,50 @@
+package derive
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type stageTimeMetrics struct {
+	testutils.TestDerivationMetrics
+	times map[string][]time.Duration
+}
+
+func (m *stageTimeMetrics) RecordDerivationStageTime(stage string, d time.Duration) {
+	m.times[stage] = append(m.times[stage], d)
+}
+
+func TestStageTimer(t *testing.T) {
+	m := &stageTimeMetrics{times: make(map[string][]time.Duration)}
+	clk := clock.NewDeterministicClock(time.Unix(1000, 0))
+	timer := newStageTimer(m, clk)
+
+	endOuter := timer.start(StageBatchQueue)
+	clk.AdvanceTime(1 * time.Second)
+	for i := 0; i < 2; i++ {
+		endInner := timer.start(StageChannelBank)
+		clk.AdvanceTime(2 * time.Second)
+		endInnermost := timer.start(StageFrameQueue)
+		clk.AdvanceTime(3 * time.Second)
+		endInnermost()
+		endInner()
+	}
+	clk.AdvanceTime(4 * time.Second)
+	endOuter()
+
+	// the time of a stage excludes the time spent in the stages it pulled from
+	require.Equal(t, map[string][]time.Duration{
+		StageFrameQueue:  {3 * time.Second, 3 * time.Second},
+		StageChannelBank: {2 * time.Second, 2 * time.Second},
+		StageBatchQueue:  {5 * time.Second},
+	}, m.times)
+
+	// a stage outside of a pipeline is not timed
+	var noTimer *stageTimer
+	noTimer.start(StageBatchQueue)()
+}
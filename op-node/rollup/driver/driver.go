@@ -30,6 +30,9 @@ type Metrics interface {
 	RecordFrame()
 
 	RecordDerivedBatches(batchType string)
+	RecordDerivationStageTime(stage string, d time.Duration)
+	RecordChannelLifecycle(event string, sinceFirstFrame time.Duration)
+	RecordBatchValidity(validity string, reason string)
 
 	RecordUnsafePayloadsBuffer(length uint64, memSize uint64, next eth.BlockID)
 
@@ -63,6 +66,7 @@ type DerivationPipeline interface {
 	Origin() eth.L1BlockRef
 	EngineReady() bool
 	LowestQueuedUnsafeBlock() eth.L2BlockRef
+	Dump() *derive.PipelineDump
 }
 
 type L1StateIface interface {
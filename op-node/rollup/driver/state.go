@@ -587,6 +587,20 @@ func (s *Driver) BlockRefWithStatus(ctx context.Context, num uint64) (eth.L2Bloc
 	}
 }
 
+// DerivationPipelineDump blocks the driver event loop and captures the data buffered in each stage of the derivation pipeline.
+// If the event loop is too busy and the context expires, a context error is returned.
+func (s *Driver) DerivationPipelineDump(ctx context.Context) (*derive.PipelineDump, error) {
+	wait := make(chan struct{})
+	select {
+	case s.stateReq <- wait:
+		resp := s.derivation.Dump()
+		<-wait
+		return resp, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
 // deferJSONString helps avoid a JSON-encoding performance hit if the snapshot logger does not run
 type deferJSONString struct {
 	x any
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
@@ -71,6 +72,16 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		return nil, fmt.Errorf("failed to create the sync config: %w", err)
 	}
 
+	signerSchedule, err := NewSignerScheduleConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load p2p signer schedule: %w", err)
+	}
+
+	validatorManagerAddr, err := NewValidatorManagerAddr(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	/* [Kroma: START]
 	haltOption := ctx.String(flags.RollupHalt.Name)
 	if haltOption == "none" {
@@ -84,6 +95,8 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		Rollup: *rollupConfig,
 		Driver: *driverConfig,
 		Beacon: NewBeaconEndpointConfig(ctx),
+
+		L1BlobArchive: ctx.String(flags.L1BlobArchive.Name),
 		RPC: node.RPCConfig{
 			ListenAddr:  ctx.String(flags.RPCListenAddr.Name),
 			ListenPort:  ctx.Int(flags.RPCListenPort.Name),
@@ -97,6 +110,8 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		Pprof:                       oppprof.ReadCLIConfig(ctx),
 		P2P:                         p2pConfig,
 		P2PSigner:                   p2pSignerSetup,
+		P2PSignerSchedule:           signerSchedule,
+		ValidatorManagerAddr:        validatorManagerAddr,
 		L1EpochPollInterval:         ctx.Duration(flags.L1EpochPollIntervalFlag.Name),
 		RuntimeConfigReloadInterval: ctx.Duration(flags.RuntimeConfigReloadIntervalFlag.Name),
 		Heartbeat: node.HeartbeatConfig{
@@ -116,6 +131,9 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		ConductorRpc:        ctx.String(flags.ConductorRpcFlag.Name),
 		ConductorRpcTimeout: ctx.Duration(flags.ConductorRpcTimeoutFlag.Name),
 
+		SafeDBRetention:          ctx.Uint64(flags.SafeDBRetention.Name),
+		SafeDBCompactionInterval: ctx.Duration(flags.SafeDBCompactionInterval.Name),
+
 		Plasma: plasma.ReadCLIConfig(ctx),
 	}
 
@@ -194,6 +212,35 @@ func NewConfigPersistence(ctx *cli.Context) node.ConfigPersistence {
 	return node.NewConfigPersistence(stateFile)
 }
 
+func NewSignerScheduleConfig(ctx *cli.Context) (node.SignerScheduleConfig, error) {
+	cfg := node.SignerScheduleConfig{
+		Overlap: ctx.Duration(flags.SignerOverlapName),
+	}
+	for _, entry := range ctx.StringSlice(flags.SignerHandoversName) {
+		signer, validFrom, ok := strings.Cut(entry, "@")
+		if !ok || !common.IsHexAddress(signer) {
+			return cfg, fmt.Errorf("invalid signer handover %q, expected <address>@<L2 timestamp>", entry)
+		}
+		t, err := strconv.ParseUint(validFrom, 10, 64)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid L2 timestamp of signer handover %q: %w", entry, err)
+		}
+		cfg.Handovers = append(cfg.Handovers, node.SignerHandover{Signer: common.HexToAddress(signer), ValidFrom: t})
+	}
+	return cfg, nil
+}
+
+func NewValidatorManagerAddr(ctx *cli.Context) (common.Address, error) {
+	addr := ctx.String(flags.ValidatorManagerName)
+	if addr == "" {
+		return common.Address{}, nil
+	}
+	if !common.IsHexAddress(addr) {
+		return common.Address{}, fmt.Errorf("invalid ValidatorManager address %q", addr)
+	}
+	return common.HexToAddress(addr), nil
+}
+
 func NewDriverConfig(ctx *cli.Context) *driver.Config {
 	return &driver.Config{
 		VerifierConfDepth:   ctx.Uint64(flags.VerifierL1Confs.Name),
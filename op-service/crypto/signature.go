@@ -64,32 +64,9 @@ func SignerFactoryFromConfig(l log.Logger, privateKey, mnemonic, hdPath string,
 			}
 		}
 	} else {
-		var privKey *ecdsa.PrivateKey
-		var err error
-
-		if privateKey != "" && mnemonic != "" {
-			return nil, common.Address{}, errors.New("cannot specify both a private key and a mnemonic")
-		}
-		if privateKey == "" {
-			// Parse l2output wallet private key and L2OO contract address.
-			wallet, err := hdwallet.NewFromMnemonic(mnemonic)
-			if err != nil {
-				return nil, common.Address{}, fmt.Errorf("failed to parse mnemonic: %w", err)
-			}
-
-			privKey, err = wallet.PrivateKey(accounts.Account{
-				URL: accounts.URL{
-					Path: hdPath,
-				},
-			})
-			if err != nil {
-				return nil, common.Address{}, fmt.Errorf("failed to create a wallet: %w", err)
-			}
-		} else {
-			privKey, err = crypto.HexToECDSA(strings.TrimPrefix(privateKey, "0x"))
-			if err != nil {
-				return nil, common.Address{}, fmt.Errorf("failed to parse the private key: %w", err)
-			}
+		privKey, err := PrivateKeyFromConfig(privateKey, mnemonic, hdPath)
+		if err != nil {
+			return nil, common.Address{}, err
 		}
 		fromAddress = crypto.PubkeyToAddress(privKey.PublicKey)
 		signer = func(chainID *big.Int) SignerFn {
@@ -102,3 +79,31 @@ func SignerFactoryFromConfig(l log.Logger, privateKey, mnemonic, hdPath string,
 
 	return signer, fromAddress, nil
 }
+
+// PrivateKeyFromConfig parses the private key, or derives it from the mnemonic at the HD path if no private key is given.
+func PrivateKeyFromConfig(privateKey, mnemonic, hdPath string) (*ecdsa.PrivateKey, error) {
+	if privateKey != "" && mnemonic != "" {
+		return nil, errors.New("cannot specify both a private key and a mnemonic")
+	}
+	if privateKey != "" {
+		privKey, err := crypto.HexToECDSA(strings.TrimPrefix(privateKey, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the private key: %w", err)
+		}
+		return privKey, nil
+	}
+
+	wallet, err := hdwallet.NewFromMnemonic(mnemonic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse mnemonic: %w", err)
+	}
+	privKey, err := wallet.PrivateKey(accounts.Account{
+		URL: accounts.URL{
+			Path: hdPath,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a wallet: %w", err)
+	}
+	return privKey, nil
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
@@ -25,6 +26,29 @@ type SafeHeadResponse struct {
 	SafeHead BlockID `json:"safeHead"`
 }
 
+// BatchProvenance identifies the batcher data that safe L2 blocks were derived from:
+// the channel, and the L1 transactions that carried the frames of the channel.
+type BatchProvenance struct {
+	ChannelID hexutil.Bytes     `json:"channelId"`
+	Frames    []FrameProvenance `json:"frames"`
+}
+
+// FrameProvenance identifies the L1 data that carried a frame of a channel.
+type FrameProvenance struct {
+	FrameNumber uint16      `json:"frameNumber"`
+	TxHash      common.Hash `json:"txHash"`
+	// BlobHash is the versioned hash of the blob that carried the frame, or nil if the frame was carried in calldata.
+	BlobHash *common.Hash `json:"blobHash,omitempty"`
+}
+
+type BatchProvenanceResponse struct {
+	L1Block  BlockID `json:"l1Block"`
+	SafeHead BlockID `json:"safeHead"`
+	// Provenance is nil if it is unknown, e.g. if the safe blocks were not derived from batcher data
+	// because the sequencing window expired.
+	Provenance *BatchProvenance `json:"provenance"`
+}
+
 var (
 	ErrInvalidOutput        = errors.New("invalid output")
 	ErrInvalidOutputVersion = errors.New("invalid output version")
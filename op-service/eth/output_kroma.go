@@ -167,3 +167,19 @@ func (o *OutputWithProofResponse) ToBlockHeaderRLP() (bindings.TypesBlockHeaderR
 		Nonce:        nonce,
 	}, nil
 }
+
+// OutputAttestation is the attestation of a validator to the output root at an L2 block.
+type OutputAttestation struct {
+	L2BlockNumber hexutil.Uint64 `json:"l2BlockNumber"`
+	OutputRoot    Bytes32        `json:"outputRoot"`
+	// Validator is the signer of the attestation, it is recovered from the signature.
+	Validator common.Address `json:"validator"`
+	Signature hexutil.Bytes  `json:"signature"`
+}
+
+type OutputAttestationsResponse struct {
+	L2BlockNumber hexutil.Uint64       `json:"l2BlockNumber"`
+	Attestations  []*OutputAttestation `json:"attestations"`
+	// Diverged is true if the validators attested different output roots at the L2 block.
+	Diverged bool `json:"diverged"`
+}
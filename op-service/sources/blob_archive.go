@@ -0,0 +1,274 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// The blob archive layout, shared by a local directory and an object-store bucket:
+//
+//	blobs/<versioned hash>         the raw blob
+//	blocks/<L1 block number>.json  the manifest of the archived blobs of the L1 block
+//
+// The blobs are keyed by their versioned hash, so they can be verified against the key.
+// The manifest of a block is written after all its blobs, so a block is only considered archived once complete.
+const (
+	blobArchiveBlobsDir  = "blobs"
+	blobArchiveBlocksDir = "blocks"
+)
+
+// ErrNotArchived is returned when the archive does not have the requested blobs.
+var ErrNotArchived = fmt.Errorf("not archived: %w", ethereum.NotFound)
+
+// ErrCorruptArchivedBlob is returned when an archived blob does not commit to its versioned hash.
+var ErrCorruptArchivedBlob = errors.New("corrupt archived blob")
+
+// BlobArchiveStore is the storage of a blob archive.
+type BlobArchiveStore interface {
+	// Get returns the data stored at the key, or ErrNotArchived if there is none.
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+// WritableBlobArchiveStore is the storage of a blob archive that can be written to.
+type WritableBlobArchiveStore interface {
+	BlobArchiveStore
+	// Put stores the data at the key, replacing any previous data.
+	Put(ctx context.Context, key string, data []byte) error
+}
+
+// NewBlobArchiveStore opens the blob archive at the location,
+// which is either the HTTP(S) URL of an object store, which is read-only, or a local directory.
+func NewBlobArchiveStore(location string, log log.Logger) BlobArchiveStore {
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		return NewHTTPBlobArchive(client.NewBasicHTTPClient(location, log))
+	}
+	return NewDirBlobArchive(location)
+}
+
+// DirBlobArchive stores a blob archive in a local directory.
+type DirBlobArchive struct {
+	dir string
+}
+
+var _ WritableBlobArchiveStore = (*DirBlobArchive)(nil)
+
+func NewDirBlobArchive(dir string) *DirBlobArchive {
+	return &DirBlobArchive{dir: dir}
+}
+
+func (d *DirBlobArchive) Get(_ context.Context, key string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(d.dir, filepath.FromSlash(key)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrNotArchived, key)
+	}
+	return data, err
+}
+
+// Put writes the data to a temporary file first, so a partially written file is never read.
+func (d *DirBlobArchive) Put(_ context.Context, key string, data []byte) error {
+	path := filepath.Join(d.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create archive directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to move %s into place: %w", key, err)
+	}
+	return nil
+}
+
+// HTTPBlobArchive reads a blob archive from an object store through HTTP,
+// e.g. from a public bucket, or from a CDN in front of one.
+type HTTPBlobArchive struct {
+	cl client.HTTP
+}
+
+var _ BlobArchiveStore = (*HTTPBlobArchive)(nil)
+
+func NewHTTPBlobArchive(cl client.HTTP) *HTTPBlobArchive {
+	return &HTTPBlobArchive{cl: cl}
+}
+
+func (h *HTTPBlobArchive) Get(ctx context.Context, key string) ([]byte, error) {
+	resp, err := h.cl.Get(ctx, key, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return io.ReadAll(resp.Body)
+	case http.StatusNotFound, http.StatusForbidden: // object stores deny access to missing keys if listing is not allowed
+		return nil, fmt.Errorf("%w: %s", ErrNotArchived, key)
+	default:
+		return nil, fmt.Errorf("failed to get %s: status %d", key, resp.StatusCode)
+	}
+}
+
+// BlobArchiveManifest lists the archived blobs of an L1 block.
+type BlobArchiveManifest struct {
+	Block eth.BlockID        `json:"block"`
+	Blobs []BlobArchiveEntry `json:"blobs"`
+}
+
+type BlobArchiveEntry struct {
+	// Index is the index of the blob in the L1 block.
+	Index uint64 `json:"index"`
+	// Hash is the versioned hash of the blob.
+	Hash common.Hash `json:"hash"`
+}
+
+func blobArchiveBlobKey(hash common.Hash) string {
+	return blobArchiveBlobsDir + "/" + hash.Hex()
+}
+
+func blobArchiveManifestKey(num uint64) string {
+	return blobArchiveBlocksDir + "/" + strconv.FormatUint(num, 10) + ".json"
+}
+
+// BlobArchive reads and writes the blobs of L1 blocks in a blob archive.
+// It implements the L1 blobs fetcher, to read batches from the archive instead of from a beacon node.
+type BlobArchive struct {
+	store BlobArchiveStore
+}
+
+func NewBlobArchive(store BlobArchiveStore) *BlobArchive {
+	return &BlobArchive{store: store}
+}
+
+// Manifest returns the manifest of the L1 block, or ErrNotArchived if the block is not archived.
+func (a *BlobArchive) Manifest(ctx context.Context, num uint64) (*BlobArchiveManifest, error) {
+	data, err := a.store.Get(ctx, blobArchiveManifestKey(num))
+	if err != nil {
+		return nil, err
+	}
+	var manifest BlobArchiveManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest of block %d: %w", num, err)
+	}
+	if manifest.Block.Number != num {
+		return nil, fmt.Errorf("manifest of block %d is for block %s", num, manifest.Block)
+	}
+	return &manifest, nil
+}
+
+// Blob returns the blob with the versioned hash, after checking that the blob commits to it.
+func (a *BlobArchive) Blob(ctx context.Context, hash common.Hash) (*eth.Blob, error) {
+	data, err := a.store.Get(ctx, blobArchiveBlobKey(hash))
+	if err != nil {
+		return nil, err
+	}
+	var blob eth.Blob
+	if len(data) != len(blob) {
+		return nil, fmt.Errorf("%w: archived blob %s has invalid size %d", ErrCorruptArchivedBlob, hash, len(data))
+	}
+	copy(blob[:], data)
+	commitment, err := blob.ComputeKZGCommitment()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to compute commitment of archived blob %s: %w", ErrCorruptArchivedBlob, hash, err)
+	}
+	if actual := eth.KZGToVersionedHash(commitment); actual != hash {
+		return nil, fmt.Errorf("%w: archived blob %s has versioned hash %s", ErrCorruptArchivedBlob, hash, actual)
+	}
+	return &blob, nil
+}
+
+// GetBlobs returns the archived blobs of the L1 block with the given indexed hashes, in the order of the hashes.
+// It returns ErrNotArchived if the block or any of the blobs are not archived.
+func (a *BlobArchive) GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error) {
+	manifest, err := a.Manifest(ctx, ref.Number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get manifest of block %s: %w", ref, err)
+	}
+	if manifest.Block.Hash != ref.Hash {
+		return nil, fmt.Errorf("%w: archived block %s does not match %s", ErrNotArchived, manifest.Block, ref)
+	}
+	archived := make(map[BlobArchiveEntry]struct{}, len(manifest.Blobs))
+	for _, entry := range manifest.Blobs {
+		archived[entry] = struct{}{}
+	}
+	blobs := make([]*eth.Blob, 0, len(hashes))
+	for _, h := range hashes {
+		if _, ok := archived[BlobArchiveEntry{Index: h.Index, Hash: h.Hash}]; !ok {
+			return nil, fmt.Errorf("%w: blob %s at index %d of block %s", ErrNotArchived, h.Hash, h.Index, ref)
+		}
+		blob, err := a.Blob(ctx, h.Hash)
+		if err != nil {
+			return nil, err
+		}
+		blobs = append(blobs, blob)
+	}
+	return blobs, nil
+}
+
+// WriteBlobs archives the blobs of the L1 block, the blobs are given in the order of the hashes.
+// The blobs are written before the manifest of the block.
+func WriteBlobs(ctx context.Context, store WritableBlobArchiveStore, block eth.BlockID, hashes []eth.IndexedBlobHash, blobs []*eth.Blob) error {
+	if len(hashes) != len(blobs) {
+		return fmt.Errorf("number of hashes and blobs mismatch, %d != %d", len(hashes), len(blobs))
+	}
+	manifest := BlobArchiveManifest{Block: block, Blobs: make([]BlobArchiveEntry, 0, len(hashes))}
+	for i, h := range hashes {
+		if err := store.Put(ctx, blobArchiveBlobKey(h.Hash), blobs[i][:]); err != nil {
+			return err
+		}
+		manifest.Blobs = append(manifest.Blobs, BlobArchiveEntry{Index: h.Index, Hash: h.Hash})
+	}
+	data, err := json.Marshal(&manifest)
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest of block %s: %w", block, err)
+	}
+	return store.Put(ctx, blobArchiveManifestKey(block.Number), data)
+}
+
+type blobsFetcher interface {
+	GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error)
+}
+
+// ArchivedBlobsFetcher fetches blobs from the archive first,
+// and falls back to the given fetcher for blobs that are not archived or corrupt in the archive.
+type ArchivedBlobsFetcher struct {
+	archive  *BlobArchive
+	fallback blobsFetcher
+	log      log.Logger
+}
+
+// NewArchivedBlobsFetcher creates a blobs fetcher backed by the archive.
+// The fallback may be nil, to fetch blobs from the archive only.
+func NewArchivedBlobsFetcher(archive *BlobArchive, fallback blobsFetcher, log log.Logger) *ArchivedBlobsFetcher {
+	return &ArchivedBlobsFetcher{archive: archive, fallback: fallback, log: log}
+}
+
+func (f *ArchivedBlobsFetcher) GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error) {
+	blobs, err := f.archive.GetBlobs(ctx, ref, hashes)
+	if f.fallback == nil {
+		return blobs, err
+	}
+	switch {
+	case errors.Is(err, ErrNotArchived):
+		return f.fallback.GetBlobs(ctx, ref, hashes)
+	case errors.Is(err, ErrCorruptArchivedBlob):
+		f.log.Warn("Archived blobs are corrupt, fetching them from the fallback", "block", ref, "err", err)
+		return f.fallback.GetBlobs(ctx, ref, hashes)
+	}
+	return blobs, err
+}
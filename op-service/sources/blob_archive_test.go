@@ -0,0 +1,122 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func newTestBlob(t *testing.T, data string) (*eth.Blob, common.Hash) {
+	var blob eth.Blob
+	require.NoError(t, blob.FromData(eth.Data(data)))
+	commitment, err := blob.ComputeKZGCommitment()
+	require.NoError(t, err)
+	return &blob, eth.KZGToVersionedHash(commitment)
+}
+
+type mockBlobsFetcher struct {
+	blobs []*eth.Blob
+	calls int
+}
+
+func (m *mockBlobsFetcher) GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error) {
+	m.calls++
+	return m.blobs, nil
+}
+
+func TestBlobArchive(t *testing.T) {
+	ctx := context.Background()
+	blob1, hash1 := newTestBlob(t, "first")
+	blob2, hash2 := newTestBlob(t, "second")
+	ref := eth.L1BlockRef{Hash: common.Hash{0xaa}, Number: 100}
+	hashes := []eth.IndexedBlobHash{{Index: 1, Hash: hash1}, {Index: 3, Hash: hash2}}
+
+	store := NewDirBlobArchive(t.TempDir())
+	require.NoError(t, WriteBlobs(ctx, store, ref.ID(), hashes, []*eth.Blob{blob1, blob2}))
+	archive := NewBlobArchive(store)
+
+	t.Run("GetBlobs", func(t *testing.T) {
+		blobs, err := archive.GetBlobs(ctx, ref, []eth.IndexedBlobHash{hashes[1], hashes[0]})
+		require.NoError(t, err)
+		require.Equal(t, []*eth.Blob{blob2, blob1}, blobs)
+	})
+	t.Run("UnknownBlock", func(t *testing.T) {
+		_, err := archive.GetBlobs(ctx, eth.L1BlockRef{Hash: common.Hash{0xbb}, Number: 101}, hashes)
+		require.ErrorIs(t, err, ErrNotArchived)
+		require.ErrorIs(t, err, ethereum.NotFound)
+	})
+	t.Run("DifferentBlock", func(t *testing.T) {
+		_, err := archive.GetBlobs(ctx, eth.L1BlockRef{Hash: common.Hash{0xbb}, Number: 100}, hashes)
+		require.ErrorIs(t, err, ErrNotArchived)
+	})
+	t.Run("UnknownBlob", func(t *testing.T) {
+		_, err := archive.GetBlobs(ctx, ref, []eth.IndexedBlobHash{{Index: 2, Hash: hash1}})
+		require.ErrorIs(t, err, ErrNotArchived)
+	})
+	t.Run("CorruptBlob", func(t *testing.T) {
+		require.NoError(t, store.Put(ctx, blobArchiveBlobKey(hash2), blob1[:]))
+		defer func() { require.NoError(t, store.Put(ctx, blobArchiveBlobKey(hash2), blob2[:])) }()
+		_, err := archive.GetBlobs(ctx, ref, hashes)
+		require.ErrorContains(t, err, "has versioned hash")
+		require.ErrorIs(t, err, ErrCorruptArchivedBlob)
+		require.False(t, errors.Is(err, ErrNotArchived))
+
+		// fetched from the fallback instead
+		fallback := &mockBlobsFetcher{blobs: []*eth.Blob{blob1, blob2}}
+		blobs, err := NewArchivedBlobsFetcher(archive, fallback, testlog.Logger(t, log.LevelInfo)).GetBlobs(ctx, ref, hashes)
+		require.NoError(t, err)
+		require.Equal(t, []*eth.Blob{blob1, blob2}, blobs)
+		require.Equal(t, 1, fallback.calls)
+
+		_, err = NewArchivedBlobsFetcher(archive, nil, testlog.Logger(t, log.LevelInfo)).GetBlobs(ctx, ref, hashes)
+		require.ErrorIs(t, err, ErrCorruptArchivedBlob)
+	})
+	t.Run("Fallback", func(t *testing.T) {
+		fallback := &mockBlobsFetcher{blobs: []*eth.Blob{blob1}}
+		fetcher := NewArchivedBlobsFetcher(archive, fallback, testlog.Logger(t, log.LevelInfo))
+		blobs, err := fetcher.GetBlobs(ctx, ref, hashes)
+		require.NoError(t, err)
+		require.Equal(t, []*eth.Blob{blob1, blob2}, blobs)
+		require.Zero(t, fallback.calls)
+
+		other := eth.L1BlockRef{Hash: common.Hash{0xbb}, Number: 101}
+		blobs, err = fetcher.GetBlobs(ctx, other, hashes[:1])
+		require.NoError(t, err)
+		require.Equal(t, []*eth.Blob{blob1}, blobs)
+		require.Equal(t, 1, fallback.calls)
+
+		_, err = NewArchivedBlobsFetcher(archive, nil, testlog.Logger(t, log.LevelInfo)).GetBlobs(ctx, other, hashes[:1])
+		require.ErrorIs(t, err, ErrNotArchived)
+	})
+}
+
+func TestHTTPBlobArchive(t *testing.T) {
+	ctx := context.Background()
+	blob, hash := newTestBlob(t, "blob")
+	ref := eth.L1BlockRef{Hash: common.Hash{0xaa}, Number: 100}
+	hashes := []eth.IndexedBlobHash{{Index: 0, Hash: hash}}
+
+	dir := t.TempDir()
+	require.NoError(t, WriteBlobs(ctx, NewDirBlobArchive(dir), ref.ID(), hashes, []*eth.Blob{blob}))
+	srv := httptest.NewServer(http.StripPrefix("/bucket/", http.FileServer(http.Dir(dir))))
+	defer srv.Close()
+
+	archive := NewBlobArchive(NewBlobArchiveStore(srv.URL+"/bucket/", testlog.Logger(t, log.LevelInfo)))
+	blobs, err := archive.GetBlobs(ctx, ref, hashes)
+	require.NoError(t, err)
+	require.Equal(t, []*eth.Blob{blob}, blobs)
+
+	_, err = archive.Manifest(ctx, 101)
+	require.ErrorIs(t, err, ErrNotArchived)
+}
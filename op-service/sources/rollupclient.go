@@ -33,6 +33,18 @@ func (r *RollupClient) SafeHeadAtL1Block(ctx context.Context, blockNum uint64) (
 	return output, err
 }
 
+func (r *RollupClient) L1InclusionAtL2Block(ctx context.Context, blockNum uint64) (*eth.SafeHeadResponse, error) {
+	var output *eth.SafeHeadResponse
+	err := r.rpc.CallContext(ctx, &output, "optimism_l1InclusionAtL2Block", hexutil.Uint64(blockNum))
+	return output, err
+}
+
+func (r *RollupClient) BatchProvenanceAtL2Block(ctx context.Context, blockNum uint64) (*eth.BatchProvenanceResponse, error) {
+	var output *eth.BatchProvenanceResponse
+	err := r.rpc.CallContext(ctx, &output, "optimism_batchProvenanceAtL2Block", hexutil.Uint64(blockNum))
+	return output, err
+}
+
 func (r *RollupClient) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
 	var output *eth.SyncStatus
 	err := r.rpc.CallContext(ctx, &output, "optimism_syncStatus")
@@ -86,4 +98,14 @@ func (r *RollupClient) OutputWithProofAtBlock(ctx context.Context, blockNum uint
 	return output, err
 }
 
+func (r *RollupClient) OutputAttestations(ctx context.Context, blockNum uint64) (*eth.OutputAttestationsResponse, error) {
+	var output *eth.OutputAttestationsResponse
+	err := r.rpc.CallContext(ctx, &output, "kroma_outputAttestations", hexutil.Uint64(blockNum))
+	return output, err
+}
+
+func (r *RollupClient) PublishOutputAttestation(ctx context.Context, attestation *eth.OutputAttestation) error {
+	return r.rpc.CallContext(ctx, nil, "kroma_publishOutputAttestation", attestation)
+}
+
 // [Kroma: END]
@@ -1,6 +1,8 @@
 package testutils
 
 import (
+	"time"
+
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
@@ -12,6 +14,8 @@ type TestDerivationMetrics struct {
 	FnRecordL2Ref             func(name string, ref eth.L2BlockRef)
 	FnRecordUnsafePayloads    func(length uint64, memSize uint64, next eth.BlockID)
 	FnRecordChannelInputBytes func(inputCompressedBytes int)
+	FnRecordChannelLifecycle  func(event string, sinceFirstFrame time.Duration)
+	FnRecordBatchValidity     func(validity string, reason string)
 }
 
 func (t *TestDerivationMetrics) RecordL1ReorgDepth(d uint64) {
@@ -56,6 +60,21 @@ func (t *TestDerivationMetrics) RecordFrame() {
 func (n *TestDerivationMetrics) RecordDerivedBatches(batchType string) {
 }
 
+func (t *TestDerivationMetrics) RecordDerivationStageTime(stage string, d time.Duration) {
+}
+
+func (t *TestDerivationMetrics) RecordChannelLifecycle(event string, sinceFirstFrame time.Duration) {
+	if t.FnRecordChannelLifecycle != nil {
+		t.FnRecordChannelLifecycle(event, sinceFirstFrame)
+	}
+}
+
+func (t *TestDerivationMetrics) RecordBatchValidity(validity string, reason string) {
+	if t.FnRecordBatchValidity != nil {
+		t.FnRecordBatchValidity(validity, reason)
+	}
+}
+
 type TestRPCMetrics struct{}
 
 func (n *TestRPCMetrics) RecordRPCServerRequest(method string) func() {
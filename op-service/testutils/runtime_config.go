@@ -9,3 +9,10 @@ type MockRuntimeConfig struct {
 func (m *MockRuntimeConfig) P2PSequencerAddress() common.Address {
 	return m.P2PSeqAddress
 }
+
+func (m *MockRuntimeConfig) P2PSequencerSigners(l2Time uint64) []common.Address {
+	if m.P2PSeqAddress == (common.Address{}) {
+		return nil
+	}
+	return []common.Address{m.P2PSeqAddress}
+}
@@ -2,6 +2,7 @@ package txmgr
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"errors"
 	"fmt"
 	"math/big"
@@ -312,6 +313,27 @@ func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 	}
 }
 
+// hdPath returns the HD path to derive the key from the mnemonic with.
+func (m CLIConfig) hdPath() string {
+	// Allow backwards compatible ways of specifying the HD path
+	hdPath := m.HDPath
+	if hdPath == "" && m.SequencerHDPath != "" {
+		hdPath = m.SequencerHDPath
+	} else if hdPath == "" && m.L2OutputHDPath != "" {
+		hdPath = m.L2OutputHDPath
+	}
+	return hdPath
+}
+
+// LocalKey returns the private key that the transactions are signed with.
+// It is not available if the transactions are signed by the remote signer.
+func (m CLIConfig) LocalKey() (*ecdsa.PrivateKey, error) {
+	if m.SignerCLIConfig.Enabled() {
+		return nil, errors.New("the key is held by the remote signer")
+	}
+	return opcrypto.PrivateKeyFromConfig(m.PrivateKey, m.Mnemonic, m.hdPath())
+}
+
 func NewConfig(cfg CLIConfig, l log.Logger) (Config, error) {
 	if err := cfg.Check(); err != nil {
 		return Config{}, fmt.Errorf("invalid config: %w", err)
@@ -331,15 +353,7 @@ func NewConfig(cfg CLIConfig, l log.Logger) (Config, error) {
 		return Config{}, fmt.Errorf("could not dial fetch L1 chain ID: %w", err)
 	}
 
-	// Allow backwards compatible ways of specifying the HD path
-	hdPath := cfg.HDPath
-	if hdPath == "" && cfg.SequencerHDPath != "" {
-		hdPath = cfg.SequencerHDPath
-	} else if hdPath == "" && cfg.L2OutputHDPath != "" {
-		hdPath = cfg.L2OutputHDPath
-	}
-
-	signerFactory, from, err := opcrypto.SignerFactoryFromConfig(l, cfg.PrivateKey, cfg.Mnemonic, hdPath, cfg.SignerCLIConfig)
+	signerFactory, from, err := opcrypto.SignerFactoryFromConfig(l, cfg.PrivateKey, cfg.Mnemonic, cfg.hdPath(), cfg.SignerCLIConfig)
 	if err != nil {
 		return Config{}, fmt.Errorf("could not init signer: %w", err)
 	}
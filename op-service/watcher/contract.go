@@ -6,7 +6,6 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 
@@ -15,11 +14,11 @@ import (
 
 type ContractWatcher struct {
 	ctx     context.Context
-	backend *ethclient.Client
+	backend bind.ContractBackend
 	log     log.Logger
 }
 
-func NewContractWatcher(ctx context.Context, backend *ethclient.Client, l log.Logger) ContractWatcher {
+func NewContractWatcher(ctx context.Context, backend bind.ContractBackend, l log.Logger) ContractWatcher {
 	return ContractWatcher{
 		ctx:     ctx,
 		backend: backend,